@@ -0,0 +1,399 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Represents a type declaration in an ABI.
+type TypeDeclaration struct {
+	Type string
+}
+
+// Represents a value in an ABI.
+type Value struct {
+	Name         string  `json:"name"`
+	Type         string  `json:"type"`
+	InternalType string  `json:"internalType,omitempty"`
+	Components   []Value `json:"components,omitempty"`
+}
+
+// Represents a parameter for an event in an ABI.
+type EventArgument struct {
+	Value
+	Indexed bool
+}
+
+// Represents a smart contract method in an ABI.
+// Constant and Payable are only present in legacy ABIs (produced by Solidity < 0.6 or by Vyper), which
+// describe mutability using them instead of StateMutability.
+// Extras holds any other fields of the item in the original ABI (e.g. "gas" or "signature"), so that
+// templates and targets can make use of them.
+type FunctionItem struct {
+	Type            string
+	Name            string                     `json:"name,omitempty"`
+	Inputs          []Value                    `json:"inputs,omitempty"`
+	Outputs         []Value                    `json:"outputs,omitempty"`
+	StateMutability string                     `json:"stateMutability,omitempty"`
+	Constant        bool                       `json:"constant,omitempty"`
+	Payable         bool                       `json:"payable,omitempty"`
+	Extras          map[string]json.RawMessage `json:"-"`
+}
+
+// Represents a log event in an ABI.
+// Extras holds any fields of the item in the original ABI which solface does not otherwise decode.
+type EventItem struct {
+	Type      string
+	Name      string `json:"name"`
+	Inputs    []EventArgument
+	Anonymous bool
+	Extras    map[string]json.RawMessage `json:"-"`
+}
+
+// Represents an exception/error in an ABI.
+// Extras holds any fields of the item in the original ABI which solface does not otherwise decode.
+type ErrorItem struct {
+	Type   string
+	Name   string
+	Inputs []Value
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// Represents the constructor of a contract in an ABI.
+// Payable is only present in legacy ABIs, which describe mutability using it instead of StateMutability.
+type ConstructorItem struct {
+	Type            string
+	Inputs          []Value                    `json:"inputs,omitempty"`
+	StateMutability string                     `json:"stateMutability,omitempty"`
+	Payable         bool                       `json:"payable,omitempty"`
+	Extras          map[string]json.RawMessage `json:"-"`
+}
+
+// Represents the fallback or receive function of a contract in an ABI. Type is either "fallback" or
+// "receive".
+// Payable is only present in legacy ABIs, which describe mutability using it instead of StateMutability.
+type SpecialFunctionItem struct {
+	Type            string
+	StateMutability string                     `json:"stateMutability,omitempty"`
+	Payable         bool                       `json:"payable,omitempty"`
+	Extras          map[string]json.RawMessage `json:"-"`
+}
+
+// Represents the position of an item in the original ABI JSON array.
+//  1. ItemType: One of "event", "function", or "error".
+//  2. ItemIndex: The position of the item in the corresponding array of the DecodedABI.
+//  3. ABIIndex: The position of the item in the original ABI JSON array.
+//  4. Raw: The original JSON of the item, including any fields which solface does not decode.
+type ItemPosition struct {
+	ItemType  string
+	ItemIndex int
+	ABIIndex  int
+	Raw       json.RawMessage
+}
+
+// Represents a parsed ABI, usable in the rest of solface.
+// Positions lists the events, functions, and errors in the order in which they appeared in the original
+// ABI JSON array. It is empty for ABIs which were not decoded from JSON.
+// Constructor is nil if the ABI does not describe a constructor. Since constructors cannot be called
+// through an interface, it is not part of the items listed in Positions.
+// Fallback and Receive are nil if the ABI does not describe a fallback or receive function. They are not
+// listed in Positions either.
+type DecodedABI struct {
+	Events      []EventItem
+	Functions   []FunctionItem
+	Errors      []ErrorItem
+	Positions   []ItemPosition
+	Constructor *ConstructorItem
+	Fallback    *SpecialFunctionItem
+	Receive     *SpecialFunctionItem
+}
+
+// Represents annotations for an ABI.
+type Annotations struct {
+	InterfaceID       []byte
+	FunctionSelectors [][]byte
+}
+
+// Decodes an ABI from its JSON representation (presented as a byte array). The input may also be a
+// compilation artifact which contains the ABI (see ParseArtifact).
+//
+// ABIs are decoded according to the Solidity Contract ABI specification:
+// https://docs.soliditylang.org/en/v0.8.17/abi-spec.html
+//
+// This decoder uses the specification as of Solidity v0.8.17.
+
+func Decode(rawJSON []byte) (DecodedABI, error) {
+	decodedABI, _, decodeErr := DecodeWithOptions(rawJSON, Options{})
+	return decodedABI, decodeErr
+}
+
+// Decodes an ABI in the same way as Decode, except that items which cannot be decoded are dropped
+// instead of failing the whole decode. A diagnostic is returned for every dropped item.
+// An error is only returned if the input is not a JSON array.
+func DecodeSkippingInvalid(rawJSON []byte) (DecodedABI, []Diagnostic, error) {
+	return DecodeWithOptions(rawJSON, Options{SkipInvalid: true})
+}
+
+// Decodes an ABI as configured by the given options: items which cannot be decoded are dropped (with
+// diagnostics) if options.SkipInvalid is set, and the size of the input, the number of items, and the
+// nesting depth of compound types are checked against options.MaxInputBytes, options.MaxItems, and
+// options.MaxNestingDepth respectively. Exceeded limits result in a *LimitExceededError or a
+// *NestingDepthError, except that items nested too deeply are dropped (with diagnostics) like undecodable
+// ones if options.SkipInvalid is set. Exact duplicates of items are dropped, with a diagnostic counting the duplicates.
+// If options.Strict is set, every item is validated first, and questionable items result in a
+// *StrictValidationError listing every problem.
+func DecodeWithOptions(rawJSON []byte, options Options) (DecodedABI, []Diagnostic, error) {
+	var rawMessages []json.RawMessage
+	var decodedABI DecodedABI
+	diagnostics := []Diagnostic{}
+
+	if options.MaxInputBytes > 0 && len(rawJSON) > options.MaxInputBytes {
+		return decodedABI, diagnostics, &LimitExceededError{Limit: "input bytes", Maximum: options.MaxInputBytes, Actual: len(rawJSON)}
+	}
+
+	artifact, artifactErr := ParseArtifact(rawJSON)
+	if artifactErr != nil {
+		return decodedABI, diagnostics, artifactErr
+	}
+	rawJSON = artifact.ABI
+
+	rawMessagesErr := json.Unmarshal(rawJSON, &rawMessages)
+	if rawMessagesErr != nil {
+		return decodedABI, diagnostics, rawMessagesErr
+	}
+
+	if options.MaxItems > 0 && len(rawMessages) > options.MaxItems {
+		return decodedABI, diagnostics, &LimitExceededError{Limit: "ABI items", Maximum: options.MaxItems, Actual: len(rawMessages)}
+	}
+
+	if options.Strict {
+		if strictProblems := strictDiagnostics(rawMessages); len(strictProblems) > 0 {
+			return decodedABI, append(diagnostics, strictProblems...), &StrictValidationError{Diagnostics: strictProblems}
+		}
+	}
+
+	// Concatenated ABIs sometimes contain exact duplicates, which would result in duplicate declarations.
+	// Items are identified by their decoded form (so that formatting and key order do not matter), and
+	// duplicates are counted against the first occurrence.
+	firstOccurrences := map[string]int{}
+	duplicateCounts := map[int]int{}
+	isDuplicate := func(index int, itemType string, item interface{}) bool {
+		key, marshalErr := json.Marshal(item)
+		if marshalErr != nil {
+			return false
+		}
+		first, ok := firstOccurrences[itemType+string(key)]
+		if !ok {
+			firstOccurrences[itemType+string(key)] = index
+			return false
+		}
+		duplicateCounts[first]++
+		return true
+	}
+
+	for i, rawMessage := range rawMessages {
+		var declaration TypeDeclaration
+		var itemErr error
+
+		itemErr = json.Unmarshal(rawMessage, &declaration)
+		if itemErr == nil {
+			if declaration.Type == "event" {
+				var eventItem EventItem
+				itemErr = json.Unmarshal(rawMessage, &eventItem)
+				if itemErr == nil {
+					eventItem.Extras, itemErr = extraFields(declaration.Type, rawMessage)
+				}
+				if itemErr == nil && !isDuplicate(i, declaration.Type, eventItem) {
+					decodedABI.Events = append(decodedABI.Events, eventItem)
+					decodedABI.Positions = append(decodedABI.Positions, ItemPosition{ItemType: "event", ItemIndex: len(decodedABI.Events) - 1, ABIIndex: i, Raw: rawMessage})
+				}
+			} else if declaration.Type == "function" {
+				var functionItem FunctionItem
+				itemErr = json.Unmarshal(rawMessage, &functionItem)
+				if itemErr == nil {
+					functionItem.Extras, itemErr = extraFields(declaration.Type, rawMessage)
+					// Legacy ABIs (Solidity < 0.6) describe mutability with the constant and payable fields.
+					functionItem.StateMutability = NormalizedStateMutability(functionItem)
+				}
+				if itemErr == nil && !isDuplicate(i, declaration.Type, functionItem) {
+					decodedABI.Functions = append(decodedABI.Functions, functionItem)
+					decodedABI.Positions = append(decodedABI.Positions, ItemPosition{ItemType: "function", ItemIndex: len(decodedABI.Functions) - 1, ABIIndex: i, Raw: rawMessage})
+				}
+			} else if declaration.Type == "constructor" {
+				var constructorItem ConstructorItem
+				itemErr = json.Unmarshal(rawMessage, &constructorItem)
+				if itemErr == nil {
+					constructorItem.Extras, itemErr = extraFields(declaration.Type, rawMessage)
+				}
+				if itemErr == nil && constructorItem.StateMutability == "" {
+					constructorItem.StateMutability = "nonpayable"
+					if constructorItem.Payable {
+						constructorItem.StateMutability = "payable"
+					}
+				}
+				if itemErr == nil && !isDuplicate(i, declaration.Type, constructorItem) {
+					if decodedABI.Constructor != nil {
+						itemErr = fmt.Errorf("ABI has more than one constructor")
+					} else {
+						decodedABI.Constructor = &constructorItem
+					}
+				}
+			} else if declaration.Type == "fallback" || declaration.Type == "receive" {
+				var specialItem SpecialFunctionItem
+				itemErr = json.Unmarshal(rawMessage, &specialItem)
+				if itemErr == nil {
+					specialItem.Extras, itemErr = extraFields(declaration.Type, rawMessage)
+				}
+				if itemErr == nil {
+					// Receive functions are always payable. Legacy fallback functions only use the payable field.
+					if declaration.Type == "receive" || specialItem.Payable {
+						specialItem.StateMutability = "payable"
+					} else if specialItem.StateMutability == "" {
+						specialItem.StateMutability = "nonpayable"
+					}
+				}
+				if itemErr == nil && !isDuplicate(i, declaration.Type, specialItem) {
+					target := &decodedABI.Fallback
+					if declaration.Type == "receive" {
+						target = &decodedABI.Receive
+					}
+					if *target != nil {
+						itemErr = fmt.Errorf("ABI has more than one %s function", declaration.Type)
+					} else {
+						*target = &specialItem
+					}
+				}
+			} else if declaration.Type == "error" {
+				var errorItem ErrorItem
+				itemErr = json.Unmarshal(rawMessage, &errorItem)
+				if itemErr == nil {
+					errorItem.Extras, itemErr = extraFields(declaration.Type, rawMessage)
+				}
+				if itemErr == nil && !isDuplicate(i, declaration.Type, errorItem) {
+					decodedABI.Errors = append(decodedABI.Errors, errorItem)
+					decodedABI.Positions = append(decodedABI.Positions, ItemPosition{ItemType: "error", ItemIndex: len(decodedABI.Errors) - 1, ABIIndex: i, Raw: rawMessage})
+				}
+			}
+		}
+
+		if itemErr != nil {
+			if !options.SkipInvalid {
+				return decodedABI, diagnostics, itemErr
+			}
+			diagnostics = append(diagnostics, Diagnostic{ItemType: declaration.Type, ItemIndex: i, Message: fmt.Sprintf("skipped undecodable item: %s", itemErr.Error())})
+		}
+	}
+
+	for _, position := range decodedABI.Positions {
+		if count := duplicateCounts[position.ABIIndex]; count > 0 {
+			diagnostics = append(diagnostics, Diagnostic{ItemType: position.ItemType, ItemIndex: position.ABIIndex, Name: itemName(decodedABI, position), Message: fmt.Sprintf("removed %d duplicate(s) of this item", count)})
+		}
+	}
+
+	dialect := options.Dialect
+	if dialect == "" {
+		dialect = DetectDialect(rawJSON)
+	}
+	if dialect == DialectVyper {
+		var dialectDiagnostics []Diagnostic
+		decodedABI, dialectDiagnostics = ApplyVyperDialect(decodedABI)
+		diagnostics = append(diagnostics, dialectDiagnostics...)
+	} else if dialect != DialectSolidity {
+		return decodedABI, diagnostics, fmt.Errorf("unknown dialect: %s", dialect)
+	}
+
+	if options.SkipInvalid {
+		var nestingDiagnostics []Diagnostic
+		decodedABI, nestingDiagnostics = RemoveUnsupported(decodedABI, NestingDepthItems(decodedABI, options.MaxNestingDepth))
+		diagnostics = append(diagnostics, nestingDiagnostics...)
+	}
+
+	nestingErr := CheckNesting(decodedABI, options.MaxNestingDepth)
+	if nestingErr != nil {
+		return decodedABI, diagnostics, nestingErr
+	}
+
+	return decodedABI, diagnostics, nil
+}
+
+// Returns the name of the item at the given position.
+func itemName(abi DecodedABI, position ItemPosition) string {
+	switch position.ItemType {
+	case "event":
+		return abi.Events[position.ItemIndex].Name
+	case "function":
+		return abi.Functions[position.ItemIndex].Name
+	case "error":
+		return abi.Errors[position.ItemIndex].Name
+	}
+	return ""
+}
+
+// Returned when an input exceeds one of the limits configured in Options.
+type LimitExceededError struct {
+	Limit   string `json:"limit"`
+	Maximum int    `json:"maximum"`
+	Actual  int    `json:"actual"`
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("limit exceeded: %d %s (maximum: %d)", e.Actual, e.Limit, e.Maximum)
+}
+
+// Returns the canonical signature of the given ABI function, e.g. "transfer(address,uint256)".
+func FunctionSignature(function FunctionItem) string {
+	argumentTypes := make([]string, len(function.Inputs))
+	for i, input := range function.Inputs {
+		argumentTypes[i] = CanonicalType(input)
+	}
+	argumentTypesString := strings.Join(argumentTypes, ",")
+	return fmt.Sprintf("%s(%s)", function.Name, argumentTypesString)
+}
+
+// Returns the canonical signature of the given ABI error, e.g. "InsufficientBalance(uint256,uint256)".
+func ErrorSignature(errorItem ErrorItem) string {
+	return FunctionSignature(FunctionItem{Name: errorItem.Name, Inputs: errorItem.Inputs})
+}
+
+// Calculates the 4-byte method selector for a given ABI function.
+func MethodSelector(function FunctionItem) []byte {
+	return MethodSelectorWithHasher(function, Keccak256Hasher)
+}
+
+// Calculates the method selector for a given ABI function using the given hasher (Keccak256Hasher if nil)
+// instead of Keccak-256.
+func MethodSelectorWithHasher(function FunctionItem, hasher Hasher) []byte {
+	signature := FunctionSignature(function)
+	return hasherOrDefault(hasher).Hash([]byte(signature))[:4]
+}
+
+// Generates annotations for a decoded ABI.
+func Annotate(decodedABI DecodedABI) (Annotations, error) {
+	return AnnotateWithHasher(decodedABI, Keccak256Hasher)
+}
+
+// Generates annotations for a decoded ABI, deriving selectors with the given hasher (Keccak256Hasher if
+// nil).
+func AnnotateWithHasher(decodedABI DecodedABI, hasher Hasher) (Annotations, error) {
+	var annotations Annotations
+	annotations.InterfaceID = []byte{0x0, 0x0, 0x0, 0x0}
+	annotations.FunctionSelectors = make([][]byte, len(decodedABI.Functions))
+	for i, functionItem := range decodedABI.Functions {
+		selector := MethodSelectorWithHasher(functionItem, hasher)
+		annotations.FunctionSelectors[i] = selector
+
+		// XOR into InterfaceID byte by byte
+		annotations.InterfaceID[0] ^= selector[0]
+		annotations.InterfaceID[1] ^= selector[1]
+		annotations.InterfaceID[2] ^= selector[2]
+		annotations.InterfaceID[3] ^= selector[3]
+	}
+	return annotations, nil
+}
+
+// Returns true if the given value is a compound type (i.e. composed of other types like a struct or array)
+// and false otherwise.
+func (v Value) IsCompoundType() bool {
+	return len(v.Components) > 0
+}
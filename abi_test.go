@@ -0,0 +1,479 @@
+package solface
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMethodSelectorOnERC721SafeTransferFromWithoutCalldata(t *testing.T) {
+	functionItem := FunctionItem{Type: "function", Name: "safeTransferFrom", Inputs: []Value{
+		{Name: "from", Type: "address"},
+		{Name: "to", Type: "address"},
+		{Name: "tokenId", Type: "uint256"},
+	}}
+
+	selector := MethodSelector(functionItem)
+
+	expectedSelectorString := "42842e0e"
+	selectorString := hex.EncodeToString(selector)
+	if selectorString != expectedSelectorString {
+		t.Fatalf("Incorrect method selector for safeTransferFrom(address,address,uint256). Expected: %s, actual: %s", expectedSelectorString, selectorString)
+	}
+}
+
+func TestMethodSelectorOnERC721SafeTransferFromWithCalldata(t *testing.T) {
+	functionItem := FunctionItem{Type: "function", Name: "safeTransferFrom", Inputs: []Value{
+		{Name: "from", Type: "address"},
+		{Name: "to", Type: "address"},
+		{Name: "tokenId", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+	}}
+
+	selector := MethodSelector(functionItem)
+
+	expectedSelectorString := "b88d4fde"
+	selectorString := hex.EncodeToString(selector)
+	if selectorString != expectedSelectorString {
+		t.Fatalf("Incorrect method selector for safeTransferFrom(address,address,uint256,bytes). Expected: %s, actual: %s", expectedSelectorString, selectorString)
+	}
+}
+
+func TestMethodSelectorOnDiamondCut(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/DiamondCutFacet.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	decodedABI, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	expectedSelectorString := "1f931c1c"
+	selectorString := hex.EncodeToString(MethodSelector(decodedABI.Functions[0]))
+	if selectorString != expectedSelectorString {
+		t.Fatalf("Incorrect method selector for diamondCut((address,uint8,bytes4[])[],address,bytes). Expected: %s, actual: %s", expectedSelectorString, selectorString)
+	}
+}
+
+func TestMethodSelectorOnTupleInput(t *testing.T) {
+	functionItem := FunctionItem{Type: "function", Name: "exactInputSingle", Inputs: []Value{
+		{Name: "params", Type: "tuple", InternalType: "struct ISwapRouter.ExactInputSingleParams", Components: []Value{
+			{Name: "tokenIn", Type: "address"},
+			{Name: "tokenOut", Type: "address"},
+			{Name: "fee", Type: "uint24"},
+			{Name: "recipient", Type: "address"},
+			{Name: "deadline", Type: "uint256"},
+			{Name: "amountIn", Type: "uint256"},
+			{Name: "amountOutMinimum", Type: "uint256"},
+			{Name: "sqrtPriceLimitX96", Type: "uint160"},
+		}},
+	}}
+
+	selector := MethodSelector(functionItem)
+
+	expectedSelectorString := "414bf389"
+	selectorString := hex.EncodeToString(selector)
+	if selectorString != expectedSelectorString {
+		t.Fatalf("Incorrect method selector for exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160)). Expected: %s, actual: %s", expectedSelectorString, selectorString)
+	}
+}
+
+func TestDecodeOwnableERC20(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	decodedABI, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	expectedNumEvents := 3
+	actualNumEvents := len(decodedABI.Events)
+	if actualNumEvents != expectedNumEvents {
+		t.Fatalf("Failure decoding events from ABI. Expected number of events: %d, actual number of events: %d", expectedNumEvents, actualNumEvents)
+	}
+
+	expectedNumFunctions := 15
+	actualNumFunctions := len(decodedABI.Functions)
+	if actualNumFunctions != expectedNumFunctions {
+		t.Fatalf("Failure decoding functions from ABI. Expected number of functions: %d, actual number of functions: %d", expectedNumFunctions, actualNumFunctions)
+	}
+
+	expectedNumErrors := 0
+	actualNumErrors := len(decodedABI.Errors)
+	if actualNumErrors != expectedNumErrors {
+		t.Fatalf("Failure decoding errors from ABI. Expected number of errors: %d, actual number of errors: %d", expectedNumErrors, actualNumErrors)
+	}
+}
+
+func TestSingleEvent(t *testing.T) {
+	var events = []byte(`[{
+    "anonymous": false,
+    "inputs": [
+      {
+        "indexed": true,
+        "internalType": "address",
+        "name": "owner",
+        "type": "address"
+      },
+      {
+        "indexed": true,
+        "internalType": "address",
+        "name": "spender",
+        "type": "address"
+      },
+      {
+        "indexed": false,
+        "internalType": "uint256",
+        "name": "value",
+        "type": "uint256"
+      }
+    ],
+    "name": "Approval",
+    "type": "event"
+  }]`)
+
+	decodedABI, err := Decode(events)
+	if err != nil {
+		t.Fatalf("Could not decode ABI: %s", err.Error())
+	}
+
+	expectedNumEvents := 1
+	actualNumEvents := len(decodedABI.Events)
+	if actualNumEvents != expectedNumEvents {
+		t.Fatalf("Failure decoding events from ABI. Expected number of events: %d, actual number of events: %d", expectedNumEvents, actualNumEvents)
+	}
+
+	expectedNumFunctions := 0
+	actualNumFunctions := len(decodedABI.Functions)
+	if actualNumFunctions != expectedNumFunctions {
+		t.Fatalf("Failure decoding functions from ABI. Expected number of functions: %d, actual number of functions: %d", expectedNumFunctions, actualNumFunctions)
+	}
+
+	expectedNumErrors := 0
+	actualNumErrors := len(decodedABI.Errors)
+	if actualNumErrors != expectedNumErrors {
+		t.Fatalf("Failure decoding errors from ABI. Expected number of errors: %d, actual number of errors: %d", expectedNumErrors, actualNumErrors)
+	}
+
+	actualEvent := decodedABI.Events[0]
+	if actualEvent.Anonymous != false {
+		t.Fatal("Expected event *not* to be anonymous. It was.")
+	}
+	expectedName := "Approval"
+	if actualEvent.Name != expectedName {
+		t.Fatalf("Expected event name: %s. Actual name: %s", expectedName, actualEvent.Name)
+	}
+	expectedType := "event"
+	if actualEvent.Type != expectedType {
+		t.Fatalf("Expected event type: %s. Actual type: %s", expectedType, actualEvent.Type)
+	}
+
+	expectedInputNames := []string{"owner", "spender", "value"}
+	expectedInputTypes := []string{"address", "address", "uint256"}
+	expectedInputIndexed := []bool{true, true, false}
+
+	expectedInputsLength := 3
+	actualInputsLength := len(actualEvent.Inputs)
+	if actualInputsLength != expectedInputsLength {
+		t.Fatalf("Expected length of inputs: %d. Actual length of inputs: %d", expectedInputsLength, actualInputsLength)
+	}
+
+	for i, inputItem := range actualEvent.Inputs {
+		if inputItem.Name != expectedInputNames[i] {
+			t.Fatalf("Input item %d: Expected name: %s. Actual name: %s", i, expectedInputNames[i], inputItem.Name)
+		}
+		if inputItem.Type != expectedInputTypes[i] {
+			t.Fatalf("Input item %d: Expected type : %s. Actual type: %s", i, expectedInputTypes[i], inputItem.Type)
+		}
+		if inputItem.Indexed != expectedInputIndexed[i] {
+			t.Fatalf("Input item %d: Expected indexed: %t. Actual indexed: %t", i, expectedInputIndexed[i], inputItem.Indexed)
+		}
+	}
+}
+
+func TestSingleFunction(t *testing.T) {
+	var functions = []byte(`[{
+    "inputs": [
+      {
+        "internalType": "address",
+        "name": "spender",
+        "type": "address"
+      },
+      {
+        "internalType": "uint256",
+        "name": "amount",
+        "type": "uint256"
+      }
+    ],
+    "name": "approve",
+    "outputs": [
+      {
+        "internalType": "bool",
+        "name": "",
+        "type": "bool"
+      }
+    ],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }]`)
+
+	decodedABI, err := Decode(functions)
+	if err != nil {
+		t.Fatalf("Could not decode ABI: %s", err.Error())
+	}
+
+	expectedNumEvents := 0
+	actualNumEvents := len(decodedABI.Events)
+	if actualNumEvents != expectedNumEvents {
+		t.Fatalf("Failure decoding events from ABI. Expected number of events: %d, actual number of events: %d", expectedNumEvents, actualNumEvents)
+	}
+
+	expectedNumFunctions := 1
+	actualNumFunctions := len(decodedABI.Functions)
+	if actualNumFunctions != expectedNumFunctions {
+		t.Fatalf("Failure decoding functions from ABI. Expected number of functions: %d, actual number of functions: %d", expectedNumFunctions, actualNumFunctions)
+	}
+
+	expectedNumErrors := 0
+	actualNumErrors := len(decodedABI.Errors)
+	if actualNumErrors != expectedNumErrors {
+		t.Fatalf("Failure decoding errors from ABI. Expected number of errors: %d, actual number of errors: %d", expectedNumErrors, actualNumErrors)
+	}
+
+	actualFunction := decodedABI.Functions[0]
+	expectedName := "approve"
+	if actualFunction.Name != expectedName {
+		t.Fatalf("Expected function name: %s. Actual name: %s", expectedName, actualFunction.Name)
+	}
+	expectedType := "function"
+	if actualFunction.Type != expectedType {
+		t.Fatalf("Expected function type: %s. Actual type: %s", expectedType, actualFunction.Type)
+	}
+
+	expectedInputNames := []string{"spender", "amount"}
+	expectedInputTypes := []string{"address", "uint256"}
+	expectedInputsLength := 2
+	actualInputsLength := len(actualFunction.Inputs)
+	if actualInputsLength != expectedInputsLength {
+		t.Fatalf("Expected length of inputs: %d. Actual length of inputs: %d", expectedInputsLength, actualInputsLength)
+	}
+
+	for i, inputItem := range actualFunction.Inputs {
+		if inputItem.Name != expectedInputNames[i] {
+			t.Fatalf("Input item %d: Expected name: %s. Actual name: %s", i, expectedInputNames[i], inputItem.Name)
+		}
+		if inputItem.Type != expectedInputTypes[i] {
+			t.Fatalf("Input item %d: Expected type : %s. Actual type: %s", i, expectedInputTypes[i], inputItem.Type)
+		}
+	}
+
+	expectedOutputNames := []string{""}
+	expectedOutputTypes := []string{"bool"}
+	expectedOutputsLength := 1
+	actualOutputsLength := len(actualFunction.Outputs)
+	if actualOutputsLength != expectedOutputsLength {
+		t.Fatalf("Expected length of outputs: %d. Actual length of outputs: %d", expectedOutputsLength, actualOutputsLength)
+	}
+
+	for i, outputItem := range actualFunction.Outputs {
+		if outputItem.Name != expectedOutputNames[i] {
+			t.Fatalf("Output item %d: Expected name: %s. Actual name: %s", i, expectedOutputNames[i], outputItem.Name)
+		}
+		if outputItem.Type != expectedOutputTypes[i] {
+			t.Fatalf("Output item %d: Expected type : %s. Actual type: %s", i, expectedOutputTypes[i], outputItem.Type)
+		}
+	}
+}
+
+func TestERC20InterfaceID(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/ERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	decodedABI, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	annotations, err := Annotate(decodedABI)
+	if err != nil {
+		t.Fatalf("Could not generate annotations: %s", err.Error())
+	}
+
+	expectedInterfaceID := "36372b07"
+	interfaceId := hex.EncodeToString(annotations.InterfaceID)
+	if interfaceId != expectedInterfaceID {
+		t.Fatalf("Incorrect interface ID generated: expected: %s, actual: %s", expectedInterfaceID, interfaceId)
+	}
+}
+
+func TestDecodeSkippingInvalid(t *testing.T) {
+	var items = []byte(`[
+  {"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"},
+  "garbage",
+  {"type": "event", "name": "Broken", "inputs": 42},
+  {"type": "error", "name": "Unauthorized", "inputs": []}
+]`)
+
+	if _, decodeErr := Decode(items); decodeErr == nil {
+		t.Fatal("Expected Decode to fail on an ABI with invalid items")
+	}
+
+	decodedABI, diagnostics, decodeErr := DecodeSkippingInvalid(items)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	if len(decodedABI.Functions) != 1 || len(decodedABI.Events) != 0 || len(decodedABI.Errors) != 1 {
+		t.Fatalf("Unexpected decoded items. Functions: %d, events: %d, errors: %d", len(decodedABI.Functions), len(decodedABI.Events), len(decodedABI.Errors))
+	}
+
+	expectedIndices := []int{1, 2}
+	if len(diagnostics) != len(expectedIndices) {
+		t.Fatalf("Expected %d diagnostics. Actual: %d", len(expectedIndices), len(diagnostics))
+	}
+	for i, diagnostic := range diagnostics {
+		if diagnostic.ItemIndex != expectedIndices[i] {
+			t.Fatalf("Diagnostic %d: Expected item index: %d. Actual item index: %d", i, expectedIndices[i], diagnostic.ItemIndex)
+		}
+	}
+}
+
+func TestDecodeWithOptionsLimits(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/ERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	var limitErr *LimitExceededError
+
+	_, _, decodeErr := DecodeWithOptions(contents, Options{MaxInputBytes: 100})
+	if !errors.As(decodeErr, &limitErr) || limitErr.Limit != "input bytes" || limitErr.Actual != len(contents) {
+		t.Fatalf("Expected input bytes limit to be exceeded. Got: %v", decodeErr)
+	}
+
+	_, _, decodeErr = DecodeWithOptions(contents, Options{MaxItems: 3})
+	if !errors.As(decodeErr, &limitErr) || limitErr.Limit != "ABI items" {
+		t.Fatalf("Expected ABI items limit to be exceeded. Got: %v", decodeErr)
+	}
+
+	nested := []byte(`[{"type": "function", "name": "f", "inputs": [{"name": "a", "type": "tuple", "components": [{"name": "b", "type": "tuple", "components": [{"name": "c", "type": "uint256"}]}]}], "outputs": [], "stateMutability": "view"}]`)
+	var depthErr *NestingDepthError
+	_, _, decodeErr = DecodeWithOptions(nested, Options{MaxNestingDepth: 1})
+	if !errors.As(decodeErr, &depthErr) {
+		t.Fatalf("Expected nesting depth limit to be exceeded. Got: %v", decodeErr)
+	}
+
+	skipped := []byte(`[{"type": "function", "name": "f", "inputs": [{"name": "a", "type": "tuple", "components": [{"name": "b", "type": "tuple", "components": [{"name": "c", "type": "uint256"}]}]}], "outputs": [], "stateMutability": "view"}, {"type": "function", "name": "g", "inputs": [], "outputs": [], "stateMutability": "view"}]`)
+	skippedABI, diagnostics, decodeErr := DecodeWithOptions(skipped, Options{MaxNestingDepth: 1, SkipInvalid: true})
+	if decodeErr != nil {
+		t.Fatalf("Expected too deeply nested items to be skipped with SkipInvalid. Got: %s", decodeErr.Error())
+	}
+	if len(skippedABI.Functions) != 1 || skippedABI.Functions[0].Name != "g" {
+		t.Fatalf("Expected only function g to remain. Actual: %v", skippedABI.Functions)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Name != "f" || !strings.Contains(diagnostics[0].Message, "nested more than 1 levels deep: a -> b") {
+		t.Fatalf("Expected a diagnostic for the skipped function f. Actual: %v", diagnostics)
+	}
+
+	decodedABI, _, decodeErr := DecodeWithOptions(contents, Options{MaxInputBytes: len(contents), MaxItems: 9, MaxNestingDepth: 1})
+	if decodeErr != nil {
+		t.Fatalf("Expected ABI within limits to decode. Got: %s", decodeErr.Error())
+	}
+	if len(decodedABI.Functions) != 6 {
+		t.Fatalf("Expected 6 functions. Actual: %d", len(decodedABI.Functions))
+	}
+}
+
+func TestDecodeRemovesDuplicateItems(t *testing.T) {
+	rawABI := `[
+		{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"},
+		{"type": "event", "name": "Ping", "inputs": [], "anonymous": false},
+		{"stateMutability": "view", "outputs": [{"type": "address", "name": ""}], "inputs": [], "name": "owner", "type": "function"},
+		{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"},
+		{"type": "function", "name": "owner", "inputs": [{"name": "index", "type": "uint256"}], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}
+	]`
+
+	abi, diagnostics, decodeErr := DecodeWithOptions([]byte(rawABI), Options{})
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	// The overload owner(uint256) is not a duplicate.
+	if len(abi.Functions) != 2 || len(abi.Events) != 1 {
+		t.Fatalf("Expected 2 functions and 1 event. Actual: %d functions, %d events", len(abi.Functions), len(abi.Events))
+	}
+	if len(diagnostics) != 1 || diagnostics[0].ItemIndex != 0 || diagnostics[0].Message != "removed 2 duplicate(s) of this item" {
+		t.Fatalf("Expected a single diagnostic counting 2 duplicates of item 0. Actual: %v", diagnostics)
+	}
+	if len(abi.Positions) != 3 || abi.Positions[2].ABIIndex != 4 {
+		t.Fatalf("Expected positions to skip duplicates. Actual: %v", abi.Positions)
+	}
+}
+
+func TestDecodePreservesExtraFields(t *testing.T) {
+	rawABI := `[
+		{"constant": true, "inputs": [], "name": "owner", "outputs": [{"name": "", "type": "address"}], "payable": false, "type": "function", "gas": 1234, "signature": "0x8da5cb5b"},
+		{"type": "event", "name": "Ping", "inputs": [], "anonymous": false, "signature": "0x"},
+		{"type": "error", "name": "Unauthorized", "inputs": []}
+	]`
+
+	abi, decodeErr := Decode([]byte(rawABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	extras := abi.Functions[0].Extras
+	if len(extras) != 2 || string(extras["gas"]) != "1234" || string(extras["signature"]) != `"0x8da5cb5b"` {
+		t.Fatalf("Expected gas and signature extras on owner. Actual: %v", extras)
+	}
+	if !abi.Functions[0].Constant || NormalizedStateMutability(abi.Functions[0]) != "view" {
+		t.Fatalf("Expected owner to be decoded as a constant (view) function. Actual: %v", abi.Functions[0])
+	}
+	if len(abi.Events[0].Extras) != 1 {
+		t.Fatalf("Expected signature extra on Ping. Actual: %v", abi.Events[0].Extras)
+	}
+	if abi.Errors[0].Extras != nil {
+		t.Fatalf("Expected no extras on Unauthorized. Actual: %v", abi.Errors[0].Extras)
+	}
+}
+
+func TestDecodeLegacyMutability(t *testing.T) {
+	rawABI := `[
+		{"constant": true, "inputs": [], "name": "owner", "outputs": [{"name": "", "type": "address"}], "payable": false, "type": "function"},
+		{"constant": false, "inputs": [], "name": "buy", "outputs": [], "payable": true, "type": "function"},
+		{"constant": false, "inputs": [], "name": "renounceOwnership", "outputs": [], "payable": false, "type": "function"},
+		{"constant": true, "inputs": [], "name": "version", "outputs": [{"name": "", "type": "uint256"}], "payable": false, "stateMutability": "pure", "type": "function"}
+	]`
+
+	abi, decodeErr := Decode([]byte(rawABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	expectedMutabilities := []string{"view", "payable", "nonpayable", "pure"}
+	for i, functionItem := range abi.Functions {
+		if functionItem.StateMutability != expectedMutabilities[i] {
+			t.Fatalf("Expected %s to be %s. Actual: %s", functionItem.Name, expectedMutabilities[i], functionItem.StateMutability)
+		}
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterface("ILegacy", "", "", abi, Annotations{}, false, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	for _, expected := range []string{"function owner() external view returns (address);", "function buy() external payable;", "function renounceOwnership() external;"} {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain: %s\nActual:\n%s", expected, output.String())
+		}
+	}
+}
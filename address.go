@@ -0,0 +1,35 @@
+package solface
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Returned when a user-provided address is malformed, or is mixed-case but does not match its EIP-55
+// checksum.
+type InvalidAddressError struct {
+	Address string
+	Reason  string
+}
+
+func (e *InvalidAddressError) Error() string {
+	return fmt.Sprintf("invalid address %q: %s", e.Address, e.Reason)
+}
+
+// Validates the given hex address and returns its EIP-55 checksummed form. All-lowercase and all-uppercase
+// addresses are accepted as they are, since they carry no checksum, but mixed-case addresses must match
+// their checksum. Returns an *InvalidAddressError if the address is malformed or its checksum is wrong.
+func ChecksumAddress(address string) (string, error) {
+	if !common.IsHexAddress(address) {
+		return "", &InvalidAddressError{Address: address, Reason: "expected 20 bytes of hex"}
+	}
+
+	checksummed := common.HexToAddress(address).Hex()
+	digits := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && "0x"+digits != checksummed {
+		return "", &InvalidAddressError{Address: address, Reason: fmt.Sprintf("checksum mismatch (expected %s)", checksummed)}
+	}
+	return checksummed, nil
+}
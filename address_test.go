@@ -0,0 +1,44 @@
+package solface
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChecksumAddress(t *testing.T) {
+	type testCase struct {
+		input    string
+		expected string
+	}
+	testCases := []testCase{
+		{input: "0xca11bde05977b3631167028862be2a173976ca11", expected: "0xcA11bde05977b3631167028862bE2a173976CA11"},
+		{input: "0xCA11BDE05977B3631167028862BE2A173976CA11", expected: "0xcA11bde05977b3631167028862bE2a173976CA11"},
+		{input: "0xcA11bde05977b3631167028862bE2a173976CA11", expected: "0xcA11bde05977b3631167028862bE2a173976CA11"},
+	}
+	for _, testCase := range testCases {
+		checksummed, checksumErr := ChecksumAddress(testCase.input)
+		if checksumErr != nil {
+			t.Fatalf("Unexpected error checksumming %s: %s", testCase.input, checksumErr.Error())
+		}
+		if checksummed != testCase.expected {
+			t.Fatalf("Expected: %s. Actual: %s", testCase.expected, checksummed)
+		}
+	}
+
+	invalidInputs := []string{"0x1234", "0xca11bde05977b3631167028862be2a173976ca1g", "0xCa11bde05977b3631167028862bE2a173976CA11"}
+	for _, input := range invalidInputs {
+		var addressErr *InvalidAddressError
+		_, checksumErr := ChecksumAddress(input)
+		if !errors.As(checksumErr, &addressErr) {
+			t.Fatalf("Expected an InvalidAddressError for %s. Actual: %v", input, checksumErr)
+		}
+	}
+
+	artifact, artifactErr := ParseArtifact([]byte(`{"contractName": "Migrations", "abi": [], "networks": {"1": {"address": "0x5b38Da6a701c568545dCfcB03FcB875f56beddC4"}}}`))
+	if artifactErr != nil {
+		t.Fatalf("Unexpected error parsing artifact with a badly checksummed deployment: %s", artifactErr.Error())
+	}
+	if len(artifact.Deployments) != 0 || len(artifact.Diagnostics) != 1 {
+		t.Fatalf("Expected the badly checksummed deployment to be skipped with a diagnostic. Actual: %v, %v", artifact.Deployments, artifact.Diagnostics)
+	}
+}
@@ -0,0 +1,164 @@
+// Package addressbook resolves well-known protocol names (e.g. "uniswap-v3-factory") to the addresses of
+// their deployments on different chains, so that interfaces to common contracts can be generated without
+// copying addresses around.
+package addressbook
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/moonstream-to/solface"
+)
+
+//go:embed addresses.json
+var embeddedAddresses []byte
+
+// The chain that protocol names are resolved on if no chain is specified.
+const DefaultChain = "ethereum"
+
+// Alternative names for chains in the address book.
+var chainAliases = map[string]string{
+	"mainnet":      "ethereum",
+	"eth":          "ethereum",
+	"arbitrum-one": "arbitrum",
+	"matic":        "polygon",
+}
+
+// Represents a mapping from protocol names to the addresses of their deployments, by chain.
+type AddressBook map[string]map[string]common.Address
+
+// Returned when a protocol is not in the address book, or is not deployed on the requested chain.
+type UnknownEntryError struct {
+	Protocol string
+	Chain    string
+	// The chains that the protocol is known to be deployed on (empty if the protocol is unknown).
+	KnownChains []string
+}
+
+func (e *UnknownEntryError) Error() string {
+	if len(e.KnownChains) == 0 {
+		return fmt.Sprintf("unknown protocol: %s", e.Protocol)
+	}
+	return fmt.Sprintf("no address for protocol %s on chain %s (known chains: %s)", e.Protocol, e.Chain, strings.Join(e.KnownChains, ", "))
+}
+
+// Returns the canonical name of the given chain, resolving aliases like "mainnet" and the chain IDs of
+// known chains (e.g. "8453" is "base", see solface.ChainID).
+func NormalizeChain(chain string) string {
+	chain = strings.ToLower(strings.TrimSpace(chain))
+	if chain == "" {
+		return DefaultChain
+	}
+	if canonical, ok := chainAliases[chain]; ok {
+		return canonical
+	}
+	if chainID, parseErr := strconv.ParseUint(chain, 10, 64); parseErr == nil {
+		for _, name := range solface.ChainNames() {
+			if knownID, _ := solface.ChainID(name); knownID == chainID {
+				return name
+			}
+		}
+	}
+	return chain
+}
+
+// Parses an address book from its JSON representation: an object mapping protocol names to objects which
+// map chain names to addresses. Mixed-case addresses must match their EIP-55 checksum.
+func Parse(rawJSON []byte) (AddressBook, error) {
+	var raw map[string]map[string]string
+	unmarshalErr := json.Unmarshal(rawJSON, &raw)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	book := AddressBook{}
+	for protocol, deployments := range raw {
+		book[protocol] = map[string]common.Address{}
+		for chain, address := range deployments {
+			checksummed, checksumErr := solface.ChecksumAddress(address)
+			if checksumErr != nil {
+				return nil, fmt.Errorf("invalid address for protocol %s on chain %s: %s", protocol, chain, checksumErr.Error())
+			}
+			book[protocol][NormalizeChain(chain)] = common.HexToAddress(checksummed)
+		}
+	}
+	return book, nil
+}
+
+// Returns the address book embedded in solface.
+func Default() AddressBook {
+	book, parseErr := Parse(embeddedAddresses)
+	if parseErr != nil {
+		panic(fmt.Sprintf("could not parse embedded address book: %s", parseErr.Error()))
+	}
+	return book
+}
+
+// Returns the embedded address book, updated with the entries in the address book file at the given path
+// (entries in the file take precedence). If the path is empty, this returns the embedded address book.
+func Load(path string) (AddressBook, error) {
+	book := Default()
+	if path == "" {
+		return book, nil
+	}
+
+	contents, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, readErr
+	}
+	overrides, parseErr := Parse(contents)
+	if parseErr != nil {
+		return nil, fmt.Errorf("could not parse address book %s: %s", path, parseErr.Error())
+	}
+	book.Merge(overrides)
+	return book, nil
+}
+
+// Adds the entries of the other address book to this one, overwriting existing entries.
+func (book AddressBook) Merge(other AddressBook) {
+	for protocol, deployments := range other {
+		if _, ok := book[protocol]; !ok {
+			book[protocol] = map[string]common.Address{}
+		}
+		for chain, address := range deployments {
+			book[protocol][chain] = address
+		}
+	}
+}
+
+// Returns the protocols in the address book, sorted by name.
+func (book AddressBook) Protocols() []string {
+	protocols := make([]string, 0, len(book))
+	for protocol := range book {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+	return protocols
+}
+
+// Returns the address of the given protocol on the given chain (an empty chain refers to DefaultChain).
+// Returns an *UnknownEntryError if there is no such entry.
+func (book AddressBook) Resolve(protocol, chain string) (common.Address, error) {
+	chain = NormalizeChain(chain)
+	deployments, ok := book[strings.ToLower(protocol)]
+	if !ok {
+		return common.Address{}, &UnknownEntryError{Protocol: protocol, Chain: chain}
+	}
+
+	address, ok := deployments[chain]
+	if !ok {
+		knownChains := make([]string, 0, len(deployments))
+		for knownChain := range deployments {
+			knownChains = append(knownChains, knownChain)
+		}
+		sort.Strings(knownChains)
+		return common.Address{}, &UnknownEntryError{Protocol: protocol, Chain: chain, KnownChains: knownChains}
+	}
+	return address, nil
+}
@@ -0,0 +1,85 @@
+package addressbook
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveDefault(t *testing.T) {
+	book := Default()
+
+	address, resolveErr := book.Resolve("uniswap-v3-factory", "arbitrum")
+	if resolveErr != nil {
+		t.Fatalf("Error resolving address: %s", resolveErr.Error())
+	}
+	if address.Hex() != "0x1F98431c8aD98523631AE4a59f267346ea31F984" {
+		t.Fatalf("Expected address: 0x1F98431c8aD98523631AE4a59f267346ea31F984. Actual: %s", address.Hex())
+	}
+
+	mainnetAddress, resolveErr := book.Resolve("weth", "mainnet")
+	if resolveErr != nil {
+		t.Fatalf("Error resolving address: %s", resolveErr.Error())
+	}
+	defaultAddress, resolveErr := book.Resolve("weth", "")
+	if resolveErr != nil {
+		t.Fatalf("Error resolving address: %s", resolveErr.Error())
+	}
+	if mainnetAddress != defaultAddress {
+		t.Fatalf("Expected mainnet and the default chain to resolve to the same address. Actual: %s, %s", mainnetAddress.Hex(), defaultAddress.Hex())
+	}
+
+	chainIDAddress, resolveErr := book.Resolve("uniswap-v3-factory", "42161")
+	if resolveErr != nil {
+		t.Fatalf("Error resolving address by chain ID: %s", resolveErr.Error())
+	}
+	if chainIDAddress != address {
+		t.Fatalf("Expected chain ID 42161 to resolve like arbitrum. Actual: %s", chainIDAddress.Hex())
+	}
+}
+
+func TestResolveUnknown(t *testing.T) {
+	book := Default()
+
+	var unknownErr *UnknownEntryError
+	_, resolveErr := book.Resolve("not-a-protocol", "ethereum")
+	if !errors.As(resolveErr, &unknownErr) || len(unknownErr.KnownChains) != 0 {
+		t.Fatalf("Expected an UnknownEntryError without known chains. Actual: %v", resolveErr)
+	}
+
+	_, resolveErr = book.Resolve("uniswap-v2-factory", "arbitrum")
+	if !errors.As(resolveErr, &unknownErr) || len(unknownErr.KnownChains) != 1 || unknownErr.KnownChains[0] != "ethereum" {
+		t.Fatalf("Expected an UnknownEntryError listing chain ethereum. Actual: %v", resolveErr)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "addresses.json")
+	contents := `{"my-protocol": {"Mainnet": "0x000000000000000000000000000000000000dEaD"}, "weth": {"sepolia": "0x7b79995e5f793A07Bc00c21412e50Ecae098E7f9"}}`
+	if writeErr := os.WriteFile(path, []byte(contents), 0644); writeErr != nil {
+		t.Fatalf("Could not write address book: %s", writeErr.Error())
+	}
+
+	book, loadErr := Load(path)
+	if loadErr != nil {
+		t.Fatalf("Error loading address book: %s", loadErr.Error())
+	}
+
+	if _, resolveErr := book.Resolve("my-protocol", "ethereum"); resolveErr != nil {
+		t.Fatalf("Expected my-protocol to resolve on ethereum. Got: %s", resolveErr.Error())
+	}
+	if _, resolveErr := book.Resolve("weth", "sepolia"); resolveErr != nil {
+		t.Fatalf("Expected weth to resolve on sepolia. Got: %s", resolveErr.Error())
+	}
+	if _, resolveErr := book.Resolve("weth", "arbitrum"); resolveErr != nil {
+		t.Fatalf("Expected embedded entries to be kept. Got: %s", resolveErr.Error())
+	}
+}
+
+func TestParseInvalidAddress(t *testing.T) {
+	_, parseErr := Parse([]byte(`{"weth": {"ethereum": "0x1234"}}`))
+	if parseErr == nil {
+		t.Fatal("Expected an error for an invalid address")
+	}
+}
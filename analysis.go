@@ -0,0 +1,101 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Represents a function in an analysis report.
+type ReportedFunction struct {
+	Signature       string `json:"signature"`
+	Selector        string `json:"selector"`
+	StateMutability string `json:"stateMutability"`
+}
+
+// Represents a group of functions which share a name prefix (e.g. "get", "set", "on").
+type FunctionCluster struct {
+	Prefix    string             `json:"prefix"`
+	Count     int                `json:"count"`
+	Functions []ReportedFunction `json:"functions"`
+}
+
+func reportFunction(functionItem FunctionItem) ReportedFunction {
+	return ReportedFunction{Signature: FunctionSignature(functionItem), Selector: hex.EncodeToString(MethodSelector(functionItem)), StateMutability: functionItem.StateMutability}
+}
+
+// Returns the prefix of a function name: its first word in camelCase or snake_case names. Leading
+// underscores are ignored. For example, "getOrderHash" has prefix "get", "onERC721Received" has prefix
+// "on", "admin_withdraw" has prefix "admin", and "DOMAIN_SEPARATOR" has prefix "DOMAIN".
+func NamePrefix(name string) string {
+	trimmed := strings.TrimLeft(name, "_")
+	if underscoreIndex := strings.Index(trimmed, "_"); underscoreIndex > 0 {
+		trimmed = trimmed[:underscoreIndex]
+	}
+
+	runes := []rune(trimmed)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsLower(runes[0]) && !unicode.IsLower(runes[i]) {
+			return string(runes[:i])
+		}
+	}
+	return trimmed
+}
+
+// Groups the functions of an ABI by name prefix (see NamePrefix). Clusters are sorted by decreasing size
+// (and then by prefix), and the functions in each cluster are sorted by signature.
+func ClusterFunctions(abi DecodedABI) []FunctionCluster {
+	clustersByPrefix := map[string]*FunctionCluster{}
+	for _, functionItem := range abi.Functions {
+		prefix := NamePrefix(functionItem.Name)
+		if _, ok := clustersByPrefix[prefix]; !ok {
+			clustersByPrefix[prefix] = &FunctionCluster{Prefix: prefix, Functions: []ReportedFunction{}}
+		}
+		cluster := clustersByPrefix[prefix]
+		cluster.Functions = append(cluster.Functions, reportFunction(functionItem))
+		cluster.Count++
+	}
+
+	clusters := make([]FunctionCluster, 0, len(clustersByPrefix))
+	for _, cluster := range clustersByPrefix {
+		sort.Slice(cluster.Functions, func(i, j int) bool { return cluster.Functions[i].Signature < cluster.Functions[j].Signature })
+		clusters = append(clusters, *cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Count != clusters[j].Count {
+			return clusters[i].Count > clusters[j].Count
+		}
+		return clusters[i].Prefix < clusters[j].Prefix
+	})
+
+	return clusters
+}
+
+// Writes a markdown report of the given function clusters.
+func WriteClustersMarkdown(clusters []FunctionCluster, writer io.Writer) error {
+	_, writeErr := fmt.Fprintf(writer, "# Functions by prefix\n\n| Prefix | Count |\n| --- | --- |\n")
+	if writeErr != nil {
+		return writeErr
+	}
+	for _, cluster := range clusters {
+		if _, writeErr := fmt.Fprintf(writer, "| `%s` | %d |\n", cluster.Prefix, cluster.Count); writeErr != nil {
+			return writeErr
+		}
+	}
+
+	for _, cluster := range clusters {
+		if _, writeErr := fmt.Fprintf(writer, "\n## `%s` (%d)\n\n| Selector | Signature | Mutability |\n| --- | --- | --- |\n", cluster.Prefix, cluster.Count); writeErr != nil {
+			return writeErr
+		}
+		for _, function := range cluster.Functions {
+			if _, writeErr := fmt.Fprintf(writer, "| `%s` | `%s` | %s |\n", function.Selector, function.Signature, function.StateMutability); writeErr != nil {
+				return writeErr
+			}
+		}
+	}
+
+	return nil
+}
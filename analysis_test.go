@@ -0,0 +1,51 @@
+package solface
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNamePrefix(t *testing.T) {
+	testCases := map[string]string{
+		"getOrderHash":     "get",
+		"onERC721Received": "on",
+		"admin_withdraw":   "admin",
+		"DOMAIN_SEPARATOR": "DOMAIN",
+		"_setOwner":        "set",
+		"transfer":         "transfer",
+	}
+
+	for name, expectedPrefix := range testCases {
+		actualPrefix := NamePrefix(name)
+		if actualPrefix != expectedPrefix {
+			t.Fatalf("Incorrect prefix for %s. Expected: %s, actual: %s", name, expectedPrefix, actualPrefix)
+		}
+	}
+}
+
+func TestClusterFunctionsOwnableERC20(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	clusters := ClusterFunctions(abi)
+
+	// transfer, transferFrom, and transferOwnership share the "transfer" prefix.
+	if clusters[0].Prefix != "transfer" || clusters[0].Count != 3 {
+		t.Fatalf("Expected largest cluster to be transfer with 3 functions. Actual: %s with %d functions", clusters[0].Prefix, clusters[0].Count)
+	}
+
+	total := 0
+	for _, cluster := range clusters {
+		total += cluster.Count
+	}
+	if total != len(abi.Functions) {
+		t.Fatalf("Expected clusters to contain all %d functions. Actual: %d", len(abi.Functions), total)
+	}
+}
@@ -0,0 +1,88 @@
+package solface
+
+import (
+	"io"
+	"text/template"
+)
+
+// Represents an identifier (a selector or topic) listed in an annotations block.
+//  1. Signature: The signature the identifier was derived from.
+//  2. Bytes: The identifier itself.
+type AnnotatedIdentifier struct {
+	Signature string
+	Bytes     []byte
+}
+
+// Represents the inputs to AnnotationsTemplate.
+type AnnotationsSpecification struct {
+	Name           string
+	SolfaceVersion string
+	InterfaceID    []byte
+	Selectors      []AnnotatedIdentifier
+	Topics         []AnnotatedIdentifier
+	ErrorSelectors []AnnotatedIdentifier
+}
+
+// This is the Go template used to generate annotation blocks. The template is meant to be applied to
+// AnnotationsSpecification structs.
+var AnnotationsTemplate string = `// Annotations generated by solface: https://github.com/moonstream-to/solface
+// solface version: {{.SolfaceVersion}}
+{{- if .Name}}
+// Interface: {{.Name}}
+{{- end}}
+// Interface ID: {{printf "%x" .InterfaceID}}
+{{- if .Selectors}}
+// Function selectors:
+{{- range .Selectors}}
+//   {{printf "%x" .Bytes}}: {{.Signature}}
+{{- end}}
+{{- end}}
+{{- if .Topics}}
+// Event topics:
+{{- range .Topics}}
+//   {{printf "%x" .Bytes}}: {{.Signature}}
+{{- end}}
+{{- end}}
+{{- if .ErrorSelectors}}
+// Error selectors:
+{{- range .ErrorSelectors}}
+//   {{printf "%x" .Bytes}}: {{.Signature}}
+{{- end}}
+{{- end}}
+`
+
+// Generates only the annotations for the given ABI (interface ID, function selectors, event topics, and
+// error selectors) as a block of comments, without the interface itself. The block is meant to be pasted
+// into hand-maintained interface files. Identifiers are derived with options.Hasher. Anonymous events are
+// not listed, since they do not emit a topic.
+func GenerateAnnotations(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	hasher := hasherOrDefault(options.Hasher)
+	spec := AnnotationsSpecification{
+		Name:           options.Name,
+		SolfaceVersion: VERSION,
+		InterfaceID:    annotations.InterfaceID,
+	}
+	if spec.InterfaceID == nil {
+		spec.InterfaceID = []byte{0x0, 0x0, 0x0, 0x0}
+	}
+
+	for _, functionItem := range abi.Functions {
+		spec.Selectors = append(spec.Selectors, AnnotatedIdentifier{Signature: FunctionSignature(functionItem), Bytes: MethodSelectorWithHasher(functionItem, hasher)})
+	}
+	for _, eventItem := range abi.Events {
+		if eventItem.Anonymous {
+			continue
+		}
+		spec.Topics = append(spec.Topics, AnnotatedIdentifier{Signature: EventSignature(eventItem), Bytes: EventTopicWithHasher(eventItem, hasher)})
+	}
+	for _, errorItem := range abi.Errors {
+		signature := ErrorSignature(errorItem)
+		spec.ErrorSelectors = append(spec.ErrorSelectors, AnnotatedIdentifier{Signature: signature, Bytes: hasher.Hash([]byte(signature))[:4]})
+	}
+
+	templ, templateParseErr := template.New("annotations").Parse(AnnotationsTemplate)
+	if templateParseErr != nil {
+		return templateParseErr
+	}
+	return templ.Execute(writer, spec)
+}
@@ -0,0 +1,54 @@
+package solface
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAnnotations(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatalf("Could not read file containing ABI: %s", readErr.Error())
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	errorABI, decodeErr := Decode([]byte(`[{"type": "error", "name": "InsufficientBalance", "inputs": [{"name": "available", "type": "uint256"}, {"name": "required", "type": "uint256"}]}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	abi.Errors = errorABI.Errors
+
+	annotations, annotationErr := Annotate(abi)
+	if annotationErr != nil {
+		t.Fatalf("Could not annotate ABI: %s", annotationErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateAnnotations(abi, annotations, Options{Name: "IOwnableERC20"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating annotations: %s", generateErr.Error())
+	}
+
+	expectedLines := []string{
+		"// Interface: IOwnableERC20",
+		"// Interface ID: 47e0e5cb",
+		"// Function selectors:\n//   dd62ed3e: allowance(address,address)\n",
+		"//   a9059cbb: transfer(address,uint256)\n",
+		"// Event topics:\n",
+		"//   ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef: Transfer(address,address,uint256)\n",
+		"// Error selectors:\n//   cf479181: InsufficientBalance(uint256,uint256)\n",
+	}
+	for _, expected := range expectedLines {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n") {
+		if !strings.HasPrefix(line, "//") {
+			t.Fatalf("Expected every line to be a comment. Actual: %q", line)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package solface
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Matches the outermost dimension of an array type, e.g. "[3]" in "uint256[][3]".
+var arrayDimensionRegexp = regexp.MustCompile(`\[\s*(\d*)\s*\]$`)
+
+// Represents a dynamic dimension of an array type (see ParseArrayType).
+const DynamicDimension = -1
+
+// Splits the given type into the type of its innermost elements and its array dimensions, innermost first,
+// with DynamicDimension for dynamic dimensions. For example, "uint256[][3]" (an array of 3 dynamic arrays
+// of uint256) is split into "uint256" and [-1, 3], and "tuple[2][]" into "tuple" and [2, -1]. Types which
+// are not arrays have no dimensions. Returns an error if a dimension is malformed or zero.
+func ParseArrayType(solidityType string) (string, []int, error) {
+	element := strings.TrimSpace(solidityType)
+	dimensions := []int{}
+	for strings.HasSuffix(element, "]") {
+		match := arrayDimensionRegexp.FindStringSubmatch(element)
+		if match == nil {
+			return "", nil, fmt.Errorf("malformed array type %q", solidityType)
+		}
+		dimension := DynamicDimension
+		if match[1] != "" {
+			length, lengthErr := strconv.Atoi(match[1])
+			if lengthErr != nil {
+				return "", nil, fmt.Errorf("malformed array type %q: dimension %s is out of range", solidityType, match[1])
+			}
+			if length == 0 {
+				return "", nil, fmt.Errorf("array type %q has a zero-length dimension", solidityType)
+			}
+			dimension = length
+		}
+		dimensions = append([]int{dimension}, dimensions...)
+		element = strings.TrimSpace(strings.TrimSuffix(element, match[0]))
+	}
+	if element == "" || strings.ContainsAny(element, "[]") {
+		return "", nil, fmt.Errorf("malformed array type %q", solidityType)
+	}
+	return element, dimensions, nil
+}
+
+// Returns the array suffix for the given dimensions (innermost first, see ParseArrayType), e.g. "[][3]".
+func FormatArrayDimensions(dimensions []int) string {
+	var builder strings.Builder
+	for _, dimension := range dimensions {
+		if dimension == DynamicDimension {
+			builder.WriteString("[]")
+		} else {
+			fmt.Fprintf(&builder, "[%d]", dimension)
+		}
+	}
+	return builder.String()
+}
+
+// Returns the type of the innermost elements of the given (possibly array) type, and its array suffix in
+// canonical form (e.g. "uint256" and "[][3]" for "uint256[ ][3]"). Malformed array types are split at
+// their first bracket.
+func splitArrayDimensions(solidityType string) (string, string) {
+	element, dimensions, parseErr := ParseArrayType(solidityType)
+	if parseErr != nil {
+		if bracket := strings.Index(solidityType, "["); bracket >= 0 {
+			return solidityType[:bracket], solidityType[bracket:]
+		}
+		return solidityType, ""
+	}
+	return element, FormatArrayDimensions(dimensions)
+}
@@ -0,0 +1,103 @@
+package solface
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseArrayType(t *testing.T) {
+	type testCase struct {
+		input      string
+		element    string
+		dimensions []int
+	}
+
+	testCases := []testCase{
+		{"uint256", "uint256", []int{}},
+		{"tuple[]", "tuple", []int{DynamicDimension}},
+		{"tuple[][]", "tuple", []int{DynamicDimension, DynamicDimension}},
+		{"uint256[][3]", "uint256", []int{DynamicDimension, 3}},
+		{"bytes[2][]", "bytes", []int{2, DynamicDimension}},
+		{"tuple[2][3][]", "tuple", []int{2, 3, DynamicDimension}},
+		{"address[ 4 ][ ]", "address", []int{4, DynamicDimension}},
+	}
+
+	for _, c := range testCases {
+		element, dimensions, parseErr := ParseArrayType(c.input)
+		if parseErr != nil {
+			t.Fatalf("Error parsing %s: %s", c.input, parseErr.Error())
+		}
+		if element != c.element || !reflect.DeepEqual(dimensions, c.dimensions) {
+			t.Fatalf("Expected %s to be split into %s and %v. Actual: %s and %v", c.input, c.element, c.dimensions, element, dimensions)
+		}
+	}
+
+	for _, input := range []string{"uint256[0]", "uint256[", "uint256]", "uint256[x]", "uint256[-1]", "[3]", "uint256[2[]]", "uint256[99999999999999999999]"} {
+		if _, _, parseErr := ParseArrayType(input); parseErr == nil {
+			t.Fatalf("Expected an error parsing %s", input)
+		}
+	}
+
+	// Dimensions which are out of range are kept as they are, rather than rewritten to another length.
+	if element, suffix := splitArrayDimensions("tuple[99999999999999999999]"); element != "tuple" || suffix != "[99999999999999999999]" {
+		t.Fatalf("Expected tuple[99999999999999999999] to be split into tuple and [99999999999999999999]. Actual: %s and %s", element, suffix)
+	}
+}
+
+func TestFormatArrayDimensions(t *testing.T) {
+	for _, input := range []string{"uint256", "tuple[][]", "uint256[][3]", "bytes[2][]", "string[1][2][3]"} {
+		element, dimensions, parseErr := ParseArrayType(input)
+		if parseErr != nil {
+			t.Fatalf("Error parsing %s: %s", input, parseErr.Error())
+		}
+		if element+FormatArrayDimensions(dimensions) != input {
+			t.Fatalf("Expected %s to be formatted back. Actual: %s", input, element+FormatArrayDimensions(dimensions))
+		}
+	}
+
+	elementType, arraySuffix := splitArrayType("uint256[][3]")
+	if elementType != "uint256[]" || arraySuffix != "[3]" {
+		t.Fatalf("Expected uint256[][3] to be split into uint256[] and [3]. Actual: %s and %s", elementType, arraySuffix)
+	}
+}
+
+func TestGenerateInterfaceNestedArrays(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "route", "stateMutability": "view", "inputs": [
+			{"name": "amounts", "type": "uint256[][3]", "internalType": "uint256[][3]"},
+			{"name": "payloads", "type": "bytes[2][]", "internalType": "bytes[2][]"},
+			{"name": "fees", "type": "uint24[3][2]", "internalType": "uint24[3][2]"}
+		], "outputs": [
+			{"name": "hops", "type": "tuple[][]", "internalType": "struct Router.Hop[][]", "components": [
+				{"name": "pool", "type": "address", "internalType": "address"},
+				{"name": "path", "type": "bytes", "internalType": "bytes"}
+			]}
+		]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IRouter"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expected := "function route(uint256[][3] memory amounts, bytes[2][] memory payloads, uint24[3][2] memory fees) external view returns (Hop0[][] memory hops);"
+	if !strings.Contains(output.String(), expected) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+	}
+	expectedSignature := "route(uint256[][3],bytes[2][],uint24[3][2])"
+	if FunctionSignature(abi.Functions[0]) != expectedSignature {
+		t.Fatalf("Expected signature %s. Actual: %s", expectedSignature, FunctionSignature(abi.Functions[0]))
+	}
+
+	humanReadable, parseErr := Decode([]byte(`function route(uint[][3] amounts, tuple(address pool, bytes path)[][] hops)`))
+	if parseErr != nil {
+		t.Fatalf("Error decoding human-readable ABI: %s", parseErr.Error())
+	}
+	if FunctionSignature(humanReadable.Functions[0]) != "route(uint256[][3],(address,bytes)[][])" {
+		t.Fatalf("Expected signature route(uint256[][3],(address,bytes)[][]). Actual: %s", FunctionSignature(humanReadable.Functions[0]))
+	}
+}
@@ -0,0 +1,391 @@
+package solface
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kinds of inputs which solface can extract ABIs from.
+const (
+	ArtifactKindABI      = "abi"
+	ArtifactKindHuman    = "human-readable"
+	ArtifactKindHardhat  = "hardhat"
+	ArtifactKindFoundry  = "foundry"
+	ArtifactKindTruffle  = "truffle"
+	ArtifactKindBrownie  = "brownie"
+	ArtifactKindCombined = "combined-json"
+	ArtifactKindStandard = "standard-json"
+	ArtifactKindBundle   = "bundle"
+	ArtifactKindArtifact = "artifact"
+	ArtifactKindJS       = "javascript"
+)
+
+// Represents the ABI extracted from an input, along with information about the input.
+//  1. Kind: The kind of input - ArtifactKindABI for raw ABI arrays, ArtifactKindHuman for human-readable
+//     ABIs (see ParseHumanReadableABI), ArtifactKindHardhat for Hardhat
+//     artifacts, ArtifactKindFoundry for Foundry artifacts, ArtifactKindTruffle for Truffle artifacts,
+//     ArtifactKindBrownie for Brownie artifacts, ArtifactKindCombined for contracts in solc --combined-json output, ArtifactKindStandard for
+//     contracts in solc --standard-json output, ArtifactKindBundle for entries of named ABI bundles (see
+//     ParseArtifacts), ArtifactKindJS for ABIs extracted from JavaScript or TypeScript source (see
+//     ParseJSSource), and ArtifactKindArtifact for any other JSON object with an "abi" field.
+//  2. ContractName: The name of the contract the artifact was compiled from (empty if unknown).
+//  3. SourceName: The path of the source file which defines the contract (empty if unknown).
+//  4. ABI: The raw ABI JSON array (converted to JSON for human-readable ABIs).
+//  5. Raw: The JSON object describing the contract, from which compiler metadata and devdoc can be read.
+//     This is the whole input, except for combined-json and standard-json output, where it is the entry
+//     for the contract.
+//  6. MethodIdentifiers: Maps function signatures to their hex-encoded selectors, as computed by the
+//     compiler (nil if the artifact does not record them).
+//  7. Deployments: Maps network IDs to the addresses at which the contract is deployed on those networks
+//     (nil if the artifact does not record them).
+//  8. Diagnostics: Problems with parts of the artifact which were skipped, such as deployments with invalid
+//     addresses.
+type Artifact struct {
+	Kind              string
+	ContractName      string
+	SourceName        string
+	ABI               []byte
+	Raw               []byte
+	MethodIdentifiers map[string]string
+	Deployments       map[string]string
+	Diagnostics       []Diagnostic
+}
+
+// Returns true if the given JSON is an object (as opposed to an array or scalar).
+func isJSONObject(rawJSON []byte) bool {
+	trimmed := bytes.TrimSpace(rawJSON)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// Represents the failure to extract a single ABI from an input which describes several contracts.
+type MultipleContractsError struct {
+	Contracts []string
+}
+
+func (e *MultipleContractsError) Error() string {
+	return fmt.Sprintf("input contains %d contracts (%s) - select one of them", len(e.Contracts), strings.Join(e.Contracts, ", "))
+}
+
+// Extracts the ABI from the given input, which may either be a raw ABI array or a compilation artifact
+// (see ParseArtifacts). Inputs which describe more than one contract result in a MultipleContractsError.
+func ParseArtifact(rawJSON []byte) (Artifact, error) {
+	artifacts, artifactsErr := ParseArtifacts(rawJSON)
+	if artifactsErr != nil {
+		return Artifact{}, artifactsErr
+	}
+	if len(artifacts) != 1 {
+		return Artifact{}, &MultipleContractsError{Contracts: ArtifactNames(artifacts)}
+	}
+	return artifacts[0], nil
+}
+
+// Extracts the ABIs from the given input, which may either be a raw ABI array, a human-readable ABI (see
+// ParseHumanReadableABI), a compilation artifact
+// describing a single contract, or the output of solc --combined-json abi or solc --standard-json (in
+// which case there is one artifact for every contract, sorted by source path and contract name).
+//
+// Inputs may also be named ABI bundles: JSON objects mapping contract names to ABIs (or to artifacts), e.g.
+// {"ERC20": [...], "Vault": [...]}. There is one artifact for every entry, sorted by name.
+//
+// Hardhat artifacts (https://hardhat.org/hardhat-runner/docs/advanced/artifacts) are recognized by their
+// "_format" field, or by having "contractName", "abi", and "bytecode" fields. Foundry artifacts (as written
+// by forge build) are recognized by their "methodIdentifiers" field, or by having "bytecode" objects; their
+// contract name is taken from the compilation target in their metadata. Truffle artifacts (as written to
+// build/contracts by truffle compile) are recognized by their "schemaVersion" or "networks" fields. Brownie
+// artifacts (as written to build/contracts by brownie compile) are recognized by their "bytecodeSha1",
+// "pcMap", or "allSourcePaths" fields.
+//
+// Any of these inputs may also be hex- or base64-encoded (see DecodeEncodedJSON). JavaScript and
+// TypeScript source files are searched for ABI arrays (see ParseJSSource).
+func ParseArtifacts(rawJSON []byte) ([]Artifact, error) {
+	if decoded, ok := DecodeEncodedJSON(rawJSON); ok {
+		rawJSON = decoded
+	}
+	if isJSSource(rawJSON) {
+		return parseJSArtifacts(rawJSON)
+	}
+
+	if fragments, ok := humanReadableFragments(rawJSON); ok {
+		rawABI, parseErr := ParseHumanReadableABI(fragments)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		return []Artifact{{Kind: ArtifactKindHuman, ABI: rawABI}}, nil
+	}
+
+	if !isJSONObject(rawJSON) {
+		return []Artifact{{Kind: ArtifactKindABI, ABI: rawJSON}}, nil
+	}
+
+	var fields map[string]json.RawMessage
+	unmarshalErr := json.Unmarshal(rawJSON, &fields)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	if _, hasABI := fields["abi"]; !hasABI && isJSONObject(fields["contracts"]) {
+		return parseCompilerContracts(fields["contracts"])
+	}
+
+	if isBundle(fields) {
+		return parseBundle(fields)
+	}
+
+	artifact, artifactErr := parseArtifactObject(rawJSON, fields)
+	if artifactErr != nil {
+		return nil, artifactErr
+	}
+	return []Artifact{artifact}, nil
+}
+
+// Returns true if the given JSON object is a named ABI bundle, i.e. a non-empty object without an "abi"
+// field, every value of which is either an array (an ABI) or an object with an "abi" field (an artifact).
+func isBundle(fields map[string]json.RawMessage) bool {
+	if _, hasABI := fields["abi"]; hasABI || len(fields) == 0 {
+		return false
+	}
+	for _, value := range fields {
+		trimmed := bytes.TrimSpace(value)
+		if len(trimmed) > 0 && trimmed[0] == '[' {
+			continue
+		}
+		var entry map[string]json.RawMessage
+		if !isJSONObject(value) || json.Unmarshal(value, &entry) != nil {
+			return false
+		}
+		if _, hasABI := entry["abi"]; !hasABI {
+			return false
+		}
+	}
+	return true
+}
+
+// Parses the entries of a named ABI bundle (see isBundle), naming each artifact after its key.
+func parseBundle(fields map[string]json.RawMessage) ([]Artifact, error) {
+	artifacts := []Artifact{}
+	for _, name := range sortedKeys(fields) {
+		entryArtifacts, entryErr := ParseArtifacts(fields[name])
+		if entryErr != nil {
+			return nil, fmt.Errorf("could not parse bundle entry %s: %s", name, entryErr.Error())
+		}
+		if len(entryArtifacts) != 1 {
+			return nil, fmt.Errorf("bundle entry %s contains %d contracts", name, len(entryArtifacts))
+		}
+		artifact := entryArtifacts[0]
+		artifact.Kind = ArtifactKindBundle
+		artifact.ContractName = name
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
+// Parses the "contracts" object of solc output. In --combined-json output, it maps
+// "<source path>:<contract name>" to an object with the requested outputs for that contract (older
+// versions of solc encode the ABI as a JSON string rather than an array). In --standard-json output, it
+// maps source paths to objects which map contract names to their outputs.
+func parseCompilerContracts(rawContracts []byte) ([]Artifact, error) {
+	var contracts map[string]json.RawMessage
+	unmarshalErr := json.Unmarshal(rawContracts, &contracts)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	artifacts := []Artifact{}
+	for _, key := range sortedKeys(contracts) {
+		var fields map[string]json.RawMessage
+		fieldsErr := json.Unmarshal(contracts[key], &fields)
+		if fieldsErr != nil {
+			return nil, fmt.Errorf("could not parse contract %s: %s", key, fieldsErr.Error())
+		}
+
+		if _, hasABI := fields["abi"]; hasABI || strings.Contains(key, ":") {
+			artifact := Artifact{Kind: ArtifactKindCombined, ContractName: key, Raw: contracts[key]}
+			if separator := strings.LastIndex(key, ":"); separator >= 0 {
+				artifact.SourceName = key[:separator]
+				artifact.ContractName = key[separator+1:]
+			}
+			rawABI, ok := fields["abi"]
+			if !ok {
+				return nil, fmt.Errorf("contract %s has no ABI (run solc with --combined-json abi)", key)
+			}
+			var encodedABI string
+			if json.Unmarshal(rawABI, &encodedABI) == nil {
+				rawABI = []byte(encodedABI)
+			}
+			artifact.ABI = rawABI
+			artifacts = append(artifacts, artifact)
+			continue
+		}
+
+		for _, contractName := range sortedKeys(fields) {
+			var contract struct {
+				ABI json.RawMessage `json:"abi"`
+				EVM struct {
+					MethodIdentifiers map[string]string `json:"methodIdentifiers"`
+				} `json:"evm"`
+			}
+			contractErr := json.Unmarshal(fields[contractName], &contract)
+			if contractErr != nil {
+				return nil, fmt.Errorf("could not parse contract %s:%s: %s", key, contractName, contractErr.Error())
+			}
+			if contract.ABI == nil {
+				return nil, fmt.Errorf("contract %s:%s has no ABI (add \"abi\" to the outputSelection of the compiler input)", key, contractName)
+			}
+			artifacts = append(artifacts, Artifact{
+				Kind:              ArtifactKindStandard,
+				ContractName:      contractName,
+				SourceName:        key,
+				ABI:               contract.ABI,
+				Raw:               fields[contractName],
+				MethodIdentifiers: contract.EVM.MethodIdentifiers,
+			})
+		}
+	}
+	if len(artifacts) == 0 {
+		return nil, fmt.Errorf("no contracts in artifact")
+	}
+	return artifacts, nil
+}
+
+// Returns the keys of the given JSON object in sorted order.
+func sortedKeys(fields map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Returns the names of the given artifacts, qualified by their source paths if they are known.
+func ArtifactNames(artifacts []Artifact) []string {
+	names := make([]string, len(artifacts))
+	for i, artifact := range artifacts {
+		names[i] = artifact.ContractName
+		if artifact.SourceName != "" {
+			names[i] = fmt.Sprintf("%s:%s", artifact.SourceName, artifact.ContractName)
+		}
+	}
+	return names
+}
+
+// Returns the artifact for the contract with the given name, which may be qualified by its source path
+// (e.g. "contracts/Token.sol:Token").
+func SelectArtifact(artifacts []Artifact, name string) (Artifact, error) {
+	var matches []Artifact
+	for _, artifact := range artifacts {
+		if artifact.ContractName == name || (artifact.SourceName != "" && fmt.Sprintf("%s:%s", artifact.SourceName, artifact.ContractName) == name) {
+			matches = append(matches, artifact)
+		}
+	}
+	if len(matches) == 0 {
+		return Artifact{}, fmt.Errorf("no contract named %s (contracts: %s)", name, strings.Join(ArtifactNames(artifacts), ", "))
+	} else if len(matches) > 1 {
+		return Artifact{}, &MultipleContractsError{Contracts: ArtifactNames(matches)}
+	}
+	return matches[0], nil
+}
+
+// Returned by ParseArtifacts for JSON objects which are neither ABIs nor supported artifacts (e.g. Hardhat
+// build-info files), since they have no "abi" field.
+var ErrNotArtifact = errors.New("input is a JSON object without an \"abi\" field, so it is neither an ABI nor a supported artifact")
+
+// Parses a compilation artifact describing a single contract.
+func parseArtifactObject(rawJSON []byte, fields map[string]json.RawMessage) (Artifact, error) {
+	rawABI, ok := fields["abi"]
+	if !ok {
+		return Artifact{}, ErrNotArtifact
+	}
+
+	artifact := Artifact{Kind: ArtifactKindArtifact, ABI: rawABI, Raw: rawJSON}
+	var format string
+	json.Unmarshal(fields["_format"], &format)
+	json.Unmarshal(fields["contractName"], &artifact.ContractName)
+	_, hasBytecode := fields["bytecode"]
+	_, hasMethodIdentifiers := fields["methodIdentifiers"]
+	_, hasSchemaVersion := fields["schemaVersion"]
+	_, hasNetworks := fields["networks"]
+	_, hasBytecodeSha1 := fields["bytecodeSha1"]
+	_, hasPCMap := fields["pcMap"]
+	_, hasAllSourcePaths := fields["allSourcePaths"]
+	if hasBytecodeSha1 || hasPCMap || hasAllSourcePaths {
+		artifact.Kind = ArtifactKindBrownie
+		json.Unmarshal(fields["sourcePath"], &artifact.SourceName)
+	} else if hasSchemaVersion || hasNetworks {
+		artifact.Kind = ArtifactKindTruffle
+		// Deployments are informational, so invalid ones are skipped rather than failing the whole artifact.
+		var networks map[string]json.RawMessage
+		if hasNetworks {
+			if networksErr := json.Unmarshal(fields["networks"], &networks); networksErr != nil {
+				artifact.Diagnostics = append(artifact.Diagnostics, Diagnostic{ItemType: "artifact", Name: artifact.ContractName, Message: fmt.Sprintf("skipped networks: %s", networksErr.Error())})
+			}
+		}
+		for _, networkID := range sortedKeys(networks) {
+			var network struct {
+				Address string `json:"address"`
+			}
+			networkErr := json.Unmarshal(networks[networkID], &network)
+			checksummed, checksumErr := ChecksumAddress(network.Address)
+			if networkErr == nil && checksumErr != nil {
+				networkErr = checksumErr
+			}
+			if networkErr != nil {
+				artifact.Diagnostics = append(artifact.Diagnostics, Diagnostic{ItemType: "artifact", Name: artifact.ContractName, Message: fmt.Sprintf("skipped deployment on network %s: %s", networkID, networkErr.Error())})
+				continue
+			}
+			if artifact.Deployments == nil {
+				artifact.Deployments = map[string]string{}
+			}
+			artifact.Deployments[networkID] = checksummed
+		}
+	} else if strings.HasPrefix(format, "hh-sol-artifact") || (artifact.ContractName != "" && hasBytecode) {
+		artifact.Kind = ArtifactKindHardhat
+	} else if hasMethodIdentifiers || isJSONObject(fields["bytecode"]) {
+		artifact.Kind = ArtifactKindFoundry
+		if metadata, metadataErr := ParseCompilerMetadata(fields["metadata"]); metadataErr == nil && len(metadata.Settings.CompilationTarget) == 1 {
+			for _, contractName := range metadata.Settings.CompilationTarget {
+				artifact.ContractName = contractName
+			}
+		}
+	}
+	if hasMethodIdentifiers {
+		methodIdentifiersErr := json.Unmarshal(fields["methodIdentifiers"], &artifact.MethodIdentifiers)
+		if methodIdentifiersErr != nil {
+			return Artifact{}, fmt.Errorf("could not parse methodIdentifiers: %s", methodIdentifiersErr.Error())
+		}
+	}
+	return artifact, nil
+}
+
+// Returns the default name of the interface for a contract, e.g. "IERC20" for "ERC20". Returns the empty
+// string if the contract name is unknown.
+func DefaultInterfaceName(contractName string) string {
+	if contractName == "" {
+		return ""
+	}
+	return fmt.Sprintf("I%s", contractName)
+}
+
+// Cross-checks the selectors of the functions in the given ABI against the method identifiers recorded by
+// the compiler (see Artifact.MethodIdentifiers). Returns a diagnostic for every function whose selector
+// differs from the recorded one, and for every function the compiler did not record. Compilers always
+// derive method identifiers with keccak256, so selectors are computed with it regardless of Options.Hasher.
+func CheckMethodIdentifiers(abi DecodedABI, methodIdentifiers map[string]string) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for i, functionItem := range abi.Functions {
+		signature := FunctionSignature(functionItem)
+		selector := hex.EncodeToString(MethodSelector(functionItem))
+		expected, ok := methodIdentifiers[signature]
+		if !ok {
+			diagnostics = append(diagnostics, Diagnostic{ItemType: "function", ItemIndex: i, Name: functionItem.Name, Message: fmt.Sprintf("%s does not appear in the method identifiers of the artifact", signature)})
+		} else if NormalizeSelector(expected) != selector {
+			diagnostics = append(diagnostics, Diagnostic{ItemType: "function", ItemIndex: i, Name: functionItem.Name, Message: fmt.Sprintf("selector 0x%s of %s does not match the method identifier 0x%s in the artifact", selector, signature, NormalizeSelector(expected))})
+		}
+	}
+	return diagnostics
+}
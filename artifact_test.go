@@ -0,0 +1,210 @@
+package solface
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseArtifact(t *testing.T) {
+	testCases := []struct {
+		description  string
+		input        string
+		kind         string
+		contractName string
+		functions    int
+	}{
+		{"raw ABI", `[{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}]`, ArtifactKindABI, "", 1},
+		{"hardhat artifact", `{"_format": "hh-sol-artifact-1", "contractName": "Ownable", "sourceName": "contracts/Ownable.sol", "abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}], "bytecode": "0x", "deployedBytecode": "0x", "linkReferences": {}, "deployedLinkReferences": {}}`, ArtifactKindHardhat, "Ownable", 1},
+		{"hardhat artifact without format", `{"contractName": "Ownable", "abi": [], "bytecode": "0x"}`, ArtifactKindHardhat, "Ownable", 0},
+		{"foundry artifact", `{"abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}], "bytecode": {"object": "0x", "sourceMap": "", "linkReferences": {}}, "methodIdentifiers": {"owner()": "8da5cb5b"}, "metadata": {"settings": {"compilationTarget": {"src/Ownable.sol": "Ownable"}}, "sources": {}}}`, ArtifactKindFoundry, "Ownable", 1},
+		{"truffle artifact", `{"contractName": "Migrations", "abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}], "bytecode": "0x", "networks": {"5777": {"events": {}, "links": {}, "address": "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4", "transactionHash": "0x"}}, "schemaVersion": "3.4.11", "updatedAt": "2023-01-01T00:00:00.000Z"}`, ArtifactKindTruffle, "Migrations", 1},
+		{"brownie artifact", `{"abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}], "allSourcePaths": {"0": "contracts/Token.sol"}, "ast": {}, "bytecode": "6080", "bytecodeSha1": "5b8f", "compiler": {"evm_version": "istanbul", "optimizer": {"enabled": true, "runs": 200}, "version": "0.6.12+commit.27d51765"}, "contractName": "Token", "coverageMap": {}, "dependencies": [], "deployedBytecode": "6080", "deployedSourceMap": "", "language": "Solidity", "natspec": {}, "offset": [0, 100], "opcodes": "", "pcMap": {}, "sha1": "a1b2", "source": "", "sourceMap": "", "sourcePath": "contracts/Token.sol", "type": "contract"}`, ArtifactKindBrownie, "Token", 1},
+		{"other artifact", `{"abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}]}`, ArtifactKindArtifact, "", 1},
+	}
+
+	for _, testCase := range testCases {
+		artifact, artifactErr := ParseArtifact([]byte(testCase.input))
+		if artifactErr != nil {
+			t.Fatalf("Case: %s. Unexpected error parsing artifact: %s", testCase.description, artifactErr.Error())
+		}
+		if artifact.Kind != testCase.kind {
+			t.Fatalf("Case: %s. Expected kind: %s. Actual: %s", testCase.description, testCase.kind, artifact.Kind)
+		}
+		if artifact.ContractName != testCase.contractName {
+			t.Fatalf("Case: %s. Expected contract name: %q. Actual: %q", testCase.description, testCase.contractName, artifact.ContractName)
+		}
+
+		abi, decodeErr := Decode([]byte(testCase.input))
+		if decodeErr != nil {
+			t.Fatalf("Case: %s. Unexpected error decoding input: %s", testCase.description, decodeErr.Error())
+		}
+		if len(abi.Functions) != testCase.functions {
+			t.Fatalf("Case: %s. Expected %d functions. Actual: %d", testCase.description, testCase.functions, len(abi.Functions))
+		}
+	}
+
+	truffleArtifact, _ := ParseArtifact([]byte(`{"contractName": "Migrations", "abi": [], "networks": {"5777": {"address": "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4"}}}`))
+	if truffleArtifact.Deployments["5777"] != "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4" {
+		t.Fatalf("Expected deployment on network 5777. Actual: %v", truffleArtifact.Deployments)
+	}
+
+	// Invalid deployments are skipped with diagnostics.
+	truffleArtifact, truffleErr := ParseArtifact([]byte(`{"contractName": "Migrations", "abi": [], "networks": {"1": {"address": ""}, "3": {"address": "0x1234"}, "5777": {"address": "0x5B38Da6a701c568545dCfcB03FcB875f56beddC4"}}}`))
+	if truffleErr != nil {
+		t.Fatalf("Unexpected error parsing artifact with invalid deployments: %s", truffleErr.Error())
+	}
+	if len(truffleArtifact.Deployments) != 1 || len(truffleArtifact.Diagnostics) != 2 || !strings.Contains(truffleArtifact.Diagnostics[0].Message, "network 1") {
+		t.Fatalf("Expected 1 deployment and diagnostics for networks 1 and 3. Actual: %v, %v", truffleArtifact.Deployments, truffleArtifact.Diagnostics)
+	}
+
+	_, artifactErr := ParseArtifact([]byte(`{"contractName": "Ownable"}`))
+	if artifactErr == nil {
+		t.Fatal("Expected error parsing object without an ABI. Actual: nil")
+	}
+
+	if DefaultInterfaceName("Ownable") != "IOwnable" {
+		t.Fatalf("Expected default interface name: IOwnable. Actual: %s", DefaultInterfaceName("Ownable"))
+	}
+}
+
+func TestCheckMethodIdentifiers(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}, {"type": "function", "name": "renounceOwnership", "inputs": [], "outputs": [], "stateMutability": "nonpayable"}, {"type": "function", "name": "transferOwnership", "inputs": [{"name": "newOwner", "type": "address"}], "outputs": [], "stateMutability": "nonpayable"}]`))
+	if decodeErr != nil {
+		t.Fatalf("Unexpected error decoding ABI: %s", decodeErr.Error())
+	}
+
+	methodIdentifiers := map[string]string{"owner()": "8da5cb5b", "renounceOwnership()": "0xdeadbeef"}
+	diagnostics := CheckMethodIdentifiers(abi, methodIdentifiers)
+	if len(diagnostics) != 2 {
+		t.Fatalf("Expected 2 diagnostics. Actual: %d (%v)", len(diagnostics), diagnostics)
+	}
+	if diagnostics[0].Name != "renounceOwnership" || diagnostics[1].Name != "transferOwnership" {
+		t.Fatalf("Expected diagnostics for renounceOwnership and transferOwnership. Actual: %s and %s", diagnostics[0].Name, diagnostics[1].Name)
+	}
+}
+
+func TestParseCombinedJSON(t *testing.T) {
+	combinedJSON := `{"contracts": {"contracts/Token.sol:Token": {"abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}]}, "contracts/Access.sol:Access": {"abi": "[{\"type\": \"function\", \"name\": \"renounceOwnership\", \"inputs\": [], \"outputs\": [], \"stateMutability\": \"nonpayable\"}]"}}, "version": "0.8.19+commit.7dd6d404"}`
+
+	artifacts, artifactsErr := ParseArtifacts([]byte(combinedJSON))
+	if artifactsErr != nil {
+		t.Fatalf("Unexpected error parsing combined JSON: %s", artifactsErr.Error())
+	}
+	names := ArtifactNames(artifacts)
+	if len(names) != 2 || names[0] != "contracts/Access.sol:Access" || names[1] != "contracts/Token.sol:Token" {
+		t.Fatalf("Expected contracts: [contracts/Access.sol:Access contracts/Token.sol:Token]. Actual: %v", names)
+	}
+
+	for _, artifact := range artifacts {
+		if artifact.Kind != ArtifactKindCombined {
+			t.Fatalf("Expected kind: %s. Actual: %s", ArtifactKindCombined, artifact.Kind)
+		}
+		abi, decodeErr := Decode(artifact.ABI)
+		if decodeErr != nil {
+			t.Fatalf("Unexpected error decoding ABI of %s: %s", artifact.ContractName, decodeErr.Error())
+		}
+		if len(abi.Functions) != 1 {
+			t.Fatalf("Expected 1 function in %s. Actual: %d", artifact.ContractName, len(abi.Functions))
+		}
+	}
+
+	for _, name := range []string{"Token", "contracts/Token.sol:Token"} {
+		selected, selectErr := SelectArtifact(artifacts, name)
+		if selectErr != nil {
+			t.Fatalf("Unexpected error selecting %s: %s", name, selectErr.Error())
+		}
+		if selected.ContractName != "Token" || selected.SourceName != "contracts/Token.sol" {
+			t.Fatalf("Expected contracts/Token.sol:Token. Actual: %s:%s", selected.SourceName, selected.ContractName)
+		}
+	}
+	_, selectErr := SelectArtifact(artifacts, "Missing")
+	if selectErr == nil {
+		t.Fatal("Expected error selecting missing contract. Actual: nil")
+	}
+
+	_, decodeErr := Decode([]byte(combinedJSON))
+	var multipleErr *MultipleContractsError
+	if !errors.As(decodeErr, &multipleErr) {
+		t.Fatalf("Expected MultipleContractsError decoding combined JSON with several contracts. Actual: %v", decodeErr)
+	}
+}
+
+func TestParseStandardJSON(t *testing.T) {
+	standardJSON := `{"contracts": {"contracts/Vault.sol": {"Vault": {"abi": [{"type": "function", "name": "deposit", "inputs": [], "outputs": [], "stateMutability": "payable"}], "devdoc": {"kind": "dev", "methods": {"deposit()": {"details": "Mints shares."}}}, "userdoc": {"kind": "user", "methods": {"deposit()": {"notice": "Deposits ether."}}}, "evm": {"methodIdentifiers": {"deposit()": "d0e30db0"}}}, "VaultLib": {"abi": []}}}, "sources": {"contracts/Vault.sol": {"id": 0}}}`
+
+	artifacts, artifactsErr := ParseArtifacts([]byte(standardJSON))
+	if artifactsErr != nil {
+		t.Fatalf("Unexpected error parsing standard JSON: %s", artifactsErr.Error())
+	}
+	names := ArtifactNames(artifacts)
+	if len(names) != 2 || names[0] != "contracts/Vault.sol:Vault" || names[1] != "contracts/Vault.sol:VaultLib" {
+		t.Fatalf("Expected contracts: [contracts/Vault.sol:Vault contracts/Vault.sol:VaultLib]. Actual: %v", names)
+	}
+
+	vault := artifacts[0]
+	if vault.Kind != ArtifactKindStandard {
+		t.Fatalf("Expected kind: %s. Actual: %s", ArtifactKindStandard, vault.Kind)
+	}
+	if vault.MethodIdentifiers["deposit()"] != "d0e30db0" {
+		t.Fatalf("Expected method identifier for deposit(). Actual: %v", vault.MethodIdentifiers)
+	}
+	devdoc, hasDevdoc := ArtifactDevdoc(vault.Raw)
+	userdoc, hasUserdoc := ArtifactUserdoc(vault.Raw)
+	if !hasDevdoc || !hasUserdoc {
+		t.Fatalf("Expected devdoc and userdoc. Actual: devdoc %v, userdoc %v", hasDevdoc, hasUserdoc)
+	}
+
+	abi, decodeErr := Decode(vault.ABI)
+	if decodeErr != nil {
+		t.Fatalf("Unexpected error decoding ABI: %s", decodeErr.Error())
+	}
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IVault", PayableNotes: true, Devdoc: devdoc, Userdoc: userdoc}, &output)
+	if generateErr != nil {
+		t.Fatalf("Unexpected error generating interface: %s", generateErr.Error())
+	}
+	expected := "\t/// @notice Deposits ether.\n\t/// @dev Mints shares.\n\tfunction deposit() external payable;"
+	if !strings.Contains(output.String(), expected) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+	}
+}
+
+func TestParseArtifactsWithoutContracts(t *testing.T) {
+	for _, input := range []string{`{"contracts": {}}`, `{"contracts": {"a.sol": {}}}`} {
+		artifacts, artifactsErr := ParseArtifacts([]byte(input))
+		if artifactsErr == nil || !strings.Contains(artifactsErr.Error(), "no contracts in artifact") {
+			t.Fatalf("Expected a \"no contracts in artifact\" error for %s. Actual: %v (artifacts: %v)", input, artifactsErr, artifacts)
+		}
+	}
+}
+
+func TestParseBundle(t *testing.T) {
+	artifacts, parseErr := ParseArtifacts([]byte(`{
+		"Vault": {"contractName": "VaultImplementation", "abi": [{"type": "function", "name": "deposit", "inputs": [{"name": "amount", "type": "uint256"}], "outputs": [], "stateMutability": "nonpayable"}]},
+		"ERC20": ["function totalSupply() view returns (uint256)"]
+	}`))
+	if parseErr != nil {
+		t.Fatalf("Error parsing bundle: %s", parseErr.Error())
+	}
+	if len(artifacts) != 2 || artifacts[0].ContractName != "ERC20" || artifacts[1].ContractName != "Vault" {
+		t.Fatalf("Expected artifacts for ERC20 and Vault. Actual: %v", ArtifactNames(artifacts))
+	}
+	for _, artifact := range artifacts {
+		if artifact.Kind != ArtifactKindBundle {
+			t.Fatalf("Expected kind: %s. Actual: %s", ArtifactKindBundle, artifact.Kind)
+		}
+		abi, decodeErr := Decode(artifact.ABI)
+		if decodeErr != nil {
+			t.Fatalf("Error decoding ABI of %s: %s", artifact.ContractName, decodeErr.Error())
+		}
+		if len(abi.Functions) != 1 {
+			t.Fatalf("Expected 1 function for %s. Actual: %d", artifact.ContractName, len(abi.Functions))
+		}
+	}
+
+	_, parseErr = ParseArtifacts([]byte(`{"ERC20": [], "version": "1.0"}`))
+	if parseErr == nil {
+		t.Fatal("Expected error parsing object which is not a bundle. Actual: nil")
+	}
+}
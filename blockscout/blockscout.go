@@ -0,0 +1,112 @@
+// Package blockscout fetches the verified ABIs of contracts from Blockscout explorers, which many L2s and
+// private chains run instead of Etherscan. Any Blockscout deployment can be used, given its URL.
+package blockscout
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/etherscan"
+)
+
+// Timeout for requests to Blockscout explorers.
+var RequestTimeout = 30 * time.Second
+
+type smartContractResponse struct {
+	Name                  string          `json:"name"`
+	ABI                   json.RawMessage `json:"abi"`
+	CompilerVersion       string          `json:"compiler_version"`
+	LicenseType           string          `json:"license_type"`
+	IsVerified            bool            `json:"is_verified"`
+	ImplementationAddress string          `json:"implementation_address"`
+	Implementations       []struct {
+		Address string `json:"address"`
+	} `json:"implementations"`
+}
+
+// SPDX identifiers of the licenses which Blockscout reports.
+var spdxLicenses = map[string]string{
+	"unlicense":     "Unlicense",
+	"mit":           "MIT",
+	"gnu_gpl_v2":    "GPL-2.0",
+	"gnu_gpl_v3":    "GPL-3.0",
+	"gnu_lgpl_v2_1": "LGPL-2.1",
+	"gnu_lgpl_v3":   "LGPL-3.0",
+	"bsd_2_clause":  "BSD-2-Clause",
+	"bsd_3_clause":  "BSD-3-Clause",
+	"mpl_2_0":       "MPL-2.0",
+	"osl_3_0":       "OSL-3.0",
+	"apache_2_0":    "Apache-2.0",
+	"gnu_agpl_v3":   "AGPL-3.0",
+	"bsl_1_1":       "BUSL-1.1",
+}
+
+// Returns the URL of the smart contract endpoint of the Blockscout REST API for the given address, on the
+// explorer at explorerURL (e.g. "https://explorer.zora.energy").
+func smartContractURL(explorerURL, address string) (string, error) {
+	parsed, parseErr := url.Parse(strings.TrimSuffix(explorerURL, "/"))
+	if parseErr != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("invalid explorer URL: %s", explorerURL)
+	}
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/api") + "/api/v2/smart-contracts/" + address
+	return parsed.String(), nil
+}
+
+// Fetches the verified contract at the given address from the Blockscout explorer at explorerURL, using
+// the Blockscout REST API (v2). explorerURL is the URL of the explorer itself (a trailing "/api" is
+// accepted). The address is validated (see solface.ChecksumAddress) before any request is made. Contracts
+// are described in the same way as by the etherscan package, and an *etherscan.NotVerifiedError is
+// returned if the contract is not verified. Requests are sent with transport, or with http.DefaultTransport
+// if it is nil.
+func FetchContract(transport http.RoundTripper, explorerURL, address string) (etherscan.Contract, error) {
+	checksummed, checksumErr := solface.ChecksumAddress(address)
+	if checksumErr != nil {
+		return etherscan.Contract{}, checksumErr
+	}
+	requestURL, urlErr := smartContractURL(explorerURL, checksummed)
+	if urlErr != nil {
+		return etherscan.Contract{}, urlErr
+	}
+
+	client := http.Client{Timeout: RequestTimeout, Transport: transport}
+	response, requestErr := client.Get(requestURL)
+	if requestErr != nil {
+		return etherscan.Contract{}, requestErr
+	}
+	defer response.Body.Close()
+	// Blockscout responds with 404 for addresses without verified source code.
+	if response.StatusCode == http.StatusNotFound {
+		return etherscan.Contract{}, &etherscan.NotVerifiedError{Address: checksummed}
+	}
+	if response.StatusCode != http.StatusOK {
+		return etherscan.Contract{}, fmt.Errorf("Blockscout request failed with status %s", response.Status)
+	}
+
+	var result smartContractResponse
+	decodeErr := json.NewDecoder(response.Body).Decode(&result)
+	if decodeErr != nil {
+		return etherscan.Contract{}, fmt.Errorf("could not decode Blockscout response: %s", decodeErr.Error())
+	}
+	if !result.IsVerified || len(result.ABI) == 0 || string(result.ABI) == "null" {
+		return etherscan.Contract{}, &etherscan.NotVerifiedError{Address: checksummed}
+	}
+
+	contract := etherscan.Contract{
+		Address:         checksummed,
+		Name:            result.Name,
+		ABI:             []byte(result.ABI),
+		License:         spdxLicenses[result.LicenseType],
+		CompilerVersion: result.CompilerVersion,
+	}
+	if len(result.Implementations) > 0 {
+		contract.Implementation = result.Implementations[0].Address
+	} else if result.ImplementationAddress != "" {
+		contract.Implementation = result.ImplementationAddress
+	}
+	return contract, nil
+}
@@ -0,0 +1,57 @@
+package blockscout
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/etherscan"
+)
+
+// Serves the smart contract endpoint of the Blockscout REST API, answering with the given response for
+// the given address and with 404 for any other address.
+func fakeBlockscout(t *testing.T, address, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/smart-contracts/"+address {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not found"}`)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+}
+
+func TestFetchContract(t *testing.T) {
+	server := fakeBlockscout(t, "0xcA11bde05977b3631167028862bE2a173976CA11", `{"name": "Ownable", "is_verified": true, "compiler_version": "v0.8.19+commit.7dd6d404", "license_type": "gnu_gpl_v3", "abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}], "implementations": [{"address": "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e", "name": "Implementation"}]}`)
+	defer server.Close()
+
+	// The explorer URL may be given with the /api suffix used for Etherscan-compatible endpoints.
+	contract, fetchErr := FetchContract(nil, server.URL+"/api", "0xca11bde05977b3631167028862be2a173976ca11")
+	if fetchErr != nil {
+		t.Fatalf("Error fetching contract: %s", fetchErr.Error())
+	}
+	if contract.Name != "Ownable" || contract.License != "GPL-3.0" || contract.Implementation != "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e" {
+		t.Fatalf("Expected Ownable proxy licensed under GPL-3.0. Actual: %v", contract)
+	}
+	abi, decodeErr := solface.Decode(contract.ABI)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding fetched ABI: %s", decodeErr.Error())
+	}
+	if len(abi.Functions) != 1 || abi.Functions[0].Name != "owner" {
+		t.Fatalf("Expected ABI with owner function. Actual: %v", abi.Functions)
+	}
+
+	var notVerifiedErr *etherscan.NotVerifiedError
+	_, fetchErr = FetchContract(nil, server.URL, "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+	if !errors.As(fetchErr, &notVerifiedErr) {
+		t.Fatalf("Expected NotVerifiedError. Actual: %v", fetchErr)
+	}
+
+	_, fetchErr = FetchContract(nil, "explorer.example", "0xcA11bde05977b3631167028862bE2a173976CA11")
+	if fetchErr == nil {
+		t.Fatalf("Expected error for explorer URL without a scheme")
+	}
+}
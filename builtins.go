@@ -0,0 +1,86 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Solidity globals and builtins which ABI functions may be named after, with a description of the builtin
+// which they shadow. Interfaces declaring functions with these names compile, but can confuse readers
+// (e.g. "token.transfer(...)" versus "payable(to).transfer(...)") and some analyzers.
+var solidityBuiltins = map[string]string{
+	"abi":          "the abi global",
+	"addmod":       "the addmod builtin",
+	"assert":       "the assert builtin",
+	"balance":      "the balance member of addresses",
+	"block":        "the block global",
+	"blockhash":    "the blockhash builtin",
+	"call":         "the call member of addresses",
+	"code":         "the code member of addresses",
+	"codehash":     "the codehash member of addresses",
+	"delegatecall": "the delegatecall member of addresses",
+	"ecrecover":    "the ecrecover builtin",
+	"gasleft":      "the gasleft builtin",
+	"keccak256":    "the keccak256 builtin",
+	"msg":          "the msg global",
+	"mulmod":       "the mulmod builtin",
+	"now":          "the now global (removed in Solidity 0.7)",
+	"require":      "the require builtin",
+	"revert":       "the revert builtin",
+	"ripemd160":    "the ripemd160 builtin",
+	"selfdestruct": "the selfdestruct builtin",
+	"send":         "the send member of addresses",
+	"sha256":       "the sha256 builtin",
+	"staticcall":   "the staticcall member of addresses",
+	"super":        "the super keyword",
+	"this":         "the this keyword",
+	"transfer":     "the transfer member of addresses",
+	"tx":           "the tx global",
+	"type":         "the type builtin",
+}
+
+// Returns a description of the Solidity global or builtin that a function with the given name would
+// shadow, and false if the name does not shadow a builtin.
+func ShadowedBuiltin(name string) (string, bool) {
+	description, ok := solidityBuiltins[name]
+	return description, ok
+}
+
+// Returns a diagnostic for every function in the given ABI whose name shadows a Solidity global or builtin
+// (see ShadowedBuiltin).
+func BuiltinShadowingDiagnostics(abi DecodedABI) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for i, functionItem := range abi.Functions {
+		if description, ok := ShadowedBuiltin(functionItem.Name); ok {
+			diagnostics = append(diagnostics, Diagnostic{ItemType: "function", ItemIndex: i, Name: functionItem.Name, Message: fmt.Sprintf("function name shadows %s", description)})
+		}
+	}
+	return diagnostics
+}
+
+// Returns the given function renaming map (selector -> function name, see ApplyRenames) extended with a
+// rename for every function in the given ABI whose name shadows a Solidity builtin: such functions are
+// renamed with a trailing underscore (e.g. "transfer_"), unless the renaming map already renames them or
+// another function already has that name. The given map is not modified.
+func WithBuiltinRenames(abi DecodedABI, renames map[string]string) map[string]string {
+	result := map[string]string{}
+	renamed := map[string]bool{}
+	for selector, name := range renames {
+		result[selector] = name
+		renamed[NormalizeSelector(selector)] = true
+	}
+
+	names := map[string]bool{}
+	for _, functionItem := range abi.Functions {
+		names[functionItem.Name] = true
+	}
+
+	for _, functionItem := range abi.Functions {
+		selector := hex.EncodeToString(MethodSelector(functionItem))
+		if _, ok := ShadowedBuiltin(functionItem.Name); !ok || renamed[selector] || names[functionItem.Name+"_"] {
+			continue
+		}
+		result[selector] = functionItem.Name + "_"
+	}
+	return result
+}
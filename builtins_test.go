@@ -0,0 +1,37 @@
+package solface
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestBuiltinShadowing(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [], "stateMutability": "nonpayable"}, {"type": "function", "name": "send", "inputs": [], "outputs": [], "stateMutability": "payable"}, {"type": "function", "name": "send_", "inputs": [], "outputs": [], "stateMutability": "view"}, {"type": "function", "name": "call", "inputs": [{"name": "data", "type": "bytes"}], "outputs": [], "stateMutability": "nonpayable"}, {"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	diagnostics := BuiltinShadowingDiagnostics(abi)
+	if len(diagnostics) != 3 || diagnostics[0].Name != "transfer" || diagnostics[1].Name != "send" || diagnostics[2].Name != "call" {
+		t.Fatalf("Expected diagnostics for transfer, send, and call. Actual: %v", diagnostics)
+	}
+
+	// call is renamed explicitly, and send cannot be renamed to send_ since that name is taken.
+	callSelector := "0x" + hex.EncodeToString(MethodSelector(abi.Functions[3]))
+	explicit := map[string]string{callSelector: "callWith"}
+	renames := WithBuiltinRenames(abi, explicit)
+	if len(explicit) != 1 {
+		t.Fatalf("Expected the given renames not to be modified. Actual: %v", explicit)
+	}
+	if len(renames) != 2 || renames[hex.EncodeToString(MethodSelector(abi.Functions[0]))] != "transfer_" || renames[callSelector] != "callWith" {
+		t.Fatalf("Expected transfer to be renamed to transfer_ and call to callWith. Actual: %v", renames)
+	}
+
+	renamedABI, originalNames, renameErr := ApplyRenames(abi, renames)
+	if renameErr != nil {
+		t.Fatalf("Could not apply renames: %s", renameErr.Error())
+	}
+	if renamedABI.Functions[0].Name != "transfer_" || originalNames[0] != "transfer" || renamedABI.Functions[1].Name != "send" {
+		t.Fatalf("Expected transfer to be renamed and send to be kept. Actual: %v", renamedABI.Functions)
+	}
+}
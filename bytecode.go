@@ -0,0 +1,326 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// EVM opcodes used to recognize function dispatchers.
+const (
+	opEQ     = 0x14
+	opJUMPI  = 0x57
+	opPUSH1  = 0x60
+	opPUSH4  = 0x63
+	opPUSH32 = 0x7f
+	opDUP1   = 0x80
+	opDUP16  = 0x8f
+	opSWAP16 = 0x9f
+)
+
+// Selectors of the builtin Error(string) and Panic(uint256) errors, which contracts compare revert data
+// against (e.g. in try/catch) with the same instructions that dispatchers use for function selectors.
+var builtinErrorSelectors = map[[4]byte]bool{
+	{0x08, 0xc3, 0x79, 0xa0}: true,
+	{0x4e, 0x48, 0x7b, 0x71}: true,
+}
+
+// Represents an instruction in EVM bytecode.
+type instruction struct {
+	Opcode byte
+	Data   []byte
+}
+
+// Decodes a hex string (with or without a 0x prefix, and ignoring surrounding whitespace) into bytecode.
+func ParseBytecode(hexBytecode string) ([]byte, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(hexBytecode), "0x")
+	bytecode, decodeErr := hex.DecodeString(trimmed)
+	if decodeErr != nil {
+		return nil, fmt.Errorf("invalid bytecode: %s", decodeErr.Error())
+	}
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("bytecode is empty - there is no contract at this address")
+	}
+	return bytecode, nil
+}
+
+// Splits bytecode into instructions. Truncated push data at the end of the bytecode is ignored.
+func disassemble(bytecode []byte) []instruction {
+	instructions := []instruction{}
+	for i := 0; i < len(bytecode); i++ {
+		current := instruction{Opcode: bytecode[i]}
+		if current.Opcode >= opPUSH1 && current.Opcode <= opPUSH32 {
+			size := int(current.Opcode-opPUSH1) + 1
+			if i+size >= len(bytecode) {
+				break
+			}
+			current.Data = bytecode[i+1 : i+1+size]
+			i += size
+		}
+		instructions = append(instructions, current)
+	}
+	return instructions
+}
+
+// Extracts the 4-byte function selectors that the dispatcher of the given runtime bytecode matches calldata
+// against, in the order in which they appear. Dispatchers compare the selector of the calldata to each
+// selector with EQ and jump to the function on a match (PUSH4 <selector> EQ PUSH <destination> JUMPI,
+// possibly with the selector duplicated or swapped into place in between). Compilers push selectors with
+// leading zero bytes using shorter pushes, which are only recognized when preceded by a DUP, to avoid
+// matching ordinary comparisons with small constants.
+//
+// The selectors of the builtin Error(string) and Panic(uint256) errors, which try/catch blocks compare
+// revert data against, are not function selectors and are excluded.
+//
+// This is a heuristic: selectors of contracts with unusual dispatchers (e.g. hand-written assembly or
+// jump tables) may be missed.
+func ExtractSelectors(bytecode []byte) [][4]byte {
+	instructions := disassemble(bytecode)
+	selectors := [][4]byte{}
+	seen := map[[4]byte]bool{}
+	isPush := func(op byte) bool { return op >= opPUSH1 && op <= opPUSH32 }
+	isStackOp := func(op byte) bool { return op >= opDUP1 && op <= opSWAP16 }
+
+	for i, current := range instructions {
+		if current.Opcode < opPUSH1 || current.Opcode > opPUSH4 {
+			continue
+		}
+		if current.Opcode != opPUSH4 && (i == 0 || instructions[i-1].Opcode < opDUP1 || instructions[i-1].Opcode > opDUP16) {
+			continue
+		}
+
+		j := i + 1
+		if j < len(instructions) && isStackOp(instructions[j].Opcode) {
+			j++
+		}
+		if j+2 >= len(instructions) || instructions[j].Opcode != opEQ || !isPush(instructions[j+1].Opcode) || instructions[j+2].Opcode != opJUMPI {
+			continue
+		}
+
+		var selector [4]byte
+		copy(selector[4-len(current.Data):], current.Data)
+		if selector == [4]byte{0xff, 0xff, 0xff, 0xff} || builtinErrorSelectors[selector] || seen[selector] {
+			continue
+		}
+		seen[selector] = true
+		selectors = append(selectors, selector)
+	}
+	return selectors
+}
+
+// Represents a function in a skeleton interface, which is only known by its selector.
+//  1. Selector: The selector of the function.
+//  2. Function: The declaration of the function, if its signature is known - otherwise, the function is
+//     declared with a placeholder name (see SkeletonPlaceholderName) and without parameters.
+//  3. Source: Where the signature of the function came from, noted in a comment above it.
+//  4. Alternatives: Other candidate signatures for the selector (e.g. collisions in a signature database),
+//     listed in a comment above the function.
+type SkeletonFunction struct {
+	Selector     [4]byte
+	Function     *FunctionItem
+	Source       string
+	Alternatives []string
+}
+
+// Represents an event in a skeleton interface, which is only known by its topic (e.g. from logs emitted by
+// the contract).
+//  1. Topic: The topic of the event.
+//  2. Event: The declaration of the event, if its signature is known - otherwise, the event is only listed
+//     in a comment, since it cannot be declared without its signature.
+//  3. Source: Where the signature of the event came from, noted in a comment above it.
+//  4. Alternatives: Other candidate signatures for the topic, listed in a comment above the event.
+type SkeletonEvent struct {
+	Topic        [32]byte
+	Event        *EventItem
+	Source       string
+	Alternatives []string
+}
+
+// Represents a skeleton interface for a contract without a verified ABI.
+//  1. Name: The name of the interface.
+//  2. SolfaceVersion: The version of solface that generated the interface.
+//  3. Source: Where the selectors came from.
+//  4. Functions: The functions of the interface.
+//  5. Events: The events of the interface.
+//  6. Recovered: Whether or not the signatures of any of the functions or events are known.
+type SkeletonSpecification struct {
+	Name           string
+	SolfaceVersion string
+	Source         string
+	Functions      []SkeletonFunction
+	Events         []SkeletonEvent
+	Recovered      bool
+}
+
+// Returns the placeholder name of the function with the given selector in a skeleton interface, e.g.
+// "selector_a9059cbb".
+func SkeletonPlaceholderName(selector [4]byte) string {
+	return fmt.Sprintf("selector_%x", selector)
+}
+
+// Returns the declaration of the given skeleton function.
+func renderSkeletonFunction(function SkeletonFunction) string {
+	if function.Function == nil {
+		return fmt.Sprintf("function %s() external;", SkeletonPlaceholderName(function.Selector))
+	}
+	return RenderFunction(*function.Function)
+}
+
+// Returns the declaration of the given recovered skeleton event. Since signatures do not record which
+// parameters are indexed, recovered events are declared without indexed parameters, which does not change
+// their topics.
+func renderSkeletonEvent(event SkeletonEvent) string {
+	parameters := make([]string, len(event.Event.Inputs))
+	for i, input := range event.Event.Inputs {
+		parameters[i] = input.Type
+	}
+	return fmt.Sprintf("event %s(%s);", event.Event.Name, strings.Join(parameters, ", "))
+}
+
+// Template used to generate skeleton interfaces.
+const SkeletonTemplate string = `// Skeleton interface generated by solface: https://github.com/moonstream-to/solface
+// solface version: {{.SolfaceVersion}}
+// Generated from the function selectors in {{.Source}}. Functions whose signatures are unknown are
+// declared with placeholder names and no parameters - their selectors do not match the contract until they
+// are replaced with the real signatures.
+{{- if .Recovered}}
+// Recovered signatures are best-effort guesses, and do not record return values, state mutability, or
+// indexed event parameters.
+{{- end}}
+interface {{.Name}} {
+{{- range .Events}}
+	// Topic: {{printf "%x" .Topic}}{{if .Source}} (signature from {{.Source}}){{else}} (unknown signature){{end}}
+{{- if .Alternatives}}
+	// Other candidates: {{join .Alternatives ", "}}
+{{- end}}
+{{- if .Event}}
+	{{renderSkeletonEvent .}}
+{{- end}}
+{{- end}}
+{{- range .Functions}}
+	// Selector: {{printf "%x" .Selector}}{{if .Source}} (signature from {{.Source}}){{else}} (unknown signature){{end}}
+{{- if .Alternatives}}
+	// Other candidates: {{join .Alternatives ", "}}
+{{- end}}
+	{{renderSkeletonFunction .}}
+{{- end}}
+}
+`
+
+// Generates a skeleton interface with the given name for a contract which is only known by the selectors
+// of its functions (e.g. as extracted from its bytecode by ExtractSelectors) and, optionally, the topics of
+// its events. source describes where the selectors came from (e.g. "the bytecode at 0x...").
+func GenerateSkeletonInterface(name, source string, functions []SkeletonFunction, events []SkeletonEvent, writer io.Writer) error {
+	spec := SkeletonSpecification{Name: name, SolfaceVersion: VERSION, Source: source, Functions: functions, Events: events}
+	for _, function := range functions {
+		spec.Recovered = spec.Recovered || function.Function != nil
+	}
+	for _, event := range events {
+		spec.Recovered = spec.Recovered || event.Event != nil
+	}
+	templateFuncs := map[string]any{"renderSkeletonFunction": renderSkeletonFunction, "renderSkeletonEvent": renderSkeletonEvent, "join": strings.Join}
+	templ, templateParseErr := template.New("skeleton").Funcs(templateFuncs).Parse(SkeletonTemplate)
+	if templateParseErr != nil {
+		return templateParseErr
+	}
+	return templ.Execute(writer, spec)
+}
+
+// Returns the skeleton function for the given selector, declared with the first of the given candidate
+// signatures (e.g. from a signature database) which parses and matches the selector. source is noted as
+// the provenance of the signature, and the remaining candidates are listed as alternatives. Since
+// signatures do not record return values or state mutability, recovered functions are declared
+// nonpayable and without return values. If no candidate can be used, the function is declared with a
+// placeholder.
+func RecoverSkeletonFunction(selector [4]byte, candidates []string, source string) SkeletonFunction {
+	function := SkeletonFunction{Selector: selector}
+	chosen := -1
+	for i, candidate := range candidates {
+		if recovered, ok := functionFromSignature(candidate, selector); ok {
+			function.Function = &recovered
+			function.Source = source
+			chosen = i
+			break
+		}
+	}
+	for i, candidate := range candidates {
+		if i != chosen {
+			function.Alternatives = append(function.Alternatives, candidate)
+		}
+	}
+	return function
+}
+
+// Parses the given function signature (e.g. "transfer(address,uint256)") into a nonpayable function
+// without return values, and returns false if the signature cannot be parsed, does not have the given
+// selector, or has struct parameters (which cannot be declared in a skeleton interface).
+func functionFromSignature(signature string, selector [4]byte) (FunctionItem, bool) {
+	rawABI, parseErr := ParseHumanReadableABI([]string{"function " + signature})
+	if parseErr != nil {
+		return FunctionItem{}, false
+	}
+	abi, decodeErr := Decode(rawABI)
+	if decodeErr != nil || len(abi.Functions) != 1 {
+		return FunctionItem{}, false
+	}
+	function := abi.Functions[0]
+	if string(MethodSelector(function)) != string(selector[:]) {
+		return FunctionItem{}, false
+	}
+	for _, input := range function.Inputs {
+		if input.IsCompoundType() {
+			return FunctionItem{}, false
+		}
+	}
+	function.StateMutability = "nonpayable"
+	return function, true
+}
+
+// Returns the skeleton event for the given topic, declared with the first of the given candidate
+// signatures (e.g. from a signature database) which parses and matches the topic, as in
+// RecoverSkeletonFunction. If no candidate can be used, the event is only listed in a comment.
+func RecoverSkeletonEvent(topic [32]byte, candidates []string, source string) SkeletonEvent {
+	event := SkeletonEvent{Topic: topic}
+	chosen := -1
+	for i, candidate := range candidates {
+		if recovered, ok := eventFromSignature(candidate, topic); ok {
+			event.Event = &recovered
+			event.Source = source
+			chosen = i
+			break
+		}
+	}
+	for i, candidate := range candidates {
+		if i != chosen {
+			event.Alternatives = append(event.Alternatives, candidate)
+		}
+	}
+	return event
+}
+
+// Parses the given event signature (e.g. "Transfer(address,address,uint256)") into an event without
+// indexed parameters, and returns false if the signature cannot be parsed, does not have the given topic,
+// or has struct parameters.
+func eventFromSignature(signature string, topic [32]byte) (EventItem, bool) {
+	rawABI, parseErr := ParseHumanReadableABI([]string{"event " + signature})
+	if parseErr != nil {
+		return EventItem{}, false
+	}
+	abi, decodeErr := Decode(rawABI)
+	if decodeErr != nil || len(abi.Events) != 1 {
+		return EventItem{}, false
+	}
+	event := abi.Events[0]
+	if string(EventTopic(event)) != string(topic[:]) {
+		return EventItem{}, false
+	}
+	for i, input := range event.Inputs {
+		if input.IsCompoundType() {
+			return EventItem{}, false
+		}
+		event.Inputs[i].Indexed = false
+	}
+	return event, true
+}
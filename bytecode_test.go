@@ -0,0 +1,105 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractSelectors(t *testing.T) {
+	bytecode, parseErr := ParseBytecode(strings.Join([]string{
+		"0x6080604052",           // PUSH1 0x80 PUSH1 0x40 MSTORE
+		"60003560e01c",           // PUSH1 0x00 CALLDATALOAD PUSH1 0xe0 SHR
+		"8063a9059cbb1461004057", // DUP1 PUSH4 a9059cbb EQ PUSH2 0x0040 JUMPI
+		"806370a082311161005057", // DUP1 PUSH4 70a08231 GT PUSH2 0x0050 JUMPI (binary search split)
+		"8062fdd58e1461006057",   // DUP1 PUSH3 00fdd58e EQ PUSH2 0x0060 JUMPI
+		"63095ea7b3811461007057", // PUSH4 095ea7b3 DUP2 EQ PUSH2 0x0070 JUMPI
+		"63ffffffff16",           // PUSH4 ffffffff AND
+		"6001146100805700",       // PUSH1 0x01 EQ PUSH2 0x0080 JUMPI STOP (comparison with a constant)
+		"8063a9059cbb1461004057", // duplicate selector
+		"806308c379a01461009057", // DUP1 PUSH4 08c379a0 EQ PUSH2 0x0090 JUMPI (try/catch matching Error(string))
+		"80634e487b71146100a057", // DUP1 PUSH4 4e487b71 EQ PUSH2 0x00a0 JUMPI (try/catch matching Panic(uint256))
+		"7f63deadbeef14",         // PUSH32 whose data contains a dispatcher-like sequence
+	}, ""))
+	if parseErr != nil {
+		t.Fatalf("Could not parse bytecode: %s", parseErr.Error())
+	}
+
+	selectors := ExtractSelectors(bytecode)
+	expected := []string{"a9059cbb", "00fdd58e", "095ea7b3"}
+	if len(selectors) != len(expected) {
+		t.Fatalf("Expected %d selectors. Actual: %x", len(expected), selectors)
+	}
+	for i, selector := range selectors {
+		if actual := SkeletonPlaceholderName(selector); actual != "selector_"+expected[i] {
+			t.Fatalf("Expected selector %d to be %s. Actual: %s", i, expected[i], actual)
+		}
+	}
+
+	var output strings.Builder
+	generateErr := GenerateSkeletonInterface("IUnknown", "the given bytecode", []SkeletonFunction{{Selector: selectors[0]}}, nil, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating skeleton interface: %s", generateErr.Error())
+	}
+	expectedBlock := "interface IUnknown {\n\t// Selector: a9059cbb (unknown signature)\n\tfunction selector_a9059cbb() external;\n}\n"
+	if !strings.Contains(output.String(), expectedBlock) {
+		t.Fatalf("Expected skeleton interface to contain:\n%s\nActual:\n%s", expectedBlock, output.String())
+	}
+
+	if _, parseErr := ParseBytecode("0x"); parseErr == nil {
+		t.Fatalf("Expected error for empty bytecode")
+	}
+}
+
+func TestRecoverSkeletonFunction(t *testing.T) {
+	selector := [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+	function := RecoverSkeletonFunction(selector, []string{"transfer(address,uint256", "approve(address,uint256)", "transfer(address,uint256)", "many_msg_babbage(bytes1)"}, "4byte.directory")
+	if function.Function == nil || RenderFunction(*function.Function) != "function transfer(address, uint256) external;" {
+		t.Fatalf("Expected transfer(address,uint256) to be recovered. Actual: %v", function.Function)
+	}
+	if len(function.Alternatives) != 3 || function.Alternatives[2] != "many_msg_babbage(bytes1)" {
+		t.Fatalf("Expected 3 alternatives. Actual: %v", function.Alternatives)
+	}
+
+	var output strings.Builder
+	generateErr := GenerateSkeletonInterface("IUnknown", "the given list", []SkeletonFunction{function, RecoverSkeletonFunction([4]byte{1, 2, 3, 4}, nil, "4byte.directory")}, nil, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating skeleton interface: %s", generateErr.Error())
+	}
+	expectedLines := []string{
+		"// Recovered signatures are best-effort guesses, and do not record return values, state mutability, or",
+		"\t// Selector: a9059cbb (signature from 4byte.directory)",
+		"\tfunction transfer(address, uint256) external;",
+		"\tfunction selector_01020304() external;",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output.String(), line+"\n") {
+			t.Fatalf("Expected skeleton interface to contain line: %s. Actual:\n%s", line, output.String())
+		}
+	}
+}
+
+func TestRecoverSkeletonEvent(t *testing.T) {
+	var topic [32]byte
+	copy(topic[:], EventTopic(EventItem{Name: "Transfer", Inputs: []EventArgument{{Value: Value{Type: "address"}}, {Value: Value{Type: "address"}}, {Value: Value{Type: "uint256"}}}}))
+	event := RecoverSkeletonEvent(topic, []string{"Approval(address,address,uint256)", "Transfer(address,address,uint256)"}, "openchain.xyz")
+	if event.Event == nil || event.Event.Name != "Transfer" || len(event.Alternatives) != 1 {
+		t.Fatalf("Expected Transfer(address,address,uint256) to be recovered with 1 alternative. Actual: %v", event)
+	}
+
+	var output strings.Builder
+	generateErr := GenerateSkeletonInterface("IUnknown", "the given list", nil, []SkeletonEvent{event, RecoverSkeletonEvent([32]byte{1}, nil, "openchain.xyz")}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating skeleton interface: %s", generateErr.Error())
+	}
+	expectedLines := []string{
+		"\t// Topic: ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef (signature from openchain.xyz)",
+		"\t// Other candidates: Approval(address,address,uint256)",
+		"\tevent Transfer(address, address, uint256);",
+		"\t// Topic: 0100000000000000000000000000000000000000000000000000000000000000 (unknown signature)",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output.String(), line+"\n") {
+			t.Fatalf("Expected skeleton interface to contain line: %s. Actual:\n%s", line, output.String())
+		}
+	}
+}
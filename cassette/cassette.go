@@ -0,0 +1,230 @@
+// Package cassette records the HTTP interactions of solface (with block explorers, JSON-RPC endpoints, and
+// signature databases) to cassette files, and replays them from cassettes, so that network-dependent
+// workflows can run deterministically offline and bug reports can include reproducible sessions.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// The value which replaces secrets (see SecretParameters and SecretPathHosts) in recorded URLs.
+const Redacted = "REDACTED"
+
+// Query parameters whose values are redacted in recorded URLs, since they hold API keys.
+var SecretParameters = []string{"apikey", "api_key", "key", "token"}
+
+// Hosts (and their subdomains) of RPC providers which embed API keys in the paths of their endpoints (e.g.
+// "https://eth-mainnet.g.alchemy.com/v2/<key>"). Path segments of their URLs which look like keys (see
+// secretPathSegmentRegexp) are redacted in recorded URLs.
+var SecretPathHosts = []string{"alchemy.com", "infura.io", "quiknode.pro", "ankr.com", "blastapi.io"}
+
+// Matches path segments which look like API keys: long runs of letters, digits, hyphens, and underscores,
+// as opposed to API versions and chain names (e.g. "v2" or "polygon").
+var secretPathSegmentRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{16,}$`)
+
+// Returns true if the given host is one of SecretPathHosts or a subdomain of one.
+func isSecretPathHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, secretHost := range SecretPathHosts {
+		if host == secretHost || strings.HasSuffix(host, "."+secretHost) {
+			return true
+		}
+	}
+	return false
+}
+
+// Represents a recorded HTTP request and its response.
+//  1. Method: The HTTP method of the request.
+//  2. URL: The URL of the request, with secrets redacted (see RedactURL).
+//  3. RequestBody: The body of the request, if any.
+//  4. Status: The status code of the response.
+//  5. ResponseBody: The body of the response.
+type Interaction struct {
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	RequestBody  string `json:"requestBody,omitempty"`
+	Status       int    `json:"status"`
+	ResponseBody string `json:"responseBody"`
+}
+
+// Represents a cassette: the HTTP interactions of a session, in order.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Loads the cassette stored at the given path.
+func Load(path string) (Cassette, error) {
+	var cassette Cassette
+	contents, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return cassette, readErr
+	}
+	unmarshalErr := json.Unmarshal(contents, &cassette)
+	if unmarshalErr != nil {
+		return cassette, fmt.Errorf("could not decode cassette %s: %s", path, unmarshalErr.Error())
+	}
+	return cassette, nil
+}
+
+// Stores the cassette at the given path.
+func (c Cassette) Save(path string) error {
+	contents, marshalErr := json.MarshalIndent(c, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	return os.WriteFile(path, append(contents, '\n'), 0644)
+}
+
+// Returns the given URL with the values of secret query parameters (see SecretParameters) and the keys in
+// the paths of RPC endpoints (see SecretPathHosts) redacted.
+func RedactURL(rawURL string) string {
+	parsed, parseErr := url.Parse(rawURL)
+	if parseErr != nil {
+		return rawURL
+	}
+	if isSecretPathHost(parsed.Hostname()) {
+		segments := strings.Split(parsed.Path, "/")
+		for i, segment := range segments {
+			if secretPathSegmentRegexp.MatchString(segment) {
+				segments[i] = Redacted
+			}
+		}
+		parsed.Path = strings.Join(segments, "/")
+		parsed.RawPath = ""
+	}
+	query := parsed.Query()
+	redacted := false
+	for name := range query {
+		for _, secret := range SecretParameters {
+			if strings.EqualFold(name, secret) {
+				query.Set(name, Redacted)
+				redacted = true
+			}
+		}
+	}
+	if redacted {
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
+
+// Reads the body of the given request (if any) and replaces it, so that it can be sent afterwards.
+func requestBody(request *http.Request) (string, error) {
+	if request.Body == nil {
+		return "", nil
+	}
+	body, readErr := io.ReadAll(request.Body)
+	request.Body.Close()
+	if readErr != nil {
+		return "", readErr
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return string(body), nil
+}
+
+// An http.RoundTripper which sends requests with Transport (http.DefaultTransport if nil) and records
+// every interaction, saving the cassette to Path after each one - so that sessions which fail midway are
+// recorded too.
+type Recorder struct {
+	Path      string
+	Transport http.RoundTripper
+
+	mutex    sync.Mutex
+	cassette Cassette
+}
+
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, bodyErr := requestBody(request)
+	if bodyErr != nil {
+		return nil, bodyErr
+	}
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	response, responseErr := transport.RoundTrip(request)
+	if responseErr != nil {
+		return nil, responseErr
+	}
+	responseBody, readErr := io.ReadAll(response.Body)
+	response.Body.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Method:       request.Method,
+		URL:          RedactURL(request.URL.String()),
+		RequestBody:  body,
+		Status:       response.StatusCode,
+		ResponseBody: string(responseBody),
+	})
+	if saveErr := r.cassette.Save(r.Path); saveErr != nil {
+		return nil, fmt.Errorf("could not record cassette %s: %s", r.Path, saveErr.Error())
+	}
+	return response, nil
+}
+
+// An http.RoundTripper which answers requests from a cassette without accessing the network. Requests are
+// matched by method, URL (with secrets redacted), and body. Identical requests are answered with the
+// recorded responses in order, and with the last of them once those are exhausted (e.g. when polling).
+// Requests which were not recorded fail.
+type Replayer struct {
+	Cassette Cassette
+
+	mutex sync.Mutex
+	used  map[int]bool
+}
+
+func (r *Replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, bodyErr := requestBody(request)
+	if bodyErr != nil {
+		return nil, bodyErr
+	}
+	requestURL := RedactURL(request.URL.String())
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.used == nil {
+		r.used = map[int]bool{}
+	}
+	match := -1
+	for i, interaction := range r.Cassette.Interactions {
+		if interaction.Method != request.Method || interaction.URL != requestURL || interaction.RequestBody != body {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no interaction recorded in the cassette for %s %s", request.Method, requestURL)
+	}
+	r.used[match] = true
+
+	interaction := r.Cassette.Interactions[match]
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       request,
+	}, nil
+}
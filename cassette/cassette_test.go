@@ -0,0 +1,90 @@
+package cassette
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, `{"call": %d, "module": %q, "body": %q}`, calls, r.URL.Query().Get("module"), string(body))
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := &Recorder{Path: path}
+	client := http.Client{Transport: recorder}
+	for _, module := range []string{"contract", "contract", "proxy"} {
+		response, requestErr := client.Get(server.URL + "?module=" + module + "&apikey=secret")
+		if requestErr != nil {
+			t.Fatalf("Error sending request: %s", requestErr.Error())
+		}
+		response.Body.Close()
+	}
+	response, requestErr := client.Post(server.URL, "application/json", strings.NewReader(`{"method": "eth_getCode"}`))
+	if requestErr != nil {
+		t.Fatalf("Error sending request: %s", requestErr.Error())
+	}
+	response.Body.Close()
+	server.Close()
+
+	cassette, loadErr := Load(path)
+	if loadErr != nil {
+		t.Fatalf("Error loading cassette: %s", loadErr.Error())
+	}
+	if len(cassette.Interactions) != 4 {
+		t.Fatalf("Expected 4 recorded interactions. Actual: %d", len(cassette.Interactions))
+	}
+	if strings.Contains(cassette.Interactions[0].URL, "secret") || !strings.Contains(cassette.Interactions[0].URL, "apikey="+Redacted) {
+		t.Fatalf("Expected the API key to be redacted. Actual URL: %s", cassette.Interactions[0].URL)
+	}
+
+	// The server is closed, so responses can only come from the cassette.
+	client = http.Client{Transport: &Replayer{Cassette: cassette}}
+	expected := []string{`"call": 1`, `"call": 2`, `"call": 2`, `"call": 3`}
+	for i, module := range []string{"contract", "contract", "contract", "proxy"} {
+		response, requestErr := client.Get(server.URL + "?module=" + module + "&apikey=other")
+		if requestErr != nil {
+			t.Fatalf("Error replaying request: %s", requestErr.Error())
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if !strings.Contains(string(body), expected[i]) {
+			t.Fatalf("Expected replayed response %d to contain %s. Actual: %s", i, expected[i], string(body))
+		}
+	}
+	response, requestErr = client.Post(server.URL, "application/json", strings.NewReader(`{"method": "eth_getCode"}`))
+	if requestErr != nil {
+		t.Fatalf("Error replaying request: %s", requestErr.Error())
+	}
+	response.Body.Close()
+
+	if _, requestErr := client.Get(server.URL + "?module=account"); requestErr == nil {
+		t.Fatalf("Expected an error replaying a request which was not recorded")
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected string
+	}{
+		{"https://eth-mainnet.g.alchemy.com/v2/abcdEFGH1234ijklMNOP5678", "https://eth-mainnet.g.alchemy.com/v2/REDACTED"},
+		{"https://mainnet.infura.io/v3/0123456789abcdef0123456789abcdef", "https://mainnet.infura.io/v3/REDACTED"},
+		{"https://rpc.ankr.com/polygon/0123456789abcdef0123456789abcdef", "https://rpc.ankr.com/polygon/REDACTED"},
+		{"https://api.etherscan.io/api?apikey=secret&module=contract", "https://api.etherscan.io/api?apikey=REDACTED&module=contract"},
+		{"https://explorer.example/api/v2/smart-contracts/0xcA11bde05977b3631167028862bE2a173976CA11", "https://explorer.example/api/v2/smart-contracts/0xcA11bde05977b3631167028862bE2a173976CA11"},
+	}
+	for _, testCase := range testCases {
+		if actual := RedactURL(testCase.url); actual != testCase.expected {
+			t.Fatalf("Expected: %s. Actual: %s", testCase.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package solface
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Chain IDs of chains which are commonly referred to by name (e.g. in deployments files, or with the
+// -chain flag).
+var chainIDs = map[string]uint64{
+	"ethereum":         1,
+	"optimism":         10,
+	"bsc":              56,
+	"gnosis":           100,
+	"polygon":          137,
+	"fantom":           250,
+	"zksync":           324,
+	"polygon-zkevm":    1101,
+	"moonbeam":         1284,
+	"mantle":           5000,
+	"base":             8453,
+	"arbitrum":         42161,
+	"arbitrum-nova":    42170,
+	"celo":             42220,
+	"avalanche":        43114,
+	"linea":            59144,
+	"blast":            81457,
+	"scroll":           534352,
+	"sepolia":          11155111,
+	"holesky":          17000,
+	"base-sepolia":     84532,
+	"arbitrum-sepolia": 421614,
+	"optimism-sepolia": 11155420,
+}
+
+// Alternative names for chains in chainIDs.
+var chainIDAliases = map[string]string{
+	"mainnet":      "ethereum",
+	"eth":          "ethereum",
+	"arbitrum-one": "arbitrum",
+	"matic":        "polygon",
+	"xdai":         "gnosis",
+	"bnb":          "bsc",
+}
+
+// Returns the chain ID of the given chain, which may either be a decimal chain ID or the name of a known
+// chain (e.g. "ethereum", "base", or "arbitrum-one"). Names are case-insensitive. Returns false if the
+// chain is not known.
+func ChainID(chain string) (uint64, bool) {
+	chain = strings.ToLower(strings.TrimSpace(chain))
+	if chainID, parseErr := strconv.ParseUint(chain, 10, 64); parseErr == nil {
+		return chainID, true
+	}
+	if canonical, ok := chainIDAliases[chain]; ok {
+		chain = canonical
+	}
+	chainID, ok := chainIDs[chain]
+	return chainID, ok
+}
+
+// Returns the names of the known chains, sorted alphabetically.
+func ChainNames() []string {
+	names := make([]string, 0, len(chainIDs))
+	for name := range chainIDs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,23 @@
+package solface
+
+import "testing"
+
+func TestChainID(t *testing.T) {
+	testCases := map[string]uint64{
+		"ethereum":     1,
+		"Mainnet":      1,
+		"arbitrum-one": 42161,
+		"base":         8453,
+		"137":          137,
+	}
+	for chain, expected := range testCases {
+		chainID, ok := ChainID(chain)
+		if !ok || chainID != expected {
+			t.Fatalf("Chain: %s. Expected chain ID: %d. Actual: %d (known: %t)", chain, expected, chainID, ok)
+		}
+	}
+
+	if _, ok := ChainID("not-a-chain"); ok {
+		t.Fatal("Expected unknown chain not to have a chain ID. Actual: known")
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/moonstream-to/solface"
+)
+
+// Implements the "solface analyze" subcommand, which produces structural reports about an ABI.
+func (c *command) runAnalyze(args []string) error {
+	var report, format string
+	var jsonOutput bool
+	flags := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	flags.StringVar(&report, "report", "clusters", "Report to produce. Options: clusters (functions grouped by name prefix), security (functions exposing dangerous capabilities), integers (parameters with integer types narrower than 256 bits).")
+	flags.StringVar(&format, "format", "markdown", "Output format for the report. Options: markdown, json.")
+	flags.BoolVar(&jsonOutput, "json", false, "If present, the report is written to stdout as JSON, along with any warnings.")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s analyze [-report <report>] [-format {markdown | json}] [-json] {<path to ABI file> | stdin}\n\n", programName)
+		flags.PrintDefaults()
+	}
+
+	out := c.newReporter("analyze", false)
+	if parseErr := out.Parse(flags, args, &jsonOutput); parseErr != nil {
+		return parseErr
+	}
+
+	if flags.NArg() > 1 || (format != "markdown" && format != "json") {
+		return out.Usage(flags)
+	}
+
+	contents, readErr := c.readABI(flags.Arg(0))
+	if readErr != nil {
+		return out.Fatalf("Error reading ABI: %s", readErr.Error())
+	}
+
+	abi, decodeErr := solface.Decode(contents)
+	if decodeErr != nil {
+		return out.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var reportErr error
+	switch report {
+	case "clusters":
+		clusters := solface.ClusterFunctions(abi)
+		out.result.Report = clusters
+		if format == "json" && !jsonOutput {
+			reportErr = c.writeJSON(clusters)
+		} else if !jsonOutput {
+			reportErr = solface.WriteClustersMarkdown(clusters, c.stdout)
+		}
+	case "security":
+		findings := solface.SecurityFindings(abi)
+		out.result.Report = findings
+		if format == "json" && !jsonOutput {
+			reportErr = c.writeJSON(findings)
+		} else if !jsonOutput {
+			reportErr = solface.WriteSecurityMarkdown(findings, c.stdout)
+		}
+	case "integers":
+		findings := solface.IntegerWidthFindings(abi)
+		out.result.Report = findings
+		if format == "json" && !jsonOutput {
+			reportErr = c.writeJSON(findings)
+		} else if !jsonOutput {
+			reportErr = solface.WriteIntegerWidthsMarkdown(findings, c.stdout)
+		}
+	default:
+		return out.Fatalf("Unknown report: %s", report)
+	}
+	if reportErr != nil {
+		return out.Fatalf("Error writing report: %s", reportErr.Error())
+	}
+	return out.Finish()
+}
+
+// Writes the given value to stdout as indented JSON.
+func (c *command) writeJSON(value interface{}) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moonstream-to/solface"
+)
+
+// Returns the paths of the ABI files to generate output for, given the positional arguments. With no
+// arguments, the ABI is read from stdin (represented by an empty path). Directories are searched
+// recursively for ".json" and ".abi" files, skipping Hardhat debug files (".dbg.json"), and glob patterns
+// (which may contain "**", see solface.Glob) are expanded to the ABI files they match. The second return
+// value is true if the arguments select batch mode, i.e. if they name several files, a directory, or a
+// pattern. Files which turn out not to be ABIs or artifacts (e.g. Hardhat build-info files) are skipped with
+// a warning when they are read.
+func inputPaths(args []string) ([]string, bool, error) {
+	if len(args) == 0 {
+		return []string{""}, false, nil
+	}
+
+	paths := []string{}
+	batch := len(args) > 1
+	for _, arg := range args {
+		info, statErr := os.Stat(arg)
+		if statErr != nil && solface.IsGlobPattern(arg) {
+			// Patterns are expanded here so that "**" works regardless of the shell in use.
+			batch = true
+			matches, globErr := solface.Glob(arg)
+			if globErr != nil {
+				return nil, false, globErr
+			}
+			for _, match := range matches {
+				if isABIFile(match) {
+					paths = append(paths, match)
+				}
+			}
+			continue
+		} else if statErr != nil {
+			return nil, false, statErr
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+
+		batch = true
+		directoryPaths := []string{}
+		walkErr := filepath.WalkDir(arg, func(path string, entry fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if !entry.IsDir() && isABIFile(path) {
+				directoryPaths = append(directoryPaths, path)
+			}
+			return nil
+		})
+		if walkErr != nil {
+			return nil, false, walkErr
+		}
+		sort.Strings(directoryPaths)
+		paths = append(paths, directoryPaths...)
+	}
+	return paths, batch, nil
+}
+
+// Returns true if the file at the given path looks like it contains an ABI or an artifact.
+func isABIFile(path string) bool {
+	if strings.HasSuffix(path, ".dbg.json") {
+		return false
+	}
+	extension := filepath.Ext(path)
+	return extension == ".json" || extension == ".abi"
+}
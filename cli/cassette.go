@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+
+	"github.com/moonstream-to/solface/cassette"
+)
+
+// Settings of the -record and -replay flags of the commands which access the network.
+type cassetteSettings struct {
+	Record string
+	Replay string
+}
+
+// Registers the -record and -replay flags on the given flag set.
+func (s *cassetteSettings) register(flags *flag.FlagSet) {
+	flags.StringVar(&s.Record, "record", "", "If provided, every HTTP interaction (with explorers, JSON-RPC endpoints, and signature databases) is recorded to this cassette file, with API keys redacted.")
+	flags.StringVar(&s.Replay, "replay", "", "If provided, HTTP interactions are replayed from this cassette file (see -record) instead of accessing the network.")
+}
+
+// Returns the transport through which the command sends its HTTP requests: a recorder or replayer, as
+// configured, or nil (for http.DefaultTransport) if neither is. Commands pass it to every client they
+// create, rather than replacing http.DefaultTransport, so that embedding programs are not affected.
+func (s cassetteSettings) transport() (http.RoundTripper, error) {
+	if s.Record != "" && s.Replay != "" {
+		return nil, fmt.Errorf("-record and -replay cannot be used together")
+	}
+	if s.Record != "" {
+		return &cassette.Recorder{Path: s.Record}, nil
+	}
+	if s.Replay != "" {
+		replayCassette, loadErr := cassette.Load(s.Replay)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return &cassette.Replayer{Cassette: replayCassette}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,112 @@
+// Package cli implements the solface command-line interface, so that programs can embed it (and tests can
+// exercise it) without running the solface binary. Run is the entry point of cmd/solface.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"log"
+)
+
+// The name of the program in usage messages.
+const programName = "solface"
+
+// Exit codes returned by Run.
+const (
+	// The command succeeded.
+	ExitSuccess = 0
+	// The command failed, or was invoked with invalid arguments.
+	ExitFailure = 1
+	// The flags of the command could not be parsed.
+	ExitUsage = 2
+)
+
+// Wrapped by the errors returned by Execute when a command is invoked with invalid flags or arguments (in
+// which case its usage is written to stderr).
+var ErrUsage = errors.New("invalid usage")
+
+// Returned by Execute (and by the subcommands it runs) when a command fails, with the exit code which Run
+// returns for it.
+//  1. Code: The exit code (ExitFailure or ExitUsage).
+//  2. Err: The cause of the failure - it wraps ErrUsage for invalid invocations, and otherwise carries the
+//     message which was logged to stderr.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// Represents an invocation of the CLI, with the streams it reads from and writes to.
+type command struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	logger *log.Logger
+}
+
+// Logs the given message to stderr and returns the *ExitError which fails the invocation with it.
+func (c *command) fail(message string) error {
+	c.logger.Print(message)
+	return &ExitError{Code: ExitFailure, Err: errors.New(message)}
+}
+
+// Writes the usage of the given flag set to stderr and returns the *ExitError which fails the invocation as
+// invalid.
+func (c *command) usage(flags *flag.FlagSet) error {
+	flags.Usage()
+	return &ExitError{Code: ExitFailure, Err: ErrUsage}
+}
+
+// Runs the solface CLI with the given arguments (without the program name, e.g. os.Args[1:]), reading
+// input from stdin and writing output to stdout and diagnostics to stderr. Returns nil if the command
+// succeeds (or help was requested), and an *ExitError otherwise.
+func Execute(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	c := &command{stdin: stdin, stdout: stdout, stderr: stderr, logger: log.New(stderr, "", log.LstdFlags)}
+	run := c.runGenerate
+	if len(args) > 0 {
+		subcommands := map[string]func([]string) error{
+			"fmt":      c.runFormat,
+			"init":     c.runInit,
+			"analyze":  c.runAnalyze,
+			"publish":  c.runPublish,
+			"matrix":   c.runMatrix,
+			"skeleton": c.runSkeleton,
+			"watch":    c.runWatch,
+			"schema":   c.runSchema,
+		}
+		if subcommand, ok := subcommands[args[0]]; ok {
+			run, args = subcommand, args[1:]
+		}
+	}
+
+	err := run(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) {
+		return &ExitError{Code: ExitFailure, Err: err}
+	}
+	return err
+}
+
+// Runs the solface CLI (see Execute) and returns its exit code: ExitSuccess, ExitFailure, or ExitUsage.
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	err := Execute(args, stdin, stdout, stderr)
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	if err != nil {
+		return ExitFailure
+	}
+	return ExitSuccess
+}
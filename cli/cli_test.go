@@ -0,0 +1,485 @@
+package cli
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/proxy"
+)
+
+func TestRunGeneratesInterfaceFromStdin(t *testing.T) {
+	contents, readErr := os.ReadFile("../fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-name", "IOwnableERC20"}, bytes.NewReader(contents), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "interface IOwnableERC20 {") {
+		t.Fatalf("Expected the interface on stdout. Actual:\n%s", stdout.String())
+	}
+}
+
+func TestRunVersion(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-version"}, strings.NewReader(""), &stdout, &stderr)
+	if code != ExitSuccess || stdout.String() != "v"+solface.VERSION+"\n" {
+		t.Fatalf("Expected exit code %d and version v%s. Actual: %d and %q", ExitSuccess, solface.VERSION, code, stdout.String())
+	}
+}
+
+func TestExecuteFailures(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := Execute([]string{"-no-such-flag"}, strings.NewReader(""), &stdout, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitUsage || !errors.Is(err, ErrUsage) {
+		t.Fatalf("Expected an *ExitError with code %d wrapping ErrUsage. Actual: %v", ExitUsage, err)
+	}
+
+	stderr.Reset()
+	err = Execute([]string{"-name", "IBroken"}, strings.NewReader("not an ABI"), &stdout, &stderr)
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitFailure || errors.Is(err, ErrUsage) {
+		t.Fatalf("Expected an *ExitError with code %d. Actual: %v", ExitFailure, err)
+	}
+	if !strings.Contains(stderr.String(), exitErr.Error()) {
+		t.Fatalf("Expected the error to be logged to stderr. Actual: %s", stderr.String())
+	}
+
+	if code := Run([]string{"analyze", "-format", "yaml"}, strings.NewReader(""), &stdout, &stderr); code != ExitFailure {
+		t.Fatalf("Expected exit code %d for an invalid invocation. Actual: %d", ExitFailure, code)
+	}
+	if code := Run([]string{"fmt", "-h"}, strings.NewReader(""), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d when help is requested. Actual: %d", ExitSuccess, code)
+	}
+}
+
+func TestRunProfileOnFailure(t *testing.T) {
+	contents, readErr := os.ReadFile("../fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	// A failing invocation stops the CPU profile, so that its profile is complete and the next invocation
+	// can start its own.
+	for _, testCase := range []struct {
+		input    []byte
+		expected int
+	}{
+		{[]byte("not an ABI"), ExitFailure},
+		{contents, ExitSuccess},
+	} {
+		profilePath := filepath.Join(t.TempDir(), "cpu.pprof")
+		var stdout, stderr bytes.Buffer
+		code := Run([]string{"-name", "IOwnableERC20", "-profile", profilePath}, bytes.NewReader(testCase.input), &stdout, &stderr)
+		if code != testCase.expected {
+			t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", testCase.expected, code, stderr.String())
+		}
+
+		profileFile, openErr := os.Open(profilePath)
+		if openErr != nil {
+			t.Fatalf("Error opening CPU profile: %s", openErr.Error())
+		}
+		defer profileFile.Close()
+		profileReader, gzipErr := gzip.NewReader(profileFile)
+		if gzipErr != nil {
+			t.Fatalf("Expected a gzipped CPU profile. Actual error: %s", gzipErr.Error())
+		}
+		if _, readErr := io.ReadAll(profileReader); readErr != nil {
+			t.Fatalf("Expected a complete CPU profile. Actual error: %s", readErr.Error())
+		}
+	}
+}
+
+func TestRunABICoderV2Pragma(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-name", "ISeaport", "-pragma", "^0.7.6", "-pragma", "abicoder v2", "-skip-invalid", "-stdout", "../fixtures/abis/Seaport.json"}, strings.NewReader(""), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "function getOrderHash(") || strings.Contains(stdout.String(), "error InvalidTime") {
+		t.Fatalf("Expected struct-taking functions to be kept and custom errors to be skipped. Actual:\n%s", stdout.String())
+	}
+}
+
+func TestRunArtifactWithoutContracts(t *testing.T) {
+	for _, input := range []string{`{"contracts": {}}`, `{"contracts": {"a.sol": {}}}`} {
+		var stdout, stderr bytes.Buffer
+		code := Run([]string{}, strings.NewReader(input), &stdout, &stderr)
+		if code != ExitFailure || !strings.Contains(stderr.String(), "no contracts in artifact") {
+			t.Fatalf("Expected exit code %d with a \"no contracts in artifact\" error for %s. Actual: %d (stderr: %s)", ExitFailure, input, code, stderr.String())
+		}
+	}
+}
+
+func TestRunBinaryTargetJSON(t *testing.T) {
+	contents, readErr := os.ReadFile("../fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	var table, stderr bytes.Buffer
+	if code := Run([]string{"-name", "IOwnableERC20", "-target", solface.TargetSelectors}, bytes.NewReader(contents), &table, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+
+	var stdout bytes.Buffer
+	if code := Run([]string{"-name", "IOwnableERC20", "-target", solface.TargetSelectors, "-json"}, bytes.NewReader(contents), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	var result commandResult
+	if unmarshalErr := json.Unmarshal(stdout.Bytes(), &result); unmarshalErr != nil {
+		t.Fatalf("Could not parse result: %s", unmarshalErr.Error())
+	}
+	decoded, decodeErr := base64.StdEncoding.DecodeString(result.Output)
+	if result.OutputEncoding != "base64" || decodeErr != nil || !bytes.Equal(decoded, table.Bytes()) {
+		t.Fatalf("Expected the base64-encoded selector table in the result. Actual: %q (encoding: %q)", result.Output, result.OutputEncoding)
+	}
+
+	if code := Run([]string{"-target", solface.TargetSelectors, "-stdout"}, bytes.NewReader(contents), &stdout, &stderr); code != ExitFailure {
+		t.Fatalf("Expected exit code %d for -stdout with a binary target. Actual: %d", ExitFailure, code)
+	}
+}
+
+func TestRunBatchSkipsNonArtifacts(t *testing.T) {
+	contents, readErr := os.ReadFile("../fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+	inputDir, outputDir := t.TempDir(), t.TempDir()
+	if writeErr := os.WriteFile(filepath.Join(inputDir, "OwnableERC20.json"), contents, 0644); writeErr != nil {
+		t.Fatalf("Could not write ABI: %s", writeErr.Error())
+	}
+	buildInfo := `{"id": "5f3c", "_format": "hh-sol-build-info-1", "solcVersion": "0.8.20", "input": {}, "output": {"contracts": {}}}`
+	if writeErr := os.WriteFile(filepath.Join(inputDir, "build-info.json"), []byte(buildInfo), 0644); writeErr != nil {
+		t.Fatalf("Could not write build info: %s", writeErr.Error())
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-output-dir", outputDir, inputDir}, strings.NewReader(""), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "build-info.json") || !strings.Contains(stderr.String(), "skipped") {
+		t.Fatalf("Expected a warning about the skipped build-info file. Actual: %s", stderr.String())
+	}
+	if _, statErr := os.Stat(filepath.Join(outputDir, "IOwnableERC20.sol")); statErr != nil {
+		t.Fatalf("Expected an interface for the ABI: %s (stderr: %s)", statErr.Error(), stderr.String())
+	}
+}
+
+func TestRunWatchConfig(t *testing.T) {
+	mutability := "nonpayable"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name": "Vault", "is_verified": true, "abi": [{"type": "function", "name": "deposit", "inputs": [], "outputs": [], "stateMutability": %q}]}`, mutability)
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	config := "version: 1\noutputDir: interfaces\njobs: []\nwatch:\n  - address: \"0xcA11bde05977b3631167028862bE2a173976CA11\"\n    output: interfaces/IVault.sol\n"
+	configPath := filepath.Join(root, solface.ConfigFileName)
+	if writeErr := os.WriteFile(configPath, []byte(config), 0644); writeErr != nil {
+		t.Fatalf("Could not write configuration: %s", writeErr.Error())
+	}
+
+	args := []string{"watch", "-config", configPath, "-explorer-url", server.URL}
+	var stdout, stderr bytes.Buffer
+	if code := Run(args, strings.NewReader(""), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if _, statErr := os.Stat(filepath.Join(root, "interfaces", "IVault.sol")); statErr != nil {
+		t.Fatalf("Expected an interface for the watched contract: %s (stderr: %s)", statErr.Error(), stderr.String())
+	}
+
+	// Changing only the mutability of a function keeps its signature, but is still reported.
+	mutability = "payable"
+	stderr.Reset()
+	if code := Run(args, strings.NewReader(""), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "Changed function `deposit()`.") {
+		t.Fatalf("Expected the changed mutability to be reported. Actual: %s", stderr.String())
+	}
+}
+
+func TestRunCheckMethodIdentifiersWithHasher(t *testing.T) {
+	artifact := `{"contractName": "Ownable", "abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}], "methodIdentifiers": {"owner()": "8da5cb5b"}}`
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-hash", "sha3-256", "-check-method-identifiers"}, strings.NewReader(artifact), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if strings.Contains(stderr.String(), "does not match") {
+		t.Fatalf("Expected method identifiers to be checked with keccak256 regardless of -hash. Actual: %s", stderr.String())
+	}
+}
+
+func TestRunPinABI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "Vault", "is_verified": true, "abi": [{"type": "function", "name": "deposit", "inputs": [], "outputs": [], "stateMutability": "payable"}]}`)
+	}))
+	defer server.Close()
+
+	interfacePath := filepath.Join(t.TempDir(), "IVault.sol")
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-address", "0xcA11bde05977b3631167028862bE2a173976CA11", "-explorer-url", server.URL, "-single-file", interfacePath, "-pin-abi"}, strings.NewReader(""), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	abiPath, checksumPath := solface.PinnedABIPaths(interfacePath)
+	for _, path := range []string{abiPath, checksumPath} {
+		if _, statErr := os.Stat(path); statErr != nil {
+			t.Fatalf("Expected the fetched ABI to be pinned: %s", statErr.Error())
+		}
+	}
+	generated, readErr := os.ReadFile(interfacePath)
+	if readErr != nil {
+		t.Fatalf("Could not read generated interface: %s", readErr.Error())
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"-pinned", interfacePath}, strings.NewReader(""), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if stdout.String() != string(generated) {
+		t.Fatalf("Expected the interface regenerated from the pinned ABI to match. Expected:\n%s\nActual:\n%s", generated, stdout.String())
+	}
+
+	if writeErr := os.WriteFile(abiPath, []byte(`[]`), 0644); writeErr != nil {
+		t.Fatalf("Could not modify pinned ABI: %s", writeErr.Error())
+	}
+	stderr.Reset()
+	if code := Run([]string{"-pinned", interfacePath}, strings.NewReader(""), &stdout, &stderr); code != ExitFailure || !strings.Contains(stderr.String(), "checksum mismatch") {
+		t.Fatalf("Expected exit code %d with a checksum mismatch. Actual: %d (stderr: %s)", ExitFailure, code, stderr.String())
+	}
+}
+
+func TestRunResolvesAddressBookNames(t *testing.T) {
+	requested := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		fmt.Fprint(w, `{"name": "Permit2", "is_verified": true, "abi": [{"type": "function", "name": "DOMAIN_SEPARATOR", "inputs": [], "outputs": [{"name": "", "type": "bytes32"}], "stateMutability": "view"}]}`)
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-address", "permit2", "-explorer-url", server.URL}, strings.NewReader(""), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if !strings.Contains(requested, "0x000000000022D473030F116dDEE9F6B43aC78BA3") {
+		t.Fatalf("Expected the ABI of permit2 to be fetched by its address. Actual request: %s", requested)
+	}
+
+	stderr.Reset()
+	if code := Run([]string{"-address", "not-a-protocol", "-explorer-url", server.URL}, strings.NewReader(""), &stdout, &stderr); code != ExitFailure || !strings.Contains(stderr.String(), "unknown protocol") {
+		t.Fatalf("Expected exit code %d for an unknown protocol. Actual: %d (stderr: %s)", ExitFailure, code, stderr.String())
+	}
+}
+
+func TestRunResolvesENSNames(t *testing.T) {
+	resolver := "0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41"
+	target := "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if decodeErr := json.NewDecoder(r.Body).Decode(&request); decodeErr != nil {
+			t.Fatalf("Could not decode RPC request: %s", decodeErr.Error())
+		}
+		// Answers the ENS registry with the resolver, the resolver with the target, and every other request
+		// (e.g. proxy slots) with zero.
+		result := strings.Repeat("0", 64)
+		if request.Method == "eth_call" {
+			var call struct {
+				To string `json:"to"`
+			}
+			json.Unmarshal(request.Params[0], &call)
+			if strings.EqualFold(call.To, "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e") {
+				result = strings.Repeat("0", 24) + strings.ToLower(resolver[2:])
+			} else if strings.EqualFold(call.To, resolver) {
+				result = strings.Repeat("0", 24) + strings.ToLower(target[2:])
+			}
+		}
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": "0x%s"}`, request.ID, result)
+	}))
+	defer rpc.Close()
+	requested := ""
+	explorer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		fmt.Fprint(w, `{"name": "Wallet", "is_verified": true, "abi": [{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}]}`)
+	}))
+	defer explorer.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-address", "vitalik.eth", "-rpc", rpc.URL, "-explorer-url", explorer.URL}, strings.NewReader(""), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	if !strings.Contains(requested, target) {
+		t.Fatalf("Expected the ABI of the address that the name resolves to to be fetched. Actual request: %s", requested)
+	}
+
+	stderr.Reset()
+	if code := Run([]string{"-address", "vitalik.eth", "-explorer-url", explorer.URL}, strings.NewReader(""), &stdout, &stderr); code != ExitFailure || !strings.Contains(stderr.String(), "-rpc") {
+		t.Fatalf("Expected exit code %d for an ENS name without -rpc. Actual: %d (stderr: %s)", ExitFailure, code, stderr.String())
+	}
+}
+
+func TestRunGenerateConfig(t *testing.T) {
+	contents, readErr := os.ReadFile("../fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+	root := t.TempDir()
+	if mkdirErr := os.MkdirAll(filepath.Join(root, "abis"), 0755); mkdirErr != nil {
+		t.Fatalf("Could not create ABI directory: %s", mkdirErr.Error())
+	}
+	if writeErr := os.WriteFile(filepath.Join(root, "abis", "OwnableERC20.json"), contents, 0644); writeErr != nil {
+		t.Fatalf("Could not write ABI: %s", writeErr.Error())
+	}
+	config := "version: 1\noutputDir: interfaces\nlicense: Apache-2.0\npragma: ^0.8.20\nannotations: true\njobs:\n  - name: IToken\n    abi: abis/OwnableERC20.json\n    output: interfaces/IToken.sol\n"
+	configPath := filepath.Join(root, solface.ConfigFileName)
+	if writeErr := os.WriteFile(configPath, []byte(config), 0644); writeErr != nil {
+		t.Fatalf("Could not write configuration: %s", writeErr.Error())
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-config", configPath}, strings.NewReader(""), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	outputPath := filepath.Join(root, "interfaces", "IToken.sol")
+	generated, readErr := os.ReadFile(outputPath)
+	if readErr != nil {
+		t.Fatalf("Expected an interface for the job: %s (stderr: %s)", readErr.Error(), stderr.String())
+	}
+	for _, expected := range []string{"SPDX-License-Identifier: Apache-2.0", "pragma solidity ^0.8.20;", "interface IToken {", "Interface ID:"} {
+		if !strings.Contains(string(generated), expected) {
+			t.Fatalf("Expected the interface to contain %q. Actual:\n%s", expected, generated)
+		}
+	}
+
+	// Flags override the settings of the configuration.
+	if code := Run([]string{"-config", configPath, "-license", "MIT", "-annotations=false"}, strings.NewReader(""), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	generated, readErr = os.ReadFile(outputPath)
+	if readErr != nil {
+		t.Fatalf("Could not read generated interface: %s", readErr.Error())
+	}
+	if !strings.Contains(string(generated), "SPDX-License-Identifier: MIT") || strings.Contains(string(generated), "Interface ID:") {
+		t.Fatalf("Expected -license and -annotations to override the configuration. Actual:\n%s", generated)
+	}
+
+	// Jobs apply their renames and deployments.
+	config += "    renames:\n      \"0xa9059cbb\": send\n    deployments:\n      base: \"0xcA11bde05977b3631167028862bE2a173976CA11\"\n"
+	if writeErr := os.WriteFile(configPath, []byte(config), 0644); writeErr != nil {
+		t.Fatalf("Could not write configuration: %s", writeErr.Error())
+	}
+	if code := Run([]string{"-config", configPath, "-deployments-library"}, strings.NewReader(""), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	generated, readErr = os.ReadFile(outputPath)
+	if readErr != nil {
+		t.Fatalf("Could not read generated interface: %s", readErr.Error())
+	}
+	for _, expected := range []string{"function send(", "address internal constant BASE = 0xcA11bde05977b3631167028862bE2a173976CA11;"} {
+		if !strings.Contains(string(generated), expected) {
+			t.Fatalf("Expected the interface to contain %q. Actual:\n%s", expected, generated)
+		}
+	}
+}
+
+func TestRunSplitCustomStandards(t *testing.T) {
+	standardsDir, outputDir := t.TempDir(), t.TempDir()
+	vault := `[{"type": "function", "name": "deposit", "inputs": [{"name": "assets", "type": "uint256"}], "outputs": [], "stateMutability": "nonpayable"}]`
+	if writeErr := os.WriteFile(filepath.Join(standardsDir, "IVaultStandard.json"), []byte(vault), 0644); writeErr != nil {
+		t.Fatalf("Could not write standard: %s", writeErr.Error())
+	}
+
+	abi := `[{"type": "function", "name": "deposit", "inputs": [{"name": "assets", "type": "uint256"}], "outputs": [], "stateMutability": "nonpayable"}, {"type": "function", "name": "sweep", "inputs": [], "outputs": [], "stateMutability": "nonpayable"}]`
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-name", "IFoo", "-split-standards", "-standards-dir", standardsDir, "-output-dir", outputDir}, strings.NewReader(abi), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	for _, filename := range []string{"IFoo_IVaultStandard.sol", "IFoo_Custom.sol"} {
+		if _, statErr := os.Stat(filepath.Join(outputDir, filename)); statErr != nil {
+			t.Fatalf("Expected %s to be written: %s (stderr: %s)", filename, statErr.Error(), stderr.String())
+		}
+	}
+}
+
+func TestRunJSONOnUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-json"},
+		{"-json", "-no-such-flag"},
+		{"analyze", "-json", "-format", "yaml"},
+		{"watch", "-json"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := Run(args, strings.NewReader("[]"), &stdout, &stderr); code == ExitSuccess {
+			t.Fatalf("Expected %v to fail. Actual: exit code %d", args, code)
+		}
+		var result commandResult
+		if unmarshalErr := json.Unmarshal(stdout.Bytes(), &result); unmarshalErr != nil {
+			t.Fatalf("Expected a JSON result for %v: %s (stdout: %q)", args, unmarshalErr.Error(), stdout.String())
+		}
+		if !strings.HasPrefix(result.Error, ErrUsage.Error()) {
+			t.Fatalf("Expected a usage error in the result for %v. Actual: %q", args, result.Error)
+		}
+	}
+}
+
+func TestRunProxyNoticeIsDiagnostic(t *testing.T) {
+	implementation := "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		if decodeErr := json.NewDecoder(r.Body).Decode(&request); decodeErr != nil {
+			t.Fatalf("Could not decode RPC request: %s", decodeErr.Error())
+		}
+		result := strings.Repeat("0", 64)
+		if request.Method == "eth_getStorageAt" && strings.EqualFold(request.Params[1].(string), proxy.EIP1967ImplementationSlot.Hex()) {
+			result = strings.Repeat("0", 24) + strings.ToLower(implementation[2:])
+		}
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": "0x%s"}`, request.ID, result)
+	}))
+	defer rpc.Close()
+	explorer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "Vault", "is_verified": true, "abi": [{"type": "function", "name": "deposit", "inputs": [], "outputs": [], "stateMutability": "payable"}]}`)
+	}))
+	defer explorer.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-json", "-address", "0xcA11bde05977b3631167028862bE2a173976CA11", "-rpc", rpc.URL, "-explorer-url", explorer.URL}, strings.NewReader(""), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Expected exit code %d. Actual: %d (stderr: %s)", ExitSuccess, code, stderr.String())
+	}
+	var result commandResult
+	if unmarshalErr := json.Unmarshal(stdout.Bytes(), &result); unmarshalErr != nil {
+		t.Fatalf("Could not parse result: %s (stdout: %q)", unmarshalErr.Error(), stdout.String())
+	}
+	if len(result.Diagnostics) != 1 || !strings.Contains(result.Diagnostics[0].Message, implementation) {
+		t.Fatalf("Expected the proxy to be reported as a diagnostic. Actual: %v", result.Diagnostics)
+	}
+}
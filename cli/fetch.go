@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/moonstream-to/solface/addressbook"
+	"github.com/moonstream-to/solface/blockscout"
+	"github.com/moonstream-to/solface/ens"
+	"github.com/moonstream-to/solface/etherscan"
+)
+
+// Specifies the block explorer that verified ABIs are fetched from: the Blockscout explorer at
+// ExplorerURL if it is set, and Etherscan (configured by the remaining fields) otherwise. Requests are
+// sent with Transport (http.DefaultTransport if nil).
+type explorerSettings struct {
+	ExplorerURL  string
+	EtherscanURL string
+	EtherscanKey string
+	Chain        string
+	Transport    http.RoundTripper
+}
+
+// Checks that the settings do not mix Blockscout and Etherscan options, and reads the Etherscan API key
+// from the environment if it is not set.
+func (s *explorerSettings) validate() error {
+	if s.ExplorerURL != "" && (s.EtherscanURL != "" || s.EtherscanKey != "" || s.Chain != "") {
+		return fmt.Errorf("-explorer-url cannot be used with -etherscan-url, -etherscan-key, or -chain")
+	}
+	if s.EtherscanKey == "" {
+		s.EtherscanKey = os.Getenv(etherscan.APIKeyEnvironmentVariable)
+	}
+	return nil
+}
+
+// Fetches the verified contract at the given address from the configured explorer.
+func (s explorerSettings) fetch(address string) (etherscan.Contract, error) {
+	if s.ExplorerURL != "" {
+		return blockscout.FetchContract(s.Transport, s.ExplorerURL, address)
+	}
+	return etherscan.FetchContractOnChain(s.Transport, s.EtherscanURL, s.EtherscanKey, s.Chain, address)
+}
+
+// Resolves the given contract (e.g. the value of -address) to a hex address: hex addresses are returned as
+// they are, protocol names (e.g. "permit2") are looked up for the given chain in the embedded address book
+// (updated with the address book file at addressBookPath if it is not empty), and other names containing
+// dots (e.g. "vitalik.eth") are resolved with ENS on the JSON-RPC endpoint at rpcURL, using the given
+// transport.
+func resolveAddress(transport http.RoundTripper, rpcURL, chain, addressBookPath, contract string) (string, error) {
+	if common.IsHexAddress(contract) {
+		return contract, nil
+	}
+	book, loadErr := addressbook.Load(addressBookPath)
+	if loadErr != nil {
+		return "", loadErr
+	}
+	address, resolveErr := book.Resolve(contract, chain)
+	var unknownErr *addressbook.UnknownEntryError
+	if errors.As(resolveErr, &unknownErr) && len(unknownErr.KnownChains) == 0 && ens.IsName(contract) {
+		if rpcURL == "" {
+			return "", fmt.Errorf("%s is not in the address book, and ENS names can only be resolved with -rpc", contract)
+		}
+		address, resolveErr = ens.Resolve(transport, rpcURL, contract)
+	}
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return address.Hex(), nil
+}
@@ -0,0 +1,15 @@
+package cli
+
+import "strings"
+
+// A flag.Value for flags which may be repeated, collecting every value in order.
+type stringListFlag []string
+
+func (f *stringListFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringListFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/moonstream-to/solface"
+)
+
+// Implements the "solface fmt" subcommand, which rewrites ABI JSON into a canonical form.
+func (c *command) runFormat(args []string) error {
+	var sortItems, write, lenient, jsonOutput bool
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flags.BoolVar(&sortItems, "sort", false, "If present, ABI items are sorted by type and name.")
+	flags.BoolVar(&lenient, "lenient", false, "If present, comments, trailing commas, and byte order marks are removed from the input before it is parsed, so that hand-maintained ABI files can be cleaned up.")
+	flags.BoolVar(&jsonOutput, "json", false, "If present, the result (the formatted ABI or the file written) is written to stdout as JSON.")
+	flags.BoolVar(&write, "w", false, "If present, the formatted ABI overwrites the input file instead of being written to stdout.")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s fmt [-sort] [-w] [-lenient] [-json] {<path to ABI file> | stdin}\n\n", programName)
+		flags.PrintDefaults()
+	}
+
+	out := c.newReporter("fmt", false)
+	if parseErr := out.Parse(flags, args, &jsonOutput); parseErr != nil {
+		return parseErr
+	}
+
+	if flags.NArg() > 1 || (write && flags.NArg() == 0) {
+		return out.Usage(flags)
+	}
+	contents, readErr := c.readABI(flags.Arg(0))
+	if readErr != nil {
+		return out.Fatalf("Error reading ABI: %s", readErr.Error())
+	}
+	if lenient {
+		contents = solface.SanitizeJSON(contents)
+	}
+
+	formatted, formatErr := solface.FormatABI(contents, sortItems)
+	if formatErr != nil {
+		return out.Fatalf("Error formatting ABI: %s", formatErr.Error())
+	}
+
+	if write {
+		info, statErr := os.Stat(flags.Arg(0))
+		if statErr != nil {
+			return out.Fatalf("Error reading ABI: %s", statErr.Error())
+		}
+		writeErr := os.WriteFile(flags.Arg(0), formatted, info.Mode())
+		if writeErr != nil {
+			return out.Fatalf("Error writing formatted ABI: %s", writeErr.Error())
+		}
+		out.Wrote(flags.Arg(0))
+	} else if jsonOutput {
+		out.result.Output = string(formatted)
+	} else {
+		c.stdout.Write(formatted)
+	}
+	return out.Finish()
+}
@@ -0,0 +1,892 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/diamond"
+	"github.com/moonstream-to/solface/etherscan"
+	"github.com/moonstream-to/solface/jsonrpc"
+	"github.com/moonstream-to/solface/proxy"
+	"github.com/moonstream-to/solface/standards"
+)
+
+// Implements the default solface command, which generates outputs (interfaces, by default) from ABIs.
+func (c *command) runGenerate(args []string) error {
+	flags := flag.NewFlagSet("solface", flag.ContinueOnError)
+	var interfaceName, singleFile, chain, address, rpcURL, explorerURL, etherscanKey, etherscanURL, nameTemplate, contractName, goPackage, target, hashName, outputDir, filenamePattern, devdocFile, deploymentsFile, license, renamesFile, dialect, contractTypes, interfaceIDFlag, nameConflicts, kind, pinnedInterface, addressBookFile, configPath, standardsDir, cpuProfile, memProfile string
+	var jsonOutput, resolveDiamond, toStdout, annotationsOnly, specialFunctions, lite, constructorComment, deploymentsLibrary, erc7201, lenient, strict, addAnnotations, checkMethodIdentifiers, payableNotes, splitStandards, securityAnnotations, natspecStubs, lintBuiltins, renameBuiltins, integerWidthAnnotations, skipInvalid, timestamp, codec, preserveABIOrder, indexComments, failOnEmpty, rawIR, udvts, eip712, pinABI, version bool
+	var maxItems, maxInputBytes, maxNestingDepth int
+	var lintSuppressions, memberLintSuppressions, pragmas, assumeViewFlags stringListFlag
+	var cassettes cassetteSettings
+	flags.BoolVar(&version, "version", false, "If present, solface prints its version and exits.")
+	flags.BoolVar(&jsonOutput, "json", false, "If present, the result (the generated output, files written, and warnings) is written to stdout as JSON. Human-readable messages are always written to stderr.")
+	flags.StringVar(&interfaceName, "name", "", "Name for Solidity interface you would like to generate. Defaults to I<contract name> for artifacts which record the contract name.")
+	flags.StringVar(&address, "address", "", "If provided, the verified ABI of the contract at this address is fetched from Etherscan instead of being read from a file. Protocol names in the address book (e.g. permit2 or uniswap-v3-factory) are resolved to their addresses on -chain (ethereum by default), and ENS names (e.g. vitalik.eth) are resolved with -rpc.")
+	flags.StringVar(&addressBookFile, "address-book", "", "Path to a JSON file mapping protocol names to their addresses by chain, which extends (and overrides) the address book that -address names are resolved with.")
+	flags.StringVar(&etherscanKey, "etherscan-key", "", fmt.Sprintf("Etherscan API key used with -address. Defaults to the %s environment variable.", etherscan.APIKeyEnvironmentVariable))
+	flags.StringVar(&etherscanURL, "etherscan-url", "", fmt.Sprintf("Etherscan API endpoint used with -address (any explorer which implements the Etherscan API can be used). Defaults to %s, or to %s with -chain.", etherscan.DefaultAPIURL, etherscan.V2APIURL))
+	flags.StringVar(&rpcURL, "rpc", "", "JSON-RPC endpoint of the chain the contract given by -address is deployed on. If provided, EIP-1967 and EIP-1822 proxies are detected, and interfaces to them are generated from the ABIs of their implementations.")
+	flags.BoolVar(&resolveDiamond, "diamond", false, "If present (along with -rpc), the contract given by -address is resolved as an EIP-2535 diamond: its facets are enumerated with the loupe functions, and a single interface is generated from the verified ABIs of all of its facets.")
+	flags.StringVar(&explorerURL, "explorer-url", "", "URL of a Blockscout explorer (e.g. https://explorer.zora.energy). If provided, the ABI of the contract given by -address is fetched from this explorer instead of Etherscan.")
+	flags.BoolVar(&pinABI, "pin-abi", false, "If present (along with -address and -single-file), the fetched ABI is written next to the generated interface (e.g. IFoo.abi.json next to IFoo.sol), along with its SHA-256 checksum, so that the interface can be regenerated offline with -pinned.")
+	flags.StringVar(&pinnedInterface, "pinned", "", "If provided, the interface at this path is regenerated from the ABI pinned next to it with -pin-abi, after verifying the ABI against its checksum.")
+	flags.StringVar(&chain, "chain", "", "Chain (chain ID or name, e.g. 8453 or base) on which the contract given by -address is deployed. Etherscan's multichain (V2) API is used to fetch its ABI.")
+	flags.StringVar(&nameTemplate, "name-template", "", "Go template for the names of interfaces generated without -name, e.g. \"I{{.Base}}\". Templates can refer to .Base (the input file name up to its first \".\"), .Contract (the contract name), and .Path (the input path). Defaults to I<contract name>.")
+	flags.StringVar(&contractName, "contract", "", "Name of the contract to generate output for, if the input contains several contracts (e.g. solc --combined-json output). Without it, output is generated for every contract and written to the output directory.")
+	flags.StringVar(&target, "target", solface.TargetInterface, fmt.Sprintf("Output to generate. Options: %s.", strings.Join(solface.TargetNames(), ", ")))
+	flags.StringVar(&goPackage, "go-package", "", "Package of the Go file generated by the go-constants target. Defaults to the lowercased interface name.")
+	flags.StringVar(&hashName, "hash", "keccak256", fmt.Sprintf("Hash function from which selectors and interface IDs are derived. Options: %s.", strings.Join(solface.HasherNames(), ", ")))
+	flags.BoolVar(&splitStandards, "split-standards", false, "If present, one interface is generated for every standard (e.g. ERC721) that the ABI implements, along with an interface for the remaining items. The interfaces are written to <name>_<standard>.sol and <name>_Custom.sol in the output directory.")
+	flags.StringVar(&standardsDir, "standards-dir", "", "Directory of ABIs (one per .json file, named after the standard, e.g. IVault.json) to register as custom standards for -split-standards, in addition to the built-in ERCs.")
+	flags.StringVar(&outputDir, "output-dir", ".", "Directory into which -split-standards, inputs that contain several contracts, and batches of ABI files (directories or several file arguments) write their output.")
+	flags.StringVar(&singleFile, "single-file", "", "If provided, all generated interfaces are written to this file, flattened into a single source with one license identifier and deduplicated pragmas and imports. Only supported for the interface target.")
+	flags.BoolVar(&toStdout, "stdout", false, "If present, the outputs for inputs with several contracts (combined-json output, ABI bundles, or batches of files) are concatenated to stdout instead of being written to the output directory.")
+	flags.StringVar(&filenamePattern, "filename-pattern", "", "Go template for the names of files written to the output directory (e.g. \"I{{.Name}}.sol\" or \"{{snake .Name}}.sol\"). Defaults to a pattern based on the target.")
+	flags.BoolVar(&addAnnotations, "annotations", false, "If present, adds annotations to generated interface. Annotations include: interface ID, method selectors, event signatures.")
+	flags.BoolVar(&annotationsOnly, "annotations-only", false, fmt.Sprintf("If present, only the annotations (interface ID, function selectors, event topics, and error selectors) are generated, as a comment block to paste into an existing interface. Equivalent to -target %s.", solface.TargetAnnotations))
+	flags.BoolVar(&checkMethodIdentifiers, "check-method-identifiers", false, "If present and the input is an artifact which records method identifiers (e.g. a Foundry artifact), selectors which do not match those identifiers are reported as warnings.")
+	flags.BoolVar(&lite, "lite", false, "If present, struct parameters and return values are declared as bytes (with NatSpec documenting their tuple encoding and the original selectors) instead of declaring structs. Intended for integrators calling contracts with low-level calls.")
+	flags.BoolVar(&specialFunctions, "special-functions", false, "If present, the fallback and receive functions of the ABI (if any) are declared in the interface. Otherwise, they are skipped with a warning.")
+	flags.BoolVar(&constructorComment, "constructor-comment", false, "If present, the signature of the constructor is included in a comment at the top of the interface.")
+	flags.BoolVar(&natspecStubs, "natspec-stubs", false, "If present, NatSpec stubs (@notice, @param, and @return tags with TODO placeholders) are generated for every function, event, and error, as a skeleton for documentation.")
+	flags.BoolVar(&lintBuiltins, "lint-builtins", false, "If present, warns about functions whose names shadow Solidity globals or builtins (e.g. send, transfer, call).")
+	flags.BoolVar(&renameBuiltins, "rename-builtins", false, "If present, functions whose names shadow Solidity globals or builtins are renamed with a trailing underscore in the generated interface (e.g. transfer_), unless -renames renames them.")
+	flags.BoolVar(&securityAnnotations, "security-annotations", false, "If present, functions which appear to expose dangerous capabilities (delegatecall, upgrades, selfdestruct, arbitrary calls) are annotated with @custom:security natspec.")
+	flags.BoolVar(&integerWidthAnnotations, "integer-width-annotations", false, "If present, functions which take or return integers narrower than 256 bits (e.g. uint48, int24) are annotated with notes about how those integers are encoded.")
+	flags.BoolVar(&preserveABIOrder, "preserve-abi-order", false, "If present, events, functions, and errors are generated in the order in which they appear in the ABI instead of being grouped by type.")
+	flags.BoolVar(&indexComments, "index-comments", false, "If present, every event, function, and error in the generated interface is preceded by a comment giving its index in the ABI.")
+	flags.BoolVar(&payableNotes, "payable-notes", false, "If present, payable functions are listed in a comment at the top of the interface and documented with their userdoc and devdoc (from -devdoc or the input artifact).")
+	flags.StringVar(&devdocFile, "devdoc", "", "Path to a devdoc JSON file (or a compilation artifact which includes devdoc) used to document payable functions with -payable-notes.")
+	flags.StringVar(&deploymentsFile, "deployments", "", "Path to a YAML or JSON file mapping chains to the addresses at which the contract is deployed on them. The deployments are listed in a comment at the top of the interface, and watched by the event filters of the event-filters and defender-sentinel targets.")
+	flags.BoolVar(&erc7201, "erc7201", false, "If present, a library with the base storage slot of every ERC-7201 namespace declared with @custom:storage-location in the devdoc (from -devdoc or the input artifact), and a slot-derivation helper, is generated after the interface.")
+	flags.BoolVar(&deploymentsLibrary, "deployments-library", false, "If present (along with -deployments), a library with an address constant for every deployment is generated after the interface.")
+	flags.StringVar(&license, "license", "", "License to include in generated interface - adds a comment at the top of the output with this as the SPDX identifier. Defaults to the license in the compiler metadata of the input, if it is an artifact that includes one.")
+	flags.BoolVar(&lenient, "lenient", false, "If present, comments, trailing commas, and byte order marks are removed from JSON inputs before they are parsed, as is common in hand-maintained ABI files.")
+	flags.BoolVar(&strict, "strict", false, "If present, every ABI item is validated (item types, names, parameter types, state mutability, and indexed event parameters) and questionable items are reported as diagnostics, failing generation.")
+	flags.BoolVar(&rawIR, "raw-ir", false, "If present, the json target includes the original JSON of every event, function, and error (under \"raw\"), so that fields which solface does not model are preserved.")
+	flags.BoolVar(&failOnEmpty, "fail-on-empty", false, "If present, generation fails for ABIs without events, functions, or errors (which are otherwise generated as empty interfaces, with a warning).")
+	flags.BoolVar(&skipInvalid, "skip-invalid", false, "If present, ABI items which cannot be decoded or rendered are skipped (with diagnostics on stderr) instead of failing the whole interface.")
+	flags.StringVar(&renamesFile, "renames", "", "Path to a YAML or JSON file mapping function selectors to the names those functions should have in the generated interface.")
+	flags.BoolVar(&codec, "codec", false, "If present, a library with encode and decode helpers for every struct in the interface is generated after the interface (along with \"using ... for\" directives, if the pragma admits Solidity >= 0.8.13).")
+	flags.Var(&lintSuppressions, "lint-suppress", "Lint-suppression directive (e.g. \"solhint-disable no-empty-blocks\") to include as a comment before the interface declaration. May be repeated.")
+	flags.Var(&memberLintSuppressions, "lint-suppress-member", "Lint-suppression directive (e.g. \"solhint-disable-next-line func-name-mixedcase\") to include as a comment before every function whose name is not mixedCase. May be repeated.")
+	flags.BoolVar(&timestamp, "timestamp", false, "If present, the generation time is included in the header of the output. Honors SOURCE_DATE_EPOCH for reproducible builds.")
+	flags.IntVar(&maxItems, "max-items", 0, "If positive, ABIs with more items than this are rejected.")
+	flags.IntVar(&maxInputBytes, "max-input-bytes", 0, "If positive, ABIs larger than this many bytes are rejected.")
+	flags.IntVar(&maxNestingDepth, "max-nesting-depth", solface.DefaultMaxNestingDepth, "ABIs whose compound types are nested more deeply than this are rejected (with -skip-invalid, the offending items are skipped instead).")
+	flags.StringVar(&interfaceIDFlag, "interface-id", "", "If provided (e.g. 0x80ac58cd), only the smallest subset of the functions of the ABI whose selectors XOR to this interface ID is declared, to diagnose why type(I).interfaceId does not match a published interface ID. Fails if there is no such subset, or if the subset is ambiguous (e.g. several subsets are equally small, or the ABI has so many functions that some subset has the ID by coincidence).")
+	flags.Var(&assumeViewFlags, "assume-view", "Comma-separated selectors (e.g. 0x70a08231,0x18160ddd) of functions to declare as view in the generated interface, with a comment documenting the override, regardless of the state mutability in the ABI. Functions named like getters which are not view are reported as warnings. May be repeated.")
+	flags.BoolVar(&eip712, "eip712", false, "If present, a library with the EIP-712 type hashes (and Permit2 witness type strings) of the structs which functions take as input, and functions hashing them, is generated after the interface.")
+	flags.BoolVar(&udvts, "udvt", false, "If present, user-defined value types (e.g. internalType \"Price\" for a uint128) are declared in the interface (\"type Price is uint128;\") and used in place of the types they wrap. Requires Solidity >= 0.8.8.")
+	flags.StringVar(&nameConflicts, "name-conflicts", "", "How to declare errors which share a name (errors with the same name and different parameters, e.g. in merged ABIs, since errors cannot be overloaded): \"suffix\" (the default - all but the first are renamed with numeric suffixes, which changes their selectors), \"prefer-first\" (only the first is declared), or \"error\" (fail).")
+	flags.StringVar(&kind, "kind", "", "The kind of contract the ABI belongs to: \"contract\" (the default) or \"library\". For libraries, the interface only declares the view and pure functions which other contracts can call on the deployed library, and functions which cannot be called that way (e.g. state-changing functions, or functions taking storage references) are reported as warnings.")
+	flags.StringVar(&contractTypes, "contract-types", "", "How to declare parameters whose internalType is a contract or interface (e.g. \"contract IERC20\"): \"address\" (the default), \"comment\" (address, with a comment naming the contract type), or \"stub\" (the contract type, with an empty interface declared for it).")
+	flags.StringVar(&dialect, "dialect", "", "Language which produced the ABI (\"solidity\" or \"vyper\"). If not provided, the dialect is detected from the ABI.")
+	flags.StringVar(&configPath, "config", "", fmt.Sprintf("Path of a %s (see \"%s init\") whose jobs are generated, instead of reading inputs. The ABIs and outputs of jobs are relative to the directory of the configuration. Its license, pragma, and annotations settings apply unless -license, -pragma, or -annotations are given, and the other flags apply to every job.", solface.ConfigFileName, programName))
+	cassettes.register(flags)
+	flags.StringVar(&cpuProfile, "profile", "", "If provided, solface writes a CPU profile (in pprof format) of the generation pipeline to this file.")
+	flags.StringVar(&memProfile, "memprofile", "", "If provided, solface writes a heap profile (in pprof format) to this file once generation is complete.")
+	flags.Var(&pragmas, "pragma", "Pragma to include at the top of the generated interface (may be repeated, e.g. -pragma \"^0.8.20\" -pragma \"abicoder v2\") - a version constraint (optionally prefixed with \"solidity\") becomes the Solidity version pragma, and other pragmas are rendered after it in the order given.")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s -name <interface name> [-target <target>] [-annotations] [-json] {<path to ABI or artifact file> | stdin}\n", programName)
+		fmt.Fprintf(flags.Output(), "%s [-target <target>] [-output-dir <directory>] [-json] {<directory> | <path to ABI or artifact file>...}\n", programName)
+		fmt.Fprintf(flags.Output(), "%s -address <address> [-etherscan-key <key> | -explorer-url <Blockscout URL>] [-rpc <url> [-diamond]] [-name <interface name>] [-target <target>] [-single-file <path> [-pin-abi]]\n", programName)
+		fmt.Fprintf(flags.Output(), "%s -config <path to solface.yaml> [-target <target>] [-json]\n", programName)
+		fmt.Fprintf(flags.Output(), "%s -pinned <path to interface> [-name <interface name>] [-target <target>]\n", programName)
+		fmt.Fprintf(flags.Output(), "%s fmt [-sort] [-w] [-lenient] [-json] {<path to ABI file> | stdin}\n", programName)
+		fmt.Fprintf(flags.Output(), "%s init [-force] [-json] [<project directory>]\n", programName)
+		fmt.Fprintf(flags.Output(), "%s analyze [-report <report>] [-format {markdown | json}] [-json] {<path to ABI file> | stdin}\n", programName)
+		fmt.Fprintf(flags.Output(), "%s publish -rpc <url> -registry <address> [-name <interface name>] [-dry-run] [-json] {<path to ABI or artifact file> | stdin}\n", programName)
+		fmt.Fprintf(flags.Output(), "%s matrix [-format {markdown | json}] [-differences] [-json] <path to ABI or artifact file>...\n", programName)
+		fmt.Fprintf(flags.Output(), "%s skeleton [-name <interface name>] [-lookup <database>] [-json] {-selectors <selectors> | -rpc <url> -address <address> | <path to bytecode file> | stdin}\n", programName)
+		fmt.Fprintf(flags.Output(), "%s watch {-address <address> -output <path> | -config <solface.yaml>} [-rpc <url>] [-poll <interval>] [-report <path>] [-json]\n", programName)
+		fmt.Fprintf(flags.Output(), "%s schema {-list | <schema>}\n\n", programName)
+		flags.PrintDefaults()
+		fmt.Fprintf(flags.Output(), "\nsolface version v%s\n", solface.VERSION)
+	}
+
+	out := c.newReporter("generate", false)
+	if parseErr := out.Parse(flags, args, &jsonOutput); parseErr != nil {
+		return parseErr
+	}
+
+	if version {
+		if jsonOutput {
+			out.Output("", fmt.Sprintf("v%s\n", solface.VERSION))
+			return out.Finish()
+		}
+		fmt.Fprintf(c.stdout, "v%s\n", solface.VERSION)
+		return nil
+	}
+
+	if annotationsOnly {
+		target = solface.TargetAnnotations
+	}
+	out.result.Target = target
+
+	// The settings of the configuration apply unless the flags which override them are given.
+	var config solface.Config
+	if configPath != "" {
+		var configErr error
+		config, configErr = solface.LoadConfig(configPath)
+		if configErr != nil {
+			return out.Fatalf("Error reading %s: %s", configPath, configErr.Error())
+		}
+		if len(config.Jobs) == 0 {
+			return out.Fatalf("Error: %s lists no jobs", configPath)
+		}
+		setFlags := map[string]bool{}
+		flags.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+		if !setFlags["license"] {
+			license = config.License
+		}
+		if !setFlags["pragma"] && config.Pragma != "" {
+			pragmas = stringListFlag{config.Pragma}
+		}
+		if !setFlags["annotations"] {
+			addAnnotations = config.Annotations
+		}
+		if !setFlags["standards-dir"] && config.StandardsDir != "" {
+			standardsDir = resolveConfigPath(filepath.Dir(configPath), config.StandardsDir)
+		}
+	}
+
+	generate, ok := solface.GetTarget(target)
+	if !ok {
+		return out.Fatalf("Unknown target: %s", target)
+	}
+
+	hasher, ok := solface.GetHasher(hashName)
+	if !ok {
+		return out.Fatalf("Unknown hash function: %s", hashName)
+	}
+
+	var renames map[string]string
+	if renamesFile != "" {
+		var renamesErr error
+		renames, renamesErr = solface.LoadRenames(renamesFile)
+		if renamesErr != nil {
+			return out.Fatalf("Error reading renames: %s", renamesErr.Error())
+		}
+	}
+	var deployments []solface.Deployment
+	if deploymentsFile != "" {
+		var deploymentsErr error
+		deployments, deploymentsErr = solface.LoadDeployments(deploymentsFile)
+		if deploymentsErr != nil {
+			return out.Fatalf("Error reading deployments: %s", deploymentsErr.Error())
+		}
+	}
+	var generationTime time.Time
+	if timestamp {
+		var timeErr error
+		generationTime, timeErr = solface.GenerationTime()
+		if timeErr != nil {
+			return out.Fatalf("Error determining generation time: %s", timeErr.Error())
+		}
+	}
+
+	// Conflicting flags are all reported at once, before any input is read.
+	problems := []string{}
+	if splitStandards && toStdout {
+		problems = append(problems, "-split-standards cannot be used with -stdout")
+	}
+	if singleFile != "" && (splitStandards || toStdout || target != solface.TargetInterface) {
+		problems = append(problems, "-single-file can only be used with the interface target, and not with -split-standards or -stdout")
+	}
+	if toStdout && solface.IsBinaryTarget(target) {
+		problems = append(problems, fmt.Sprintf("-stdout cannot be used with the %s target, whose outputs are binary and cannot be concatenated", target))
+	}
+	if splitStandards && target != solface.TargetInterface {
+		problems = append(problems, fmt.Sprintf("-split-standards can only be used with the %s target", solface.TargetInterface))
+	}
+	transport, transportErr := cassettes.transport()
+	if transportErr != nil {
+		problems = append(problems, transportErr.Error())
+	}
+	var interfaceID []byte
+	if interfaceIDFlag != "" {
+		var interfaceIDErr error
+		interfaceID, interfaceIDErr = solface.ParseInterfaceID(interfaceIDFlag)
+		if interfaceIDErr != nil {
+			problems = append(problems, fmt.Sprintf("-interface-id: %s", interfaceIDErr.Error()))
+		}
+	}
+	if rawIR && target != solface.TargetJSON {
+		problems = append(problems, fmt.Sprintf("-raw-ir can only be used with the %s target", solface.TargetJSON))
+	}
+	if address != "" && flags.NArg() > 0 {
+		problems = append(problems, "-address cannot be used with input files")
+	}
+	if pinABI && (address == "" || singleFile == "") {
+		problems = append(problems, "-pin-abi requires -address and -single-file")
+	}
+	if pinnedInterface != "" && (address != "" || flags.NArg() > 0) {
+		problems = append(problems, "-pinned cannot be used with -address or input files")
+	}
+	if configPath != "" && (address != "" || pinnedInterface != "" || flags.NArg() > 0 || interfaceName != "" || contractName != "" || singleFile != "" || toStdout) {
+		problems = append(problems, "-config cannot be used with -address, -pinned, input files, -name, -contract, -single-file, or -stdout")
+	}
+	if resolveDiamond && rpcURL == "" {
+		problems = append(problems, "-diamond requires -rpc")
+	}
+	pragma, extraPragmas, pragmasErr := solface.ParsePragmas(pragmas)
+	if pragmasErr != nil {
+		problems = append(problems, fmt.Sprintf("-pragma: %s", pragmasErr.Error()))
+	}
+	explorer := explorerSettings{ExplorerURL: explorerURL, EtherscanURL: etherscanURL, EtherscanKey: etherscanKey, Chain: chain, Transport: transport}
+	if validateErr := explorer.validate(); validateErr != nil {
+		problems = append(problems, validateErr.Error())
+	}
+	assumeView := []string{}
+	for _, selectors := range assumeViewFlags {
+		for _, selector := range strings.Split(selectors, ",") {
+			if selector = strings.TrimSpace(selector); selector != "" {
+				assumeView = append(assumeView, selector)
+			}
+		}
+	}
+	// Jobs of a configuration may list their own deployments, so -deployments-library does not require
+	// -deployments with -config.
+	flagOptions := solface.Options{
+		Name:                  interfaceName,
+		Pragma:                pragma,
+		ExtraPragmas:          extraPragmas,
+		Dialect:               dialect,
+		ContractTypes:         contractTypes,
+		NameConflicts:         nameConflicts,
+		Kind:                  kind,
+		UserDefinedValueTypes: udvts,
+		MaxNestingDepth:       maxNestingDepth,
+		MaxItems:              maxItems,
+		MaxInputBytes:         maxInputBytes,
+		SkipInvalid:           skipInvalid,
+		Strict:                strict,
+		Codec:                 codec,
+		EIP712:                eip712,
+		Lite:                  lite,
+		Deployments:           deployments,
+		DeploymentsLibrary:    deploymentsLibrary && configPath == "",
+		FunctionRenames:       renames,
+		AssumeView:            assumeView,
+	}
+	var optionsErr *solface.OptionsError
+	if errors.As(flagOptions.Validate(), &optionsErr) {
+		for _, problem := range optionsErr.Problems {
+			problems = append(problems, fmt.Sprintf("%s: %s", optionFlags(problem.Fields), problem.Message))
+		}
+	}
+	if len(problems) > 0 {
+		return out.Fatalf("Invalid options:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+
+	if cpuProfile != "" {
+		profileFile, profileErr := os.Create(cpuProfile)
+		if profileErr != nil {
+			return out.Fatalf("Error creating CPU profile: %s", profileErr.Error())
+		}
+		defer profileFile.Close()
+		if profileErr := pprof.StartCPUProfile(profileFile); profileErr != nil {
+			return out.Fatalf("Error starting CPU profile: %s", profileErr.Error())
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	if standardsDir != "" {
+		if registerErr := standards.RegisterDirectory(standardsDir); registerErr != nil {
+			return out.Fatalf("Error registering standards: %s", registerErr.Error())
+		}
+	}
+
+	inputs, batch, inputsErr := inputPaths(flags.Args())
+	if inputsErr != nil {
+		return out.Fatalf("Error reading inputs: %s", inputsErr.Error())
+	}
+	if batch && len(inputs) == 0 {
+		return out.Fatalf("No ABI files found in %s", strings.Join(flags.Args(), ", "))
+	}
+
+	// Interface names are determined for each artifact as it is read, since name templates may refer to the
+	// input file.
+	artifacts := []solface.Artifact{}
+	interfaceNames := []string{}
+	addArtifact := func(artifact solface.Artifact, input string) error {
+		name := solface.DefaultInterfaceName(artifact.ContractName)
+		if nameTemplate != "" {
+			var nameErr error
+			name, nameErr = solface.InterfaceNameFromTemplate(nameTemplate, solface.NewInterfaceNameData(input, artifact.ContractName))
+			if nameErr != nil {
+				return out.Fatalf("Error naming interface: %s", nameErr.Error())
+			}
+		}
+		out.Warn(artifact.Diagnostics)
+		artifacts = append(artifacts, artifact)
+		interfaceNames = append(interfaceNames, name)
+		return nil
+	}
+
+	// The address of the proxy that the interface is generated for, if -address is a proxy.
+	var proxyAddress string
+	if address != "" {
+		resolved, resolveErr := resolveAddress(transport, rpcURL, chain, addressBookFile, address)
+		if resolveErr != nil {
+			return out.Fatalf("Error resolving %s: %s", address, resolveErr.Error())
+		}
+		address = resolved
+
+		if resolveDiamond {
+			facets, facetsErr := diamond.Facets(jsonrpc.Client{URL: rpcURL, Transport: transport}, address)
+			if facetsErr != nil {
+				return out.Fatalf("Error enumerating facets: %s", facetsErr.Error())
+			}
+			facetABIs := make([][]byte, len(facets))
+			for i, facet := range facets {
+				contract, fetchErr := explorer.fetch(facet.Address)
+				if fetchErr != nil {
+					return out.Fatalf("Error fetching ABI: %s", fetchErr.Error())
+				}
+				facetABIs[i] = contract.ABI
+			}
+			mergedABI, mergeDiagnostics, mergeErr := diamond.MergeABIs(facets, facetABIs)
+			if mergeErr != nil {
+				return out.Fatalf("Error merging facet ABIs: %s", mergeErr.Error())
+			}
+			out.Warn(mergeDiagnostics)
+			if addErr := addArtifact(solface.Artifact{Kind: solface.ArtifactKindABI, ContractName: "Diamond", ABI: mergedABI}, address); addErr != nil {
+				return addErr
+			}
+		} else {
+			contractAddress := address
+			if rpcURL != "" {
+				detected, isProxy, detectErr := proxy.Detect(jsonrpc.Client{URL: rpcURL, Transport: transport}, address)
+				if detectErr != nil {
+					return out.Fatalf("Error detecting proxy: %s", detectErr.Error())
+				}
+				if isProxy {
+					out.Warn([]solface.Diagnostic{{ItemType: "contract", Name: detected.Address, Message: fmt.Sprintf("is an %s proxy - generating an interface from the ABI of its implementation at %s", detected.Kind, detected.Implementation)}})
+					proxyAddress = detected.Address
+					contractAddress = detected.Implementation
+				}
+			}
+			contract, fetchErr := explorer.fetch(contractAddress)
+			if fetchErr != nil {
+				return out.Fatalf("Error fetching ABI: %s", fetchErr.Error())
+			}
+			if contract.Implementation != "" && proxyAddress == "" {
+				out.Warn([]solface.Diagnostic{{ItemType: "contract", Name: contract.Name, Message: fmt.Sprintf("%s is a proxy - generating an interface to the proxy itself (the implementation is at %s, pass -rpc to use it instead)", contract.Address, contract.Implementation)}})
+			}
+			if license == "" {
+				license = contract.License
+			}
+			if addErr := addArtifact(solface.Artifact{Kind: solface.ArtifactKindABI, ContractName: contract.Name, ABI: contract.ABI}, address); addErr != nil {
+				return addErr
+			}
+		}
+		inputs = nil
+	}
+
+	if pinnedInterface != "" {
+		contents, readErr := solface.ReadPinnedABI(pinnedInterface)
+		if readErr != nil {
+			return out.Fatalf("Error reading pinned ABI: %s", readErr.Error())
+		}
+		inputArtifacts, artifactsErr := solface.ParseArtifacts(contents)
+		if artifactsErr != nil {
+			return out.Fatalf("Error reading artifact: %s", artifactsErr.Error())
+		}
+		for _, artifact := range inputArtifacts {
+			if addErr := addArtifact(artifact, pinnedInterface); addErr != nil {
+				return addErr
+			}
+		}
+		// Pinned ABIs do not record the contract name, so the interface keeps the name of its file.
+		if interfaceName == "" {
+			interfaceName = strings.TrimSuffix(filepath.Base(pinnedInterface), filepath.Ext(pinnedInterface))
+		}
+		inputs = nil
+	}
+
+	// The output path, renames, and deployments of every job of the configuration, in the order of artifacts.
+	jobOutputs := []string{}
+	jobRenames := []map[string]string{}
+	jobDeployments := [][]solface.Deployment{}
+	if configPath != "" {
+		root := filepath.Dir(configPath)
+		for _, job := range config.Jobs {
+			abiPath := resolveConfigPath(root, job.ABI)
+			contents, readErr := os.ReadFile(abiPath)
+			if readErr != nil {
+				return out.Fatalf("Error reading ABI: %s", readErr.Error())
+			}
+			if lenient {
+				contents = solface.SanitizeJSON(contents)
+			}
+			jobArtifacts, artifactsErr := solface.ParseArtifacts(contents)
+			if artifactsErr != nil {
+				return out.Fatalf("Error reading artifact %s: %s", abiPath, artifactsErr.Error())
+			}
+			if len(jobArtifacts) != 1 {
+				return out.Fatalf("Error reading artifact %s: jobs generate a single interface, but it contains %d contracts", abiPath, len(jobArtifacts))
+			}
+			if addErr := addArtifact(jobArtifacts[0], abiPath); addErr != nil {
+				return addErr
+			}
+			if job.Name != "" {
+				interfaceNames[len(interfaceNames)-1] = job.Name
+			}
+			jobOutputs = append(jobOutputs, resolveConfigPath(root, job.Output))
+
+			// Renames given by -renames take precedence over those of the job, and -deployments replaces the
+			// deployments of the job.
+			mergedRenames := map[string]string{}
+			for selector, name := range job.Renames {
+				mergedRenames[solface.NormalizeSelector(selector)] = name
+			}
+			for selector, name := range renames {
+				mergedRenames[solface.NormalizeSelector(selector)] = name
+			}
+			if validateErr := (solface.Options{FunctionRenames: mergedRenames}).Validate(); validateErr != nil {
+				return out.Fatalf("Error in renames of job %s: %s", interfaceNames[len(interfaceNames)-1], validateErr.Error())
+			}
+			jobRenames = append(jobRenames, mergedRenames)
+			jobDeploymentList := deployments
+			if deploymentsFile == "" {
+				var deploymentsErr error
+				jobDeploymentList, deploymentsErr = solface.ParseDeployments(job.Deployments)
+				if deploymentsErr != nil {
+					return out.Fatalf("Error in deployments of job %s: %s", interfaceNames[len(interfaceNames)-1], deploymentsErr.Error())
+				}
+			}
+			if deploymentsLibrary && len(jobDeploymentList) > 0 {
+				if validateErr := (solface.Options{Deployments: jobDeploymentList, DeploymentsLibrary: true}).Validate(); validateErr != nil {
+					return out.Fatalf("Error in deployments of job %s: %s", interfaceNames[len(interfaceNames)-1], validateErr.Error())
+				}
+			}
+			jobDeployments = append(jobDeployments, jobDeploymentList)
+		}
+		inputs = nil
+	}
+
+	for _, input := range inputs {
+		contents, readErr := c.readABI(input)
+		if readErr != nil {
+			return out.Fatalf("Error reading ABI: %s", readErr.Error())
+		}
+		if lenient {
+			contents = solface.SanitizeJSON(contents)
+		}
+
+		inputArtifacts, artifactsErr := solface.ParseArtifacts(contents)
+		if batch && errors.Is(artifactsErr, solface.ErrNotArtifact) {
+			// Directories of artifacts often contain other JSON files, such as Hardhat build-info files.
+			out.Warn([]solface.Diagnostic{{ItemType: "file", Name: input, Message: "skipped: not an ABI or a supported artifact (e.g. a Hardhat build-info file)"}})
+			continue
+		}
+		if artifactsErr != nil {
+			if batch {
+				return out.Fatalf("Error reading artifact %s: %s", input, artifactsErr.Error())
+			}
+			return out.Fatalf("Error reading artifact: %s", artifactsErr.Error())
+		}
+		for _, artifact := range inputArtifacts {
+			// In batch mode, interfaces for ABIs which do not record their contract name are named after
+			// their files.
+			if batch && artifact.ContractName == "" {
+				artifact.ContractName = solface.ContractNameFromFilename(input)
+			}
+			if addErr := addArtifact(artifact, input); addErr != nil {
+				return addErr
+			}
+		}
+	}
+	if contractName != "" {
+		artifact, selectErr := solface.SelectArtifact(artifacts, contractName)
+		if selectErr != nil {
+			return out.Fatalf("Error selecting contract: %s", selectErr.Error())
+		}
+		for i := range artifacts {
+			if artifacts[i].ContractName == artifact.ContractName {
+				interfaceNames = []string{interfaceNames[i]}
+				break
+			}
+		}
+		artifacts = []solface.Artifact{artifact}
+	}
+	// Inputs with several contracts (e.g. solc --combined-json output, or several ABI files) produce one
+	// file per contract.
+	multipleOutputs := len(artifacts) > 1 || (batch && contractName == "") || configPath != ""
+	if multipleOutputs && interfaceName != "" {
+		return out.Fatalf("-name cannot be used with an input that contains %d contracts - select one of them with -contract", len(artifacts))
+	}
+
+	if len(artifacts) == 0 {
+		return out.Fatalf("Error reading artifact: no contracts in input")
+	}
+	if interfaceName == "" && !multipleOutputs {
+		interfaceName = interfaceNames[0]
+	}
+	out.result.Name = interfaceName
+	if interfaceName == "" && target == solface.TargetInterface && !multipleOutputs {
+		return out.Usage(flags)
+	}
+
+	// Generates the output for a single contract, either to the given writer or, with -split-standards, to
+	// files in the output directory.
+	generateArtifact := func(artifact solface.Artifact, interfaceName string, writer io.Writer) error {
+		artifactLicense := license
+		if artifactLicense == "" {
+			// Propagate the license of the original source if the input is an artifact which records it.
+			artifactLicense = solface.ArtifactLicense(artifact.Raw)
+		}
+
+		options := solface.Options{
+			Name:                    interfaceName,
+			License:                 artifactLicense,
+			Pragma:                  pragma,
+			ExtraPragmas:            extraPragmas,
+			IncludeAnnotations:      addAnnotations,
+			SecurityAnnotations:     securityAnnotations,
+			LintSuppressions:        lintSuppressions,
+			MemberLintSuppressions:  memberLintSuppressions,
+			MaxNestingDepth:         maxNestingDepth,
+			SkipInvalid:             skipInvalid,
+			MaxItems:                maxItems,
+			MaxInputBytes:           maxInputBytes,
+			Dialect:                 dialect,
+			ContractTypes:           contractTypes,
+			NameConflicts:           nameConflicts,
+			Kind:                    kind,
+			UserDefinedValueTypes:   udvts,
+			RawIRItems:              rawIR,
+			Codec:                   codec,
+			EIP712:                  eip712,
+			IntegerWidthAnnotations: integerWidthAnnotations,
+			PreserveABIOrder:        preserveABIOrder,
+			IndexComments:           indexComments,
+			Hasher:                  hasher,
+			PayableNotes:            payableNotes,
+			Timestamp:               generationTime,
+			Deployments:             deployments,
+			DeploymentsLibrary:      deploymentsLibrary && len(deployments) > 0,
+			GoPackage:               goPackage,
+			ConstructorComment:      constructorComment,
+			Lite:                    lite,
+			SpecialFunctions:        specialFunctions,
+			ProxyAddress:            proxyAddress,
+			NatspecStubs:            natspecStubs,
+			Strict:                  strict,
+		}
+
+		abi, diagnostics, decodeErr := solface.DecodeWithOptions(artifact.ABI, options)
+		var strictErr *solface.StrictValidationError
+		if errors.As(decodeErr, &strictErr) {
+			out.Warn(strictErr.Diagnostics)
+			return out.Fatalf("Error decoding ABI: %d item(s) failed strict validation", len(strictErr.Diagnostics))
+		}
+		if decodeErr != nil {
+			return out.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+		}
+
+		if skipInvalid {
+			var unsupportedErr *solface.UnsupportedFeaturesError
+			if errors.As(solface.CheckSupport(abi, pragma, extraPragmas), &unsupportedErr) {
+				var removalDiagnostics []solface.Diagnostic
+				abi, removalDiagnostics = solface.RemoveUnsupported(abi, unsupportedErr.Items)
+				diagnostics = append(diagnostics, removalDiagnostics...)
+			}
+		}
+		if interfaceID != nil {
+			trimmedABI, trimErr := solface.TrimToInterfaceID(abi, interfaceID, hasher)
+			if trimErr != nil {
+				return out.Fatalf("Error trimming %s: %s", options.Name, trimErr.Error())
+			}
+			diagnostics = append(diagnostics, solface.Diagnostic{ItemType: "contract", Name: options.Name, Message: fmt.Sprintf("kept %d of %d functions, whose selectors XOR to interface ID 0x%x", len(trimmedABI.Functions), len(abi.Functions), interfaceID)})
+			abi = trimmedABI
+		}
+		if solface.IsEmptyABI(abi) && failOnEmpty {
+			return out.Fatalf("Error generating %s: ABI has no events, functions, or errors", options.Name)
+		}
+		diagnostics = append(diagnostics, solface.EmptyABIDiagnostics(abi, options.Name)...)
+		if target == solface.TargetInterface {
+			diagnostics = append(diagnostics, solface.NameConflictDiagnostics(abi, nameConflicts)...)
+			if contractTypes == solface.ContractTypesComment || contractTypes == solface.ContractTypesStub {
+				diagnostics = append(diagnostics, solface.MalformedContractTypeDiagnostics(abi)...)
+			}
+			if kind == solface.KindLibrary {
+				diagnostics = append(diagnostics, solface.LibraryDiagnostics(abi, hasher)...)
+			}
+		}
+		diagnostics = append(diagnostics, solface.SecurityDiagnostics(abi)...)
+		if assumedABI, _, assumeErr := solface.AssumeView(abi, assumedViewForABI(assumeView, abi)); assumeErr == nil {
+			// Functions assumed to be view are no longer suspicious.
+			diagnostics = append(diagnostics, solface.SuspiciousGetterDiagnostics(assumedABI)...)
+		}
+		if lintBuiltins {
+			diagnostics = append(diagnostics, solface.BuiltinShadowingDiagnostics(abi)...)
+		}
+		if !specialFunctions {
+			diagnostics = append(diagnostics, solface.SkippedSpecialFunctionDiagnostics(abi)...)
+		}
+		if checkMethodIdentifiers && artifact.MethodIdentifiers != nil {
+			diagnostics = append(diagnostics, solface.CheckMethodIdentifiers(abi, artifact.MethodIdentifiers)...)
+		}
+		out.Warn(diagnostics)
+
+		annotations, annotationErr := solface.AnnotateWithHasher(abi, hasher)
+		if annotationErr != nil && addAnnotations {
+			return out.Fatalf("Error generating annotations: %s", annotationErr.Error())
+		}
+
+		if multipleOutputs {
+			options.FunctionRenames = renamesForABI(renames, abi)
+			options.AssumeView = assumedViewForABI(assumeView, abi)
+		} else {
+			options.FunctionRenames = renames
+			options.AssumeView = assumeView
+		}
+		if renameBuiltins {
+			options.FunctionRenames = solface.WithBuiltinRenames(abi, options.FunctionRenames)
+		}
+		if devdocFile != "" {
+			devdoc, devdocErr := solface.LoadDevdoc(devdocFile)
+			if devdocErr != nil {
+				return out.Fatalf("Error reading devdoc: %s", devdocErr.Error())
+			}
+			options.Devdoc = devdoc
+		} else if devdoc, ok := solface.ArtifactDevdoc(artifact.Raw); ok {
+			options.Devdoc = devdoc
+		}
+		if userdoc, ok := solface.ArtifactUserdoc(artifact.Raw); ok {
+			options.Userdoc = userdoc
+		}
+		if erc7201 {
+			options.StorageNamespaces = solface.StorageNamespaces(options.Devdoc)
+			if len(options.StorageNamespaces) == 0 {
+				out.Warn([]solface.Diagnostic{{ItemType: "contract", Name: options.Name, Message: "no ERC-7201 storage namespaces (@custom:storage-location erc7201:...) found in devdoc"}})
+			}
+		}
+
+		var generateErr error
+		if splitStandards {
+			generateErr = writeSplitStandards(abi, options, outputDir, filenamePattern, out)
+		} else {
+			generateErr = generate(abi, annotations, options, writer)
+		}
+		if generateErr != nil {
+			var unsupportedErr *solface.UnsupportedFeaturesError
+			if errors.As(generateErr, &unsupportedErr) {
+				// Emit the offending items as JSON on stderr so that scripts can act on them.
+				json.NewEncoder(c.stderr).Encode(unsupportedErr)
+				out.result.Report = unsupportedErr
+			}
+			return out.Fatalf("Error generating %s (%s): %s", target, interfaceName, generateErr.Error())
+		}
+		return nil
+	}
+
+	if multipleOutputs {
+		seenNames := map[string]bool{}
+		for _, name := range interfaceNames {
+			if seenNames[name] {
+				return out.Fatalf("Several inputs produce an interface named %s - select one of them with -contract, or use -name-template", name)
+			}
+			seenNames[name] = true
+		}
+	}
+
+	// Generates the output for a single contract to the file at the given path.
+	writeOutput := func(artifact solface.Artifact, interfaceName, outputPath string) error {
+		if mkdirErr := os.MkdirAll(filepath.Dir(outputPath), 0755); mkdirErr != nil {
+			return out.Fatalf("Error creating output directory: %s", mkdirErr.Error())
+		}
+		outputFile, createErr := os.Create(outputPath)
+		if createErr != nil {
+			return out.Fatalf("Error creating %s: %s", outputPath, createErr.Error())
+		}
+		if generateErr := generateArtifact(artifact, interfaceName, outputFile); generateErr != nil {
+			outputFile.Close()
+			return generateErr
+		}
+		if closeErr := outputFile.Close(); closeErr != nil {
+			return out.Fatalf("Error writing %s: %s", outputPath, closeErr.Error())
+		}
+		out.Wrote(outputPath)
+		return nil
+	}
+
+	if singleFile != "" {
+		sources := make([]string, len(artifacts))
+		for i, artifact := range artifacts {
+			name := interfaceName
+			if multipleOutputs {
+				name = interfaceNames[i]
+			}
+			var output strings.Builder
+			if generateErr := generateArtifact(artifact, name, &output); generateErr != nil {
+				return generateErr
+			}
+			sources[i] = output.String()
+		}
+		if mkdirErr := os.MkdirAll(filepath.Dir(singleFile), 0755); mkdirErr != nil {
+			return out.Fatalf("Error creating output directory: %s", mkdirErr.Error())
+		}
+		if writeErr := os.WriteFile(singleFile, []byte(solface.FlattenSolidity(sources)), 0644); writeErr != nil {
+			return out.Fatalf("Error writing %s: %s", singleFile, writeErr.Error())
+		}
+		out.Wrote(singleFile)
+		if pinABI {
+			pinned, pinErr := solface.PinABI(artifacts[0].ABI, singleFile)
+			if pinErr != nil {
+				return out.Fatalf("Error pinning ABI: %s", pinErr.Error())
+			}
+			out.Wrote(pinned.ABIPath)
+			out.Wrote(pinned.ChecksumPath)
+		}
+	} else if configPath != "" {
+		for i, artifact := range artifacts {
+			renames, deployments = jobRenames[i], jobDeployments[i]
+			if splitStandards {
+				// The interfaces of jobs split into standards are written next to the outputs of the jobs.
+				outputDir = filepath.Dir(jobOutputs[i])
+				if generateErr := generateArtifact(artifact, interfaceNames[i], nil); generateErr != nil {
+					return generateErr
+				}
+				continue
+			}
+			if generateErr := writeOutput(artifact, interfaceNames[i], jobOutputs[i]); generateErr != nil {
+				return generateErr
+			}
+		}
+	} else if multipleOutputs {
+		var concatenated strings.Builder
+		for i, artifact := range artifacts {
+			name := interfaceNames[i]
+			if splitStandards {
+				if generateErr := generateArtifact(artifact, name, nil); generateErr != nil {
+					return generateErr
+				}
+				continue
+			}
+			if toStdout {
+				// Outputs are concatenated, separated by blank lines.
+				if i > 0 {
+					concatenated.WriteString("\n")
+				}
+				if generateErr := generateArtifact(artifact, name, &concatenated); generateErr != nil {
+					return generateErr
+				}
+				continue
+			}
+			filename, filenameErr := solface.OutputFilename(filenamePattern, target, name)
+			if filenameErr != nil {
+				return out.Fatalf("Error naming output for %s: %s", artifact.ContractName, filenameErr.Error())
+			}
+			if generateErr := writeOutput(artifact, name, filepath.Join(outputDir, filename)); generateErr != nil {
+				return generateErr
+			}
+		}
+		if toStdout && jsonOutput {
+			out.Output(target, concatenated.String())
+		} else if toStdout {
+			io.WriteString(c.stdout, concatenated.String())
+		}
+	} else if jsonOutput {
+		var output strings.Builder
+		if generateErr := generateArtifact(artifacts[0], interfaceName, &output); generateErr != nil {
+			return generateErr
+		}
+		out.Output(target, output.String())
+	} else {
+		if generateErr := generateArtifact(artifacts[0], interfaceName, c.stdout); generateErr != nil {
+			return generateErr
+		}
+	}
+
+	// Profiles are complete before the result is written, so that errors writing them are part of it.
+	if cpuProfile != "" {
+		pprof.StopCPUProfile()
+	}
+	if memProfile != "" {
+		profileFile, profileErr := os.Create(memProfile)
+		if profileErr != nil {
+			return out.Fatalf("Error creating heap profile: %s", profileErr.Error())
+		}
+		defer profileFile.Close()
+		// Get up-to-date statistics on live objects before writing the profile.
+		runtime.GC()
+		if profileErr := pprof.WriteHeapProfile(profileFile); profileErr != nil {
+			return out.Fatalf("Error writing heap profile: %s", profileErr.Error())
+		}
+		if closeErr := profileFile.Close(); closeErr != nil {
+			return out.Fatalf("Error writing heap profile: %s", closeErr.Error())
+		}
+	}
+
+	return out.Finish()
+}
+
+// Reads an ABI from the file at the given path, or from stdin if the path is empty.
+func (c *command) readABI(path string) ([]byte, error) {
+	if path == "" {
+		return io.ReadAll(c.stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// Maps Options fields to the flags which set them, for reporting invalid options.
+var fieldFlags = map[string]string{
+	"Name":                  "-name",
+	"Pragma":                "-pragma",
+	"ExtraPragmas":          "-pragma",
+	"Dialect":               "-dialect",
+	"ContractTypes":         "-contract-types",
+	"UserDefinedValueTypes": "-udvt",
+	"NameConflicts":         "-name-conflicts",
+	"Kind":                  "-kind",
+	"MaxNestingDepth":       "-max-nesting-depth",
+	"MaxItems":              "-max-items",
+	"MaxInputBytes":         "-max-input-bytes",
+	"Codec":                 "-codec",
+	"EIP712":                "-eip712",
+	"Lite":                  "-lite",
+	"Deployments":           "-deployments",
+	"DeploymentsLibrary":    "-deployments-library",
+	"FunctionRenames":       "-renames",
+	"AssumeView":            "-assume-view",
+	"StorageNamespaces":     "-erc7201",
+	"SkipInvalid":           "-skip-invalid",
+	"Strict":                "-strict",
+}
+
+// Returns the flags which set the given Options fields, e.g. "-codec, -lite".
+func optionFlags(fields []string) string {
+	flags := make([]string, len(fields))
+	for i, field := range fields {
+		flags[i] = field
+		if name, ok := fieldFlags[field]; ok {
+			flags[i] = name
+		}
+	}
+	return strings.Join(flags, ", ")
+}
+
+// Returns the given path from a project configuration in the directory root: relative paths are relative to
+// root.
+func resolveConfigPath(root, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(root, path)
+}
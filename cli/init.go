@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/moonstream-to/solface"
+)
+
+// Implements the "solface init" subcommand, which scaffolds a solface project configuration.
+func (c *command) runInit(args []string) error {
+	var force, jsonOutput bool
+	flags := flag.NewFlagSet("init", flag.ContinueOnError)
+	flags.BoolVar(&force, "force", false, "If present, an existing solface.yaml is overwritten.")
+	flags.BoolVar(&jsonOutput, "json", false, "If present, the result (the files written) is written to stdout as JSON.")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s init [-force] [-json] [<project directory>]\n\n", programName)
+		flags.PrintDefaults()
+	}
+
+	out := c.newReporter("init", false)
+	if parseErr := out.Parse(flags, args, &jsonOutput); parseErr != nil {
+		return parseErr
+	}
+
+	root := "."
+	if flags.NArg() > 1 {
+		return out.Usage(flags)
+	} else if flags.NArg() == 1 {
+		root = flags.Arg(0)
+	}
+
+	configPath := filepath.Join(root, solface.ConfigFileName)
+	if _, statErr := os.Stat(configPath); statErr == nil && !force {
+		return out.Fatalf("%s already exists (use -force to overwrite it)", configPath)
+	}
+
+	layout := solface.DetectProjectLayout(root)
+	config, scaffoldErr := solface.ScaffoldConfig(root)
+	if scaffoldErr != nil {
+		return out.Fatalf("Error scaffolding configuration: %s", scaffoldErr.Error())
+	}
+
+	serialized, marshalErr := solface.MarshalConfig(config)
+	if marshalErr != nil {
+		return out.Fatalf("Error serializing configuration: %s", marshalErr.Error())
+	}
+
+	header := "# solface project configuration: https://github.com/moonstream-to/solface\n"
+	if layout.Kind != "" {
+		header += fmt.Sprintf("# Jobs were inferred from the %s project layout. Build your contracts before generating interfaces.\n", layout.Kind)
+	}
+	writeErr := os.WriteFile(configPath, append([]byte(header), serialized...), 0644)
+	if writeErr != nil {
+		return out.Fatalf("Error writing configuration: %s", writeErr.Error())
+	}
+
+	mkdirErr := os.MkdirAll(filepath.Join(root, config.OutputDir), 0755)
+	if mkdirErr != nil {
+		return out.Fatalf("Error creating output directory: %s", mkdirErr.Error())
+	}
+
+	fmt.Fprintf(c.stderr, "Inferred %d job(s)\n", len(config.Jobs))
+	out.Wrote(configPath)
+	return out.Finish()
+}
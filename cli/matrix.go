@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/moonstream-to/solface"
+)
+
+// Implements the "solface matrix" subcommand, which tabulates the items supported by each of several ABIs.
+func (c *command) runMatrix(args []string) error {
+	var format string
+	var differences, jsonOutput bool
+	flags := flag.NewFlagSet("matrix", flag.ContinueOnError)
+	flags.StringVar(&format, "format", "markdown", "Output format for the matrix. Options: markdown, json.")
+	flags.BoolVar(&differences, "differences", false, "If present, only items which are missing from some of the ABIs are listed.")
+	flags.BoolVar(&jsonOutput, "json", false, "If present, the matrix is written to stdout as JSON, along with any warnings.")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s matrix [-format {markdown | json}] [-differences] [-json] <path to ABI or artifact file> <path to ABI or artifact file> ...\n\n", programName)
+		flags.PrintDefaults()
+	}
+
+	out := c.newReporter("matrix", false)
+	if parseErr := out.Parse(flags, args, &jsonOutput); parseErr != nil {
+		return parseErr
+	}
+
+	if flags.NArg() < 2 || (format != "markdown" && format != "json") {
+		return out.Usage(flags)
+	}
+
+	var names []string
+	var abis []solface.DecodedABI
+	for _, input := range flags.Args() {
+		contents, readErr := c.readABI(input)
+		if readErr != nil {
+			return out.Fatalf("Error reading ABI: %s", readErr.Error())
+		}
+		artifacts, artifactsErr := solface.ParseArtifacts(contents)
+		if artifactsErr != nil {
+			return out.Fatalf("Error reading artifact %s: %s", input, artifactsErr.Error())
+		}
+		for _, artifact := range artifacts {
+			abi, decodeErr := solface.Decode(artifact.ABI)
+			if decodeErr != nil {
+				return out.Fatalf("Error decoding ABI %s: %s", input, decodeErr.Error())
+			}
+			// Files containing several contracts get a column per contract.
+			name := input
+			if len(artifacts) > 1 {
+				name = fmt.Sprintf("%s:%s", input, artifact.ContractName)
+			}
+			names = append(names, name)
+			abis = append(abis, abi)
+		}
+	}
+
+	matrix := solface.BuildCompatibilityMatrix(names, abis)
+	if differences {
+		matrix.Rows = matrix.Differences()
+	}
+
+	out.result.Report = matrix
+	var writeErr error
+	if format == "json" && !jsonOutput {
+		writeErr = c.writeJSON(matrix)
+	} else if !jsonOutput {
+		writeErr = solface.WriteCompatibilityMatrixMarkdown(matrix, c.stdout)
+	}
+	if writeErr != nil {
+		return out.Fatalf("Error writing matrix: %s", writeErr.Error())
+	}
+	return out.Finish()
+}
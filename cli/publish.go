@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/jsonrpc"
+	"github.com/moonstream-to/solface/registry"
+)
+
+// The environment variable from which "solface publish" reads the private key if no key file is given.
+const privateKeyEnvironmentVariable = "SOLFACE_PRIVATE_KEY"
+
+// Implements the "solface publish" subcommand, which publishes the interface ID and selectors of an ABI to
+// an on-chain interface registry.
+func (c *command) runPublish(args []string) error {
+	var interfaceName, rpcURL, registryAddress, privateKeyFile string
+	var dryRun, jsonOutput bool
+	flags := flag.NewFlagSet("publish", flag.ContinueOnError)
+	flags.StringVar(&interfaceName, "name", "", "Name under which the interface is published. Defaults to I<contract name> for artifacts which record the contract name.")
+	flags.StringVar(&rpcURL, "rpc", "", "JSON-RPC endpoint of the chain the registry is deployed on.")
+	flags.StringVar(&registryAddress, "registry", "", fmt.Sprintf("Address of the registry contract, which must implement %s.", registry.RegisterSignature))
+	flags.StringVar(&privateKeyFile, "private-key-file", "", fmt.Sprintf("Path to a file containing the hex-encoded private key which signs the transaction. Defaults to the %s environment variable.", privateKeyEnvironmentVariable))
+	flags.BoolVar(&dryRun, "dry-run", false, "If present, the calldata of the transaction is printed instead of being sent.")
+	flags.BoolVar(&jsonOutput, "json", false, "If present, the result (the transaction hash or calldata) is written to stdout as JSON.")
+
+	var cassettes cassetteSettings
+	cassettes.register(flags)
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s publish -rpc <url> -registry <address> [-name <interface name>] [-private-key-file <path>] [-dry-run] [-json] {<path to ABI or artifact file> | stdin}\n\n", programName)
+		flags.PrintDefaults()
+	}
+
+	out := c.newReporter("publish", false)
+	if parseErr := out.Parse(flags, args, &jsonOutput); parseErr != nil {
+		return parseErr
+	}
+	transport, transportErr := cassettes.transport()
+	if transportErr != nil {
+		return out.Fatalf("Error setting up cassette: %s", transportErr.Error())
+	}
+
+	if flags.NArg() > 1 || registryAddress == "" || (rpcURL == "" && !dryRun) {
+		return out.Usage(flags)
+	}
+
+	contents, readErr := c.readABI(flags.Arg(0))
+	if readErr != nil {
+		return out.Fatalf("Error reading ABI: %s", readErr.Error())
+	}
+	artifact, artifactErr := solface.ParseArtifact(contents)
+	if artifactErr != nil {
+		return out.Fatalf("Error reading artifact: %s", artifactErr.Error())
+	}
+	if interfaceName == "" {
+		interfaceName = solface.DefaultInterfaceName(artifact.ContractName)
+	}
+	if interfaceName == "" {
+		return out.Fatalf("The interface name could not be determined from the input - pass it with -name")
+	}
+	out.result.Name = interfaceName
+
+	abi, decodeErr := solface.Decode(artifact.ABI)
+	if decodeErr != nil {
+		return out.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	annotations, annotationErr := solface.Annotate(abi)
+	if annotationErr != nil {
+		return out.Fatalf("Error generating annotations: %s", annotationErr.Error())
+	}
+	entry := registry.NewEntry(interfaceName, annotations)
+
+	if dryRun {
+		calldata, calldataErr := registry.Calldata(entry)
+		if calldataErr != nil {
+			return out.Fatalf("Error encoding calldata: %s", calldataErr.Error())
+		}
+		out.result.Output = hexutil.Encode(calldata)
+		if !jsonOutput {
+			fmt.Fprintln(c.stdout, out.result.Output)
+		}
+		return out.Finish()
+	}
+
+	privateKey := os.Getenv(privateKeyEnvironmentVariable)
+	if privateKeyFile != "" {
+		keyContents, keyReadErr := os.ReadFile(privateKeyFile)
+		if keyReadErr != nil {
+			return out.Fatalf("Error reading private key: %s", keyReadErr.Error())
+		}
+		privateKey = string(keyContents)
+	}
+	if privateKey == "" {
+		return out.Fatalf("No private key - pass -private-key-file or set %s", privateKeyEnvironmentVariable)
+	}
+	key, keyErr := registry.ParsePrivateKey(privateKey)
+	if keyErr != nil {
+		return out.Fatalf("Error reading private key: %s", keyErr.Error())
+	}
+
+	hash, publishErr := registry.Publish(jsonrpc.Client{URL: rpcURL, Transport: transport}, registryAddress, key, entry)
+	if publishErr != nil {
+		return out.Fatalf("Error publishing %s: %s", interfaceName, publishErr.Error())
+	}
+	out.result.Output = hash.Hex()
+	if !jsonOutput {
+		fmt.Fprintf(c.stderr, "Published %s (interface ID %x) in transaction %s\n", interfaceName, annotations.InterfaceID, hash.Hex())
+	}
+	return out.Finish()
+}
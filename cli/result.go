@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/moonstream-to/solface"
+)
+
+// Represents the machine-readable result of a solface subcommand, written to stdout when the subcommand is
+// invoked with -json.
+//  1. Command: The subcommand that produced the result ("generate", "fmt", "init", or "analyze").
+//  2. Name: The name of the generated interface, if any.
+//  3. Target: The target that was generated, if any.
+//  4. Output: The output which the subcommand would otherwise have written to stdout.
+//  5. OutputEncoding: "base64" if the output is binary (see solface.IsBinaryTarget) and Output holds its
+//     base64 encoding, and empty if Output holds the output itself.
+//  6. Files: The files which the subcommand wrote.
+//  7. Report: The report produced by the analyze subcommand, or the unsupported items which caused
+//     generation to fail.
+//  8. Diagnostics: Warnings about the input.
+//  9. Error: The error which caused the subcommand to fail, if it failed.
+type commandResult struct {
+	Command        string               `json:"command"`
+	Name           string               `json:"name,omitempty"`
+	Target         string               `json:"target,omitempty"`
+	Output         string               `json:"output,omitempty"`
+	OutputEncoding string               `json:"outputEncoding,omitempty"`
+	Files          []string             `json:"files,omitempty"`
+	Report         interface{}          `json:"report,omitempty"`
+	Diagnostics    []solface.Diagnostic `json:"diagnostics"`
+	Error          string               `json:"error,omitempty"`
+}
+
+// Collects the result of a subcommand. If enabled, the result is written to stdout as JSON when the
+// subcommand finishes (or fails). Human-readable messages always go to stderr.
+type reporter struct {
+	cmd     *command
+	enabled bool
+	result  commandResult
+}
+
+func (c *command) newReporter(command string, enabled bool) *reporter {
+	return &reporter{cmd: c, enabled: enabled, result: commandResult{Command: command, Diagnostics: []solface.Diagnostic{}}}
+}
+
+// Parses the given arguments with the given flag set, writing errors and usage to stderr. Returns an
+// *ExitError if parsing fails, and flag.ErrHelp (which ends the subcommand successfully) if help was
+// requested. The result is enabled if jsonOutput (the value of the -json flag, if the subcommand has one)
+// is set once parsing stops, so that invocations with -json produce a result even if their flags are
+// invalid or help is requested.
+func (r *reporter) Parse(flags *flag.FlagSet, args []string, jsonOutput *bool) error {
+	flags.SetOutput(r.cmd.stderr)
+	parseErr := flags.Parse(args)
+	r.enabled = jsonOutput != nil && *jsonOutput
+	if errors.Is(parseErr, flag.ErrHelp) {
+		if finishErr := r.Finish(); finishErr != nil {
+			return finishErr
+		}
+		return parseErr
+	}
+	if parseErr != nil {
+		exitErr := &ExitError{Code: ExitUsage, Err: fmt.Errorf("%w: %s", ErrUsage, parseErr.Error())}
+		if r.enabled {
+			r.result.Error = exitErr.Error()
+			r.cmd.writeJSON(r.result)
+		}
+		return exitErr
+	}
+	return nil
+}
+
+// Writes the usage of the given flag set to stderr and returns the *ExitError which fails the subcommand
+// as invalid (see command.usage), recording the failure in the result written to stdout if enabled.
+func (r *reporter) Usage(flags *flag.FlagSet) error {
+	if r.enabled {
+		r.result.Error = ErrUsage.Error()
+		r.cmd.writeJSON(r.result)
+	}
+	return r.cmd.usage(flags)
+}
+
+// Records the given diagnostics and prints them to stderr as warnings.
+func (r *reporter) Warn(diagnostics []solface.Diagnostic) {
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintf(r.cmd.stderr, "Warning: %s\n", diagnostic.String())
+	}
+	r.result.Diagnostics = append(r.result.Diagnostics, diagnostics...)
+}
+
+// Records the output of the subcommand for the given target, which is base64-encoded if it is binary, since
+// JSON strings cannot hold arbitrary bytes.
+func (r *reporter) Output(target, output string) {
+	if solface.IsBinaryTarget(target) {
+		r.result.Output = base64.StdEncoding.EncodeToString([]byte(output))
+		r.result.OutputEncoding = "base64"
+		return
+	}
+	r.result.Output = output
+}
+
+// Records a file written by the subcommand and reports it on stderr.
+func (r *reporter) Wrote(path string) {
+	fmt.Fprintf(r.cmd.stderr, "Wrote %s\n", path)
+	r.result.Files = append(r.result.Files, path)
+}
+
+// Writes the result to stdout, if enabled. Returns an *ExitError if the result cannot be written.
+func (r *reporter) Finish() error {
+	if !r.enabled {
+		return nil
+	}
+	if writeErr := r.cmd.writeJSON(r.result); writeErr != nil {
+		return r.cmd.fail(fmt.Sprintf("Error writing result: %s", writeErr.Error()))
+	}
+	return nil
+}
+
+// Returns the *ExitError which fails the subcommand with the given message, after logging it to stderr
+// and, if enabled, recording it in the result written to stdout.
+func (r *reporter) Fatalf(format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	if r.enabled {
+		r.result.Error = message
+		r.cmd.writeJSON(r.result)
+	}
+	return r.cmd.fail(message)
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/moonstream-to/solface"
+)
+
+// The name of the schema of the results written by subcommands invoked with -json.
+const resultSchema = "result"
+
+// Implements the "solface schema" subcommand, which prints the JSON Schema of one of solface's JSON
+// outputs, generated from the Go types that produce it.
+func (c *command) runSchema(args []string) error {
+	var list bool
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	flags.BoolVar(&list, "list", false, "If present, the names of the available schemas are listed instead.")
+
+	names := append(solface.SchemaNames(), resultSchema)
+	sort.Strings(names)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s schema {-list | <schema>}\n\nSchemas: %s\n\n", programName, strings.Join(names, ", "))
+		flags.PrintDefaults()
+	}
+
+	out := c.newReporter("schema", false)
+	if parseErr := out.Parse(flags, args, nil); parseErr != nil {
+		return parseErr
+	}
+
+	if list {
+		fmt.Fprintln(c.stdout, strings.Join(names, "\n"))
+		return nil
+	}
+	if flags.NArg() != 1 {
+		return out.Usage(flags)
+	}
+
+	name := flags.Arg(0)
+	var value interface{} = commandResult{}
+	if name != resultSchema {
+		var ok bool
+		value, ok = solface.GetSchemaType(name)
+		if !ok {
+			return out.Fatalf("Unknown schema: %s (expected one of %s)", name, strings.Join(names, ", "))
+		}
+	}
+	if writeErr := c.writeJSON(solface.JSONSchema(fmt.Sprintf("solface %s (v%s)", name, solface.VERSION), value)); writeErr != nil {
+		return out.Fatalf("Error writing schema: %s", writeErr.Error())
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/fourbyte"
+	"github.com/moonstream-to/solface/jsonrpc"
+	"github.com/moonstream-to/solface/openchain"
+)
+
+// Implements the "solface skeleton" subcommand, which generates a skeleton interface for an unverified
+// contract from the selectors in its runtime bytecode.
+func (c *command) runSkeleton(args []string) error {
+	var interfaceName, rpcURL, address, selectorList, topicList, lookup, lookupURL string
+	var jsonOutput bool
+	flags := flag.NewFlagSet("skeleton", flag.ContinueOnError)
+	flags.StringVar(&interfaceName, "name", "IUnknown", "Name of the generated interface.")
+	flags.StringVar(&rpcURL, "rpc", "", "JSON-RPC endpoint to fetch the runtime bytecode of the contract given by -address from.")
+	flags.StringVar(&address, "address", "", "Address of the contract whose runtime bytecode is fetched (requires -rpc).")
+	flags.StringVar(&selectorList, "selectors", "", "Comma-separated list of selectors (e.g. 0xa9059cbb,0x095ea7b3) to generate the skeleton from, instead of extracting them from bytecode.")
+	flags.StringVar(&topicList, "topics", "", "Comma-separated list of event topics (e.g. from logs emitted by the contract) to add events to the skeleton for.")
+	flags.StringVar(&lookup, "lookup", "", fmt.Sprintf("Signature database to recover the signatures of the selectors and topics from. Options: %s (functions only), %s.", fourbyte.Source, openchain.Source))
+	flags.StringVar(&lookupURL, "lookup-url", "", fmt.Sprintf("API endpoint of the signature database given by -lookup. Defaults to %s for %s, and %s for %s.", fourbyte.DefaultAPIURL, fourbyte.Source, openchain.DefaultAPIURL, openchain.Source))
+	flags.BoolVar(&jsonOutput, "json", false, "If present, the result (the generated interface and warnings) is written to stdout as JSON.")
+
+	var cassettes cassetteSettings
+	cassettes.register(flags)
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s skeleton [-name <interface name>] [-topics <topics>] [-lookup <database>] [-json] {-selectors <selectors> | -rpc <url> -address <address> | <path to file with hex-encoded runtime bytecode> | stdin}\n\n", programName)
+		flags.PrintDefaults()
+	}
+
+	out := c.newReporter("skeleton", false)
+	if parseErr := out.Parse(flags, args, &jsonOutput); parseErr != nil {
+		return parseErr
+	}
+	transport, transportErr := cassettes.transport()
+	if transportErr != nil {
+		return out.Fatalf("Error setting up cassette: %s", transportErr.Error())
+	}
+	out.result.Name = interfaceName
+
+	if flags.NArg() > 1 || (address != "") != (rpcURL != "") || (address != "" && flags.NArg() > 0) || (selectorList != "" && (address != "" || flags.NArg() > 0)) {
+		return out.Usage(flags)
+	}
+	var functionSignatures func(http.RoundTripper, string, [4]byte) ([]string, error)
+	var eventSignatures func(http.RoundTripper, string, [32]byte) ([]string, error)
+	switch lookup {
+	case "":
+	case fourbyte.Source:
+		functionSignatures = fourbyte.FunctionSignatures
+	case openchain.Source:
+		functionSignatures, eventSignatures = openchain.FunctionSignatures, openchain.EventSignatures
+	default:
+		return out.Fatalf("Unknown signature database: %s", lookup)
+	}
+	if topicList != "" && lookup != "" && eventSignatures == nil {
+		return out.Fatalf("Error: %s lookups do not cover events - use -lookup %s to recover events from -topics", lookup, openchain.Source)
+	}
+
+	var topics [][32]byte
+	if topicList != "" {
+		for _, item := range strings.Split(topicList, ",") {
+			decoded, decodeErr := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(item), "0x"))
+			if decodeErr != nil || len(decoded) != 32 {
+				return out.Fatalf("Invalid topic: %s", item)
+			}
+			var topic [32]byte
+			copy(topic[:], decoded)
+			topics = append(topics, topic)
+		}
+	}
+
+	var selectors [][4]byte
+	var source string
+	if selectorList != "" {
+		for _, item := range strings.Split(selectorList, ",") {
+			decoded, decodeErr := hex.DecodeString(solface.NormalizeSelector(item))
+			if decodeErr != nil || len(decoded) != 4 {
+				return out.Fatalf("Invalid selector: %s", item)
+			}
+			var selector [4]byte
+			copy(selector[:], decoded)
+			selectors = append(selectors, selector)
+		}
+		source = "the given list"
+	} else {
+		var hexBytecode string
+		if address != "" {
+			checksummed, checksumErr := solface.ChecksumAddress(address)
+			if checksumErr != nil {
+				return out.Fatalf("Error reading address: %s", checksumErr.Error())
+			}
+			callErr := jsonrpc.Client{URL: rpcURL, Transport: transport}.Call("eth_getCode", []interface{}{checksummed, "latest"}, &hexBytecode)
+			if callErr != nil {
+				return out.Fatalf("Error fetching bytecode: %s", callErr.Error())
+			}
+			source = fmt.Sprintf("the bytecode at %s", checksummed)
+		} else {
+			contents, readErr := c.readABI(flags.Arg(0))
+			if readErr != nil {
+				return out.Fatalf("Error reading bytecode: %s", readErr.Error())
+			}
+			hexBytecode = string(contents)
+			source = "the given bytecode"
+			if flags.NArg() > 0 {
+				source = flags.Arg(0)
+			}
+		}
+
+		bytecode, bytecodeErr := solface.ParseBytecode(hexBytecode)
+		if bytecodeErr != nil {
+			return out.Fatalf("Error reading bytecode: %s", bytecodeErr.Error())
+		}
+		selectors = solface.ExtractSelectors(bytecode)
+		if len(selectors) == 0 {
+			out.Warn([]solface.Diagnostic{{ItemType: "contract", Name: interfaceName, Message: "no function selectors found in the bytecode - the contract may be a proxy, or use an unusual dispatcher"}})
+		}
+	}
+
+	functions := make([]solface.SkeletonFunction, len(selectors))
+	for i, selector := range selectors {
+		functions[i] = solface.SkeletonFunction{Selector: selector}
+		if functionSignatures == nil {
+			continue
+		}
+		candidates, lookupErr := functionSignatures(transport, lookupURL, selector)
+		if lookupErr != nil {
+			return out.Fatalf("Error looking up selector %x: %s", selector, lookupErr.Error())
+		}
+		functions[i] = solface.RecoverSkeletonFunction(selector, candidates, lookup)
+	}
+
+	events := make([]solface.SkeletonEvent, len(topics))
+	for i, topic := range topics {
+		events[i] = solface.SkeletonEvent{Topic: topic}
+		if eventSignatures == nil {
+			continue
+		}
+		candidates, lookupErr := eventSignatures(transport, lookupURL, topic)
+		if lookupErr != nil {
+			return out.Fatalf("Error looking up topic %x: %s", topic, lookupErr.Error())
+		}
+		events[i] = solface.RecoverSkeletonEvent(topic, candidates, lookup)
+	}
+
+	var writer io.Writer = c.stdout
+	var output strings.Builder
+	if jsonOutput {
+		writer = &output
+	}
+	generateErr := solface.GenerateSkeletonInterface(interfaceName, source, functions, events, writer)
+	if generateErr != nil {
+		return out.Fatalf("Error generating skeleton interface: %s", generateErr.Error())
+	}
+	out.result.Output = output.String()
+	return out.Finish()
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/standards"
+)
+
+// Returns the renames which apply to functions in the given ABI, since ApplyRenames rejects renames of
+// functions that are not in the ABI.
+func renamesForABI(renames map[string]string, abi solface.DecodedABI) map[string]string {
+	selectors := map[string]bool{}
+	for _, functionItem := range abi.Functions {
+		selectors[hex.EncodeToString(solface.MethodSelector(functionItem))] = true
+	}
+	result := map[string]string{}
+	for selector, name := range renames {
+		if selectors[solface.NormalizeSelector(selector)] {
+			result[selector] = name
+		}
+	}
+	return result
+}
+
+// Returns the selectors assumed to be view which belong to functions in the given ABI, since AssumeView
+// rejects selectors of functions that are not in the ABI.
+func assumedViewForABI(assumeView []string, abi solface.DecodedABI) []string {
+	selectors := map[string]bool{}
+	for _, functionItem := range abi.Functions {
+		selectors[hex.EncodeToString(solface.MethodSelector(functionItem))] = true
+	}
+	result := []string{}
+	for _, selector := range assumeView {
+		if selectors[solface.NormalizeSelector(selector)] {
+			result = append(result, selector)
+		}
+	}
+	return result
+}
+
+// Generates one interface per standard implemented by the given ABI, plus an interface for the items which
+// do not belong to any standard (see standards.Split). The interface for standard S is named
+// <options.Name>_S and written to the given directory under the name given by the filename pattern (by
+// default <options.Name>_S.sol - see solface.OutputFilename).
+func writeSplitStandards(abi solface.DecodedABI, options solface.Options, outputDir, filenamePattern string, out *reporter) error {
+	for _, slice := range standards.Split(abi) {
+		sliceOptions := options
+		sliceOptions.Name = fmt.Sprintf("%s_%s", options.Name, slice.Name)
+		sliceOptions.FunctionRenames = renamesForABI(options.FunctionRenames, slice.ABI)
+		sliceOptions.AssumeView = assumedViewForABI(options.AssumeView, slice.ABI)
+
+		annotations, annotationErr := solface.AnnotateWithHasher(slice.ABI, options.Hasher)
+		if annotationErr != nil {
+			return annotationErr
+		}
+
+		filename, filenameErr := solface.OutputFilename(filenamePattern, solface.TargetInterface, sliceOptions.Name)
+		if filenameErr != nil {
+			return filenameErr
+		}
+		outputPath := filepath.Join(outputDir, filename)
+		mkdirErr := os.MkdirAll(filepath.Dir(outputPath), 0755)
+		if mkdirErr != nil {
+			return mkdirErr
+		}
+		outputFile, createErr := os.Create(outputPath)
+		if createErr != nil {
+			return createErr
+		}
+		generateErr := solface.GenerateInterfaceWithOptions(slice.ABI, annotations, sliceOptions, outputFile)
+		closeErr := outputFile.Close()
+		if generateErr != nil {
+			return fmt.Errorf("could not generate %s: %s", outputPath, generateErr.Error())
+		}
+		if closeErr != nil {
+			return closeErr
+		}
+		out.Wrote(outputPath)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/jsonrpc"
+	"github.com/moonstream-to/solface/proxy"
+)
+
+// Represents the state of a watched contract as of the last poll, which is stored next to the generated
+// interface so that changes are detected across runs.
+type watchSnapshot struct {
+	Implementation string          `json:"implementation,omitempty"`
+	ABI            json.RawMessage `json:"abi"`
+}
+
+// Settings of the "solface watch" subcommand.
+type watchSettings struct {
+	Address      string
+	AddressBook  string
+	RPCURL       string
+	Name         string
+	Output       string
+	Report       string
+	License      string
+	Pragma       string
+	ExtraPragmas []string
+	Explorer     explorerSettings
+}
+
+// Implements the "solface watch" subcommand, which re-fetches the verified ABIs of deployed contracts
+// (following proxies to their implementations) and regenerates their interfaces whenever the ABIs change,
+// e.g. because a proxy was upgraded. The contract is given by flags, or the contracts are listed under
+// "watch" in a solface.yaml given by -config.
+func (c *command) runWatch(args []string) error {
+	var settings watchSettings
+	var configPath string
+	var poll time.Duration
+	var jsonOutput bool
+	var pragmas stringListFlag
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	flags.StringVar(&settings.Address, "address", "", "Address of the contract to watch, the name of a protocol in the address book (e.g. permit2), or an ENS name (resolved with -rpc).")
+	flags.StringVar(&settings.AddressBook, "address-book", "", "Path to a JSON file mapping protocol names to their addresses by chain, which extends the address book.")
+	flags.StringVar(&settings.RPCURL, "rpc", "", "JSON-RPC endpoint of the chain the contract is deployed on. If provided, EIP-1967 and EIP-1822 proxies are followed to their implementations, so that upgrades are detected.")
+	flags.StringVar(&settings.Name, "name", "", "Name of the generated interface. Defaults to I<contract name>.")
+	flags.StringVar(&settings.Output, "output", "", "Path of the generated interface. The state of the contract as of the last poll is stored next to it, in <output>.watch.json.")
+	flags.StringVar(&settings.Report, "report", "", "Path of a Markdown file that a report of every change is appended to. Defaults to stderr.")
+	flags.StringVar(&settings.License, "license", "", "SPDX license identifier of the generated interface. Defaults to the license of the verified source.")
+	flags.Var(&pragmas, "pragma", "Pragma of the generated interface (may be repeated, e.g. -pragma \"^0.8.20\" -pragma \"abicoder v2\").")
+	flags.StringVar(&settings.Explorer.ExplorerURL, "explorer-url", "", "URL of a Blockscout explorer to fetch the ABI from instead of Etherscan.")
+	flags.StringVar(&settings.Explorer.EtherscanURL, "etherscan-url", "", "Etherscan API endpoint to fetch the ABI from.")
+	flags.StringVar(&settings.Explorer.EtherscanKey, "etherscan-key", "", "Etherscan API key. Defaults to the ETHERSCAN_API_KEY environment variable.")
+	flags.StringVar(&settings.Explorer.Chain, "chain", "", "Chain (chain ID or name) on which the contract is deployed, for Etherscan's multichain API.")
+	flags.StringVar(&configPath, "config", "", "Path of a solface.yaml listing the contracts to watch (under \"watch\"), instead of -address and -output. Outputs are relative to the directory of the configuration, and the other flags apply to every contract.")
+	flags.BoolVar(&jsonOutput, "json", false, "If present, the result (the files written, and any error) is written to stdout as JSON when the command finishes. Reports are still written to -report or stderr.")
+	flags.DurationVar(&poll, "poll", 0, "Interval between polls (e.g. 24h). If zero, the contracts are checked once.")
+
+	var cassettes cassetteSettings
+	cassettes.register(flags)
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "%s watch {-address <address> -output <path> | -config <solface.yaml>} [-rpc <url>] [-poll <interval>] [-report <path>] [-json]\n\n", programName)
+		flags.PrintDefaults()
+	}
+
+	out := c.newReporter("watch", false)
+	if parseErr := out.Parse(flags, args, &jsonOutput); parseErr != nil {
+		return parseErr
+	}
+	transport, transportErr := cassettes.transport()
+	if transportErr != nil {
+		return out.Fatalf("Error setting up cassette: %s", transportErr.Error())
+	}
+	settings.Explorer.Transport = transport
+
+	if flags.NArg() > 0 || (configPath == "") != (settings.Address != "" && settings.Output != "") || (configPath != "" && (settings.Address != "" || settings.Output != "")) {
+		return out.Usage(flags)
+	}
+	if validateErr := settings.Explorer.validate(); validateErr != nil {
+		return out.Fatalf("%s", validateErr.Error())
+	}
+	var pragmasErr error
+	settings.Pragma, settings.ExtraPragmas, pragmasErr = solface.ParsePragmas(pragmas)
+	if pragmasErr != nil {
+		return out.Fatalf("Error parsing -pragma: %s", pragmasErr.Error())
+	}
+
+	targets := []watchSettings{settings}
+	if configPath != "" {
+		config, configErr := solface.LoadConfig(configPath)
+		if configErr != nil {
+			return out.Fatalf("Error reading %s: %s", configPath, configErr.Error())
+		}
+		if len(config.Watch) == 0 {
+			return out.Fatalf("Error: %s lists no contracts to watch", configPath)
+		}
+		targets = watchTargets(settings, config.Watch, filepath.Dir(configPath))
+	}
+
+	for {
+		for _, target := range targets {
+			checkErr := c.checkWatchedContract(target, out)
+			if checkErr != nil {
+				if poll == 0 {
+					return out.Fatalf("Error checking %s: %s", target.Address, checkErr.Error())
+				}
+				// Explorers and nodes are often briefly unavailable, so errors only fail single polls.
+				fmt.Fprintf(c.stderr, "Error checking %s (retrying in %s): %s\n", target.Address, poll, checkErr.Error())
+			}
+		}
+		if poll == 0 {
+			break
+		}
+		time.Sleep(poll)
+	}
+	return out.Finish()
+}
+
+// Returns the settings for each of the given contracts listed in a solface.yaml in the directory root,
+// based on the given settings (from flags). Contracts override the name, chain, and JSON-RPC endpoint of
+// the settings, and their outputs are relative to root.
+func watchTargets(settings watchSettings, watched []solface.WatchTarget, root string) []watchSettings {
+	targets := make([]watchSettings, len(watched))
+	for i, watchTarget := range watched {
+		target := settings
+		target.Address = watchTarget.Address
+		target.Output = resolveConfigPath(root, watchTarget.Output)
+		if watchTarget.Name != "" {
+			target.Name = watchTarget.Name
+		}
+		if watchTarget.Chain != "" {
+			target.Explorer.Chain = watchTarget.Chain
+		}
+		if watchTarget.RPC != "" {
+			target.RPCURL = watchTarget.RPC
+		}
+		targets[i] = target
+	}
+	return targets
+}
+
+// Fetches the current ABI of the watched contract and, if it differs from the snapshot of the last poll,
+// regenerates the interface and reports the changes.
+func (c *command) checkWatchedContract(settings watchSettings, out *reporter) error {
+	address, resolveErr := resolveAddress(settings.Explorer.Transport, settings.RPCURL, settings.Explorer.Chain, settings.AddressBook, settings.Address)
+	if resolveErr != nil {
+		return resolveErr
+	}
+	contractAddress, proxyAddress := address, ""
+	if settings.RPCURL != "" {
+		detected, isProxy, detectErr := proxy.Detect(jsonrpc.Client{URL: settings.RPCURL, Transport: settings.Explorer.Transport}, address)
+		if detectErr != nil {
+			return detectErr
+		}
+		if isProxy {
+			contractAddress, proxyAddress = detected.Implementation, detected.Address
+		}
+	}
+	snapshotPath := settings.Output + ".watch.json"
+	contract, fetchErr := settings.Explorer.fetch(contractAddress)
+	if fetchErr != nil {
+		return fetchErr
+	}
+	current, decodeErr := solface.Decode(contract.ABI)
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	var previous *watchSnapshot
+	contents, readErr := os.ReadFile(snapshotPath)
+	if readErr == nil {
+		previous = &watchSnapshot{}
+		if unmarshalErr := json.Unmarshal(contents, previous); unmarshalErr != nil {
+			return fmt.Errorf("could not read %s: %s", snapshotPath, unmarshalErr.Error())
+		}
+	} else if !errors.Is(readErr, os.ErrNotExist) {
+		return readErr
+	}
+
+	implementation := ""
+	if proxyAddress != "" {
+		implementation = contractAddress
+	}
+	report := []string{}
+	if previous == nil {
+		report = append(report, fmt.Sprintf("Started watching (%d functions, %d events, %d errors).", len(current.Functions), len(current.Events), len(current.Errors)))
+	} else {
+		previousABI, previousDecodeErr := solface.Decode(previous.ABI)
+		if previousDecodeErr != nil {
+			return fmt.Errorf("could not decode ABI in %s: %s", snapshotPath, previousDecodeErr.Error())
+		}
+		if previous.Implementation != implementation {
+			report = append(report, fmt.Sprintf("Implementation changed from %s to %s.", valueOrNone(previous.Implementation), valueOrNone(implementation)))
+		}
+		changes := append(solface.ABIChanges(previousABI, current), solface.ModifiedABIItems(previousABI, current)...)
+		for _, change := range changes {
+			verb := "Removed"
+			if change.Modified {
+				verb = "Changed"
+			} else if change.Added {
+				verb = "Added"
+			}
+			report = append(report, fmt.Sprintf("%s %s `%s`.", verb, change.Kind, change.Signature))
+		}
+		// Changes outside the functions, events, and errors (e.g. to the constructor) still change the ABI.
+		previousNormalized, previousFormatErr := solface.FormatABI(previous.ABI, true)
+		currentNormalized, currentFormatErr := solface.FormatABI(contract.ABI, true)
+		if len(changes) == 0 && (previousFormatErr != nil || currentFormatErr != nil || !bytes.Equal(previousNormalized, currentNormalized)) {
+			report = append(report, "Changed the ABI outside its functions, events, and errors (e.g. the constructor).")
+		}
+	}
+	if len(report) == 0 {
+		fmt.Fprintf(c.stderr, "No changes to %s\n", settings.Address)
+		return nil
+	}
+
+	name := settings.Name
+	if name == "" {
+		name = solface.DefaultInterfaceName(contract.Name)
+	}
+	license := settings.License
+	if license == "" {
+		license = contract.License
+	}
+	var output strings.Builder
+	generateErr := solface.GenerateInterfaceWithOptions(current, solface.Annotations{}, solface.Options{Name: name, License: license, Pragma: settings.Pragma, ExtraPragmas: settings.ExtraPragmas, ProxyAddress: proxyAddress}, &output)
+	if generateErr != nil {
+		return generateErr
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(settings.Output), 0755); mkdirErr != nil {
+		return mkdirErr
+	}
+	if writeErr := os.WriteFile(settings.Output, []byte(output.String()), 0644); writeErr != nil {
+		return writeErr
+	}
+	out.Wrote(settings.Output)
+
+	snapshot, marshalErr := json.MarshalIndent(watchSnapshot{Implementation: implementation, ABI: contract.ABI}, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	if writeErr := os.WriteFile(snapshotPath, snapshot, 0644); writeErr != nil {
+		return writeErr
+	}
+
+	entry := fmt.Sprintf("## %s: %s\n\n", time.Now().UTC().Format(time.RFC3339), settings.Address)
+	for _, line := range report {
+		entry += fmt.Sprintf("- %s\n", line)
+	}
+	entry += "\n"
+	if settings.Report == "" {
+		_, writeErr := io.WriteString(c.stderr, entry)
+		return writeErr
+	}
+	reportFile, openErr := os.OpenFile(settings.Report, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if openErr != nil {
+		return openErr
+	}
+	defer reportFile.Close()
+	_, writeErr := reportFile.WriteString(entry)
+	return writeErr
+}
+
+// Returns the given value, or "none" if it is empty.
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
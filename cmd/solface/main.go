@@ -0,0 +1,12 @@
+package main
+
+import (
+	"os"
+
+	"github.com/moonstream-to/solface/cli"
+)
+
+// Implements the solface CLI.
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,86 @@
+package solface
+
+import (
+	"bytes"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The name of the solface project configuration file.
+const ConfigFileName string = "solface.yaml"
+
+// Represents a solface project configuration (solface.yaml).
+//  1. Version: The version of the configuration format.
+//  2. OutputDir: The directory into which generated interfaces are written.
+//  3. License: The default SPDX license identifier for generated interfaces.
+//  4. Pragma: The default Solidity pragma for generated interfaces.
+//  5. Annotations: Whether or not to annotate generated interfaces by default.
+//  6. Jobs: The interfaces to generate.
+//  7. StandardsDir: A directory of ABIs (one per ".json" file) to register as custom standards for
+//     detection, in addition to the built-in ERCs (see standards.RegisterDirectory).
+//  8. Watch: The deployed contracts whose interfaces "solface watch -config" keeps up to date.
+type Config struct {
+	Version      int           `yaml:"version"`
+	OutputDir    string        `yaml:"outputDir"`
+	License      string        `yaml:"license,omitempty"`
+	Pragma       string        `yaml:"pragma,omitempty"`
+	Annotations  bool          `yaml:"annotations"`
+	Jobs         []Job         `yaml:"jobs"`
+	StandardsDir string        `yaml:"standardsDir,omitempty"`
+	Watch        []WatchTarget `yaml:"watch,omitempty"`
+}
+
+// Represents a single interface generation job in a solface project configuration.
+//  1. Name: The name of the generated Solidity interface.
+//  2. ABI: The path to the ABI (or build artifact) the interface is generated from.
+//  3. Output: The path of the generated interface file.
+//  4. Renames: Maps function selectors to the names those functions should have in the generated
+//     interface (see ApplyRenames).
+//  5. Deployments: Maps chains to the addresses at which the contract is deployed on them (see
+//     ParseDeployments).
+type Job struct {
+	Name        string            `yaml:"name"`
+	ABI         string            `yaml:"abi"`
+	Output      string            `yaml:"output"`
+	Renames     map[string]string `yaml:"renames,omitempty"`
+	Deployments map[string]string `yaml:"deployments,omitempty"`
+}
+
+// Represents a deployed contract watched for upgrades in a solface project configuration.
+//  1. Address: The address of the contract.
+//  2. Output: The path of the generated interface file.
+//  3. Name: The name of the generated Solidity interface - if empty, it is named after the contract.
+//  4. Chain: The chain (chain ID or name) on which the contract is deployed, for Etherscan's multichain API.
+//  5. RPC: The JSON-RPC endpoint of that chain, through which proxies are followed to their implementations.
+type WatchTarget struct {
+	Address string `yaml:"address"`
+	Output  string `yaml:"output"`
+	Name    string `yaml:"name,omitempty"`
+	Chain   string `yaml:"chain,omitempty"`
+	RPC     string `yaml:"rpc,omitempty"`
+}
+
+// Reads a solface project configuration from the given file.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	contents, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return config, readErr
+	}
+	unmarshalErr := yaml.Unmarshal(contents, &config)
+	return config, unmarshalErr
+}
+
+// Serializes a solface project configuration to YAML.
+func MarshalConfig(config Config) ([]byte, error) {
+	var output bytes.Buffer
+	encoder := yaml.NewEncoder(&output)
+	encoder.SetIndent(2)
+	encodeErr := encoder.Encode(config)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	closeErr := encoder.Close()
+	return output.Bytes(), closeErr
+}
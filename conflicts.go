@@ -0,0 +1,155 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Strategies for resolving name conflicts between errors, the only ABI items which Solidity does not allow
+// to share a name: functions and events can be overloaded, and structs are named with unique numeric
+// suffixes (see ResolveCompounds). See Options.NameConflicts.
+const (
+	// Conflicting errors are renamed with numeric suffixes (e.g. "Unauthorized_2"), documented with their
+	// original signatures (the default). Renaming an error changes its selector, which is reported by
+	// NameConflictDiagnostics.
+	NameConflictsSuffix = "suffix"
+	// Only the first of the conflicting errors is declared.
+	NameConflictsPreferFirst = "prefer-first"
+	// Conflicts fail generation with a *NameConflictError.
+	NameConflictsError = "error"
+)
+
+// Represents a set of ABI items which share a name although Solidity does not allow them to - since custom
+// errors cannot be overloaded, these are errors with the same name and different parameters, which merged
+// ABIs (e.g. of the facets of a diamond) often contain.
+//  1. ItemType: The type of the items ("error").
+//  2. Name: The name which the items share.
+//  3. Signatures: The canonical signatures of the items, in ABI order.
+type NameConflict struct {
+	ItemType   string   `json:"itemType"`
+	Name       string   `json:"name"`
+	Signatures []string `json:"signatures"`
+}
+
+// Returned when generation fails because of name conflicts (see NameConflictsError).
+type NameConflictError struct {
+	Conflicts []NameConflict `json:"conflicts"`
+}
+
+func (e *NameConflictError) Error() string {
+	descriptions := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		descriptions[i] = fmt.Sprintf("%s %s (%s)", conflict.ItemType, conflict.Name, strings.Join(conflict.Signatures, ", "))
+	}
+	return fmt.Sprintf("%d name conflict(s): %s", len(e.Conflicts), strings.Join(descriptions, "; "))
+}
+
+// Returns the name conflicts between the items of the given ABI, in order of their first items.
+func NameConflicts(abi DecodedABI) []NameConflict {
+	conflicts := []NameConflict{}
+	indices := map[string]int{}
+	for _, errorItem := range abi.Errors {
+		signature := ErrorSignature(errorItem)
+		if index, ok := indices[errorItem.Name]; ok {
+			conflicts[index].Signatures = append(conflicts[index].Signatures, signature)
+			continue
+		}
+		indices[errorItem.Name] = len(conflicts)
+		conflicts = append(conflicts, NameConflict{ItemType: "error", Name: errorItem.Name, Signatures: []string{signature}})
+	}
+
+	result := []NameConflict{}
+	for _, conflict := range conflicts {
+		if len(conflict.Signatures) > 1 {
+			result = append(result, conflict)
+		}
+	}
+	return result
+}
+
+// Returns a diagnostic for every name conflict of the given ABI (see NameConflicts), describing how the
+// given strategy resolves it. With NameConflictsSuffix, every renamed error is also reported, since its
+// selector in the interface differs from the selector of the original error.
+func NameConflictDiagnostics(abi DecodedABI, strategy string) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for _, conflict := range NameConflicts(abi) {
+		resolution := "all but the first are renamed with numeric suffixes"
+		if strategy == NameConflictsPreferFirst {
+			resolution = fmt.Sprintf("only %s is declared", conflict.Signatures[0])
+		} else if strategy == NameConflictsError {
+			resolution = "generation fails"
+		}
+		diagnostics = append(diagnostics, Diagnostic{ItemType: conflict.ItemType, Name: conflict.Name, Message: fmt.Sprintf("%d items share this name (%s) - %s", len(conflict.Signatures), strings.Join(conflict.Signatures, ", "), resolution)})
+	}
+	if strategy != "" && strategy != NameConflictsSuffix {
+		return diagnostics
+	}
+
+	// Error selectors are always keccak256 hashes, whatever the hasher of the interface.
+	renamed, _, _ := ResolveNameConflicts(abi, NameConflictsSuffix, nil)
+	for i, errorItem := range abi.Errors {
+		if renamed.Errors[i].Name == errorItem.Name {
+			continue
+		}
+		originalSignature, renamedSignature := ErrorSignature(errorItem), ErrorSignature(renamed.Errors[i])
+		originalSelector := hasherOrDefault(nil).Hash([]byte(originalSignature))[:4]
+		renamedSelector := hasherOrDefault(nil).Hash([]byte(renamedSignature))[:4]
+		diagnostics = append(diagnostics, Diagnostic{ItemType: "error", ItemIndex: i, Name: errorItem.Name, Message: fmt.Sprintf("renamed to %s, which changes its selector from 0x%s (%s) to 0x%s (%s) - reverts with the original error do not match the renamed one", renamed.Errors[i].Name, hex.EncodeToString(originalSelector), originalSignature, hex.EncodeToString(renamedSelector), renamedSignature)})
+	}
+	return diagnostics
+}
+
+// Resolves the name conflicts of the given ABI (see NameConflicts) with the given strategy - if empty,
+// NameConflictsSuffix is used. Returns the resulting ABI along with, for renamed errors (by index in the
+// resulting ABI), the comment documenting their original signature and selector.
+func ResolveNameConflicts(abi DecodedABI, strategy string, hasher Hasher) (DecodedABI, map[int]string, error) {
+	notes := map[int]string{}
+	conflicts := NameConflicts(abi)
+	if len(conflicts) == 0 {
+		return abi, notes, nil
+	}
+
+	switch strategy {
+	case NameConflictsError:
+		return abi, notes, &NameConflictError{Conflicts: conflicts}
+	case NameConflictsPreferFirst:
+		seen := map[string]bool{}
+		return FilterABI(abi, func(itemType string, itemIndex int) bool {
+			if itemType != "error" {
+				return true
+			}
+			name := abi.Errors[itemIndex].Name
+			if seen[name] {
+				return false
+			}
+			seen[name] = true
+			return true
+		}), notes, nil
+	}
+
+	taken := map[string]bool{}
+	for _, errorItem := range abi.Errors {
+		taken[errorItem.Name] = true
+	}
+	counts := map[string]int{}
+	result := abi
+	result.Errors = make([]ErrorItem, len(abi.Errors))
+	for i, errorItem := range abi.Errors {
+		result.Errors[i] = errorItem
+		counts[errorItem.Name]++
+		if counts[errorItem.Name] == 1 {
+			continue
+		}
+		suffix := counts[errorItem.Name]
+		for taken[fmt.Sprintf("%s_%d", errorItem.Name, suffix)] {
+			suffix++
+		}
+		result.Errors[i].Name = fmt.Sprintf("%s_%d", errorItem.Name, suffix)
+		taken[result.Errors[i].Name] = true
+		signature := ErrorSignature(errorItem)
+		selector := hasherOrDefault(hasher).Hash([]byte(signature))[:4]
+		notes[i] = fmt.Sprintf("// original: %s, selector 0x%s", signature, hex.EncodeToString(selector))
+	}
+	return result, notes, nil
+}
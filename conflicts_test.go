@@ -0,0 +1,58 @@
+package solface
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const conflictingErrorsABI = `[
+	{"type": "error", "name": "Unauthorized", "inputs": []},
+	{"type": "error", "name": "Unauthorized", "inputs": [{"name": "account", "type": "address"}]},
+	{"type": "error", "name": "Paused", "inputs": []}
+]`
+
+func TestNameConflicts(t *testing.T) {
+	abi, decodeErr := Decode([]byte(conflictingErrorsABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	conflicts := NameConflicts(abi)
+	if len(conflicts) != 1 || conflicts[0].Name != "Unauthorized" || len(conflicts[0].Signatures) != 2 || conflicts[0].Signatures[1] != "Unauthorized(address)" {
+		t.Fatalf("Expected a single conflict between the Unauthorized errors. Actual: %v", conflicts)
+	}
+
+	diagnostics := NameConflictDiagnostics(abi, "")
+	if len(diagnostics) != 2 || diagnostics[1].ItemIndex != 1 || !strings.Contains(diagnostics[1].Message, "from 0x8e4a23d6 (Unauthorized(address))") || !strings.Contains(diagnostics[1].Message, "Unauthorized_2(address)") {
+		t.Fatalf("Expected a diagnostic for the conflict and one for the changed selector of Unauthorized(address). Actual: %v", diagnostics)
+	}
+	if diagnostics := NameConflictDiagnostics(abi, NameConflictsPreferFirst); len(diagnostics) != 1 {
+		t.Fatalf("Expected only the conflict to be reported when no error is renamed. Actual: %v", diagnostics)
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IDiamond"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expected := "\terror Unauthorized();\n\t// original: Unauthorized(address), selector 0x8e4a23d6\n\terror Unauthorized_2(address account);\n\terror Paused();"
+	if !strings.Contains(output.String(), expected) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+	}
+
+	output.Reset()
+	generateErr = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IDiamond", NameConflicts: NameConflictsPreferFirst}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	if strings.Count(output.String(), "error Unauthorized") != 1 || !strings.Contains(output.String(), "error Unauthorized();") {
+		t.Fatalf("Expected only the first Unauthorized error to be declared. Actual:\n%s", output.String())
+	}
+
+	output.Reset()
+	generateErr = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IDiamond", NameConflicts: NameConflictsError}, &output)
+	var conflictErr *NameConflictError
+	if !errors.As(generateErr, &conflictErr) || len(conflictErr.Conflicts) != 1 {
+		t.Fatalf("Expected a NameConflictError. Actual: %v", generateErr)
+	}
+}
@@ -0,0 +1,120 @@
+package solface
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Ways in which values whose internalType is a contract or interface (e.g. "contract IERC20") can be
+// rendered in generated interfaces (see Options.ContractTypes).
+const (
+	// Values are declared as address, as the ABI encodes them (the default).
+	ContractTypesAddress = "address"
+	// Values are declared as address, with a comment naming the contract type, e.g.
+	// "address /* contract IERC20 */ token".
+	ContractTypesComment = "comment"
+	// Values are declared with the contract type (e.g. "IERC20 token"), and an empty interface is declared
+	// for every such type before the generated interface.
+	ContractTypesStub = "stub"
+)
+
+// Returns the contract type of the given value (e.g. "IERC20" or "IERC20[]" for arrays), if its
+// internalType is a contract or interface named by an identifier (see IsNamedInternalType), and an empty
+// string otherwise - values with other internal types are declared as address.
+func ContractTypeName(value Value) string {
+	if !isContractInternalType(value) || !IsNamedInternalType(value.InternalType) {
+		return ""
+	}
+	return strings.TrimPrefix(value.InternalType, "contract ")
+}
+
+// Returns true if the internalType of the given address value claims that it is a contract or interface.
+func isContractInternalType(value Value) bool {
+	return strings.HasPrefix(value.InternalType, "contract ") && strings.HasPrefix(value.Type, "address")
+}
+
+// Returns a diagnostic for every parameter, return value, and struct member of the given ABI whose
+// internalType claims that it is a contract but does not name one by an identifier (see ContractTypeName),
+// and which is therefore declared as address regardless of the ContractTypes mode.
+func MalformedContractTypeDiagnostics(abi DecodedABI) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	var check func(itemType string, itemIndex int, name string, values []Value)
+	check = func(itemType string, itemIndex int, name string, values []Value) {
+		for _, value := range values {
+			if isContractInternalType(value) && !IsNamedInternalType(value.InternalType) {
+				diagnostics = append(diagnostics, Diagnostic{ItemType: itemType, ItemIndex: itemIndex, Name: name, Message: fmt.Sprintf("internalType %q of %s does not name a contract, so it is declared as address", value.InternalType, value.Name)})
+			}
+			check(itemType, itemIndex, name, value.Components)
+		}
+	}
+	for i, eventItem := range abi.Events {
+		for _, input := range eventItem.Inputs {
+			check("event", i, eventItem.Name, []Value{input.Value})
+		}
+	}
+	for i, functionItem := range abi.Functions {
+		check("function", i, functionItem.Name, append(append([]Value{}, functionItem.Inputs...), functionItem.Outputs...))
+	}
+	for i, errorItem := range abi.Errors {
+		check("error", i, errorItem.Name, errorItem.Inputs)
+	}
+	return diagnostics
+}
+
+// Returns an inline comment documenting the contract type of the given value (e.g.
+// "/* contract IERC20 */"), if it is declared as an address but its internalType is a contract or interface
+// (see ContractTypeName), and an empty string otherwise.
+func contractComment(value Value) string {
+	if ContractTypeName(value) == "" {
+		return ""
+	}
+	return fmt.Sprintf("/* %s */", value.InternalType)
+}
+
+// Returns the name under which a stub is declared for the given contract type (see ContractTypeName) - the
+// last component of qualified names, since stubs are declared at the top level of the file (e.g. "Pool[]"
+// for "Lib.Pool[]").
+func contractStubType(contractType string) string {
+	element, dimensions := splitArrayDimensions(contractType)
+	components := strings.Split(element, ".")
+	return components[len(components)-1] + dimensions
+}
+
+// Rewrites the given value according to the given ContractTypes mode, recording the name of its contract
+// type (without array dimensions) in the stubs set if it needs a stub.
+func applyContractTypesToValue(value Value, mode string, stubs map[string]bool) Value {
+	contractType := ContractTypeName(value)
+	if contractType == "" {
+		return value
+	}
+	switch mode {
+	case ContractTypesComment:
+	case ContractTypesStub:
+		value.Type = contractStubType(contractType)
+		contractType, _ = splitArrayDimensions(value.Type)
+		stubs[contractType] = true
+	default:
+		value.InternalType = ""
+	}
+	return value
+}
+
+// Applies the given ContractTypes mode (see Options.ContractTypes) to the parameters, return values, and
+// struct members of the given resolved ABI, which are all declared as address by default. Returns the
+// rewritten ABI and compound types, along with the (sorted) names of the contract types for which stubs
+// should be declared - these exclude the interface itself (given by name), which may refer to itself.
+func ApplyContractTypes(abi DecodedABI, compoundTypes []CompoundType, mode, name string) (DecodedABI, []CompoundType, []string) {
+	stubs := map[string]bool{}
+	result, compounds := MapValues(abi, compoundTypes, func(value Value) Value {
+		return applyContractTypesToValue(value, mode, stubs)
+	})
+
+	delete(stubs, name)
+	stubNames := []string{}
+	for stub := range stubs {
+		stubNames = append(stubNames, stub)
+	}
+	sort.Strings(stubNames)
+	return result, compounds, stubNames
+}
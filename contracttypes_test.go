@@ -0,0 +1,126 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+const contractTypesABI = `[
+	{"type": "event", "name": "VaultCreated", "anonymous": false, "inputs": [{"name": "asset", "type": "address", "internalType": "contract IERC20", "indexed": true}]},
+	{"type": "function", "name": "deposit", "stateMutability": "nonpayable", "inputs": [{"name": "assets", "type": "address[]", "internalType": "contract IERC20[]"}, {"name": "receiver", "type": "address", "internalType": "address"}], "outputs": [{"name": "", "type": "address", "internalType": "contract IVault"}]},
+	{"type": "function", "name": "oracle", "stateMutability": "view", "inputs": [], "outputs": [{"name": "config", "type": "tuple", "internalType": "struct IVault.Config", "components": [{"name": "feed", "type": "address", "internalType": "contract IAggregator"}]}]}
+]`
+
+func TestContractTypes(t *testing.T) {
+	abi, decodeErr := Decode([]byte(contractTypesABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	expectations := map[string][]string{
+		"": {
+			"event VaultCreated(address indexed asset);",
+			"function deposit(address[] memory assets, address receiver) external returns (address);",
+			"\t\taddress feed;",
+		},
+		ContractTypesComment: {
+			"event VaultCreated(address /* contract IERC20 */ indexed asset);",
+			"function deposit(address[] /* contract IERC20[] */ memory assets, address receiver) external returns (address /* contract IVault */);",
+			"\t\taddress /* contract IAggregator */ feed;",
+		},
+		ContractTypesStub: {
+			"interface IAggregator {}\n\ninterface IERC20 {}\n\n// Interface generated by solface",
+			"event VaultCreated(IERC20 indexed asset);",
+			"function deposit(IERC20[] memory assets, address receiver) external returns (IVault);",
+			"\t\tIAggregator feed;",
+		},
+	}
+	for mode, expected := range expectations {
+		var output strings.Builder
+		generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IVault", ContractTypes: mode}, &output)
+		if generateErr != nil {
+			t.Fatalf("Error generating interface (contract types: %q): %s", mode, generateErr.Error())
+		}
+		for _, line := range expected {
+			if !strings.Contains(output.String(), line) {
+				t.Fatalf("Expected output (contract types: %q) to contain:\n%s\nActual:\n%s", mode, line, output.String())
+			}
+		}
+		if mode == ContractTypesStub && strings.Contains(output.String(), "interface IVault {}") {
+			t.Fatalf("Expected no stub for the interface itself. Actual:\n%s", output.String())
+		}
+	}
+
+	if validateErr := (Options{Name: "IVault", ContractTypes: "typed"}).Validate(); validateErr == nil {
+		t.Fatalf("Expected an error validating unknown contract type mode")
+	}
+}
+
+func TestContractTypeStubsOfQualifiedNames(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "pools", "stateMutability": "view", "inputs": [{"name": "factory", "type": "address", "internalType": "contract Lib.Factory"}], "outputs": [{"name": "", "type": "address[2]", "internalType": "contract Lib.Pool[2]"}]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IRouter", ContractTypes: ContractTypesStub}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	for _, expected := range []string{
+		"interface Factory {}\n\ninterface Pool {}\n\n// Interface generated by solface",
+		"function pools(Factory factory) external view returns (Pool[2] memory);",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+		}
+	}
+	if strings.Contains(output.String(), "Lib.") {
+		t.Fatalf("Expected no qualified contract types to be declared. Actual:\n%s", output.String())
+	}
+}
+
+func TestContractTypesRejectInjection(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "setToken", "stateMutability": "nonpayable", "inputs": [{"name": "token", "type": "address", "internalType": "contract IERC20 {} function pwn() external; interface X"}], "outputs": []},
+		{"type": "function", "name": "setOracle", "stateMutability": "nonpayable", "inputs": [{"name": "oracle", "type": "address", "internalType": "contract IOracle */ function pwn() external; /*"}], "outputs": []}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	for _, mode := range []string{ContractTypesComment, ContractTypesStub} {
+		var output strings.Builder
+		generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IVault", ContractTypes: mode}, &output)
+		if generateErr != nil {
+			t.Fatalf("Error generating interface (contract types: %q): %s", mode, generateErr.Error())
+		}
+		if strings.Contains(output.String(), "pwn") || !strings.Contains(output.String(), "function setToken(address token) external;") {
+			t.Fatalf("Expected malformed contract types to be declared as address (contract types: %q). Actual:\n%s", mode, output.String())
+		}
+	}
+
+	diagnostics := MalformedContractTypeDiagnostics(abi)
+	if len(diagnostics) != 2 || diagnostics[0].Name != "setToken" || diagnostics[1].Name != "setOracle" {
+		t.Fatalf("Expected diagnostics for setToken and setOracle. Actual: %v", diagnostics)
+	}
+}
+
+func TestFlattenContractStubs(t *testing.T) {
+	sources := []string{
+		"pragma solidity ^0.8.0;\n\ninterface IERC20 {}\n\ninterface IVault {\n}\n",
+		"pragma solidity ^0.8.0;\n\ninterface IERC20 {}\n\ninterface IRouter {\n}\n",
+	}
+	flattened := FlattenSolidity(sources)
+	if strings.Count(flattened, "interface IERC20 {}") != 1 {
+		t.Fatalf("Expected a single IERC20 stub. Actual:\n%s", flattened)
+	}
+
+	sources = append(sources, "pragma solidity ^0.8.0;\n\ninterface IERC20 {\n\tfunction totalSupply() external view returns (uint256);\n}\n")
+	flattened = FlattenSolidity(sources)
+	if strings.Contains(flattened, "interface IERC20 {}") {
+		t.Fatalf("Expected no IERC20 stub when IERC20 is declared. Actual:\n%s", flattened)
+	}
+}
@@ -0,0 +1,111 @@
+package solface
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Represents a known deployment of a contract.
+//  1. Chain: The name (e.g. "ethereum") or ID (e.g. "1") of the chain the contract is deployed on.
+//  2. Address: The checksummed address of the deployment.
+type Deployment struct {
+	Chain   string
+	Address string
+}
+
+// Represents a deployment as an address constant in the generated deployments library.
+type DeploymentConstant struct {
+	Name    string
+	Address string
+}
+
+// Converts a mapping from chains to deployed addresses into a list of deployments, sorted by chain.
+// Addresses are checksummed, and invalid addresses result in an error (see ChecksumAddress).
+func ParseDeployments(addresses map[string]string) ([]Deployment, error) {
+	deployments := make([]Deployment, 0, len(addresses))
+	for chain, address := range addresses {
+		checksummed, checksumErr := ChecksumAddress(address)
+		if checksumErr != nil {
+			return nil, fmt.Errorf("invalid deployment on chain %s: %s", chain, checksumErr.Error())
+		}
+		deployments = append(deployments, Deployment{Chain: chain, Address: checksummed})
+	}
+	sort.Slice(deployments, func(i, j int) bool { return deployments[i].Chain < deployments[j].Chain })
+	return deployments, nil
+}
+
+// Reads a mapping from chains to deployed addresses from a YAML or JSON file, e.g.:
+//
+//	ethereum: "0xcA11bde05977b3631167028862bE2a173976CA11"
+//	polygon: "0xcA11bde05977b3631167028862bE2a173976CA11"
+func LoadDeployments(path string) ([]Deployment, error) {
+	contents, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	// Addresses must be quoted in YAML, since unquoted hex literals are parsed as integers.
+	var addresses map[string]string
+	unmarshalErr := yaml.Unmarshal(contents, &addresses)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	return ParseDeployments(addresses)
+}
+
+// Returns the name of the address constant for the deployment on the given chain, e.g. "ETHEREUM" for
+// "ethereum", "ARBITRUM_NOVA" for "arbitrum-nova", and "CHAIN_1" for "1".
+func deploymentConstantName(chain string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, chain)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = fmt.Sprintf("CHAIN_%s", name)
+	}
+	return name
+}
+
+// Returns the address constants for the given deployments.
+func deploymentConstants(deployments []Deployment) []DeploymentConstant {
+	constants := make([]DeploymentConstant, len(deployments))
+	for i, deployment := range deployments {
+		constants[i] = DeploymentConstant{Name: deploymentConstantName(deployment.Chain), Address: deployment.Address}
+	}
+	return constants
+}
+
+// Returns a description of every pair of the given deployments whose chains name the same address constant
+// (e.g. "arbitrum-nova" and "arbitrum_nova"), which would be declared twice in the deployments library.
+func deploymentConstantCollisions(deployments []Deployment) []string {
+	collisions := []string{}
+	chains := map[string]string{}
+	for _, deployment := range deployments {
+		name := deploymentConstantName(deployment.Chain)
+		if chain, ok := chains[name]; ok {
+			collisions = append(collisions, fmt.Sprintf("chains %q and %q both name the address constant %s of the deployments library - rename one of them", chain, deployment.Chain, name))
+			continue
+		}
+		chains[name] = deployment.Chain
+	}
+	return collisions
+}
+
+// Returns the comment lines listing the given deployments, to be generated before the interface.
+func deploymentNotes(deployments []Deployment) []string {
+	if len(deployments) == 0 {
+		return nil
+	}
+	notes := []string{"// Deployments:"}
+	for _, deployment := range deployments {
+		notes = append(notes, fmt.Sprintf("//   %s: %s", deployment.Chain, deployment.Address))
+	}
+	return notes
+}
@@ -0,0 +1,77 @@
+package solface
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDeployments(t *testing.T) {
+	deployments, parseErr := ParseDeployments(map[string]string{"ethereum": "0xca11bde05977b3631167028862be2a173976ca11", "1": "0xcA11bde05977b3631167028862bE2a173976CA11", "arbitrum-nova": "0xcA11bde05977b3631167028862bE2a173976CA11"})
+	if parseErr != nil {
+		t.Fatalf("Unexpected error parsing deployments: %s", parseErr.Error())
+	}
+
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatalf("Could not read file containing ABI: %s", readErr.Error())
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	var output bytes.Buffer
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IOwnableERC20", Deployments: deployments, DeploymentsLibrary: true}, &output)
+	if generateErr != nil {
+		t.Fatalf("Unexpected error generating interface: %s", generateErr.Error())
+	}
+
+	expectedLines := []string{
+		"// Deployments:\n//   1: 0xcA11bde05977b3631167028862bE2a173976CA11\n//   arbitrum-nova: 0xcA11bde05977b3631167028862bE2a173976CA11\n//   ethereum: 0xcA11bde05977b3631167028862bE2a173976CA11\ninterface IOwnableERC20 {",
+		"library IOwnableERC20Deployments {\n\taddress internal constant CHAIN_1 = 0xcA11bde05977b3631167028862bE2a173976CA11;\n\taddress internal constant ARBITRUM_NOVA = 0xcA11bde05977b3631167028862bE2a173976CA11;\n\taddress internal constant ETHEREUM = 0xcA11bde05977b3631167028862bE2a173976CA11;\n}",
+	}
+	for _, expected := range expectedLines {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+		}
+	}
+
+	_, parseErr = ParseDeployments(map[string]string{"ethereum": "0x1234"})
+	if parseErr == nil {
+		t.Fatal("Expected error parsing invalid address. Actual: nil")
+	}
+}
+
+func TestDeploymentConstantCollisions(t *testing.T) {
+	deployments, parseErr := ParseDeployments(map[string]string{"arbitrum-nova": "0xcA11bde05977b3631167028862bE2a173976CA11", "arbitrum_nova": "0xcA11bde05977b3631167028862bE2a173976CA11", "ethereum": "0xcA11bde05977b3631167028862bE2a173976CA11"})
+	if parseErr != nil {
+		t.Fatalf("Unexpected error parsing deployments: %s", parseErr.Error())
+	}
+
+	var optionsErr *OptionsError
+	if !errors.As((Options{Deployments: deployments, DeploymentsLibrary: true}).Validate(), &optionsErr) {
+		t.Fatal("Expected an *OptionsError for colliding deployment constants")
+	}
+	if len(optionsErr.Problems) != 1 || !strings.Contains(optionsErr.Problems[0].Message, `chains "arbitrum-nova" and "arbitrum_nova" both name the address constant ARBITRUM_NOVA`) {
+		t.Fatalf("Expected a problem naming the colliding chains. Actual: %v", optionsErr.Problems)
+	}
+	if validateErr := (Options{Deployments: deployments}).Validate(); validateErr != nil {
+		t.Fatalf("Expected colliding chains to be valid without a deployments library. Actual error: %s", validateErr.Error())
+	}
+
+	abi, decodeErr := Decode([]byte(`[{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IOwnable", Deployments: deployments, DeploymentsLibrary: true}, &output)
+	if !errors.As(generateErr, &optionsErr) {
+		t.Fatalf("Expected an *OptionsError generating a deployments library with colliding constants. Actual: %v", generateErr)
+	}
+	if output.Len() != 0 {
+		t.Fatalf("Expected nothing to be written. Actual:\n%s", output.String())
+	}
+}
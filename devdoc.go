@@ -0,0 +1,170 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Represents the developer documentation (devdoc) which the Solidity compiler extracts from NatSpec
+// comments: https://docs.soliditylang.org/en/latest/natspec-format.html#developer-documentation
+// Methods maps canonical function signatures (e.g. "deposit(uint256)") to their documentation.
+// StorageLocation holds the @custom:storage-location annotations of the contract (see StorageNamespaces).
+type Devdoc struct {
+	Details         string                  `json:"details,omitempty"`
+	Methods         map[string]DevdocMethod `json:"methods,omitempty"`
+	StorageLocation string                  `json:"custom:storage-location,omitempty"`
+}
+
+// Represents the developer documentation of a single function.
+type DevdocMethod struct {
+	Details string            `json:"details,omitempty"`
+	Params  map[string]string `json:"params,omitempty"`
+	Returns map[string]string `json:"returns,omitempty"`
+}
+
+// Represents the user documentation (userdoc) which the Solidity compiler extracts from NatSpec comments:
+// https://docs.soliditylang.org/en/latest/natspec-format.html#user-documentation
+// Methods maps canonical function signatures to their documentation.
+type Userdoc struct {
+	Notice  string                   `json:"notice,omitempty"`
+	Methods map[string]UserdocMethod `json:"methods,omitempty"`
+}
+
+// Represents the user documentation of a single function.
+type UserdocMethod struct {
+	Notice string `json:"notice,omitempty"`
+}
+
+// Parses devdoc from its JSON representation.
+func ParseDevdoc(rawJSON []byte) (Devdoc, error) {
+	var devdoc Devdoc
+	unmarshalErr := json.Unmarshal(rawJSON, &devdoc)
+	return devdoc, unmarshalErr
+}
+
+// Reads devdoc from a file, which may either contain the devdoc itself or be a compilation artifact which
+// includes it (see ArtifactDevdoc).
+func LoadDevdoc(path string) (Devdoc, error) {
+	contents, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return Devdoc{}, readErr
+	}
+	if devdoc, ok := ArtifactDevdoc(contents); ok {
+		return devdoc, nil
+	}
+	return ParseDevdoc(contents)
+}
+
+// Returns the devdoc included in a compilation artifact, either as a top-level "devdoc" field (as in
+// Foundry artifacts, solc combined JSON, and solc standard JSON) or in the output section of the compiler
+// metadata. The second return value is false if the input is not an artifact with devdoc.
+func ArtifactDevdoc(rawJSON []byte) (Devdoc, bool) {
+	var devdoc Devdoc
+	ok := artifactDocumentation(rawJSON, "devdoc", &devdoc)
+	return devdoc, ok
+}
+
+// Returns the userdoc included in a compilation artifact, in the same places as ArtifactDevdoc looks for
+// devdoc. The second return value is false if the input is not an artifact with userdoc.
+func ArtifactUserdoc(rawJSON []byte) (Userdoc, bool) {
+	var userdoc Userdoc
+	ok := artifactDocumentation(rawJSON, "userdoc", &userdoc)
+	return userdoc, ok
+}
+
+// Decodes the documentation with the given key ("devdoc" or "userdoc") from a compilation artifact into
+// the given value. Returns false if the artifact does not include that documentation.
+func artifactDocumentation(rawJSON []byte, key string, value interface{}) bool {
+	var artifact map[string]json.RawMessage
+	if json.Unmarshal(rawJSON, &artifact) != nil {
+		return false
+	}
+
+	if rawDocumentation, ok := artifact[key]; ok {
+		if json.Unmarshal(rawDocumentation, value) == nil {
+			return true
+		}
+	}
+
+	for _, field := range []string{"metadata", "rawMetadata"} {
+		rawMetadata, ok := artifact[field]
+		if !ok {
+			continue
+		}
+		var encoded string
+		if json.Unmarshal(rawMetadata, &encoded) == nil {
+			rawMetadata = []byte(encoded)
+		}
+		var metadata struct {
+			Output map[string]json.RawMessage `json:"output"`
+		}
+		if json.Unmarshal(rawMetadata, &metadata) != nil {
+			continue
+		}
+		if rawDocumentation, ok := metadata.Output[key]; ok && json.Unmarshal(rawDocumentation, value) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the NatSpec comment lines which document the given function according to the userdoc and
+// devdoc, or nil if neither has an entry for it.
+func devdocNatspec(function FunctionItem, devdoc Devdoc, userdoc Userdoc) []string {
+	signature := FunctionSignature(function)
+	method, hasDevdoc := devdoc.Methods[signature]
+	userMethod, hasUserdoc := userdoc.Methods[signature]
+	if !hasDevdoc && !hasUserdoc {
+		return nil
+	}
+
+	lines := []string{}
+	if userMethod.Notice != "" {
+		for i, line := range strings.Split(strings.TrimSpace(userMethod.Notice), "\n") {
+			if i == 0 {
+				lines = append(lines, fmt.Sprintf("/// @notice %s", strings.TrimSpace(line)))
+			} else {
+				lines = append(lines, fmt.Sprintf("/// %s", strings.TrimSpace(line)))
+			}
+		}
+	}
+	if method.Details != "" {
+		for i, line := range strings.Split(strings.TrimSpace(method.Details), "\n") {
+			if i == 0 {
+				lines = append(lines, fmt.Sprintf("/// @dev %s", strings.TrimSpace(line)))
+			} else {
+				lines = append(lines, fmt.Sprintf("/// %s", strings.TrimSpace(line)))
+			}
+		}
+	}
+	// Parameters are documented in declaration order.
+	for _, input := range function.Inputs {
+		if description, ok := method.Params[input.Name]; ok && input.Name != "" {
+			lines = append(lines, fmt.Sprintf("/// @param %s %s", input.Name, strings.TrimSpace(description)))
+		}
+	}
+	return lines
+}
+
+// Returns the comment lines summarizing the payable functions of the given ABI, to be rendered at the top
+// of an interface. Returns nil if there are no payable functions.
+func payableSummary(abi DecodedABI) []string {
+	signatures := []string{}
+	for _, functionItem := range abi.Functions {
+		if NormalizedStateMutability(functionItem) == "payable" {
+			signatures = append(signatures, FunctionSignature(functionItem))
+		}
+	}
+	if len(signatures) == 0 {
+		return nil
+	}
+
+	lines := []string{fmt.Sprintf("// Payable functions (%d):", len(signatures))}
+	for _, signature := range signatures {
+		lines = append(lines, fmt.Sprintf("//   %s", signature))
+	}
+	return lines
+}
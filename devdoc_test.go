@@ -0,0 +1,41 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateInterfacePayableNotes(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "deposit", Inputs: []Value{{Name: "minShares", Type: "uint256"}}, StateMutability: "payable"},
+		{Type: "function", Name: "withdraw", Inputs: []Value{{Name: "shares", Type: "uint256"}}, StateMutability: "nonpayable"},
+		{Type: "function", Name: "buy", StateMutability: "payable"},
+	}}
+
+	artifact := `{"abi": [], "metadata": "{\"output\": {\"devdoc\": {\"kind\": \"dev\", \"methods\": {\"deposit(uint256)\": {\"details\": \"msg.value must be at least 0.1 ether.\\nExcess value is refunded.\", \"params\": {\"minShares\": \"Minimum number of shares to mint.\"}}, \"withdraw(uint256)\": {\"details\": \"Burns shares.\"}}}}}"}`
+	devdoc, ok := ArtifactDevdoc([]byte(artifact))
+	if !ok {
+		t.Fatal("Expected devdoc to be found in artifact metadata")
+	}
+
+	var output strings.Builder
+	err := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IVault", PayableNotes: true, Devdoc: devdoc}, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+
+	expectedSections := []string{
+		"// Payable functions (2):\n//   deposit(uint256)\n//   buy()\ninterface IVault {",
+		"\t/// @dev msg.value must be at least 0.1 ether.\n\t/// Excess value is refunded.\n\t/// @param minShares Minimum number of shares to mint.\n\tfunction deposit(uint256 minShares) external payable;",
+		"\tfunction buy() external payable;",
+	}
+	for _, expectedSection := range expectedSections {
+		if !strings.Contains(output.String(), expectedSection) {
+			t.Fatalf("Expected generated interface to contain:\n%s\nActual interface:\n%s", expectedSection, output.String())
+		}
+	}
+	// Only payable functions are documented.
+	if strings.Contains(output.String(), "Burns shares.") {
+		t.Fatalf("Expected non-payable functions not to be documented. Actual interface:\n%s", output.String())
+	}
+}
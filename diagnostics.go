@@ -0,0 +1,29 @@
+package solface
+
+import "fmt"
+
+// Represents a non-fatal problem that solface encountered while processing an ABI.
+// ItemType is the type of the ABI item the diagnostic refers to ("event", "function", "error", ...) and
+// may be empty if the type of the item could not be determined. For diagnostics raised while decoding,
+// ItemIndex is the position of the item in the raw ABI array. For diagnostics raised after decoding, it
+// is the position of the item in the corresponding array (Events, Functions, Errors) of the DecodedABI.
+// Code identifies the kind of problem for diagnostics raised by strict validation (e.g.
+// DiagnosticInvalidType), and is empty otherwise.
+type Diagnostic struct {
+	ItemType  string `json:"itemType"`
+	ItemIndex int    `json:"itemIndex"`
+	Name      string `json:"name,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	itemType := d.ItemType
+	if itemType == "" {
+		itemType = "item"
+	}
+	if d.Name != "" {
+		return fmt.Sprintf("%s %s (index %d): %s", itemType, d.Name, d.ItemIndex, d.Message)
+	}
+	return fmt.Sprintf("%s (index %d): %s", itemType, d.ItemIndex, d.Message)
+}
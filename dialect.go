@@ -0,0 +1,140 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Dialects of ABIs, named after the languages that produce them.
+const (
+	DialectSolidity = "solidity"
+	DialectVyper    = "vyper"
+)
+
+// Solidity keywords and reserved words, which cannot be used as identifiers in Solidity but may be used as
+// parameter names in other languages.
+var solidityReservedWords = map[string]bool{
+	"abstract": true, "after": true, "alias": true, "anonymous": true, "apply": true, "as": true,
+	"assembly": true, "auto": true, "break": true, "byte": true, "calldata": true, "case": true,
+	"catch": true, "constant": true, "constructor": true, "continue": true, "contract": true,
+	"copyof": true, "default": true, "define": true, "delete": true, "do": true, "else": true,
+	"emit": true, "enum": true, "error": true, "event": true, "external": true, "fallback": true,
+	"false": true, "final": true, "for": true, "function": true, "if": true, "immutable": true,
+	"implements": true, "import": true, "in": true, "indexed": true, "inline": true, "interface": true,
+	"internal": true, "is": true, "let": true, "library": true, "macro": true, "mapping": true,
+	"match": true, "memory": true, "modifier": true, "mutable": true, "new": true, "null": true,
+	"of": true, "override": true, "partial": true, "payable": true, "pragma": true, "private": true,
+	"promise": true, "public": true, "pure": true, "receive": true, "reference": true,
+	"relocatable": true, "return": true, "returns": true, "sealed": true, "sizeof": true,
+	"static": true, "storage": true, "struct": true, "super": true, "supports": true, "switch": true,
+	"this": true, "true": true, "try": true, "type": true, "typedef": true, "typeof": true,
+	"unchecked": true, "using": true, "var": true, "view": true, "virtual": true, "while": true,
+}
+
+// Returns true if the given name is a Solidity keyword or reserved word.
+func IsSolidityReservedWord(name string) bool {
+	return solidityReservedWords[name]
+}
+
+// Detects the dialect of an ABI from its JSON representation. An ABI is considered to be produced by
+// Vyper if any of its items has a "gas" field (emitted by Vyper's compiler for gas estimates) or is
+// named like a Vyper special function (__init__, __default__). Otherwise, it is considered to be
+// produced by Solidity.
+func DetectDialect(rawJSON []byte) string {
+	var items []map[string]json.RawMessage
+	if json.Unmarshal(rawJSON, &items) != nil {
+		return DialectSolidity
+	}
+
+	for _, item := range items {
+		if _, ok := item["gas"]; ok {
+			return DialectVyper
+		}
+		var name string
+		if json.Unmarshal(item["name"], &name) == nil && (name == "__init__" || name == "__default__") {
+			return DialectVyper
+		}
+	}
+
+	return DialectSolidity
+}
+
+// Returns the modern state mutability of a function, deriving it from the legacy constant and payable
+// fields if the function does not specify one.
+func NormalizedStateMutability(functionItem FunctionItem) string {
+	if functionItem.StateMutability != "" {
+		return functionItem.StateMutability
+	} else if functionItem.Constant {
+		return "view"
+	} else if functionItem.Payable {
+		return "payable"
+	}
+	return "nonpayable"
+}
+
+// Renames values whose names are Solidity reserved words by appending an underscore. Renaming
+// parameters does not affect selectors.
+func renameReservedValues(values []Value, itemType string, itemIndex int, itemName string) ([]Value, []Diagnostic) {
+	diagnostics := []Diagnostic{}
+	result := make([]Value, len(values))
+	for i, value := range values {
+		result[i] = value
+		if IsSolidityReservedWord(value.Name) {
+			result[i].Name = fmt.Sprintf("%s_", value.Name)
+			diagnostics = append(diagnostics, Diagnostic{ItemType: itemType, ItemIndex: itemIndex, Name: itemName, Message: fmt.Sprintf("renamed parameter %s to %s since it is a Solidity reserved word", value.Name, result[i].Name)})
+		}
+		if len(value.Components) > 0 {
+			var componentDiagnostics []Diagnostic
+			result[i].Components, componentDiagnostics = renameReservedValues(value.Components, itemType, itemIndex, itemName)
+			diagnostics = append(diagnostics, componentDiagnostics...)
+		}
+	}
+	return result, diagnostics
+}
+
+// Adapts an ABI produced by Vyper so that it can be rendered as a Solidity interface:
+//  1. Functions which only describe their mutability with the legacy constant and payable fields are
+//     given the corresponding state mutability.
+//  2. Parameters (and struct members) whose names are Solidity reserved words are renamed.
+//
+// Vyper ABIs do not include internalType, so structs are named generically. Diagnostics (with
+// ItemIndex referring to the DecodedABI arrays) are returned for every renamed parameter.
+func ApplyVyperDialect(abi DecodedABI) (DecodedABI, []Diagnostic) {
+	diagnostics := []Diagnostic{}
+	result := abi
+
+	result.Events = make([]EventItem, len(abi.Events))
+	for i, eventItem := range abi.Events {
+		result.Events[i] = eventItem
+		result.Events[i].Inputs = make([]EventArgument, len(eventItem.Inputs))
+		values := make([]Value, len(eventItem.Inputs))
+		for j, input := range eventItem.Inputs {
+			values[j] = input.Value
+		}
+		renamed, renameDiagnostics := renameReservedValues(values, "event", i, eventItem.Name)
+		for j, input := range eventItem.Inputs {
+			result.Events[i].Inputs[j] = EventArgument{Value: renamed[j], Indexed: input.Indexed}
+		}
+		diagnostics = append(diagnostics, renameDiagnostics...)
+	}
+
+	result.Functions = make([]FunctionItem, len(abi.Functions))
+	for i, functionItem := range abi.Functions {
+		result.Functions[i] = functionItem
+		result.Functions[i].StateMutability = NormalizedStateMutability(functionItem)
+		var inputDiagnostics, outputDiagnostics []Diagnostic
+		result.Functions[i].Inputs, inputDiagnostics = renameReservedValues(functionItem.Inputs, "function", i, functionItem.Name)
+		result.Functions[i].Outputs, outputDiagnostics = renameReservedValues(functionItem.Outputs, "function", i, functionItem.Name)
+		diagnostics = append(append(diagnostics, inputDiagnostics...), outputDiagnostics...)
+	}
+
+	result.Errors = make([]ErrorItem, len(abi.Errors))
+	for i, errorItem := range abi.Errors {
+		result.Errors[i] = errorItem
+		var renameDiagnostics []Diagnostic
+		result.Errors[i].Inputs, renameDiagnostics = renameReservedValues(errorItem.Inputs, "error", i, errorItem.Name)
+		diagnostics = append(diagnostics, renameDiagnostics...)
+	}
+
+	return result, diagnostics
+}
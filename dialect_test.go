@@ -0,0 +1,61 @@
+package solface
+
+import (
+	"testing"
+)
+
+const vyperABI = `[
+	{"name": "Transfer", "inputs": [{"name": "sender", "type": "address", "indexed": true}, {"name": "receiver", "type": "address", "indexed": true}, {"name": "value", "type": "uint256", "indexed": false}], "anonymous": false, "type": "event"},
+	{"stateMutability": "nonpayable", "type": "constructor", "inputs": [], "outputs": []},
+	{"name": "balanceOf", "outputs": [{"type": "uint256", "name": ""}], "inputs": [{"type": "address", "name": "arg0"}], "constant": true, "payable": false, "type": "function", "gas": 1823},
+	{"name": "deposit", "outputs": [], "inputs": [{"type": "uint256", "name": "amount"}], "constant": false, "payable": true, "type": "function", "gas": 38000},
+	{"name": "transfer", "outputs": [{"type": "bool", "name": ""}], "inputs": [{"type": "address", "name": "to"}, {"type": "uint256", "name": "value"}], "constant": false, "payable": false, "type": "function", "gas": 74000},
+	{"name": "setStorage", "outputs": [], "inputs": [{"type": "address", "name": "storage"}], "stateMutability": "nonpayable", "type": "function", "gas": 36000}
+]`
+
+func TestDetectDialect(t *testing.T) {
+	if dialect := DetectDialect([]byte(vyperABI)); dialect != DialectVyper {
+		t.Fatalf("Expected dialect: %s. Actual: %s", DialectVyper, dialect)
+	}
+
+	solidityABI := `[{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address", "internalType": "address"}], "stateMutability": "view"}]`
+	if dialect := DetectDialect([]byte(solidityABI)); dialect != DialectSolidity {
+		t.Fatalf("Expected dialect: %s. Actual: %s", DialectSolidity, dialect)
+	}
+}
+
+func TestDecodeVyperABI(t *testing.T) {
+	abi, diagnostics, decodeErr := DecodeWithOptions([]byte(vyperABI), Options{})
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	expectedMutabilities := []string{"view", "payable", "nonpayable", "nonpayable"}
+	if len(abi.Functions) != len(expectedMutabilities) {
+		t.Fatalf("Expected %d functions. Actual: %d", len(expectedMutabilities), len(abi.Functions))
+	}
+	for i, functionItem := range abi.Functions {
+		if functionItem.StateMutability != expectedMutabilities[i] {
+			t.Fatalf("Function %s: Expected state mutability: %s. Actual: %s", functionItem.Name, expectedMutabilities[i], functionItem.StateMutability)
+		}
+	}
+
+	if abi.Functions[3].Inputs[0].Name != "storage_" {
+		t.Fatalf("Expected parameter storage to be renamed to storage_. Actual: %s", abi.Functions[3].Inputs[0].Name)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Name != "setStorage" {
+		t.Fatalf("Expected a single diagnostic for function setStorage. Actual: %v", diagnostics)
+	}
+
+	// Renaming parameters must not change selectors.
+	if signature := FunctionSignature(abi.Functions[3]); signature != "setStorage(address)" {
+		t.Fatalf("Expected renaming parameters to preserve the signature. Actual: %s", signature)
+	}
+}
+
+func TestDecodeUnknownDialect(t *testing.T) {
+	_, _, decodeErr := DecodeWithOptions([]byte(vyperABI), Options{Dialect: "fe"})
+	if decodeErr == nil {
+		t.Fatal("Expected an error for an unknown dialect")
+	}
+}
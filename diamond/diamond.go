@@ -0,0 +1,180 @@
+// Package diamond resolves EIP-2535 diamonds: it enumerates the facets of a diamond and their selectors
+// using the loupe functions, and merges the ABIs of the facets into a single ABI for the whole diamond.
+//
+// See https://eips.ethereum.org/EIPS/eip-2535.
+package diamond
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/jsonrpc"
+)
+
+// The signature of the loupe function which enumerates the facets of a diamond.
+const FacetsSignature = "facets()"
+
+// Represents a facet of a diamond.
+//  1. Address: The checksummed address of the facet.
+//  2. Selectors: The selectors of the functions of the diamond which are implemented by the facet.
+type Facet struct {
+	Address   string
+	Selectors [][4]byte
+}
+
+// Returns the integer held by the 32-byte word at the given offset of data, or an error if the word is out
+// of bounds or the integer does not fit in an int.
+func readWord(data []byte, offset int) (int, error) {
+	if offset < 0 || offset+32 > len(data) {
+		return 0, fmt.Errorf("offset %d out of bounds", offset)
+	}
+	value := new(big.Int).SetBytes(data[offset : offset+32])
+	if !value.IsInt64() || value.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("invalid value at offset %d", offset)
+	}
+	return int(value.Int64()), nil
+}
+
+// Decodes the return data of facets(), which is an ABI-encoded array of (address, bytes4[]) tuples.
+func DecodeFacets(data []byte) ([]Facet, error) {
+	arrayOffset, offsetErr := readWord(data, 0)
+	if offsetErr != nil {
+		return nil, fmt.Errorf("could not decode facets: %s", offsetErr.Error())
+	}
+	count, countErr := readWord(data, arrayOffset)
+	if countErr != nil {
+		return nil, fmt.Errorf("could not decode facets: %s", countErr.Error())
+	}
+
+	// The offsets of the tuples are relative to the start of the array contents.
+	contents := arrayOffset + 32
+	facets := make([]Facet, count)
+	for i := 0; i < count; i++ {
+		tupleOffset, tupleErr := readWord(data, contents+32*i)
+		if tupleErr != nil {
+			return nil, fmt.Errorf("could not decode facet %d: %s", i, tupleErr.Error())
+		}
+		tuple := contents + tupleOffset
+		if tuple+64 > len(data) {
+			return nil, fmt.Errorf("could not decode facet %d: offset %d out of bounds", i, tuple)
+		}
+		facets[i].Address = common.BytesToAddress(data[tuple : tuple+32]).Hex()
+
+		selectorsOffset, selectorsErr := readWord(data, tuple+32)
+		if selectorsErr != nil {
+			return nil, fmt.Errorf("could not decode selectors of facet %d: %s", i, selectorsErr.Error())
+		}
+		selectors := tuple + selectorsOffset
+		selectorCount, selectorCountErr := readWord(data, selectors)
+		if selectorCountErr != nil {
+			return nil, fmt.Errorf("could not decode selectors of facet %d: %s", i, selectorCountErr.Error())
+		}
+		if selectors+32+32*selectorCount > len(data) {
+			return nil, fmt.Errorf("could not decode selectors of facet %d: %d selectors out of bounds", i, selectorCount)
+		}
+		facets[i].Selectors = make([][4]byte, selectorCount)
+		for j := range facets[i].Selectors {
+			copy(facets[i].Selectors[j][:], data[selectors+32+32*j:])
+		}
+	}
+	return facets, nil
+}
+
+// Enumerates the facets of the diamond at the given address by calling facets() on it through the given
+// JSON-RPC client. The address is validated (see solface.ChecksumAddress) before any request is made.
+func Facets(client jsonrpc.Client, diamondAddress string) ([]Facet, error) {
+	checksummed, checksumErr := solface.ChecksumAddress(diamondAddress)
+	if checksumErr != nil {
+		return nil, checksumErr
+	}
+
+	calldata := "0x" + hex.EncodeToString(crypto.Keccak256([]byte(FacetsSignature))[:4])
+	var result string
+	callErr := client.Call("eth_call", []interface{}{map[string]string{"to": checksummed, "data": calldata}, "latest"}, &result)
+	if callErr != nil {
+		return nil, fmt.Errorf("could not call %s on %s: %s", FacetsSignature, checksummed, callErr.Error())
+	}
+	data, hexErr := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+	if hexErr != nil {
+		return nil, fmt.Errorf("invalid RPC result %q: %s", result, hexErr.Error())
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s returned no data - %s is not a diamond", FacetsSignature, checksummed)
+	}
+	return DecodeFacets(data)
+}
+
+// Merges the ABIs of the facets of a diamond (abis[i] is the raw ABI of facets[i]) into the raw ABI of the
+// diamond. Only the functions whose selectors the diamond routes to a facet are taken from its ABI, since
+// facets may implement functions which were not added to the diamond. Events and errors are taken from
+// every facet, without duplicates. Constructors, fallback, and receive functions are dropped, since they
+// belong to the facets rather than the diamond.
+//
+// Selectors which are routed to a facet but do not appear in its ABI are reported as diagnostics.
+func MergeABIs(facets []Facet, abis [][]byte) ([]byte, []solface.Diagnostic, error) {
+	if len(facets) != len(abis) {
+		return nil, nil, fmt.Errorf("expected %d facet ABIs, got %d", len(facets), len(abis))
+	}
+
+	merged := []json.RawMessage{}
+	diagnostics := []solface.Diagnostic{}
+	seen := map[string]bool{}
+	for i, facet := range facets {
+		var items []json.RawMessage
+		unmarshalErr := json.Unmarshal(abis[i], &items)
+		if unmarshalErr != nil {
+			return nil, nil, fmt.Errorf("could not parse ABI of facet %s: %s", facet.Address, unmarshalErr.Error())
+		}
+
+		routed := map[[4]byte]bool{}
+		for _, selector := range facet.Selectors {
+			routed[selector] = false
+		}
+		for _, item := range items {
+			decoded, decodeErr := solface.Decode([]byte("[" + string(item) + "]"))
+			if decodeErr != nil {
+				return nil, nil, fmt.Errorf("could not decode ABI of facet %s: %s", facet.Address, decodeErr.Error())
+			}
+
+			var key string
+			if len(decoded.Functions) == 1 {
+				var selector [4]byte
+				copy(selector[:], solface.MethodSelector(decoded.Functions[0]))
+				if _, ok := routed[selector]; !ok {
+					continue
+				}
+				routed[selector] = true
+				key = "function " + solface.FunctionSignature(decoded.Functions[0])
+			} else if len(decoded.Events) == 1 {
+				key = fmt.Sprintf("event %s %t", solface.EventSignature(decoded.Events[0]), decoded.Events[0].Anonymous)
+			} else if len(decoded.Errors) == 1 {
+				key = "error " + solface.ErrorSignature(decoded.Errors[0])
+			} else {
+				continue
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, item)
+		}
+
+		for _, selector := range facet.Selectors {
+			if !routed[selector] {
+				diagnostics = append(diagnostics, solface.Diagnostic{ItemType: "function", Name: fmt.Sprintf("0x%x", selector), Message: fmt.Sprintf("selector is routed to facet %s, but does not appear in its ABI", facet.Address)})
+			}
+		}
+	}
+
+	mergedABI, marshalErr := json.Marshal(merged)
+	if marshalErr != nil {
+		return nil, nil, marshalErr
+	}
+	return mergedABI, diagnostics, nil
+}
@@ -0,0 +1,121 @@
+package diamond
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/jsonrpc"
+)
+
+// Returns a 32-byte word holding the given integer.
+func word(value int) []byte {
+	result := make([]byte, 32)
+	binary.BigEndian.PutUint64(result[24:], uint64(value))
+	return result
+}
+
+// ABI-encodes the given facets as the return data of facets().
+func encodeFacets(facets []Facet) []byte {
+	tuples := [][]byte{}
+	for _, facet := range facets {
+		tuple := append(common.LeftPadBytes(common.HexToAddress(facet.Address).Bytes(), 32), word(64)...)
+		tuple = append(tuple, word(len(facet.Selectors))...)
+		for _, selector := range facet.Selectors {
+			tuple = append(tuple, common.RightPadBytes(selector[:], 32)...)
+		}
+		tuples = append(tuples, tuple)
+	}
+
+	data := append(word(32), word(len(facets))...)
+	offset := 32 * len(facets)
+	for _, tuple := range tuples {
+		data = append(data, word(offset)...)
+		offset += len(tuple)
+	}
+	for _, tuple := range tuples {
+		data = append(data, tuple...)
+	}
+	return data
+}
+
+// Returns the selector of the function with the given signature.
+func selector(signature string) [4]byte {
+	var result [4]byte
+	copy(result[:], crypto.Keccak256([]byte(signature))[:4])
+	return result
+}
+
+func TestFacets(t *testing.T) {
+	expected := []Facet{
+		{Address: "0xcA11bde05977b3631167028862bE2a173976CA11", Selectors: [][4]byte{selector("facets()"), selector("owner()")}},
+		{Address: "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e", Selectors: [][4]byte{selector("transfer(address,uint256)")}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		call := request.Params[0].(map[string]interface{})
+		if request.Method != "eth_call" || call["data"] != "0x7a0ed627" {
+			t.Fatalf("Unexpected request: %v", request)
+		}
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": 1, "result": "0x%s"}`, hex.EncodeToString(encodeFacets(expected)))
+	}))
+	defer server.Close()
+
+	facets, facetsErr := Facets(jsonrpc.Client{URL: server.URL}, "0x1f98431c8ad98523631ae4a59f267346ea31f984")
+	if facetsErr != nil {
+		t.Fatalf("Error enumerating facets: %s", facetsErr.Error())
+	}
+	if len(facets) != 2 {
+		t.Fatalf("Expected 2 facets. Actual: %v", facets)
+	}
+	for i, facet := range facets {
+		if facet.Address != expected[i].Address || len(facet.Selectors) != len(expected[i].Selectors) || facet.Selectors[0] != expected[i].Selectors[0] {
+			t.Fatalf("Expected facet %d to be %v. Actual: %v", i, expected[i], facet)
+		}
+	}
+
+	_, decodeErr := DecodeFacets(encodeFacets(expected)[:100])
+	if decodeErr == nil {
+		t.Fatalf("Expected error decoding truncated facets")
+	}
+}
+
+func TestMergeABIs(t *testing.T) {
+	facets := []Facet{
+		{Address: "0xcA11bde05977b3631167028862bE2a173976CA11", Selectors: [][4]byte{selector("owner()"), selector("paused()")}},
+		{Address: "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e", Selectors: [][4]byte{selector("transfer(address,uint256)")}},
+	}
+	abis := [][]byte{
+		[]byte(`[{"type": "constructor", "inputs": []}, {"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}, {"type": "function", "name": "init", "inputs": [], "outputs": [], "stateMutability": "nonpayable"}, {"type": "error", "name": "Unauthorized", "inputs": []}]`),
+		[]byte(`[{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}], "stateMutability": "nonpayable"}, {"type": "error", "name": "Unauthorized", "inputs": []}]`),
+	}
+
+	merged, diagnostics, mergeErr := MergeABIs(facets, abis)
+	if mergeErr != nil {
+		t.Fatalf("Error merging ABIs: %s", mergeErr.Error())
+	}
+	abi, decodeErr := solface.Decode(merged)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding merged ABI: %s", decodeErr.Error())
+	}
+	if abi.Constructor != nil || len(abi.Functions) != 2 || abi.Functions[0].Name != "owner" || abi.Functions[1].Name != "transfer" {
+		t.Fatalf("Expected owner and transfer functions without constructor. Actual: %v", abi.Functions)
+	}
+	if len(abi.Errors) != 1 {
+		t.Fatalf("Expected a single Unauthorized error. Actual: %v", abi.Errors)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Name != "0x5c975abb" {
+		t.Fatalf("Expected diagnostic for paused() selector missing from facet ABI. Actual: %v", diagnostics)
+	}
+}
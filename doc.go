@@ -0,0 +1,6 @@
+// Package solface generates Solidity interfaces from smart contract ABIs. It decodes ABIs (see Decode and
+// DecodeWithOptions), annotates them with selectors and interface IDs (see Annotate), and renders them as
+// Solidity interfaces (see GenerateInterfaceWithOptions).
+//
+// The solface command-line tool is in the cmd/solface directory of this module.
+package solface
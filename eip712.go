@@ -0,0 +1,265 @@
+package solface
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The type which Permit2 appends to the referenced types of witness type strings.
+const permit2TokenPermissions = "TokenPermissions(address token,uint256 amount)"
+
+// Represents a member of an EIP-712 struct type.
+//  1. Name: The name of the member.
+//  2. Type: The EIP-712 type of the member (e.g. "uint256" or "Item[]").
+type EIP712Member struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Represents the EIP-712 definition of a struct which functions of an ABI take as input (e.g. an order or
+// a witness signed off-chain).
+//  1. Name: The EIP-712 name of the struct - its name in the original source (e.g. "Order"), unless the ABI
+//     does not record one or it is shared with another struct, in which case the generated name is used.
+//  2. TypeName: The name of the struct generated in the interface (e.g. "Order0").
+//  3. Members: The members of the struct.
+//  4. EncodeType: The EIP-712 type string of the struct: its own definition followed by those of the
+//     structs it references, sorted by name (e.g. "Order(address maker,Item[] items)Item(address token)").
+//  5. TypeHash: The hex-encoded hash of EncodeType, with a 0x prefix.
+//  6. WitnessTypeString: The type string with which the struct is passed as a witness to the
+//     permitWitnessTransferFrom functions of Permit2 (e.g. "Order witness)Item(...)Order(...)TokenPermissions(...)").
+type EIP712Type struct {
+	Name              string         `json:"name"`
+	TypeName          string         `json:"typeName"`
+	Members           []EIP712Member `json:"members"`
+	EncodeType        string         `json:"encodeType"`
+	TypeHash          string         `json:"typeHash"`
+	WitnessTypeString string         `json:"witnessTypeString"`
+}
+
+// Represents the EIP-712 definitions of the structs of an ABI, as generated by the eip712 target.
+//  1. Types: The members of every struct by EIP-712 name, in the format expected by eth_signTypedData.
+//  2. Structs: The definitions of the structs, in the order in which they are declared in the interface.
+type EIP712Definitions struct {
+	Types   map[string][]EIP712Member `json:"types"`
+	Structs []EIP712Type              `json:"structs"`
+}
+
+// Returns the indices (in compoundTypes) of the structs which the functions of the given resolved ABI take
+// as input, directly or through other structs.
+func functionInputStructs(abi DecodedABI, compoundTypes []CompoundType) map[int]bool {
+	types := []string{}
+	for _, functionItem := range abi.Functions {
+		for _, input := range functionItem.Inputs {
+			types = append(types, input.Type)
+		}
+	}
+	return reachableCompoundTypes(compoundTypes, types)
+}
+
+// Returns the EIP-712 definitions of the structs which the functions of the given resolved ABI (see
+// ResolveCompounds) take as input, in the order of compoundTypes. Type hashes are always computed with
+// keccak256, as EIP-712 specifies, regardless of Options.Hasher.
+func EIP712Types(abi DecodedABI, compoundTypes []CompoundType) []EIP712Type {
+	reachable := functionInputStructs(abi, compoundTypes)
+
+	// Structs are named as in the original source, unless two different structs share a name. The same
+	// struct may be generated more than once (e.g. for two parameters), and then shares its EIP-712 name.
+	byName := map[string]CompoundType{}
+	for _, compound := range compoundTypes {
+		byName[compound.TypeName] = compound
+	}
+	var structure func(compound CompoundType) string
+	structure = func(compound CompoundType) string {
+		members := make([]string, len(compound.Members))
+		for i, member := range compound.Members {
+			element, dimensions := splitArrayDimensions(member.Value.Type)
+			if nested, ok := byName[element]; ok {
+				element = structure(nested)
+			}
+			members[i] = fmt.Sprintf("%s%s %s", element, dimensions, member.Value.Name)
+		}
+		return fmt.Sprintf("%s(%s)", compound.OriginalName, strings.Join(members, ","))
+	}
+	names := map[string]string{}
+	structures := map[string]map[string]bool{}
+	for i, compound := range compoundTypes {
+		if !reachable[i] || compound.OriginalName == "" {
+			continue
+		}
+		originalName := compound.OriginalName[strings.LastIndex(compound.OriginalName, ".")+1:]
+		names[compound.TypeName] = originalName
+		if structures[originalName] == nil {
+			structures[originalName] = map[string]bool{}
+		}
+		structures[originalName][structure(compound)] = true
+	}
+	for i, compound := range compoundTypes {
+		if name, ok := names[compound.TypeName]; reachable[i] && (!ok || len(structures[name]) > 1) {
+			names[compound.TypeName] = compound.TypeName
+		}
+	}
+
+	definitions := map[string]string{}
+	references := map[string][]string{}
+	result := []EIP712Type{}
+	for i, compound := range compoundTypes {
+		if !reachable[i] {
+			continue
+		}
+		eip712Type := EIP712Type{Name: names[compound.TypeName], TypeName: compound.TypeName, Members: make([]EIP712Member, len(compound.Members))}
+		memberDefinitions := make([]string, len(compound.Members))
+		for j, member := range compound.Members {
+			memberName := member.Value.Name
+			if memberName == "" {
+				memberName = member.Name
+			}
+			memberType := member.Value.Type
+			element, dimensions := splitArrayDimensions(memberType)
+			if name, ok := names[element]; ok {
+				memberType = name + dimensions
+				references[eip712Type.Name] = append(references[eip712Type.Name], name)
+			}
+			eip712Type.Members[j] = EIP712Member{Name: memberName, Type: memberType}
+			memberDefinitions[j] = memberType + " " + memberName
+		}
+		definitions[eip712Type.Name] = fmt.Sprintf("%s(%s)", eip712Type.Name, strings.Join(memberDefinitions, ","))
+		result = append(result, eip712Type)
+	}
+
+	for i, eip712Type := range result {
+		// The referenced structs are collected transitively, and sorted by name.
+		referenced := map[string]bool{}
+		pending := append([]string{}, references[eip712Type.Name]...)
+		for len(pending) > 0 {
+			name := pending[0]
+			pending = pending[1:]
+			if name == eip712Type.Name || referenced[name] {
+				continue
+			}
+			referenced[name] = true
+			pending = append(pending, references[name]...)
+		}
+		sortedReferences := []string{}
+		for name := range referenced {
+			sortedReferences = append(sortedReferences, definitions[name])
+		}
+		sort.Strings(sortedReferences)
+
+		result[i].EncodeType = definitions[eip712Type.Name] + strings.Join(sortedReferences, "")
+		result[i].TypeHash = "0x" + hex.EncodeToString(Keccak256Hasher.Hash([]byte(result[i].EncodeType)))
+
+		witnessTypes := append(sortedReferences, definitions[eip712Type.Name], permit2TokenPermissions)
+		sort.Strings(witnessTypes)
+		result[i].WitnessTypeString = fmt.Sprintf("%s witness)%s", eip712Type.Name, strings.Join(witnessTypes, ""))
+	}
+	return result
+}
+
+// Represents a type hash constant of the EIP-712 library generated after an interface.
+//  1. Prefix: The prefix of the names of the constants for the struct (e.g. "ORDER").
+//  2. Type: The EIP-712 definition of the struct.
+type eip712Constant struct {
+	Prefix string
+	Type   EIP712Type
+}
+
+// Represents the EIP-712 library generated after an interface (see Options.EIP712).
+//  1. Constants: The type hash and witness type string constants of every struct.
+//  2. Functions: The rendered hash functions - one for every struct, and one for every array type which
+//     cannot be hashed inline.
+type eip712Library struct {
+	Constants []eip712Constant
+	Functions []string
+}
+
+// Returns the expression which encodes the given value (e.g. "value.amount") of the given type in the
+// encodeData of a struct, registering the array types which need hash functions of their own.
+func eip712Encoding(expression, solidityType string, arrays *[]string) string {
+	element, dimensions, _ := ParseArrayType(solidityType)
+	switch {
+	case len(dimensions) == 0 && element == "string":
+		return fmt.Sprintf("keccak256(bytes(%s))", expression)
+	case len(dimensions) == 0 && element == "bytes":
+		return fmt.Sprintf("keccak256(%s)", expression)
+	case len(dimensions) == 0 && isElementaryValueType(element):
+		return expression
+	case len(dimensions) == 1 && isElementaryValueType(element):
+		// Packed encoding pads the elements of arrays to 32 bytes, as encodeData requires.
+		return fmt.Sprintf("keccak256(abi.encodePacked(%s))", expression)
+	}
+	if len(dimensions) > 0 {
+		*arrays = append(*arrays, solidityType)
+	}
+	return fmt.Sprintf("hash(%s)", expression)
+}
+
+// Builds the EIP-712 library for the structs of the given interface, whose functions hash structs as
+// defined by EIP-712 (hashStruct).
+func buildEIP712Library(name string, eip712Types []EIP712Type, compoundTypes []CompoundType) *eip712Library {
+	if len(eip712Types) == 0 {
+		return nil
+	}
+	byName := map[string]CompoundType{}
+	for _, compound := range compoundTypes {
+		byName[compound.TypeName] = compound
+	}
+	qualify := func(solidityType string) string {
+		element, dimensions := splitArrayDimensions(solidityType)
+		if _, ok := byName[element]; ok {
+			return fmt.Sprintf("%s.%s%s", name, element, dimensions)
+		}
+		return solidityType
+	}
+
+	library := &eip712Library{}
+	arrays := []string{}
+	declared := map[string]bool{}
+	for _, eip712Type := range eip712Types {
+		prefix := strings.ToUpper(SnakeCase(eip712Type.Name))
+		if !declared[prefix] {
+			declared[prefix] = true
+			library.Constants = append(library.Constants, eip712Constant{Prefix: prefix, Type: eip712Type})
+		}
+
+		encoded := []string{prefix + "_TYPEHASH"}
+		for _, member := range byName[eip712Type.TypeName].Members {
+			encoded = append(encoded, eip712Encoding("value."+member.Name, member.Value.Type, &arrays))
+		}
+		library.Functions = append(library.Functions, fmt.Sprintf("\tfunction hash(%s memory value) internal pure returns (bytes32) {\n\t\treturn keccak256(abi.encode(%s));\n\t}", qualify(eip712Type.TypeName), strings.Join(encoded, ", ")))
+	}
+
+	seen := map[string]bool{}
+	for len(arrays) > 0 {
+		arrayType := arrays[0]
+		arrays = arrays[1:]
+		if seen[arrayType] {
+			continue
+		}
+		seen[arrayType] = true
+		elementType, _ := splitArrayType(arrayType)
+		library.Functions = append(library.Functions, fmt.Sprintf("\tfunction hash(%s memory values) internal pure returns (bytes32) {\n\t\tbytes32[] memory hashes = new bytes32[](values.length);\n\t\tfor (uint256 i = 0; i < values.length; i++) {\n\t\t\thashes[i] = %s;\n\t\t}\n\t\treturn keccak256(abi.encodePacked(hashes));\n\t}", qualify(arrayType), eip712Encoding("values[i]", elementType, &arrays)))
+	}
+	return library
+}
+
+// Generates the EIP-712 definitions (see EIP712Definitions) of the structs which the functions of the
+// given ABI take as input, as JSON.
+func GenerateEIP712Definitions(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	resolved, resolveErr := ResolveCompoundsWithOptions(abi, options)
+	if resolveErr != nil {
+		return resolveErr
+	}
+
+	definitions := EIP712Definitions{Types: map[string][]EIP712Member{}, Structs: EIP712Types(resolved.EnrichedABI, resolved.CompoundTypes)}
+	for _, eip712Type := range definitions.Structs {
+		definitions.Types[eip712Type.Name] = eip712Type.Members
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(definitions)
+}
@@ -0,0 +1,127 @@
+package solface
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// The Mail example from the EIP-712 specification.
+const eip712MailABI = `[
+	{"type": "function", "name": "send", "stateMutability": "nonpayable", "inputs": [
+		{"name": "mail", "type": "tuple", "internalType": "struct Mailbox.Mail", "components": [
+			{"name": "from", "type": "tuple", "internalType": "struct Mailbox.Person", "components": [
+				{"name": "name", "type": "string", "internalType": "string"},
+				{"name": "wallet", "type": "address", "internalType": "address"}
+			]},
+			{"name": "to", "type": "tuple", "internalType": "struct Mailbox.Person", "components": [
+				{"name": "name", "type": "string", "internalType": "string"},
+				{"name": "wallet", "type": "address", "internalType": "address"}
+			]},
+			{"name": "contents", "type": "string", "internalType": "string"}
+		]}
+	], "outputs": []},
+	{"type": "event", "name": "Sent", "anonymous": false, "inputs": [
+		{"name": "receipt", "type": "tuple", "indexed": false, "internalType": "struct Mailbox.Receipt", "components": [
+			{"name": "id", "type": "uint256", "internalType": "uint256"}
+		]}
+	]}
+]`
+
+func TestEIP712Types(t *testing.T) {
+	abi, decodeErr := Decode([]byte(eip712MailABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	resolved := ResolveCompounds(abi)
+	eip712Types := EIP712Types(resolved.EnrichedABI, resolved.CompoundTypes)
+	// Person is generated twice (for from and to), and both structs share its EIP-712 definition.
+	if len(eip712Types) != 3 || eip712Types[0].Name != "Person" || eip712Types[1].Name != "Person" || eip712Types[0].TypeHash != eip712Types[1].TypeHash {
+		t.Fatalf("Expected EIP-712 types for Mail and both Person structs only. Actual: %v", eip712Types)
+	}
+
+	var mail EIP712Type
+	for _, eip712Type := range eip712Types {
+		if eip712Type.Name == "Mail" {
+			mail = eip712Type
+		}
+	}
+	expectedEncodeType := "Mail(Person from,Person to,string contents)Person(string name,address wallet)"
+	if mail.EncodeType != expectedEncodeType {
+		t.Fatalf("Expected encodeType %s. Actual: %s", expectedEncodeType, mail.EncodeType)
+	}
+	expectedTypeHash := "0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"
+	if mail.TypeHash != expectedTypeHash {
+		t.Fatalf("Expected type hash %s. Actual: %s", expectedTypeHash, mail.TypeHash)
+	}
+	expectedWitness := "Mail witness)Mail(Person from,Person to,string contents)Person(string name,address wallet)TokenPermissions(address token,uint256 amount)"
+	if mail.WitnessTypeString != expectedWitness {
+		t.Fatalf("Expected witness type string %s. Actual: %s", expectedWitness, mail.WitnessTypeString)
+	}
+}
+
+func TestGenerateInterfaceEIP712(t *testing.T) {
+	abi, decodeErr := Decode([]byte(eip712MailABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IMailbox", EIP712: true}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	resolved := ResolveCompounds(abi)
+	mailType := resolved.EnrichedABI.Functions[0].Inputs[0].Type
+	expectedLines := []string{
+		"library IMailboxEIP712 {",
+		"bytes32 internal constant MAIL_TYPEHASH = 0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2;",
+		"function hash(IMailbox." + mailType + " memory value) internal pure returns (bytes32) {",
+		"return keccak256(abi.encode(MAIL_TYPEHASH, hash(value.from), hash(value.to), keccak256(bytes(value.contents))));",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output.String(), line) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", line, output.String())
+		}
+	}
+
+	// EIP-712 type hashes are keccak256 regardless of the hasher used for selectors.
+	var withHasher strings.Builder
+	generateErr = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IMailbox", EIP712: true, Hasher: SHA3256Hasher}, &withHasher)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	if !strings.Contains(withHasher.String(), expectedLines[1]) {
+		t.Fatalf("Expected output with the sha3-256 hasher to contain:\n%s\nActual:\n%s", expectedLines[1], withHasher.String())
+	}
+
+	var withoutEIP712 strings.Builder
+	generateErr = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IMailbox"}, &withoutEIP712)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	if strings.Contains(withoutEIP712.String(), "EIP712") {
+		t.Fatalf("Expected no EIP-712 library without the option. Actual:\n%s", withoutEIP712.String())
+	}
+}
+
+func TestGenerateEIP712Definitions(t *testing.T) {
+	abi, decodeErr := Decode([]byte(eip712MailABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateEIP712Definitions(abi, Annotations{}, Options{Name: "IMailbox"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating EIP-712 definitions: %s", generateErr.Error())
+	}
+	var definitions EIP712Definitions
+	if unmarshalErr := json.Unmarshal([]byte(output.String()), &definitions); unmarshalErr != nil {
+		t.Fatalf("Error decoding EIP-712 definitions: %s", unmarshalErr.Error())
+	}
+	if len(definitions.Types) != 2 || len(definitions.Types["Mail"]) != 3 || definitions.Types["Mail"][0].Type != "Person" {
+		t.Fatalf("Expected Mail and Person types, with Mail.from of type Person. Actual: %v", definitions.Types)
+	}
+}
@@ -0,0 +1,17 @@
+package solface
+
+// Returns true if the given ABI has no events, functions, errors, or fallback and receive functions, so
+// that the interface generated from it has no members. Empty ABIs are valid, but often indicate a problem
+// upstream (e.g. an artifact of an abstract contract or a library with internal functions only).
+func IsEmptyABI(abi DecodedABI) bool {
+	return len(abi.Events)+len(abi.Functions)+len(abi.Errors) == 0 && abi.Fallback == nil && abi.Receive == nil
+}
+
+// Returns a diagnostic for the contract with the given name if its ABI is empty (see IsEmptyABI), and no
+// diagnostics otherwise.
+func EmptyABIDiagnostics(abi DecodedABI, name string) []Diagnostic {
+	if !IsEmptyABI(abi) {
+		return []Diagnostic{}
+	}
+	return []Diagnostic{{ItemType: "contract", Name: name, Message: "ABI has no events, functions, or errors - the generated interface is empty"}}
+}
@@ -0,0 +1,45 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmptyABI(t *testing.T) {
+	abi, decodeErr := Decode([]byte("[]"))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding empty ABI: %s", decodeErr.Error())
+	}
+	if !IsEmptyABI(abi) {
+		t.Fatalf("Expected [] to be an empty ABI")
+	}
+	diagnostics := EmptyABIDiagnostics(abi, "IEmpty")
+	if len(diagnostics) != 1 || diagnostics[0].Name != "IEmpty" {
+		t.Fatalf("Expected 1 diagnostic for IEmpty. Actual: %v", diagnostics)
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IEmpty", License: "MIT", Pragma: "^0.8.0"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	if !strings.HasSuffix(output.String(), "\ninterface IEmpty {}\n") || strings.Contains(output.String(), "// events") {
+		t.Fatalf("Expected an empty interface without section comments. Actual interface:\n%s", output.String())
+	}
+
+	constructorOnly, decodeErr := Decode([]byte(`[{"type":"constructor","inputs":[],"stateMutability":"nonpayable"}]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	if !IsEmptyABI(constructorOnly) {
+		t.Fatalf("Expected an ABI with only a constructor to be empty")
+	}
+
+	receiveOnly, decodeErr := Decode([]byte(`[{"type":"receive","stateMutability":"payable"}]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	if IsEmptyABI(receiveOnly) || len(EmptyABIDiagnostics(receiveOnly, "IReceiver")) != 0 {
+		t.Fatalf("Expected an ABI with a receive function not to be empty")
+	}
+}
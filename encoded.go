@@ -0,0 +1,48 @@
+package solface
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Returns the JSON encoded in the given input, and false if the input is not an encoded JSON document.
+// Inputs may be hex-encoded (with or without a "0x" prefix) or base64-encoded (in the standard or URL-safe
+// alphabet, with or without padding), and may be wrapped in a JSON string, as is common for ABIs stored in
+// environment variables or on-chain registries. Since short words can be valid hex or base64, inputs are
+// only considered encoded if they decode to a JSON array or object.
+func DecodeEncodedJSON(rawInput []byte) ([]byte, bool) {
+	trimmed := strings.TrimSpace(string(rawInput))
+	if strings.HasPrefix(trimmed, `"`) {
+		unquoted, unquoteErr := strconv.Unquote(trimmed)
+		if unquoteErr != nil {
+			return nil, false
+		}
+		trimmed = strings.TrimSpace(unquoted)
+	}
+	if trimmed == "" || strings.ContainsAny(trimmed, " \t\r\n[{") {
+		return nil, false
+	}
+
+	candidates := [][]byte{}
+	if decoded, hexErr := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(trimmed, "0x"), "0X")); hexErr == nil {
+		candidates = append(candidates, decoded)
+	}
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if decoded, base64Err := encoding.DecodeString(trimmed); base64Err == nil {
+			candidates = append(candidates, decoded)
+			break
+		}
+	}
+
+	for _, candidate := range candidates {
+		decoded := bytes.TrimSpace(candidate)
+		if len(decoded) > 0 && (decoded[0] == '[' || decoded[0] == '{') && json.Valid(decoded) {
+			return decoded, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,46 @@
+package solface
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"testing"
+)
+
+func TestDecodeEncodedJSON(t *testing.T) {
+	rawABI := `[{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}]`
+	inputs := map[string]string{
+		"hex":              hex.EncodeToString([]byte(rawABI)),
+		"prefixed hex":     "0x" + hex.EncodeToString([]byte(rawABI)) + "\n",
+		"base64":           base64.StdEncoding.EncodeToString([]byte(rawABI)),
+		"unpadded base64":  base64.RawURLEncoding.EncodeToString([]byte(rawABI + " ")),
+		"quoted hex":       strconv.Quote("0x" + hex.EncodeToString([]byte(rawABI))),
+		"quoted base64":    strconv.Quote(base64.StdEncoding.EncodeToString([]byte(rawABI))),
+		"base64 of object": base64.StdEncoding.EncodeToString([]byte(`{"abi": ` + rawABI + `}`)),
+	}
+	for name, input := range inputs {
+		decoded, ok := DecodeEncodedJSON([]byte(input))
+		if !ok {
+			t.Fatalf("Expected %s input to be decoded", name)
+		}
+		if _, parseErr := ParseArtifact([]byte(input)); parseErr != nil {
+			t.Fatalf("Error parsing %s input: %s", name, parseErr.Error())
+		}
+		if decoded[0] != '[' && decoded[0] != '{' {
+			t.Fatalf("Expected %s input to decode to JSON. Actual: %s", name, string(decoded))
+		}
+	}
+
+	notEncoded := []string{
+		rawABI,
+		"function owner() view returns (address)",
+		"deadbeef",
+		base64.StdEncoding.EncodeToString([]byte("not json")),
+		"",
+	}
+	for _, input := range notEncoded {
+		if decoded, ok := DecodeEncodedJSON([]byte(input)); ok {
+			t.Fatalf("Expected %q not to be decoded. Actual: %s", input, string(decoded))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Package ens resolves ENS names (e.g. "app.uniswap.eth") to addresses using an Ethereum JSON-RPC
+// endpoint, so that names can be used wherever solface accepts contract addresses.
+package ens
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/jsonrpc"
+)
+
+// The address of the ENS registry, which is the same on every chain that ENS is deployed on.
+var RegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+// Timeout for JSON-RPC requests made while resolving names.
+var RequestTimeout = 30 * time.Second
+
+// Returned when a name has no resolver, or its resolver does not have an address for it.
+type NotFoundError struct {
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("ENS name %s does not resolve to an address", e.Name)
+}
+
+// Returns true if the given string looks like an ENS name rather than a hex address.
+func IsName(nameOrAddress string) bool {
+	return strings.Contains(nameOrAddress, ".") && !common.IsHexAddress(nameOrAddress)
+}
+
+// Computes the ENS namehash of the given name, as specified in EIP-137. Names are lower-cased, but no other
+// normalization is applied, so names must already be in normalized form.
+func Namehash(name string) common.Hash {
+	node := common.Hash{}
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		node = common.BytesToHash(crypto.Keccak256(node.Bytes(), labelHash))
+	}
+	return node
+}
+
+// Returns the calldata for calling the given function (with a single bytes32 argument) on node.
+func callData(signature string, node common.Hash) string {
+	selector := crypto.Keccak256([]byte(signature))[:4]
+	return "0x" + hex.EncodeToString(append(selector, node.Bytes()...))
+}
+
+// Makes an eth_call to the given contract against the latest block and returns the result. The request is
+// sent with the given transport (http.DefaultTransport if nil).
+func ethCall(transport http.RoundTripper, rpcURL string, to common.Address, data string) ([]byte, error) {
+	client := jsonrpc.Client{URL: rpcURL, Timeout: RequestTimeout, Transport: transport}
+	var result string
+	callErr := client.Call("eth_call", []interface{}{map[string]string{"to": to.Hex(), "data": data}, "latest"}, &result)
+	if callErr != nil {
+		return nil, callErr
+	}
+
+	decoded, hexErr := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+	if hexErr != nil {
+		return nil, fmt.Errorf("invalid RPC result %q: %s", result, hexErr.Error())
+	}
+	return decoded, nil
+}
+
+// Resolves the given ENS name to an address using the JSON-RPC endpoint at rpcURL: the resolver for the
+// name is looked up in the ENS registry, and the address is then looked up in the resolver.
+// Requests are sent with the given transport (http.DefaultTransport if nil). Returns a *NotFoundError if
+// the name has no resolver or no address.
+func Resolve(transport http.RoundTripper, rpcURL, name string) (common.Address, error) {
+	node := Namehash(name)
+
+	resolverResult, resolverErr := ethCall(transport, rpcURL, RegistryAddress, callData("resolver(bytes32)", node))
+	if resolverErr != nil {
+		return common.Address{}, fmt.Errorf("could not look up resolver for %s: %s", name, resolverErr.Error())
+	}
+	if len(resolverResult) != 32 {
+		return common.Address{}, fmt.Errorf("unexpected response from ENS registry for %s: %x", name, resolverResult)
+	}
+	resolver := common.BytesToAddress(resolverResult)
+	if resolver == (common.Address{}) {
+		return common.Address{}, &NotFoundError{Name: name}
+	}
+
+	addressResult, addressErr := ethCall(transport, rpcURL, resolver, callData("addr(bytes32)", node))
+	if addressErr != nil {
+		return common.Address{}, fmt.Errorf("could not look up address for %s: %s", name, addressErr.Error())
+	}
+	if len(addressResult) != 32 {
+		return common.Address{}, fmt.Errorf("unexpected response from resolver for %s: %x", name, addressResult)
+	}
+	address := common.BytesToAddress(addressResult)
+	if address == (common.Address{}) {
+		return common.Address{}, &NotFoundError{Name: name}
+	}
+	return address, nil
+}
+
+// Resolves the given string to an address: hex addresses are validated (see solface.ChecksumAddress) and
+// returned as they are, and ENS names are resolved using Resolve.
+func ResolveAddress(transport http.RoundTripper, rpcURL, nameOrAddress string) (common.Address, error) {
+	if common.IsHexAddress(nameOrAddress) {
+		checksummed, checksumErr := solface.ChecksumAddress(nameOrAddress)
+		if checksumErr != nil {
+			return common.Address{}, checksumErr
+		}
+		return common.HexToAddress(checksummed), nil
+	}
+	if !IsName(nameOrAddress) {
+		return common.Address{}, fmt.Errorf("%s is neither an address nor an ENS name", nameOrAddress)
+	}
+	return Resolve(transport, rpcURL, nameOrAddress)
+}
@@ -0,0 +1,86 @@
+package ens
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNamehash(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"", "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{"eth", "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"},
+		{"foo.eth", "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"},
+		{"Foo.ETH", "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"},
+	}
+
+	for _, testCase := range testCases {
+		actual := Namehash(testCase.name).Hex()
+		if actual != testCase.expected {
+			t.Fatalf("Name: %s. Expected namehash: %s. Actual: %s", testCase.name, testCase.expected, actual)
+		}
+	}
+}
+
+// Serves eth_call requests, answering calls to the ENS registry with the given resolver and calls to
+// the resolver with the given address.
+func fakeRPC(t *testing.T, resolver, address common.Address) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Params []interface{} `json:"params"`
+		}
+		if decodeErr := json.NewDecoder(r.Body).Decode(&request); decodeErr != nil {
+			t.Fatalf("Could not decode RPC request: %s", decodeErr.Error())
+		}
+		call := request.Params[0].(map[string]interface{})
+		result := common.Hash{}
+		if strings.EqualFold(call["to"].(string), RegistryAddress.Hex()) {
+			result = common.BytesToHash(resolver.Bytes())
+		} else if strings.EqualFold(call["to"].(string), resolver.Hex()) {
+			result = common.BytesToHash(address.Bytes())
+		}
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": "%s"}`, request.ID, result.Hex())
+	}))
+}
+
+func TestResolve(t *testing.T) {
+	resolver := common.HexToAddress("0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41")
+	expected := common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	server := fakeRPC(t, resolver, expected)
+	defer server.Close()
+
+	address, resolveErr := ResolveAddress(nil, server.URL, "vitalik.eth")
+	if resolveErr != nil {
+		t.Fatalf("Error resolving name: %s", resolveErr.Error())
+	}
+	if address != expected {
+		t.Fatalf("Expected address: %s. Actual: %s", expected.Hex(), address.Hex())
+	}
+
+	// Addresses are passed through without making any requests.
+	address, resolveErr = ResolveAddress(nil, "", expected.Hex())
+	if resolveErr != nil || address != expected {
+		t.Fatalf("Expected address %s to be passed through. Actual: %s (error: %v)", expected.Hex(), address.Hex(), resolveErr)
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	server := fakeRPC(t, common.Address{}, common.Address{})
+	defer server.Close()
+
+	_, resolveErr := Resolve(nil, server.URL, "does-not-exist.eth")
+	var notFoundErr *NotFoundError
+	if !errors.As(resolveErr, &notFoundErr) {
+		t.Fatalf("Expected a NotFoundError. Actual: %v", resolveErr)
+	}
+}
@@ -0,0 +1,90 @@
+package solface
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The prefix of ERC-7201 storage locations in @custom:storage-location NatSpec annotations.
+const erc7201Formula = "erc7201:"
+
+// Represents a constant holding the base storage slot of an ERC-7201 namespace, generated in a library
+// after the interface.
+//  1. Name: The name of the constant (e.g. "OPENZEPPELIN_STORAGE_OWNABLE_LOCATION").
+//  2. NamespaceID: The ID of the namespace (e.g. "openzeppelin.storage.Ownable").
+//  3. Slot: The hex-encoded base storage slot of the namespace, with a 0x prefix.
+type StorageNamespaceConstant struct {
+	Name        string
+	NamespaceID string
+	Slot        string
+}
+
+// Computes the base storage slot of the ERC-7201 namespace with the given ID:
+// keccak256(abi.encode(uint256(keccak256(id)) - 1)) & ~bytes32(uint256(0xff)).
+func ERC7201Slot(namespaceID string) [32]byte {
+	hash := new(big.Int).SetBytes(crypto.Keccak256([]byte(namespaceID)))
+	hash.Sub(hash, big.NewInt(1))
+	var encoded [32]byte
+	hash.FillBytes(encoded[:])
+
+	var slot [32]byte
+	copy(slot[:], crypto.Keccak256(encoded[:]))
+	slot[31] = 0
+	return slot
+}
+
+// Returns the IDs of the ERC-7201 namespaces declared by @custom:storage-location annotations in the given
+// devdoc, in order of appearance and without duplicates. Storage locations using formulas other than
+// erc7201 are ignored.
+func StorageNamespaces(devdoc Devdoc) []string {
+	namespaces := []string{}
+	seen := map[string]bool{}
+	for _, location := range strings.Fields(devdoc.StorageLocation) {
+		if !strings.HasPrefix(location, erc7201Formula) {
+			continue
+		}
+		namespaceID := strings.TrimPrefix(location, erc7201Formula)
+		if namespaceID != "" && !seen[namespaceID] {
+			seen[namespaceID] = true
+			namespaces = append(namespaces, namespaceID)
+		}
+	}
+	return namespaces
+}
+
+// Returns the name of the constant holding the base storage slot of the given namespace, e.g.
+// "OPENZEPPELIN_STORAGE_OWNABLE_LOCATION" for "openzeppelin.storage.Ownable".
+func storageNamespaceConstantName(namespaceID string) string {
+	parts := strings.FieldsFunc(namespaceID, func(r rune) bool {
+		return r >= unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	for i, part := range parts {
+		parts[i] = strings.ToUpper(SnakeCase(part))
+	}
+	name := strings.Join(append(parts, "LOCATION"), "_")
+	if unicode.IsDigit(rune(name[0])) {
+		name = "NAMESPACE_" + name
+	}
+	return name
+}
+
+// Returns the storage slot constants for the given namespaces. Namespaces whose constant names would
+// collide are disambiguated with numeric suffixes.
+func storageNamespaceConstants(namespaceIDs []string) []StorageNamespaceConstant {
+	constants := make([]StorageNamespaceConstant, len(namespaceIDs))
+	names := map[string]int{}
+	for i, namespaceID := range namespaceIDs {
+		name := storageNamespaceConstantName(namespaceID)
+		names[name]++
+		if names[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, names[name])
+		}
+		slot := ERC7201Slot(namespaceID)
+		constants[i] = StorageNamespaceConstant{Name: name, NamespaceID: namespaceID, Slot: fmt.Sprintf("0x%x", slot)}
+	}
+	return constants
+}
@@ -0,0 +1,54 @@
+package solface
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestERC7201Slot(t *testing.T) {
+	// Slot of OpenZeppelin's OwnableUpgradeable storage, as hard-coded in OwnableStorageLocation.
+	expected := "9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300"
+	if actual := fmt.Sprintf("%x", ERC7201Slot("openzeppelin.storage.Ownable")); actual != expected {
+		t.Fatalf("Expected slot %s. Actual: %s", expected, actual)
+	}
+}
+
+func TestGenerateInterfaceStorageNamespaces(t *testing.T) {
+	devdoc, parseErr := ParseDevdoc([]byte(`{"kind": "dev", "methods": {}, "custom:storage-location": "erc7201:openzeppelin.storage.Ownable\nerc1234:ignored erc7201:example.main erc7201:example.main"}`))
+	if parseErr != nil {
+		t.Fatalf("Error parsing devdoc: %s", parseErr.Error())
+	}
+	namespaces := StorageNamespaces(devdoc)
+	if len(namespaces) != 2 || namespaces[0] != "openzeppelin.storage.Ownable" || namespaces[1] != "example.main" {
+		t.Fatalf("Expected namespaces openzeppelin.storage.Ownable and example.main. Actual: %v", namespaces)
+	}
+
+	abi := DecodedABI{Functions: []FunctionItem{{Type: "function", Name: "owner", Outputs: []Value{{Type: "address"}}, StateMutability: "view"}}}
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IOwnable", StorageNamespaces: namespaces}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expectedLines := []string{
+		"library IOwnableStorage {",
+		"\t// erc7201:openzeppelin.storage.Ownable",
+		"\tbytes32 internal constant OPENZEPPELIN_STORAGE_OWNABLE_LOCATION = 0x9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300;",
+		"\t// erc7201:example.main",
+		"\tfunction erc7201Slot(string memory namespaceId) internal pure returns (bytes32) {",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output.String(), line+"\n") {
+			t.Fatalf("Expected generated interface to contain line: %s. Actual interface:\n%s", line, output.String())
+		}
+	}
+
+	var plain strings.Builder
+	generateErr = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IOwnable"}, &plain)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	if strings.Contains(plain.String(), "library") {
+		t.Fatalf("Expected no library without storage namespaces. Actual interface:\n%s", plain.String())
+	}
+}
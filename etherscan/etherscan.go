@@ -0,0 +1,202 @@
+// Package etherscan fetches the verified ABIs of contracts from Etherscan (and explorers which implement
+// the Etherscan API), so that interfaces to deployed contracts can be generated without downloading their
+// ABIs by hand.
+package etherscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moonstream-to/solface"
+)
+
+// The Etherscan API endpoint for Ethereum mainnet.
+const DefaultAPIURL = "https://api.etherscan.io/api"
+
+// The Etherscan V2 API endpoint, which serves every chain supported by Etherscan (including those of
+// Polygonscan, Arbiscan, Basescan, etc.) with a single API key, selected by chain ID.
+const V2APIURL = "https://api.etherscan.io/v2/api"
+
+// The environment variable from which the solface CLI reads the Etherscan API key if none is given.
+const APIKeyEnvironmentVariable = "ETHERSCAN_API_KEY"
+
+// Timeout for requests to the Etherscan API.
+var RequestTimeout = 30 * time.Second
+
+// The message with which Etherscan reports the ABI of contracts whose source code is not verified.
+const notVerifiedMessage = "Contract source code not verified"
+
+// Represents a verified contract, as described by the Etherscan API.
+//  1. Address: The (checksummed) address of the contract.
+//  2. Name: The name of the contract.
+//  3. ABI: The raw ABI JSON array of the contract.
+//  4. License: The SPDX identifier of the license of the source code of the contract (e.g. "MIT"), or the
+//     empty string if it is not known.
+//  5. CompilerVersion: The version of the compiler the contract was verified with (e.g. "v0.8.19+commit.7dd6d404").
+//  6. Implementation: The address of the implementation, if Etherscan recognizes the contract as a
+//     proxy, or the empty string otherwise.
+type Contract struct {
+	Address         string
+	Name            string
+	ABI             []byte
+	License         string
+	CompilerVersion string
+	Implementation  string
+}
+
+// Returned when the contract at an address is not verified on the explorer.
+type NotVerifiedError struct {
+	Address string
+}
+
+func (e *NotVerifiedError) Error() string {
+	return fmt.Sprintf("contract %s is not verified", e.Address)
+}
+
+// Returned when the Etherscan API reports an error (e.g. an invalid API key or rate limiting).
+type APIError struct {
+	Message string
+	Result  string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Etherscan API error: %s (%s)", e.Message, e.Result)
+}
+
+type apiResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+type sourceCodeResult struct {
+	ABI             string `json:"ABI"`
+	ContractName    string `json:"ContractName"`
+	CompilerVersion string `json:"CompilerVersion"`
+	LicenseType     string `json:"LicenseType"`
+	Proxy           string `json:"Proxy"`
+	Implementation  string `json:"Implementation"`
+}
+
+// Licenses which Etherscan reports for contracts without a license.
+var unknownLicenses = map[string]bool{"": true, "None": true, "Unknown": true}
+
+// SPDX identifiers of the licenses which Etherscan reports under other names.
+var spdxLicenses = map[string]string{
+	"GNU GPLv2":    "GPL-2.0",
+	"GNU GPLv3":    "GPL-3.0",
+	"GNU LGPLv2.1": "LGPL-2.1",
+	"GNU LGPLv3":   "LGPL-3.0",
+	"GNU AGPLv3":   "AGPL-3.0",
+	"BSL 1.1":      "BUSL-1.1",
+}
+
+// Makes a GET request to the Etherscan API at apiURL with the given query parameters, sent with transport
+// (http.DefaultTransport if nil), and returns the result.
+func get(transport http.RoundTripper, apiURL string, parameters url.Values) (json.RawMessage, error) {
+	requestURL, parseErr := url.Parse(apiURL)
+	if parseErr != nil {
+		return nil, fmt.Errorf("invalid API URL %s: %s", apiURL, parseErr.Error())
+	}
+	query := requestURL.Query()
+	for key, values := range parameters {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	requestURL.RawQuery = query.Encode()
+
+	client := http.Client{Timeout: RequestTimeout, Transport: transport}
+	response, requestErr := client.Get(requestURL.String())
+	if requestErr != nil {
+		return nil, requestErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Etherscan request failed with status %s", response.Status)
+	}
+
+	var decoded apiResponse
+	decodeErr := json.NewDecoder(response.Body).Decode(&decoded)
+	if decodeErr != nil {
+		return nil, fmt.Errorf("could not decode Etherscan response: %s", decodeErr.Error())
+	}
+	if decoded.Status != "1" {
+		var result string
+		json.Unmarshal(decoded.Result, &result)
+		return nil, &APIError{Message: decoded.Message, Result: result}
+	}
+	return decoded.Result, nil
+}
+
+// Fetches the verified contract at the given address from the Etherscan API at apiURL (DefaultAPIURL if
+// empty), authenticating with the given API key. The address is validated (see solface.ChecksumAddress)
+// before any request is made. Returns a *NotVerifiedError if the contract is not verified, and an
+// *APIError if the API reports any other error. Requests are sent with http.DefaultTransport.
+func FetchContract(apiURL, apiKey, address string) (Contract, error) {
+	return FetchContractOnChain(nil, apiURL, apiKey, "", address)
+}
+
+// Fetches the verified contract at the given address on the given chain (a chain ID or the name of a known
+// chain, see solface.ChainID) in the same way as FetchContract. If a chain is given, the request is made
+// to the Etherscan V2 API (V2APIURL, unless apiURL is set) with the ID of that chain. An empty chain
+// behaves like FetchContract. Requests are sent with transport, or with http.DefaultTransport if it is nil.
+func FetchContractOnChain(transport http.RoundTripper, apiURL, apiKey, chain, address string) (Contract, error) {
+	checksummed, checksumErr := solface.ChecksumAddress(address)
+	if checksumErr != nil {
+		return Contract{}, checksumErr
+	}
+
+	parameters := url.Values{"module": {"contract"}, "action": {"getsourcecode"}, "address": {checksummed}}
+	if chain != "" {
+		chainID, ok := solface.ChainID(chain)
+		if !ok {
+			return Contract{}, fmt.Errorf("unknown chain: %s (known chains: %s)", chain, strings.Join(solface.ChainNames(), ", "))
+		}
+		parameters.Set("chainid", strconv.FormatUint(chainID, 10))
+		if apiURL == "" {
+			apiURL = V2APIURL
+		}
+	}
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	if apiKey != "" {
+		parameters.Set("apikey", apiKey)
+	}
+	rawResult, getErr := get(transport, apiURL, parameters)
+	if getErr != nil {
+		return Contract{}, getErr
+	}
+
+	var results []sourceCodeResult
+	unmarshalErr := json.Unmarshal(rawResult, &results)
+	if unmarshalErr != nil {
+		return Contract{}, fmt.Errorf("could not decode Etherscan result: %s", unmarshalErr.Error())
+	}
+	if len(results) == 0 || results[0].ABI == notVerifiedMessage || results[0].ABI == "" {
+		return Contract{}, &NotVerifiedError{Address: checksummed}
+	}
+
+	result := results[0]
+	contract := Contract{
+		Address:         checksummed,
+		Name:            result.ContractName,
+		ABI:             []byte(result.ABI),
+		CompilerVersion: result.CompilerVersion,
+	}
+	if spdxLicense, ok := spdxLicenses[result.LicenseType]; ok {
+		contract.License = spdxLicense
+	} else if !unknownLicenses[result.LicenseType] {
+		contract.License = result.LicenseType
+	}
+	if result.Proxy == "1" && result.Implementation != "" {
+		contract.Implementation = result.Implementation
+	}
+	return contract, nil
+}
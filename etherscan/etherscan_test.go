@@ -0,0 +1,91 @@
+package etherscan
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moonstream-to/solface"
+)
+
+// Serves getsourcecode requests, answering with the given result for every request with the given API key.
+func fakeEtherscan(t *testing.T, apiKey, result string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("module") != "contract" || query.Get("action") != "getsourcecode" {
+			t.Fatalf("Unexpected request: %s", r.URL.String())
+		}
+		if query.Get("apikey") != apiKey {
+			fmt.Fprint(w, `{"status": "0", "message": "NOTOK", "result": "Invalid API Key"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status": "1", "message": "OK", "result": %s}`, result)
+	}))
+}
+
+func TestFetchContract(t *testing.T) {
+	server := fakeEtherscan(t, "key", `[{"ABI": "[{\"type\": \"function\", \"name\": \"owner\", \"inputs\": [], \"outputs\": [{\"name\": \"\", \"type\": \"address\"}], \"stateMutability\": \"view\"}]", "ContractName": "Ownable", "CompilerVersion": "v0.8.19+commit.7dd6d404", "LicenseType": "GNU GPLv3", "Proxy": "0", "Implementation": ""}]`)
+	defer server.Close()
+
+	contract, fetchErr := FetchContract(server.URL, "key", "0xca11bde05977b3631167028862be2a173976ca11")
+	if fetchErr != nil {
+		t.Fatalf("Error fetching contract: %s", fetchErr.Error())
+	}
+	if contract.Name != "Ownable" || contract.License != "GPL-3.0" || contract.Address != "0xcA11bde05977b3631167028862bE2a173976CA11" {
+		t.Fatalf("Expected Ownable contract licensed under GPL-3.0. Actual: %v", contract)
+	}
+	abi, decodeErr := solface.Decode(contract.ABI)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding fetched ABI: %s", decodeErr.Error())
+	}
+	if len(abi.Functions) != 1 || abi.Functions[0].Name != "owner" {
+		t.Fatalf("Expected ABI with owner function. Actual: %v", abi.Functions)
+	}
+
+	var apiErr *APIError
+	_, fetchErr = FetchContract(server.URL, "wrong", "0xcA11bde05977b3631167028862bE2a173976CA11")
+	if !errors.As(fetchErr, &apiErr) || apiErr.Result != "Invalid API Key" {
+		t.Fatalf("Expected APIError for invalid API key. Actual: %v", fetchErr)
+	}
+
+	var addressErr *solface.InvalidAddressError
+	_, fetchErr = FetchContract(server.URL, "key", "0x1234")
+	if !errors.As(fetchErr, &addressErr) {
+		t.Fatalf("Expected InvalidAddressError. Actual: %v", fetchErr)
+	}
+}
+
+func TestFetchContractNotVerified(t *testing.T) {
+	server := fakeEtherscan(t, "key", `[{"ABI": "Contract source code not verified", "ContractName": ""}]`)
+	defer server.Close()
+
+	var notVerifiedErr *NotVerifiedError
+	_, fetchErr := FetchContract(server.URL, "key", "0xcA11bde05977b3631167028862bE2a173976CA11")
+	if !errors.As(fetchErr, &notVerifiedErr) {
+		t.Fatalf("Expected NotVerifiedError. Actual: %v", fetchErr)
+	}
+}
+
+func TestFetchContractOnChain(t *testing.T) {
+	var chainID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chainID = r.URL.Query().Get("chainid")
+		fmt.Fprint(w, `{"status": "1", "message": "OK", "result": [{"ABI": "[]", "ContractName": "Ownable"}]}`)
+	}))
+	defer server.Close()
+
+	_, fetchErr := FetchContractOnChain(nil, server.URL, "key", "arbitrum-one", "0xcA11bde05977b3631167028862bE2a173976CA11")
+	if fetchErr != nil {
+		t.Fatalf("Error fetching contract: %s", fetchErr.Error())
+	}
+	if chainID != "42161" {
+		t.Fatalf("Expected request for chain 42161. Actual: %q", chainID)
+	}
+
+	_, fetchErr = FetchContractOnChain(nil, server.URL, "key", "not-a-chain", "0xcA11bde05977b3631167028862bE2a173976CA11")
+	if fetchErr == nil {
+		t.Fatal("Expected error fetching contract on unknown chain. Actual: nil")
+	}
+}
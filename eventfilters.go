@@ -0,0 +1,184 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The address used in generated log filters when no deployments are known, to be replaced by the address
+// of the contract being monitored.
+const EventFilterAddressPlaceholder = "<CONTRACT_ADDRESS>"
+
+// The network used in generated Defender Sentinel configurations when no deployments are known.
+const SentinelNetworkPlaceholder = "<NETWORK>"
+
+// Represents a log filter for a single event.
+//  1. Event: The name of the event.
+//  2. Signature: The canonical signature of the event.
+//  3. Addresses: The addresses of the contracts emitting the event.
+//  4. Topics: The topics to filter on, in the format of eth_getLogs (the first topic is the event topic).
+type EventFilter struct {
+	Event     string   `json:"event"`
+	Signature string   `json:"signature"`
+	Addresses []string `json:"addresses"`
+	Topics    []string `json:"topics"`
+}
+
+// Represents the log filters generated by the event-filters target.
+type EventFilters struct {
+	Contract string        `json:"contract"`
+	Filters  []EventFilter `json:"filters"`
+}
+
+// Represents a condition on the events of a Defender Sentinel.
+type SentinelEventCondition struct {
+	EventSignature string `json:"eventSignature"`
+	Expression     string `json:"expression,omitempty"`
+}
+
+// Represents the configuration of an OpenZeppelin Defender Sentinel monitoring the events of a contract.
+// ABI is the JSON representation of the monitored events, as a string.
+type SentinelConfig struct {
+	Name            string                   `json:"name"`
+	Type            string                   `json:"type"`
+	Network         string                   `json:"network"`
+	Addresses       []string                 `json:"addresses"`
+	ABI             string                   `json:"abi"`
+	EventConditions []SentinelEventCondition `json:"eventConditions"`
+	ConfirmLevel    int                      `json:"confirmLevel"`
+	Paused          bool                     `json:"paused"`
+}
+
+// Chains whose names differ in Defender.
+var sentinelNetworks = map[string]string{
+	"ethereum": "mainnet",
+	"polygon":  "matic",
+}
+
+// Returns the addresses of the given deployments, or the address placeholder if there are none.
+func eventFilterAddresses(deployments []Deployment) []string {
+	if len(deployments) == 0 {
+		return []string{EventFilterAddressPlaceholder}
+	}
+	addresses := make([]string, len(deployments))
+	for i, deployment := range deployments {
+		addresses[i] = deployment.Address
+	}
+	return addresses
+}
+
+// Returns a log filter for every event of the given ABI which emits an event topic (i.e. every event
+// which is not anonymous). The filters match the given deployments (or EventFilterAddressPlaceholder, if
+// there are none). Topics are derived with the given hasher (Keccak256Hasher if nil).
+func EventFiltersForABI(abi DecodedABI, deployments []Deployment, hasher Hasher) []EventFilter {
+	filters := []EventFilter{}
+	for _, eventItem := range abi.Events {
+		if eventItem.Anonymous {
+			continue
+		}
+		filters = append(filters, EventFilter{
+			Event:     eventItem.Name,
+			Signature: EventSignature(eventItem),
+			Addresses: eventFilterAddresses(deployments),
+			Topics:    []string{fmt.Sprintf("0x%x", EventTopicWithHasher(eventItem, hasher))},
+		})
+	}
+	return filters
+}
+
+// Generates log filters for the events of the given ABI as JSON (see EventFiltersForABI). The filters
+// match the addresses in options.Deployments.
+func GenerateEventFilters(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	filters := EventFilters{Contract: options.Name, Filters: EventFiltersForABI(abi, options.Deployments, options.Hasher)}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	// Keep the placeholders readable.
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(filters)
+}
+
+// Returns the JSON representation of the given value as it would appear in an ABI.
+func abiValueJSON(value Value) map[string]interface{} {
+	result := map[string]interface{}{"name": value.Name, "type": value.Type}
+	if value.InternalType != "" {
+		result["internalType"] = value.InternalType
+	}
+	if len(value.Components) > 0 {
+		components := make([]map[string]interface{}, len(value.Components))
+		for i, component := range value.Components {
+			components[i] = abiValueJSON(component)
+		}
+		result["components"] = components
+	}
+	return result
+}
+
+// Returns the JSON representation of the given event as it would appear in an ABI.
+func abiEventJSON(eventItem EventItem) map[string]interface{} {
+	inputs := make([]map[string]interface{}, len(eventItem.Inputs))
+	for i, input := range eventItem.Inputs {
+		inputs[i] = abiValueJSON(input.Value)
+		inputs[i]["indexed"] = input.Indexed
+	}
+	return map[string]interface{}{"type": "event", "name": eventItem.Name, "inputs": inputs, "anonymous": eventItem.Anonymous}
+}
+
+// Returns Defender Sentinel configurations monitoring the events of the given ABI which emit an event
+// topic: one for every deployment, or a single one with placeholders for the network and address if there
+// are no deployments.
+func SentinelConfigs(abi DecodedABI, name string, deployments []Deployment) ([]SentinelConfig, error) {
+	events := []map[string]interface{}{}
+	conditions := []SentinelEventCondition{}
+	for _, eventItem := range abi.Events {
+		if eventItem.Anonymous {
+			continue
+		}
+		events = append(events, abiEventJSON(eventItem))
+		conditions = append(conditions, SentinelEventCondition{EventSignature: EventSignature(eventItem)})
+	}
+	eventsJSON, marshalErr := json.Marshal(events)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+
+	newConfig := func(network string, addresses []string) SentinelConfig {
+		return SentinelConfig{
+			Name:            fmt.Sprintf("%s events", name),
+			Type:            "BLOCK",
+			Network:         network,
+			Addresses:       addresses,
+			ABI:             string(eventsJSON),
+			EventConditions: conditions,
+			ConfirmLevel:    1,
+		}
+	}
+
+	if len(deployments) == 0 {
+		return []SentinelConfig{newConfig(SentinelNetworkPlaceholder, []string{EventFilterAddressPlaceholder})}, nil
+	}
+	configs := make([]SentinelConfig, len(deployments))
+	for i, deployment := range deployments {
+		network, ok := sentinelNetworks[deployment.Chain]
+		if !ok {
+			network = deployment.Chain
+		}
+		configs[i] = newConfig(network, []string{deployment.Address})
+	}
+	return configs, nil
+}
+
+// Generates OpenZeppelin Defender Sentinel configurations for the events of the given ABI as JSON (see
+// SentinelConfigs), one for every deployment in options.Deployments. Since Defender identifies events by
+// their signatures, options.Hasher is not used.
+func GenerateSentinelConfigs(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	configs, configsErr := SentinelConfigs(abi, options.Name, options.Deployments)
+	if configsErr != nil {
+		return configsErr
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	// Keep the placeholders readable.
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(configs)
+}
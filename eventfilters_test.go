@@ -0,0 +1,81 @@
+package solface
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEventFilters(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatalf("Could not read file containing ABI: %s", readErr.Error())
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateEventFilters(abi, Annotations{}, Options{Name: "IOwnableERC20"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating event filters: %s", generateErr.Error())
+	}
+	var filters EventFilters
+	unmarshalErr := json.Unmarshal([]byte(output.String()), &filters)
+	if unmarshalErr != nil {
+		t.Fatalf("Could not parse event filters: %s", unmarshalErr.Error())
+	}
+	if len(filters.Filters) != 3 {
+		t.Fatalf("Expected 3 filters. Actual: %d", len(filters.Filters))
+	}
+	transfer := filters.Filters[2]
+	if transfer.Event != "Transfer" || transfer.Topics[0] != "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Fatalf("Expected filter for Transfer events. Actual: %v", transfer)
+	}
+	if len(transfer.Addresses) != 1 || transfer.Addresses[0] != EventFilterAddressPlaceholder {
+		t.Fatalf("Expected address placeholder. Actual: %v", transfer.Addresses)
+	}
+}
+
+func TestSentinelConfigs(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "event", "name": "Transfer", "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "value", "type": "uint256", "indexed": false}], "anonymous": false},
+		{"type": "event", "name": "Log", "inputs": [{"name": "data", "type": "bytes", "indexed": false}], "anonymous": true}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	deployments, parseErr := ParseDeployments(map[string]string{"ethereum": "0xca11bde05977b3631167028862be2a173976ca11", "base": "0xcA11bde05977b3631167028862bE2a173976CA11"})
+	if parseErr != nil {
+		t.Fatalf("Error parsing deployments: %s", parseErr.Error())
+	}
+
+	configs, configsErr := SentinelConfigs(abi, "IToken", deployments)
+	if configsErr != nil {
+		t.Fatalf("Error generating Sentinel configs: %s", configsErr.Error())
+	}
+	if len(configs) != 2 || configs[0].Network != "base" || configs[1].Network != "mainnet" {
+		t.Fatalf("Expected configs for base and mainnet. Actual: %v", configs)
+	}
+	if len(configs[1].EventConditions) != 1 || configs[1].EventConditions[0].EventSignature != "Transfer(address,address,uint256)" {
+		t.Fatalf("Expected a single condition on Transfer events. Actual: %v", configs[1].EventConditions)
+	}
+	if configs[1].Addresses[0] != "0xcA11bde05977b3631167028862bE2a173976CA11" {
+		t.Fatalf("Expected checksummed address. Actual: %s", configs[1].Addresses[0])
+	}
+
+	sentinelABI, decodeErr := Decode([]byte(configs[0].ABI))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode Sentinel ABI: %s", decodeErr.Error())
+	}
+	if len(sentinelABI.Events) != 1 || EventSignature(sentinelABI.Events[0]) != "Transfer(address,address,uint256)" || !sentinelABI.Events[0].Inputs[0].Indexed {
+		t.Fatalf("Expected Sentinel ABI with the Transfer event. Actual: %v", sentinelABI.Events)
+	}
+
+	configs, _ = SentinelConfigs(abi, "IToken", nil)
+	if len(configs) != 1 || configs[0].Network != SentinelNetworkPlaceholder || configs[0].Addresses[0] != EventFilterAddressPlaceholder {
+		t.Fatalf("Expected a single config with placeholders. Actual: %v", configs)
+	}
+}
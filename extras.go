@@ -0,0 +1,38 @@
+package solface
+
+import (
+	"encoding/json"
+)
+
+// The fields of each type of ABI item which solface decodes. Any other fields of an item (e.g. "gas" or
+// "signature" in legacy ABIs) are preserved in its Extras.
+var knownItemFields = map[string]map[string]bool{
+	"event":       {"type": true, "name": true, "inputs": true, "anonymous": true},
+	"function":    {"type": true, "name": true, "inputs": true, "outputs": true, "stateMutability": true, "constant": true, "payable": true},
+	"error":       {"type": true, "name": true, "inputs": true},
+	"constructor": {"type": true, "inputs": true, "stateMutability": true, "payable": true},
+	"fallback":    {"type": true, "stateMutability": true, "payable": true},
+	"receive":     {"type": true, "stateMutability": true, "payable": true},
+}
+
+// Returns the fields of the given raw ABI item which solface does not decode for items of the given
+// type, or nil if there are no such fields.
+func extraFields(itemType string, rawItem json.RawMessage) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	unmarshalErr := json.Unmarshal(rawItem, &fields)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	var extras map[string]json.RawMessage
+	for field, value := range fields {
+		if knownItemFields[itemType][field] {
+			continue
+		}
+		if extras == nil {
+			extras = map[string]json.RawMessage{}
+		}
+		extras[field] = value
+	}
+	return extras, nil
+}
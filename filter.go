@@ -0,0 +1,83 @@
+package solface
+
+// Returns the ABI consisting of the items of the given ABI for which keep returns true. ItemType is one of
+// "event", "function", or "error" and ItemIndex is the position of the item in the corresponding array of
+// the given ABI. The relative order of the remaining items (including their recorded positions in the
+// original ABI JSON array) is preserved. The constructor and the fallback and receive functions, if any,
+// are always kept.
+func FilterABI(abi DecodedABI, keep func(itemType string, itemIndex int) bool) DecodedABI {
+	result := DecodedABI{Constructor: abi.Constructor, Fallback: abi.Fallback, Receive: abi.Receive}
+	newIndices := map[string]map[int]int{"event": {}, "function": {}, "error": {}}
+	for i, eventItem := range abi.Events {
+		if keep("event", i) {
+			newIndices["event"][i] = len(result.Events)
+			result.Events = append(result.Events, eventItem)
+		}
+	}
+	for i, functionItem := range abi.Functions {
+		if keep("function", i) {
+			newIndices["function"][i] = len(result.Functions)
+			result.Functions = append(result.Functions, functionItem)
+		}
+	}
+	for i, errorItem := range abi.Errors {
+		if keep("error", i) {
+			newIndices["error"][i] = len(result.Errors)
+			result.Errors = append(result.Errors, errorItem)
+		}
+	}
+	for _, position := range abi.Positions {
+		if newIndex, ok := newIndices[position.ItemType][position.ItemIndex]; ok {
+			result.Positions = append(result.Positions, ItemPosition{ItemType: position.ItemType, ItemIndex: newIndex, ABIIndex: position.ABIIndex, Raw: position.Raw})
+		}
+	}
+	return result
+}
+
+// Returns copies of the given ABI and compound types in which every parameter, return value, and struct
+// member (but not the members of nested values, which are resolved into compound types) is replaced by the
+// result of the given transformation. The given ABI and compound types are not modified.
+func MapValues(abi DecodedABI, compoundTypes []CompoundType, transform func(Value) Value) (DecodedABI, []CompoundType) {
+	mapSlice := func(values []Value) []Value {
+		if values == nil {
+			return nil
+		}
+		result := make([]Value, len(values))
+		for i, value := range values {
+			result[i] = transform(value)
+		}
+		return result
+	}
+
+	result := abi
+	result.Events = make([]EventItem, len(abi.Events))
+	for i, eventItem := range abi.Events {
+		result.Events[i] = eventItem
+		result.Events[i].Inputs = make([]EventArgument, len(eventItem.Inputs))
+		for j, input := range eventItem.Inputs {
+			result.Events[i].Inputs[j] = EventArgument{Value: transform(input.Value), Indexed: input.Indexed}
+		}
+	}
+	result.Functions = make([]FunctionItem, len(abi.Functions))
+	for i, functionItem := range abi.Functions {
+		result.Functions[i] = functionItem
+		result.Functions[i].Inputs = mapSlice(functionItem.Inputs)
+		result.Functions[i].Outputs = mapSlice(functionItem.Outputs)
+	}
+	result.Errors = make([]ErrorItem, len(abi.Errors))
+	for i, errorItem := range abi.Errors {
+		result.Errors[i] = errorItem
+		result.Errors[i].Inputs = mapSlice(errorItem.Inputs)
+	}
+
+	compounds := make([]CompoundType, len(compoundTypes))
+	for i, compound := range compoundTypes {
+		compounds[i] = compound
+		compounds[i].Members = make([]NamedValue, len(compound.Members))
+		for j, member := range compound.Members {
+			compounds[i].Members[j] = member
+			compounds[i].Members[j].Value = transform(member.Value)
+		}
+	}
+	return result, compounds
+}
@@ -0,0 +1,45 @@
+// Interface generated by solface: https://github.com/moonstream-to/solface
+// solface version: 0.2.3
+// Interface ID: 47e0e5cb
+interface IOwnableERC20 {
+	// structs
+
+	// events
+	event Approval(address indexed owner, address indexed spender, uint256 value);
+	event OwnershipTransferred(address indexed previousOwner, address indexed newOwner);
+	event Transfer(address indexed from, address indexed to, uint256 value);
+
+	// functions
+	// Selector: dd62ed3e
+	function allowance(address owner, address spender) external view returns (uint256);
+	// Selector: 095ea7b3
+	function approve(address spender, uint256 amount) external returns (bool);
+	// Selector: 70a08231
+	function balanceOf(address account) external view returns (uint256);
+	// Selector: 313ce567
+	function decimals() external view returns (uint8);
+	// Selector: a457c2d7
+	function decreaseAllowance(address spender, uint256 subtractedValue) external returns (bool);
+	// Selector: 39509351
+	function increaseAllowance(address spender, uint256 addedValue) external returns (bool);
+	// Selector: 40c10f19
+	function mint(address account, uint256 amount) external;
+	// Selector: 06fdde03
+	function name() external view returns (string memory);
+	// Selector: 8da5cb5b
+	function owner() external view returns (address);
+	// Selector: 715018a6
+	function renounceOwnership() external;
+	// Selector: 95d89b41
+	function symbol() external view returns (string memory);
+	// Selector: 18160ddd
+	function totalSupply() external view returns (uint256);
+	// Selector: a9059cbb
+	function transfer(address recipient, uint256 amount) external returns (bool);
+	// Selector: 23b872dd
+	function transferFrom(address sender, address recipient, uint256 amount) external returns (bool);
+	// Selector: f2fde38b
+	function transferOwnership(address newOwner) external;
+
+	// errors
+}
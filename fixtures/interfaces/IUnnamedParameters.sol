@@ -0,0 +1,18 @@
+// Interface generated by solface: https://github.com/moonstream-to/solface
+// solface version: 0.2.3
+// Interface ID: b6b55f25
+interface IUnnamedParameters {
+	// structs
+
+	// events
+	event Deposited(address indexed, uint256 amount);
+
+	// functions
+	// Selector: b6b55f25
+	function deposit(uint256) external returns (bool);
+
+	// errors
+	error InsufficientBalance(uint256, uint256);
+	error InvalidOrder(bytes32 orderHash, uint256);
+	error Paused();
+}
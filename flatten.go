@@ -0,0 +1,75 @@
+package solface
+
+import (
+	"strings"
+)
+
+// The prefix of SPDX license identifier comments.
+const spdxPrefix = "// SPDX-License-Identifier:"
+
+// Flattens the given Solidity sources (e.g. several generated interfaces) into a single source file, as
+// preferred by some verification and audit workflows. The license identifiers, pragmas, and imports at the
+// top of the sources are hoisted to the top of the file without duplicates, since compilers reject files
+// with several license identifiers. Distinct licenses are combined into a single SPDX expression (e.g.
+// "MIT AND GPL-3.0"). Stubs of contract types (see ContractTypesStub) are hoisted after them, without
+// duplicates and without the stubs of interfaces which the sources declare in full. The remaining contents
+// of the sources follow in order, separated by blank lines.
+func FlattenSolidity(sources []string) string {
+	var licenses, pragmas, imports, stubs, bodies []string
+	seen := map[string]bool{}
+	addUnique := func(list *[]string, line string) {
+		if !seen[line] {
+			seen[line] = true
+			*list = append(*list, line)
+		}
+	}
+
+	for _, source := range sources {
+		lines := strings.Split(strings.TrimRight(source, "\n"), "\n")
+		headerEnd := 0
+		for headerEnd < len(lines) {
+			line := strings.TrimSpace(lines[headerEnd])
+			if strings.HasPrefix(line, spdxPrefix) {
+				addUnique(&licenses, strings.TrimSpace(strings.TrimPrefix(line, spdxPrefix)))
+			} else if strings.HasPrefix(line, "pragma ") {
+				addUnique(&pragmas, line)
+			} else if strings.HasPrefix(line, "import ") {
+				addUnique(&imports, line)
+			} else if strings.HasPrefix(line, "interface ") && strings.HasSuffix(line, "{}") {
+				addUnique(&stubs, line)
+			} else if line != "" {
+				break
+			}
+			headerEnd++
+		}
+		if headerEnd < len(lines) {
+			bodies = append(bodies, strings.Join(lines[headerEnd:], "\n"))
+		}
+	}
+
+	remainingStubs := []string{}
+	for _, stub := range stubs {
+		declaration := strings.TrimSuffix(stub, "{}") + "{"
+		declared := false
+		for _, body := range bodies {
+			if strings.HasPrefix(body, declaration) || strings.Contains(body, "\n"+declaration) {
+				declared = true
+			}
+		}
+		if !declared {
+			remainingStubs = append(remainingStubs, stub)
+		}
+	}
+
+	sections := []string{}
+	if len(licenses) > 0 {
+		sections = append(sections, spdxPrefix+" "+strings.Join(licenses, " AND "))
+	}
+	for _, header := range [][]string{pragmas, imports, remainingStubs} {
+		if len(header) > 0 {
+			sections = append(sections, strings.Join(header, "\n"))
+		}
+	}
+	sections = append(sections, bodies...)
+	return strings.Join(sections, "\n\n") + "\n"
+}
@@ -0,0 +1,22 @@
+package solface
+
+import (
+	"testing"
+)
+
+func TestFlattenSolidity(t *testing.T) {
+	sources := []string{
+		"// SPDX-License-Identifier: MIT\n\npragma solidity ^0.8.0;\n\ninterface IA {\n}\n",
+		"// SPDX-License-Identifier: MIT\npragma solidity ^0.8.0;\npragma abicoder v2;\n\n// Interface B\ninterface IB {\n}\n",
+		"// SPDX-License-Identifier: GPL-3.0\n\ninterface IC {\n}\n",
+	}
+	expected := "// SPDX-License-Identifier: MIT AND GPL-3.0\n\npragma solidity ^0.8.0;\npragma abicoder v2;\n\ninterface IA {\n}\n\n// Interface B\ninterface IB {\n}\n\ninterface IC {\n}\n"
+	if flattened := FlattenSolidity(sources); flattened != expected {
+		t.Fatalf("Expected flattened source:\n%s\nActual:\n%s", expected, flattened)
+	}
+
+	expected = "interface IA {\n}\n"
+	if flattened := FlattenSolidity([]string{"interface IA {\n}\n"}); flattened != expected {
+		t.Fatalf("Expected source without header to be unchanged. Actual:\n%s", flattened)
+	}
+}
@@ -0,0 +1,82 @@
+package solface
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Determines the position of each ABI item type when items are sorted by FormatABI.
+var itemTypeOrder = map[string]int{
+	"constructor": 0,
+	"event":       1,
+	"error":       2,
+	"function":    3,
+	"fallback":    4,
+	"receive":     5,
+}
+
+// Returns the value of the given string field of a raw ABI item, or the empty string if the item does
+// not have that field.
+func stringField(item interface{}, field string) string {
+	object, ok := item.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := object[field].(string)
+	return value
+}
+
+// Rewrites the JSON representation of an ABI into a canonical form: object keys are sorted
+// alphabetically, indentation uses 2 spaces, and the output ends with a newline. Numbers and strings
+// are preserved exactly.
+// If sortItems is true, the items of the ABI are also sorted by type (constructor, events, errors,
+// functions, fallback, receive) and then by name. Items with the same type and name (overloads) keep
+// their original relative order.
+func FormatABI(rawJSON []byte, sortItems bool) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawJSON))
+	decoder.UseNumber()
+
+	var items []interface{}
+	decodeErr := decoder.Decode(&items)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after ABI array")
+	}
+
+	if sortItems {
+		sort.SliceStable(items, func(i, j int) bool {
+			iType, jType := stringField(items[i], "type"), stringField(items[j], "type")
+			iOrder, iKnown := itemTypeOrder[iType]
+			jOrder, jKnown := itemTypeOrder[jType]
+			if !iKnown {
+				iOrder = len(itemTypeOrder)
+			}
+			if !jKnown {
+				jOrder = len(itemTypeOrder)
+			}
+			if iOrder != jOrder {
+				return iOrder < jOrder
+			}
+			if iType != jType {
+				return iType < jType
+			}
+			return stringField(items[i], "name") < stringField(items[j], "name")
+		})
+	}
+
+	// encoding/json marshals map keys in sorted order, which gives us the stable key ordering.
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	encodeErr := encoder.Encode(items)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+
+	return output.Bytes(), nil
+}
@@ -0,0 +1,74 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatABI(t *testing.T) {
+	rawJSON := []byte(`[{"type":"function","name":"transfer","stateMutability":"nonpayable","outputs":[{"type":"bool","name":""}],"inputs":[{"type":"address","name":"to"},{"type":"uint256","name":"amount"}]},
+{"name": "Transfer", "type": "event", "anonymous": false, "inputs": [], "gas": 1e18}]`)
+
+	expectedUnsorted := `[
+  {
+    "inputs": [
+      {
+        "name": "to",
+        "type": "address"
+      },
+      {
+        "name": "amount",
+        "type": "uint256"
+      }
+    ],
+    "name": "transfer",
+    "outputs": [
+      {
+        "name": "",
+        "type": "bool"
+      }
+    ],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "anonymous": false,
+    "gas": 1e18,
+    "inputs": [],
+    "name": "Transfer",
+    "type": "event"
+  }
+]
+`
+
+	formatted, formatErr := FormatABI(rawJSON, false)
+	if formatErr != nil {
+		t.Fatalf("Could not format ABI: %s", formatErr.Error())
+	}
+	if string(formatted) != expectedUnsorted {
+		t.Fatalf("Unexpected formatted ABI. Expected:\n%s\nActual:\n%s", expectedUnsorted, string(formatted))
+	}
+
+	sorted, sortErr := FormatABI(rawJSON, true)
+	if sortErr != nil {
+		t.Fatalf("Could not format ABI: %s", sortErr.Error())
+	}
+	if strings.Index(string(sorted), `"type": "event"`) > strings.Index(string(sorted), `"type": "function"`) {
+		t.Fatalf("Expected events to be sorted before functions. Actual:\n%s", string(sorted))
+	}
+	reformatted, reformatErr := FormatABI(sorted, true)
+	if reformatErr != nil {
+		t.Fatalf("Could not format ABI: %s", reformatErr.Error())
+	}
+	if string(sorted) != string(reformatted) {
+		t.Fatal("Expected formatting to be idempotent")
+	}
+
+	decodedABI, decodeErr := Decode(sorted)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode formatted ABI: %s", decodeErr.Error())
+	}
+	if len(decodedABI.Events) != 1 || len(decodedABI.Functions) != 1 {
+		t.Fatalf("Formatted ABI lost items. Events: %d, functions: %d", len(decodedABI.Events), len(decodedABI.Functions))
+	}
+}
@@ -0,0 +1,70 @@
+// Package fourbyte looks up function signatures by selector in the 4byte.directory signature database, to
+// recover interfaces of contracts which are only known by their selectors.
+package fourbyte
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The 4byte.directory API endpoint for function signatures.
+const DefaultAPIURL = "https://www.4byte.directory/api/v1/signatures/"
+
+// The name of the signature database, for provenance comments.
+const Source = "4byte.directory"
+
+// Timeout for requests to the 4byte.directory API.
+var RequestTimeout = 30 * time.Second
+
+type signaturesResponse struct {
+	Results []struct {
+		ID            int    `json:"id"`
+		TextSignature string `json:"text_signature"`
+	} `json:"results"`
+}
+
+// Returns the function signatures with the given selector which are known to the 4byte.directory API at
+// apiURL (DefaultAPIURL if empty), oldest first. Since selectors collide, there may be several candidates -
+// the oldest submission is usually the real one, since collisions are typically submitted later (often
+// deliberately). Returns an empty list if the selector is not known. Requests are sent with transport, or
+// with http.DefaultTransport if it is nil.
+func FunctionSignatures(transport http.RoundTripper, apiURL string, selector [4]byte) ([]string, error) {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	requestURL, parseErr := url.Parse(apiURL)
+	if parseErr != nil {
+		return nil, fmt.Errorf("invalid API URL %s: %s", apiURL, parseErr.Error())
+	}
+	query := requestURL.Query()
+	query.Set("hex_signature", fmt.Sprintf("0x%x", selector))
+	requestURL.RawQuery = query.Encode()
+
+	client := http.Client{Timeout: RequestTimeout, Transport: transport}
+	response, requestErr := client.Get(requestURL.String())
+	if requestErr != nil {
+		return nil, requestErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("4byte.directory request failed with status %s", response.Status)
+	}
+
+	var decoded signaturesResponse
+	decodeErr := json.NewDecoder(response.Body).Decode(&decoded)
+	if decodeErr != nil {
+		return nil, fmt.Errorf("could not decode 4byte.directory response: %s", decodeErr.Error())
+	}
+	sort.SliceStable(decoded.Results, func(i, j int) bool { return decoded.Results[i].ID < decoded.Results[j].ID })
+
+	signatures := []string{}
+	for _, result := range decoded.Results {
+		signatures = append(signatures, strings.TrimSpace(result.TextSignature))
+	}
+	return signatures, nil
+}
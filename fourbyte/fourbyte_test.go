@@ -0,0 +1,32 @@
+package fourbyte
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFunctionSignatures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("hex_signature") != "0xa9059cbb" {
+			fmt.Fprint(w, `{"count": 0, "results": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"count": 2, "results": [{"id": 313067, "text_signature": "many_msg_babbage(bytes1)"}, {"id": 145, "text_signature": "transfer(address,uint256)"}]}`)
+	}))
+	defer server.Close()
+
+	signatures, lookupErr := FunctionSignatures(nil, server.URL, [4]byte{0xa9, 0x05, 0x9c, 0xbb})
+	if lookupErr != nil {
+		t.Fatalf("Error looking up signatures: %s", lookupErr.Error())
+	}
+	if len(signatures) != 2 || signatures[0] != "transfer(address,uint256)" {
+		t.Fatalf("Expected transfer(address,uint256) to be the first of 2 candidates. Actual: %v", signatures)
+	}
+
+	signatures, lookupErr = FunctionSignatures(nil, server.URL, [4]byte{0x01, 0x02, 0x03, 0x04})
+	if lookupErr != nil || len(signatures) != 0 {
+		t.Fatalf("Expected no signatures for unknown selector. Actual: %v (error: %v)", signatures, lookupErr)
+	}
+}
@@ -0,0 +1,90 @@
+package solface
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Returns true if the given string contains any of the special characters of glob patterns.
+func IsGlobPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// Returns true if the given slash-separated path matches the given slash-separated glob pattern. Patterns
+// follow path.Match, except that a "**" segment matches any number (including zero) of path segments, e.g.
+// "artifacts/**/*.json" matches both "artifacts/Token.json" and "artifacts/contracts/Token.sol/Token.json".
+func MatchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(patternSegments, nameSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(nameSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(nameSegments); i++ {
+			if matchSegments(patternSegments[1:], nameSegments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(nameSegments) == 0 {
+		return false
+	}
+	matched, matchErr := path.Match(patternSegments[0], nameSegments[0])
+	return matchErr == nil && matched && matchSegments(patternSegments[1:], nameSegments[1:])
+}
+
+// Returns the paths of the files matching the given glob pattern (see MatchGlob), sorted. Unlike
+// filepath.Glob, this supports "**" segments, so that a single pattern can cover an entire build output
+// tree regardless of the shell in use. Only the directory named by the segments of the pattern before the
+// first special character is searched.
+func Glob(pattern string) ([]string, error) {
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	segments := strings.Split(pattern, "/")
+	rootSegments := []string{}
+	for _, segment := range segments {
+		if IsGlobPattern(segment) {
+			break
+		}
+		rootSegments = append(rootSegments, segment)
+	}
+	root := strings.Join(rootSegments, "/")
+	if root == "" {
+		if strings.HasPrefix(pattern, "/") {
+			root = "/"
+		} else {
+			root = "."
+		}
+	}
+	for _, segment := range segments {
+		if _, matchErr := path.Match(segment, ""); matchErr != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %s", pattern, matchErr.Error())
+		}
+	}
+
+	matches := []string{}
+	walkErr := filepath.WalkDir(filepath.FromSlash(root), func(walkPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if MatchGlob(pattern, filepath.ToSlash(walkPath)) {
+			matches = append(matches, walkPath)
+		}
+		return nil
+	})
+	if walkErr != nil && !errors.Is(walkErr, fs.ErrNotExist) {
+		return nil, walkErr
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
@@ -0,0 +1,59 @@
+package solface
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"artifacts/**/*.json", "artifacts/Token.json", true},
+		{"artifacts/**/*.json", "artifacts/contracts/Token.sol/Token.json", true},
+		{"artifacts/**/*.json", "build/Token.json", false},
+		{"artifacts/*.json", "artifacts/contracts/Token.json", false},
+		{"**/Token.json", "Token.json", true},
+		{"abis/[A-Z]*.json", "abis/ERC20.json", true},
+	}
+	for _, testCase := range testCases {
+		if actual := MatchGlob(testCase.pattern, testCase.name); actual != testCase.expected {
+			t.Fatalf("Pattern: %s. Name: %s. Expected: %t. Actual: %t", testCase.pattern, testCase.name, testCase.expected, actual)
+		}
+	}
+}
+
+func TestGlob(t *testing.T) {
+	root := t.TempDir()
+	files := []string{"contracts/Token.sol/Token.json", "contracts/Token.sol/Token.dbg.json", "contracts/Vault.sol/Vault.json", "README.md"}
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if mkdirErr := os.MkdirAll(filepath.Dir(path), 0755); mkdirErr != nil {
+			t.Fatalf("Error creating directory: %s", mkdirErr.Error())
+		}
+		if writeErr := os.WriteFile(path, []byte("[]"), 0644); writeErr != nil {
+			t.Fatalf("Error writing file: %s", writeErr.Error())
+		}
+	}
+
+	matches, globErr := Glob(filepath.Join(root, "**", "*.json"))
+	if globErr != nil {
+		t.Fatalf("Error expanding pattern: %s", globErr.Error())
+	}
+	if len(matches) != 3 || matches[0] != filepath.Join(root, "contracts", "Token.sol", "Token.dbg.json") {
+		t.Fatalf("Expected 3 JSON files. Actual: %v", matches)
+	}
+
+	matches, globErr = Glob(filepath.Join(root, "missing", "**", "*.json"))
+	if globErr != nil || len(matches) != 0 {
+		t.Fatalf("Expected no matches for a missing directory. Actual: %v, %v", matches, globErr)
+	}
+
+	_, globErr = Glob(filepath.Join(root, "[*.json"))
+	if globErr == nil {
+		t.Fatal("Expected error expanding invalid pattern. Actual: nil")
+	}
+}
@@ -2,11 +2,15 @@ module github.com/moonstream-to/solface
 
 go 1.19
 
+require (
+	github.com/ethereum/go-ethereum v1.11.5
+	golang.org/x/crypto v0.1.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/btcsuite/btcd/btcec/v2 v2.2.0 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1 // indirect
-	github.com/ethereum/go-ethereum v1.11.5 // indirect
 	github.com/holiman/uint256 v1.2.0 // indirect
-	golang.org/x/crypto v0.1.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
 )
@@ -0,0 +1,183 @@
+package solface
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Represents a named identifier (a selector or topic) in a generated Go constants file.
+//  1. Name: The Go identifier.
+//  2. Signature: The signature the identifier was derived from.
+//  3. Bytes: The identifier itself.
+type GoConstant struct {
+	Name      string
+	Signature string
+	Bytes     []byte
+}
+
+// Represents the inputs to GoConstantsTemplate.
+type GoConstantsSpecification struct {
+	Package        string
+	SolfaceVersion string
+	Selectors      []GoConstant
+	Topics         []GoConstant
+}
+
+var GoConstantsTemplate string = `// Code generated by solface: https://github.com/moonstream-to/solface. DO NOT EDIT.
+// solface version: {{.SolfaceVersion}}
+
+package {{.Package}}
+{{- if .Topics}}
+
+import "github.com/ethereum/go-ethereum/common"
+{{- end}}
+{{- if .Selectors}}
+
+// Function selectors.
+var (
+{{- range .Selectors}}
+	// {{.Signature}}
+	{{.Name}} = [4]byte{ {{- range $i, $b := .Bytes}}{{if $i}}, {{end}}{{printf "0x%02x" $b}}{{end -}} }
+{{- end}}
+)
+{{- end}}
+{{- if .Topics}}
+
+// Event topics.
+var (
+{{- range .Topics}}
+	// {{.Signature}}
+	{{.Name}} = common.HexToHash("{{printf "0x%x" .Bytes}}")
+{{- end}}
+)
+{{- end}}
+`
+
+// Returns the Go package name for the given interface name, e.g. "iownableerc20" for "IOwnableERC20".
+func GoPackageName(name string) string {
+	packageName := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+	if packageName == "" || unicode.IsDigit(rune(packageName[0])) {
+		packageName = "abi" + packageName
+	}
+	return packageName
+}
+
+// Returns an exported Go identifier derived from the given ABI item name and suffix. Overloaded items
+// are disambiguated by the types of their parameters, e.g. "SafeTransferFromAddressAddressUint256".
+func goConstantName(name string, overloaded bool, argumentTypes []string, suffix string) string {
+	var builder strings.Builder
+	builder.WriteString(goIdentifierPart(name))
+	if overloaded {
+		if len(argumentTypes) == 0 {
+			builder.WriteString("NoArgs")
+		}
+		for _, argumentType := range argumentTypes {
+			argumentType = strings.NewReplacer("[]", "Array", "(", "Tuple", ")", "", ",", "").Replace(argumentType)
+			argumentType = strings.NewReplacer("[", "Array", "]", "").Replace(argumentType)
+			builder.WriteString(goIdentifierPart(argumentType))
+		}
+	}
+	builder.WriteString(suffix)
+	return builder.String()
+}
+
+// Capitalizes the first letter of the given string and drops any characters which may not appear in Go
+// identifiers.
+func goIdentifierPart(part string) string {
+	part = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, part)
+	if part == "" {
+		return part
+	}
+	runes := []rune(part)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// Generates a Go file declaring the selector of every function (as a [4]byte) and the topic of every
+// non-anonymous event (as a common.Hash) in the given ABI. Selectors are named <Function>Selector and
+// topics <Event>Topic. The package is options.GoPackage, or derived from options.Name if that is empty.
+func GenerateGoConstants(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	spec := GoConstantsSpecification{
+		Package:        options.GoPackage,
+		SolfaceVersion: VERSION,
+	}
+	if spec.Package == "" {
+		spec.Package = GoPackageName(options.Name)
+	}
+
+	functionCounts := map[string]int{}
+	for _, functionItem := range abi.Functions {
+		functionCounts[functionItem.Name]++
+	}
+	for _, functionItem := range abi.Functions {
+		argumentTypes := make([]string, len(functionItem.Inputs))
+		for i, input := range functionItem.Inputs {
+			argumentTypes[i] = CanonicalType(input)
+		}
+		spec.Selectors = append(spec.Selectors, GoConstant{
+			Name:      goConstantName(functionItem.Name, functionCounts[functionItem.Name] > 1, argumentTypes, "Selector"),
+			Signature: FunctionSignature(functionItem),
+			Bytes:     MethodSelectorWithHasher(functionItem, options.Hasher),
+		})
+	}
+
+	eventCounts := map[string]int{}
+	for _, eventItem := range abi.Events {
+		if !eventItem.Anonymous {
+			eventCounts[eventItem.Name]++
+		}
+	}
+	for _, eventItem := range abi.Events {
+		if eventItem.Anonymous {
+			continue
+		}
+		argumentTypes := make([]string, len(eventItem.Inputs))
+		for i, input := range eventItem.Inputs {
+			argumentTypes[i] = CanonicalType(input.Value)
+		}
+		spec.Topics = append(spec.Topics, GoConstant{
+			Name:      goConstantName(eventItem.Name, eventCounts[eventItem.Name] > 1, argumentTypes, "Topic"),
+			Signature: EventSignature(eventItem),
+			Bytes:     EventTopicWithHasher(eventItem, options.Hasher),
+		})
+	}
+
+	declared := map[string]string{}
+	for _, constant := range append(append([]GoConstant{}, spec.Selectors...), spec.Topics...) {
+		if signature, ok := declared[constant.Name]; ok {
+			return fmt.Errorf("%s and %s would both be declared as %s", signature, constant.Signature, constant.Name)
+		}
+		declared[constant.Name] = constant.Signature
+	}
+
+	templ, templateErr := template.New("go-constants").Parse(GoConstantsTemplate)
+	if templateErr != nil {
+		return templateErr
+	}
+	var source bytes.Buffer
+	executeErr := templ.Execute(&source, spec)
+	if executeErr != nil {
+		return executeErr
+	}
+	formatted, formatErr := format.Source(source.Bytes())
+	if formatErr != nil {
+		return formatErr
+	}
+	_, writeErr := writer.Write(formatted)
+	return writeErr
+}
@@ -0,0 +1,50 @@
+package solface
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenerateGoConstants(t *testing.T) {
+	rawABI := `[
+		{"type": "function", "name": "safeTransferFrom", "inputs": [{"name": "from", "type": "address"}, {"name": "to", "type": "address"}, {"name": "tokenId", "type": "uint256"}], "outputs": [], "stateMutability": "nonpayable"},
+		{"type": "function", "name": "safeTransferFrom", "inputs": [{"name": "from", "type": "address"}, {"name": "to", "type": "address"}, {"name": "tokenId", "type": "uint256"}, {"name": "data", "type": "bytes"}], "outputs": [], "stateMutability": "nonpayable"},
+		{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"},
+		{"type": "event", "name": "Transfer", "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "tokenId", "type": "uint256", "indexed": true}], "anonymous": false},
+		{"type": "event", "name": "Hidden", "inputs": [], "anonymous": true}
+	]`
+	abi, decodeErr := Decode([]byte(rawABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output bytes.Buffer
+	generateErr := GenerateGoConstants(abi, Annotations{}, Options{Name: "IERC721"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating Go constants: %s", generateErr.Error())
+	}
+
+	_, parseErr := parser.ParseFile(token.NewFileSet(), "constants.go", output.Bytes(), 0)
+	if parseErr != nil {
+		t.Fatalf("Expected generated file to be valid Go. Actual error: %s\n%s", parseErr.Error(), output.String())
+	}
+
+	expectedLines := []string{
+		"package ierc721\n",
+		"SafeTransferFromAddressAddressUint256Selector = [4]byte{0x42, 0x84, 0x2e, 0x0e}",
+		"SafeTransferFromAddressAddressUint256BytesSelector = [4]byte{0xb8, 0x8d, 0x4f, 0xde}",
+		"OwnerSelector = [4]byte{0x8d, 0xa5, 0xcb, 0x5b}",
+		"TransferTopic = common.HexToHash(\"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef\")",
+	}
+	for _, expected := range expectedLines {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain: %s\nActual:\n%s", expected, output.String())
+		}
+	}
+	if strings.Contains(output.String(), "Hidden") {
+		t.Fatalf("Expected no topic for anonymous event. Actual:\n%s", output.String())
+	}
+}
@@ -0,0 +1,62 @@
+package solface
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/sha3"
+)
+
+// Computes the hash of the given data. Selectors, event topics, and interface IDs are derived from the
+// hashes of signatures, so swapping the Hasher supports networks (or internal registries) which use a
+// different selector scheme than Ethereum.
+type Hasher interface {
+	Hash(data []byte) []byte
+}
+
+// Adapts an ordinary function to the Hasher interface.
+type HasherFunc func(data []byte) []byte
+
+func (f HasherFunc) Hash(data []byte) []byte {
+	return f(data)
+}
+
+// The Keccak-256 hasher used by Ethereum, which is the default.
+var Keccak256Hasher Hasher = HasherFunc(func(data []byte) []byte {
+	return crypto.Keccak256(data)
+})
+
+// The SHA3-256 hasher, as standardized in FIPS 202 (which differs from Keccak-256 in its padding).
+var SHA3256Hasher Hasher = HasherFunc(func(data []byte) []byte {
+	digest := sha3.Sum256(data)
+	return digest[:]
+})
+
+var hashers = map[string]Hasher{
+	"keccak256": Keccak256Hasher,
+	"sha3-256":  SHA3256Hasher,
+}
+
+// Returns the hasher with the given name ("keccak256" or "sha3-256"), and false if there is no such hasher.
+func GetHasher(name string) (Hasher, bool) {
+	hasher, ok := hashers[name]
+	return hasher, ok
+}
+
+// Returns the names of all built-in hashers, sorted alphabetically.
+func HasherNames() []string {
+	names := make([]string, 0, len(hashers))
+	for name := range hashers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the given hasher, or Keccak256Hasher if it is nil.
+func hasherOrDefault(hasher Hasher) Hasher {
+	if hasher == nil {
+		return Keccak256Hasher
+	}
+	return hasher
+}
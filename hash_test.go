@@ -0,0 +1,49 @@
+package solface
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestMethodSelectorWithHasher(t *testing.T) {
+	transfer := FunctionItem{Type: "function", Name: "transfer", Inputs: []Value{{Name: "to", Type: "address"}, {Name: "amount", Type: "uint256"}}}
+
+	testCases := []struct {
+		hasher   Hasher
+		expected string
+	}{
+		{nil, "a9059cbb"},
+		{Keccak256Hasher, "a9059cbb"},
+		{SHA3256Hasher, "4b40e901"},
+		{HasherFunc(func(data []byte) []byte { return []byte{1, 2, 3, 4, 5} }), "01020304"},
+	}
+
+	for i, testCase := range testCases {
+		actual := hex.EncodeToString(MethodSelectorWithHasher(transfer, testCase.hasher))
+		if actual != testCase.expected {
+			t.Fatalf("Case %d: Expected selector: %s. Actual: %s", i, testCase.expected, actual)
+		}
+	}
+}
+
+func TestAnnotateWithHasher(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "transfer", Inputs: []Value{{Name: "to", Type: "address"}, {Name: "amount", Type: "uint256"}}},
+	}}
+
+	annotations, annotateErr := AnnotateWithHasher(abi, SHA3256Hasher)
+	if annotateErr != nil {
+		t.Fatalf("Error annotating ABI: %s", annotateErr.Error())
+	}
+	if hex.EncodeToString(annotations.InterfaceID) != "4b40e901" {
+		t.Fatalf("Expected interface ID: 4b40e901. Actual: %x", annotations.InterfaceID)
+	}
+
+	hasher, ok := GetHasher("sha3-256")
+	if !ok {
+		t.Fatal("Expected hasher sha3-256 to exist")
+	}
+	if hex.EncodeToString(MethodSelectorWithHasher(abi.Functions[0], hasher)) != "4b40e901" {
+		t.Fatal("Expected GetHasher(\"sha3-256\") to return SHA3256Hasher")
+	}
+}
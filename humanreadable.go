@@ -0,0 +1,298 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Matches the parameter types of human-readable ABI fragments once tuples are parsed: a type name (e.g.
+// "uint256", "tuple", or "Token.Kind") followed by any number of array dimensions.
+var humanReadableTypeRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$.]*(\[[0-9]*\])*$`)
+
+// Represents a parameter parsed from a human-readable ABI fragment, in the JSON form of ABI values.
+type humanReadableValue struct {
+	Name       string               `json:"name"`
+	Type       string               `json:"type"`
+	Indexed    *bool                `json:"indexed,omitempty"`
+	Components []humanReadableValue `json:"components,omitempty"`
+}
+
+// Represents an ABI item parsed from a human-readable ABI fragment, in the JSON form of ABI items.
+type humanReadableItem struct {
+	Type            string               `json:"type"`
+	Name            string               `json:"name,omitempty"`
+	Inputs          []humanReadableValue `json:"inputs"`
+	Outputs         []humanReadableValue `json:"outputs,omitempty"`
+	StateMutability string               `json:"stateMutability,omitempty"`
+	Anonymous       *bool                `json:"anonymous,omitempty"`
+}
+
+// Returns the human-readable ABI fragments in the given input, and false if the input is not a
+// human-readable ABI. Human-readable ABIs are either JSON arrays of strings or plain text with one
+// fragment per line (blank lines and lines starting with "//" or "#" are ignored).
+func humanReadableFragments(rawInput []byte) ([]string, bool) {
+	trimmed := strings.TrimSpace(string(rawInput))
+	if trimmed == "" {
+		return nil, false
+	}
+
+	if strings.HasPrefix(trimmed, "[") {
+		var fragments []string
+		if json.Unmarshal([]byte(trimmed), &fragments) != nil || len(fragments) == 0 {
+			return nil, false
+		}
+		return fragments, true
+	} else if strings.HasPrefix(trimmed, "{") {
+		return nil, false
+	}
+
+	fragments := []string{}
+	for _, line := range strings.Split(trimmed, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fragments = append(fragments, line)
+	}
+	return fragments, true
+}
+
+// Converts human-readable ABI fragments (as accepted by ethers.js), e.g.
+//
+//	function transfer(address to, uint256 amount) returns (bool)
+//	event Transfer(address indexed from, address indexed to, uint256 value)
+//	error InsufficientBalance(uint256 available, uint256 required)
+//
+// into the JSON representation of the ABI. Tuples may be written either as "tuple(...)" or "(...)".
+func ParseHumanReadableABI(fragments []string) ([]byte, error) {
+	items := make([]humanReadableItem, len(fragments))
+	for i, fragment := range fragments {
+		item, parseErr := parseHumanReadableFragment(fragment)
+		if parseErr != nil {
+			return nil, fmt.Errorf("could not parse fragment %d (%q): %s", i, fragment, parseErr.Error())
+		}
+		items[i] = item
+	}
+	return json.Marshal(items)
+}
+
+// Parses a single human-readable ABI fragment.
+func parseHumanReadableFragment(fragment string) (humanReadableItem, error) {
+	var item humanReadableItem
+	fragment = strings.TrimSuffix(strings.TrimSpace(fragment), ";")
+
+	openIndex := strings.Index(fragment, "(")
+	if openIndex < 0 {
+		return item, fmt.Errorf("missing parameter list")
+	}
+	head := strings.Fields(fragment[:openIndex])
+	if len(head) == 0 || len(head) > 2 {
+		return item, fmt.Errorf("expected a keyword and a name before the parameter list")
+	}
+	item.Type = head[0]
+	switch {
+	case item.Type == "constructor" || item.Type == "fallback" || item.Type == "receive":
+		if len(head) == 2 {
+			return item, fmt.Errorf("unexpected name %q after %s", head[1], item.Type)
+		}
+	case len(head) == 2:
+		item.Name = head[1]
+	case item.Type == "function" || item.Type == "event" || item.Type == "error":
+		return item, fmt.Errorf("expected a name after %q", item.Type)
+	default:
+		// Fragments without a keyword are functions, as in ethers.js.
+		item.Type, item.Name = "function", head[0]
+	}
+	if item.Name != "" && !IsValidIdentifier(item.Name) {
+		return item, fmt.Errorf("%q is not a valid name", item.Name)
+	}
+
+	inputs, rest, inputsErr := parseHumanReadableParameterList(fragment[openIndex:])
+	if inputsErr != nil {
+		return item, inputsErr
+	}
+
+	switch item.Type {
+	case "event":
+		item.Inputs = inputs
+		anonymous := false
+		for _, modifier := range strings.Fields(rest) {
+			if modifier != "anonymous" {
+				return item, fmt.Errorf("unexpected %q after event parameters", modifier)
+			}
+			anonymous = true
+		}
+		item.Anonymous = &anonymous
+		for i := range item.Inputs {
+			if item.Inputs[i].Indexed == nil {
+				indexed := false
+				item.Inputs[i].Indexed = &indexed
+			}
+		}
+		return item, nil
+	case "error":
+		item.Inputs = inputs
+		if strings.TrimSpace(rest) != "" {
+			return item, fmt.Errorf("unexpected %q after error parameters", strings.TrimSpace(rest))
+		}
+	case "function", "constructor", "fallback", "receive":
+		item.Inputs = inputs
+		item.StateMutability = "nonpayable"
+		hasOutputs := false
+		for strings.TrimSpace(rest) != "" {
+			fields := strings.Fields(rest)
+			modifier := fields[0]
+			if modifier == "returns" || strings.HasPrefix(modifier, "returns(") {
+				if hasOutputs {
+					return item, fmt.Errorf("more than one returns clause")
+				}
+				outputs, afterOutputs, outputsErr := parseHumanReadableParameterList(strings.TrimPrefix(strings.TrimSpace(rest), "returns"))
+				if outputsErr != nil {
+					return item, outputsErr
+				}
+				item.Outputs, hasOutputs = outputs, true
+				rest = afterOutputs
+				continue
+			}
+			switch modifier {
+			case "view", "pure", "payable", "nonpayable":
+				item.StateMutability = modifier
+			case "constant":
+				item.StateMutability = "view"
+			case "external", "public":
+			default:
+				return item, fmt.Errorf("unexpected %q after %s parameters", modifier, item.Type)
+			}
+			rest = strings.TrimPrefix(strings.TrimSpace(rest), modifier)
+		}
+	default:
+		return item, fmt.Errorf("unknown fragment type %q", item.Type)
+	}
+
+	for i := range item.Inputs {
+		if item.Inputs[i].Indexed != nil {
+			return item, fmt.Errorf("only event parameters may be indexed")
+		}
+	}
+	return item, nil
+}
+
+// Parses a parenthesized parameter list at the start of the given string, returning the parameters and
+// the remainder of the string.
+func parseHumanReadableParameterList(input string) ([]humanReadableValue, string, error) {
+	input = strings.TrimSpace(input)
+	closeIndex, matchErr := matchingParenthesis(input)
+	if matchErr != nil {
+		return nil, "", matchErr
+	}
+
+	parameters := []humanReadableValue{}
+	for _, rawParameter := range splitTopLevel(input[1:closeIndex]) {
+		parameter, parameterErr := parseHumanReadableParameter(rawParameter)
+		if parameterErr != nil {
+			return nil, "", parameterErr
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters, input[closeIndex+1:], nil
+}
+
+// Parses a single parameter, e.g. "address indexed from" or "tuple(uint256 id, address owner)[] items".
+func parseHumanReadableParameter(input string) (humanReadableValue, error) {
+	var parameter humanReadableValue
+	input = strings.TrimSpace(input)
+
+	var rest string
+	if strings.HasPrefix(input, "(") || strings.HasPrefix(input, "tuple(") {
+		components, afterComponents, componentsErr := parseHumanReadableParameterList(strings.TrimPrefix(input, "tuple"))
+		if componentsErr != nil {
+			return parameter, componentsErr
+		}
+		parameter.Components = components
+		suffixEnd := 0
+		for suffixEnd < len(afterComponents) && strings.ContainsRune("[]0123456789", rune(afterComponents[suffixEnd])) {
+			suffixEnd++
+		}
+		parameter.Type = "tuple" + afterComponents[:suffixEnd]
+		rest = afterComponents[suffixEnd:]
+	} else {
+		fields := strings.Fields(input)
+		if len(fields) == 0 {
+			return parameter, fmt.Errorf("empty parameter")
+		}
+		parameter.Type = normalizeHumanReadableType(fields[0])
+		rest = strings.Join(fields[1:], " ")
+	}
+	if !humanReadableTypeRegexp.MatchString(parameter.Type) {
+		return parameter, fmt.Errorf("invalid type %q in parameter %q", parameter.Type, input)
+	}
+
+	for _, word := range strings.Fields(rest) {
+		switch word {
+		case "indexed":
+			indexed := true
+			parameter.Indexed = &indexed
+		case "memory", "calldata", "storage":
+		default:
+			if parameter.Name != "" || !IsValidIdentifier(word) {
+				return parameter, fmt.Errorf("unexpected %q in parameter %q", word, input)
+			}
+			parameter.Name = word
+		}
+	}
+	return parameter, nil
+}
+
+// Expands the aliases "uint" and "int" (including in array types) to their canonical 256-bit forms, and
+// writes array dimensions in canonical form (e.g. "uint256[][3]" for "uint[ ][3]").
+func normalizeHumanReadableType(parameterType string) string {
+	base, suffix := splitArrayDimensions(parameterType)
+	if base == "uint" || base == "int" {
+		base += "256"
+	}
+	return base + suffix
+}
+
+// Returns the index of the parenthesis which closes the one at the start of the given string.
+func matchingParenthesis(input string) (int, error) {
+	if !strings.HasPrefix(input, "(") {
+		return 0, fmt.Errorf("expected \"(\" at %q", input)
+	}
+	depth := 0
+	for i, r := range input {
+		if r == '(' {
+			depth++
+		} else if r == ')' {
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unbalanced parentheses in %q", input)
+}
+
+// Splits the given string on commas which are not nested inside parentheses. Returns nil for blank input.
+func splitTopLevel(input string) []string {
+	if strings.TrimSpace(input) == "" {
+		return nil
+	}
+	parts := []string{}
+	depth, start := 0, 0
+	for i, r := range input {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, input[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, input[start:])
+}
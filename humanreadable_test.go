@@ -0,0 +1,93 @@
+package solface
+
+import (
+	"testing"
+)
+
+func TestParseHumanReadableABI(t *testing.T) {
+	inputs := []string{
+		`function transfer(address to, uint amount) returns (bool)
+event Transfer(address indexed from, address indexed to, uint256 value)
+// comments and blank lines are ignored
+
+error InsufficientBalance(uint256 available, uint256 required)
+function orders(tuple(uint256 id, address[] owners)[] orders) view returns ((uint256 a, bytes b) result)
+function deposit() external payable`,
+		`["function transfer(address to, uint amount) returns (bool)", "event Transfer(address indexed from, address indexed to, uint256 value)", "error InsufficientBalance(uint256 available, uint256 required)", "function orders((uint256 id, address[] owners)[] orders) view returns (tuple(uint256 a, bytes b) result)", "function deposit() payable"]`,
+	}
+
+	for _, input := range inputs {
+		abi, decodeErr := Decode([]byte(input))
+		if decodeErr != nil {
+			t.Fatalf("Error decoding human-readable ABI: %s", decodeErr.Error())
+		}
+
+		if len(abi.Functions) != 3 || len(abi.Events) != 1 || len(abi.Errors) != 1 {
+			t.Fatalf("Expected 3 functions, 1 event, and 1 error. Actual: %d functions, %d events, %d errors", len(abi.Functions), len(abi.Events), len(abi.Errors))
+		}
+
+		expectedSignatures := []string{"transfer(address,uint256)", "orders((uint256,address[])[])", "deposit()"}
+		expectedMutabilities := []string{"nonpayable", "view", "payable"}
+		for i, functionItem := range abi.Functions {
+			if FunctionSignature(functionItem) != expectedSignatures[i] {
+				t.Fatalf("Expected signature: %s. Actual: %s", expectedSignatures[i], FunctionSignature(functionItem))
+			}
+			if functionItem.StateMutability != expectedMutabilities[i] {
+				t.Fatalf("Expected %s to be %s. Actual: %s", functionItem.Name, expectedMutabilities[i], functionItem.StateMutability)
+			}
+		}
+		if abi.Functions[0].Outputs[0].Type != "bool" || abi.Functions[1].Outputs[0].Name != "result" || len(abi.Functions[1].Outputs[0].Components) != 2 {
+			t.Fatalf("Expected outputs to be parsed. Actual: %v, %v", abi.Functions[0].Outputs, abi.Functions[1].Outputs)
+		}
+		if !abi.Events[0].Inputs[0].Indexed || abi.Events[0].Inputs[2].Indexed || abi.Events[0].Anonymous {
+			t.Fatalf("Expected from and to to be indexed. Actual: %v", abi.Events[0])
+		}
+		if abi.Errors[0].Inputs[1].Name != "required" {
+			t.Fatalf("Expected error input named required. Actual: %s", abi.Errors[0].Inputs[1].Name)
+		}
+	}
+
+	for _, invalid := range []string{"function broken(uint256", "function f(uint256 indexed x)", "event E(uint256) view", "modifier onlyOwner()"} {
+		_, decodeErr := Decode([]byte(invalid))
+		if decodeErr == nil {
+			t.Fatalf("Expected error decoding %q. Actual: nil", invalid)
+		}
+	}
+}
+
+func TestParseHumanReadableABIRejectsMalformedFragments(t *testing.T) {
+	for _, invalid := range []string{
+		"function f)(uint256)",
+		"function f(uint256))",
+		"function f((uint256 a)",
+		"function f(uint256( a))",
+		"function f((uint256 a)] x)",
+		"function f((uint256 a)[2]] x)",
+		"function f(uint256 a-b)",
+		"function f(uint256 a) returns (bool))",
+		"function f(uint256 a) returns (bool) garbage",
+		"function f(uint256 a) returns (bool) returns (bool)",
+		"function f(uint256 a) returnsx (bool)",
+		"function (uint256)",
+		"event (uint256)",
+		"event E(uint256 a)) anonymous",
+		"error E(uint256 a)) x",
+		"constructor foo(uint256)",
+	} {
+		_, parseErr := ParseHumanReadableABI([]string{invalid})
+		if parseErr == nil {
+			t.Fatalf("Expected error parsing %q. Actual: nil", invalid)
+		}
+	}
+
+	for _, valid := range []string{
+		"function f(uint256 a) returns(bool)",
+		"function f((uint256 a)[2][] x) view returns (bool)",
+		"constructor(uint256 supply)",
+		"balanceOf(address) view returns (uint256)",
+	} {
+		if _, parseErr := ParseHumanReadableABI([]string{valid}); parseErr != nil {
+			t.Fatalf("Expected %q to parse. Actual error: %s", valid, parseErr.Error())
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package solface
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Represents a parameter (or struct member) of an ABI item whose type is an integer narrower than 256
+// bits, e.g. uint48 or int24[].
+//  1. ItemType: One of "event", "function", or "error".
+//  2. ItemIndex: The position of the item in the corresponding array of the DecodedABI.
+//  3. Signature: The canonical signature of the item.
+//  4. Parameter: The path to the parameter, e.g. "order.startTime" for a struct member ("_N" is used
+//     for the Nth unnamed parameter or member).
+//  5. Type: The type of the parameter, including array suffixes.
+//  6. Bits: The width of the integer.
+//  7. Signed: Whether or not the integer is signed.
+//  8. Note: Explains how values of this type are encoded and what can go wrong.
+type IntegerWidthFinding struct {
+	ItemType  string `json:"itemType"`
+	ItemIndex int    `json:"itemIndex"`
+	Signature string `json:"signature"`
+	Parameter string `json:"parameter"`
+	Type      string `json:"type"`
+	Bits      int    `json:"bits"`
+	Signed    bool   `json:"signed"`
+	Note      string `json:"note"`
+}
+
+// Returns the width and signedness of the given integer type (ignoring array suffixes). The last return
+// value is false if the type is not an integer type.
+func integerWidth(solidityType string) (int, bool, bool) {
+	solidityType, _ = splitArrayDimensions(solidityType)
+
+	signed := strings.HasPrefix(solidityType, "int")
+	if !signed && !strings.HasPrefix(solidityType, "uint") {
+		return 0, false, false
+	}
+
+	widthString := strings.TrimPrefix(strings.TrimPrefix(solidityType, "u"), "int")
+	if widthString == "" {
+		return 256, signed, true
+	}
+	width, widthErr := strconv.Atoi(widthString)
+	if widthErr != nil {
+		return 0, false, false
+	}
+	return width, signed, true
+}
+
+// Explains how a sub-256-bit integer of the given width is ABI-encoded.
+func integerWidthNote(bits int, signed bool) string {
+	packing := fmt.Sprintf("occupies a full 32-byte word in ABI encoding (only %d bytes in storage and abi.encodePacked)", bits/8)
+	if signed {
+		return fmt.Sprintf("%s; must be sign-extended to 256 bits, so encoders which zero-pad negative values produce invalid data", packing)
+	}
+	return fmt.Sprintf("%s; values of 2^%d or more are rejected by the decoder", packing, bits)
+}
+
+// Appends a finding for every sub-256-bit integer in the given value (including struct members) to
+// findings.
+func integerWidthFindings(findings []IntegerWidthFinding, value Value, path string, template IntegerWidthFinding) []IntegerWidthFinding {
+	if bits, signed, ok := integerWidth(value.Type); ok && bits < 256 {
+		finding := template
+		finding.Parameter = path
+		finding.Type = value.Type
+		finding.Bits = bits
+		finding.Signed = signed
+		finding.Note = integerWidthNote(bits, signed)
+		findings = append(findings, finding)
+	}
+
+	for i, component := range value.Components {
+		componentName := component.Name
+		if componentName == "" {
+			componentName = fmt.Sprintf("_%d", i)
+		}
+		findings = integerWidthFindings(findings, component, fmt.Sprintf("%s.%s", path, componentName), template)
+	}
+	return findings
+}
+
+// Returns the path used to refer to the parameter at the given position in a report.
+func parameterPath(value Value, index int) string {
+	if value.Name == "" {
+		return fmt.Sprintf("_%d", index)
+	}
+	return value.Name
+}
+
+// Finds every parameter (and struct member) of every item in the ABI whose type is an integer narrower
+// than 256 bits. Integration teams need to know about these since their encoding is easy to get wrong
+// (e.g. forgetting to sign-extend an int24) and out-of-range values make calls revert.
+func IntegerWidthFindings(abi DecodedABI) []IntegerWidthFinding {
+	findings := []IntegerWidthFinding{}
+
+	for i, eventItem := range abi.Events {
+		template := IntegerWidthFinding{ItemType: "event", ItemIndex: i, Signature: EventSignature(eventItem)}
+		for j, input := range eventItem.Inputs {
+			findings = integerWidthFindings(findings, input.Value, parameterPath(input.Value, j), template)
+		}
+	}
+
+	for i, functionItem := range abi.Functions {
+		template := IntegerWidthFinding{ItemType: "function", ItemIndex: i, Signature: FunctionSignature(functionItem)}
+		for j, input := range functionItem.Inputs {
+			findings = integerWidthFindings(findings, input, parameterPath(input, j), template)
+		}
+		for j, output := range functionItem.Outputs {
+			findings = integerWidthFindings(findings, output, fmt.Sprintf("returns.%s", parameterPath(output, j)), template)
+		}
+	}
+
+	for i, errorItem := range abi.Errors {
+		template := IntegerWidthFinding{ItemType: "error", ItemIndex: i, Signature: ErrorSignature(errorItem)}
+		for j, input := range errorItem.Inputs {
+			findings = integerWidthFindings(findings, input, parameterPath(input, j), template)
+		}
+	}
+
+	return findings
+}
+
+// Returns, for each function in the ABI, the comment line summarizing its sub-256-bit integer parameters
+// (empty for functions without any).
+func integerWidthNotes(abi DecodedABI) []string {
+	parameters := make([][]string, len(abi.Functions))
+	for _, finding := range IntegerWidthFindings(abi) {
+		if finding.ItemType == "function" {
+			parameters[finding.ItemIndex] = append(parameters[finding.ItemIndex], fmt.Sprintf("%s %s", finding.Type, finding.Parameter))
+		}
+	}
+
+	notes := make([]string, len(abi.Functions))
+	for i, functionParameters := range parameters {
+		if len(functionParameters) > 0 {
+			notes[i] = fmt.Sprintf("// integer widths: %s (each value occupies a full 32-byte word when ABI-encoded; out-of-range values revert)", strings.Join(functionParameters, ", "))
+		}
+	}
+	return notes
+}
+
+// Writes a markdown report of the given integer width findings.
+func WriteIntegerWidthsMarkdown(findings []IntegerWidthFinding, writer io.Writer) error {
+	_, writeErr := fmt.Fprintf(writer, "# Sub-256-bit integers\n\n| Item | Signature | Parameter | Type | Note |\n| --- | --- | --- | --- | --- |\n")
+	if writeErr != nil {
+		return writeErr
+	}
+	for _, finding := range findings {
+		if _, writeErr := fmt.Fprintf(writer, "| %s | `%s` | `%s` | `%s` | %s |\n", finding.ItemType, finding.Signature, finding.Parameter, finding.Type, finding.Note); writeErr != nil {
+			return writeErr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package solface
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIntegerWidthFindingsUniswapV3Factory(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/UniswapV3Factory.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	findings := IntegerWidthFindings(abi)
+	var found bool
+	for _, finding := range findings {
+		if finding.Bits >= 256 {
+			t.Fatalf("Unexpected finding for a 256-bit integer: %v", finding)
+		}
+		if finding.Signature == "feeAmountTickSpacing(uint24)" && finding.Parameter == "returns._0" {
+			found = true
+			if finding.Type != "int24" || finding.Bits != 24 || !finding.Signed {
+				t.Fatalf("Expected a signed 24-bit finding. Actual: %v", finding)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("Expected a finding for the return value of feeAmountTickSpacing. Actual findings: %v", findings)
+	}
+}
+
+func TestIntegerWidthFindingsStructMembers(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "fill", StateMutability: "nonpayable", Inputs: []Value{
+			{Name: "order", Type: "tuple", Components: []Value{
+				{Name: "amount", Type: "uint256"},
+				{Name: "deadlines", Type: "uint48[]"},
+			}},
+		}},
+	}}
+
+	findings := IntegerWidthFindings(abi)
+	if len(findings) != 1 || findings[0].Parameter != "order.deadlines" || findings[0].Type != "uint48[]" || findings[0].Bits != 48 {
+		t.Fatalf("Expected a single finding for order.deadlines. Actual: %v", findings)
+	}
+
+	var output strings.Builder
+	err := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IFill", IntegerWidthAnnotations: true}, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+	expectedLine := "// integer widths: uint48[] order.deadlines"
+	if !strings.Contains(output.String(), expectedLine) {
+		t.Fatalf("Expected generated interface to contain line: %s. Actual interface:\n%s", expectedLine, output.String())
+	}
+}
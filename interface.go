@@ -0,0 +1,978 @@
+package solface
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Represents an ordered pair of array indices, the first index representing a position in the ABI array,
+// and the second index representing a position in the parameters array for that ABI item.
+type ItemValueIndex struct {
+	ItemIndex  int
+	ValueIndex int
+}
+
+// Represents a named parameter in an ABI item.
+//  1. Name: The name of the parameter.
+//  2. Value: The parameter itself.
+//  3. InternalTypePath: For members of compound types, the path of the member in the original source, built
+//     from the internalType of the struct it belongs to (e.g. "LibAppStorage.Listing.seller") - empty if
+//     the ABI does not name the struct or the member.
+type NamedValue struct {
+	Name             string `json:"name"`
+	Value            Value  `json:"value"`
+	InternalTypePath string `json:"internalTypePath,omitempty"`
+}
+
+// Represents a compound type.
+//  1. TypeName: The name of the struct generated for the compound type.
+//  2. Members: The members of the struct.
+//  3. OriginalName: The qualified name of the struct in the original source, from its internalType (e.g.
+//     "LibAppStorage.Listing") - empty if the ABI does not include it.
+//  4. BaseName: The name to which GenerateType appended a numeric suffix to form TypeName (e.g. "Vec3" for
+//     "Vec30", see ParseInternalType) - empty for compound types which ResolveCompounds did not name.
+type CompoundType struct {
+	TypeName     string       `json:"typeName"`
+	Members      []NamedValue `json:"members"`
+	OriginalName string       `json:"originalName,omitempty"`
+	BaseName     string       `json:"-"`
+}
+
+// Represents a decoded ABI along with the compound types that need to be defined in a Solidity interface
+// to a contract exposing that ABI.
+type DecodedABIWithCompundTypes struct {
+	OriginalABI   DecodedABI
+	CompoundTypes []CompoundType
+	EnrichedABI   DecodedABI
+}
+
+// Represents an entry in the body of a generated interface, after the struct definitions.
+//  1. ItemType: One of "event", "function", "error", or "section" (for section headings).
+//  2. Index: The position of the item in the corresponding array of the ABI being rendered.
+//  3. Comment: The heading (for sections) or the comment line (including the leading "//") to be
+//     generated before the item - if empty, no comment is generated.
+type InterfaceItem struct {
+	ItemType string
+	Index    int
+	Comment  string
+}
+
+// InterfaceSpecification specifies certain details about the Solidity interface that should be generated.
+//  1. Name: The name of the Solidity interface.
+//  2. ABI: The ABI that the interface is being generated for.
+//  3. Annotations: A list of annotations (interface ID, method selectors) for the interface.
+//  4. IncludeAnnotations: Whether or not to include the annotations in the generated interface.
+//  5. CompoundTypes: The compound types that need to be defined in the interface.
+//  6. SolfaceVersion: The version of solface that generated the interface.
+//  7. License: The SPDX license identifier to be generated at the top of the output - if empty, this
+//     will not be included.
+//  8. Pragma: The Solidity pragma to be generated at the top of the output - if empty, this will not
+//     be included.
+//  9. GeneratedAt: The generation time to be included in the header of the output - if empty, this will
+//     not be included.
+//  10. LintSuppressions: The lint-suppression comment lines to be generated before the interface
+//     declaration.
+//  11. FunctionNotes: For each function in the ABI, the comment lines (including the leading "//" or
+//     "///") to be generated immediately before its declaration.
+//  12. Codec: Whether or not to generate a library with encode/decode helpers for every compound type.
+//  13. CodecUsingDirectives: Whether or not to generate file-level "using ... for" directives attaching
+//     the codec library to the compound types (these require Solidity >= 0.8.13).
+//  14. Items: The order in which the events, functions, and errors of the ABI are rendered (see
+//     InterfaceItems).
+//  15. HeaderNotes: The comment lines (e.g. summaries) to be generated before the interface declaration.
+//  16. Deployments: The address constants to be generated in a library after the interface - if empty,
+//     the library will not be included.
+//  17. SpecialFunctions: The declarations of the fallback and receive functions to be generated at the
+//     end of the interface - if empty, they will not be included.
+//  18. EventNotes: For each event in the ABI, the comment lines to be generated immediately before its
+//     declaration.
+//  19. ErrorNotes: For each error in the ABI, the comment lines to be generated immediately before its
+//     declaration.
+//  20. StorageNamespaces: The ERC-7201 storage slot constants to be generated in a library after the
+//     interface - if empty, the library will not be included.
+//  21. ExtraPragmas: The pragmas (e.g. "abicoder v2") to be generated in order after the Solidity version
+//     pragma.
+//  22. ContractStubs: The names of the contract types for which empty interfaces are to be generated before
+//     the interface (see ContractTypesStub).
+//  23. UserDefinedValueTypes: The user-defined value types to be declared at the top of the interface (see
+//     Options.UserDefinedValueTypes).
+//  24. EIP712: The EIP-712 library to be generated after the interface (see Options.EIP712) - if nil, the
+//     library will not be included.
+type InterfaceSpecification struct {
+	Name                  string
+	ABI                   DecodedABI
+	Annotations           Annotations
+	IncludeAnnotations    bool
+	CompoundTypes         []CompoundType
+	SolfaceVersion        string
+	License               string
+	Pragma                string
+	GeneratedAt           string
+	LintSuppressions      []string
+	FunctionNotes         [][]string
+	Codec                 bool
+	CodecUsingDirectives  bool
+	Items                 []InterfaceItem
+	HeaderNotes           []string
+	Deployments           []DeploymentConstant
+	SpecialFunctions      []string
+	EventNotes            [][]string
+	ErrorNotes            [][]string
+	StorageNamespaces     []StorageNamespaceConstant
+	ExtraPragmas          []string
+	ContractStubs         []string
+	UserDefinedValueTypes []UserDefinedValueType
+	EIP712                *eip712Library
+}
+
+// Generates a fresh name for an anonymous attribute.
+func GenerateName(nameCounter *int) string {
+	result := fmt.Sprintf("Attribute%d", *nameCounter)
+	(*nameCounter) += 1
+	return result
+}
+
+// Parses the name of an internal type and either returns that name (for structs) or "Compound" (for
+// any other type).
+// For nested structs (e.g. structs defined in other contracts or interfaces), this only returns the
+// final component of the name. Array suffixes (e.g. "struct SpentItem[]") are dropped.
+func ParseInternalType(internalType string) string {
+	structQualifiedName := QualifiedStructName(internalType)
+	if structQualifiedName == "" {
+		return "Compound"
+	}
+
+	structNameComponents := strings.Split(structQualifiedName, ".")
+	structName := structNameComponents[len(structNameComponents)-1]
+	return structName
+}
+
+// Returns the qualified name of the struct named by an internal type, including the contracts or
+// libraries it is defined in (e.g. "LibAppStorage.Listing" for "struct LibAppStorage.Listing[]"), or the
+// empty string if the internal type does not name a struct by an identifier (see IsNamedInternalType).
+func QualifiedStructName(internalType string) string {
+	if !strings.HasPrefix(internalType, "struct ") || !IsNamedInternalType(internalType) {
+		return ""
+	}
+
+	structQualifiedName, _ := splitArrayDimensions(strings.TrimPrefix(internalType, "struct "))
+	return structQualifiedName
+}
+
+// Generates a fresh name for an anonymous compound type.
+func GenerateType(typeCounter *int, internalType string) string {
+	typeName := ParseInternalType(internalType)
+	result := fmt.Sprintf("%s%d", typeName, *typeCounter)
+	(*typeCounter) += 1
+	return result
+}
+
+// This function returns true if the given Solidity type requires a location modifier ("memory", "storage", "calldata")
+// when used as a function parameter or return value. These are arrays of any shape (e.g. "uint256[]",
+// "address[2]", or "bytes32[][3]"), string, bytes, and structs - all types other than elementary value types.
+func SolidityTypeRequiresLocation(solidityType string) bool {
+	element, dimensions, parseErr := ParseArrayType(solidityType)
+	if parseErr != nil {
+		return strings.ContainsAny(solidityType, "[]")
+	}
+	if len(dimensions) > 0 {
+		return true
+	}
+	return !isElementaryValueType(element)
+}
+
+// Returns true if the given type is an elementary value type, which is passed by value (e.g. "bool",
+// "uint64", "bytes32", or "address payable").
+func isElementaryValueType(solidityType string) bool {
+	switch solidityType {
+	case "bool", "address", "address payable", "uint", "int", "fixed", "ufixed", "function", "byte":
+		return true
+	}
+	return strictIntegerRegexp.MatchString(solidityType) || strictBytesRegexp.MatchString(solidityType) || strictFixedRegexp.MatchString(solidityType)
+}
+
+// Finds all the compound types that need to be defined in order to interface with a contract with the
+// given decoded ABI.
+//
+// Return values specify which items in the following arrays are compound types (by index):
+// 1. Event inputs
+// 2. Function inputs
+// 3. Function outputs
+// 4. Error inputs
+func FindCompoundTypes(abi DecodedABI) ([]ItemValueIndex, []ItemValueIndex, []ItemValueIndex, []ItemValueIndex) {
+	var eventInputs, functionInputs, functionOutputs, errorInputs []ItemValueIndex
+
+	eventInputs = make([]ItemValueIndex, 0)
+	for i, eventItem := range abi.Events {
+		for j, input := range eventItem.Inputs {
+			if input.IsCompoundType() {
+				eventInputs = append(eventInputs, ItemValueIndex{i, j})
+			}
+		}
+	}
+
+	functionInputs = make([]ItemValueIndex, 0)
+	functionOutputs = make([]ItemValueIndex, 0)
+	for i, functionItem := range abi.Functions {
+		for j, input := range functionItem.Inputs {
+			if input.IsCompoundType() {
+				functionInputs = append(functionInputs, ItemValueIndex{i, j})
+			}
+		}
+
+		for k, output := range functionItem.Outputs {
+			if output.IsCompoundType() {
+				functionOutputs = append(functionOutputs, ItemValueIndex{i, k})
+			}
+		}
+	}
+
+	errorInputs = make([]ItemValueIndex, 0)
+	for i, errorItem := range abi.Errors {
+		for j, input := range errorItem.Inputs {
+			if input.IsCompoundType() {
+				errorInputs = append(errorInputs, ItemValueIndex{i, j})
+			}
+		}
+	}
+
+	return eventInputs, functionInputs, functionOutputs, errorInputs
+}
+
+// Recursively creates the compound types required to represent the given value.
+// If the top-level compound type has members which are also compound types themselves, they will be
+// included in the second return value.
+// The first return value is a transformation of the original value represented using the new
+// compound types.
+func CompoundSingleValue(val Value, typeCounter, nameCounter *int) (Value, []CompoundType) {
+	newTypes := make([]CompoundType, 0)
+
+	// base case of recursion
+	if !val.IsCompoundType() {
+		return val, newTypes
+	}
+
+	var result Value
+	result.Name = val.Name
+
+	updatedComponents := make([]Value, 0)
+	for _, component := range val.Components {
+		subvalue, subTypes := CompoundSingleValue(component, typeCounter, nameCounter)
+		updatedComponents = append(updatedComponents, subvalue)
+		if len(subTypes) > 0 {
+			newTypes = append(newTypes, subTypes...)
+		}
+	}
+
+	var compound CompoundType
+	compound.TypeName = GenerateType(typeCounter, val.InternalType)
+	compound.BaseName = ParseInternalType(val.InternalType)
+	compound.OriginalName = QualifiedStructName(val.InternalType)
+	compound.Members = make([]NamedValue, len(updatedComponents))
+	for i, component := range updatedComponents {
+		memberName := component.Name
+		if memberName == "" && nameCounter != nil {
+			memberName = GenerateName(nameCounter)
+		}
+		compound.Members[i] = NamedValue{Name: memberName, Value: component}
+		if compound.OriginalName != "" && component.Name != "" {
+			compound.Members[i].InternalTypePath = fmt.Sprintf("%s.%s", compound.OriginalName, component.Name)
+		}
+	}
+	newTypes = append(newTypes, compound)
+
+	// Arrays of tuples keep their dimensions, e.g. "tuple[]", "tuple[3]", or "tuple[][2]".
+	_, dimensions := splitArrayDimensions(val.Type)
+	result.Type = compound.TypeName + dimensions
+
+	return result, newTypes
+}
+
+// Transitively resolves all compound types comprising the parameters and return values of all items
+// in the given decoded ABI, after checking that the compound types are acyclic and nested no more deeply
+// than options.MaxNestingDepth (see CheckNesting).
+func ResolveCompoundsWithOptions(abi DecodedABI, options Options) (DecodedABIWithCompundTypes, error) {
+	nestingErr := CheckNesting(abi, options.MaxNestingDepth)
+	if nestingErr != nil {
+		return DecodedABIWithCompundTypes{OriginalABI: abi}, nestingErr
+	}
+	return ResolveCompounds(abi), nil
+}
+
+// Transitively resolves all compound types comprising the parameters and return values of all items
+// in the given decoded ABI.
+// This does not check the ABI for cyclic compound types - use ResolveCompoundsWithOptions for ABIs which
+// were not decoded from JSON.
+func ResolveCompounds(abi DecodedABI) DecodedABIWithCompundTypes {
+	var typeCounter, nameCounter int
+
+	var result DecodedABIWithCompundTypes
+	result.OriginalABI = abi
+	result.EnrichedABI.Events = make([]EventItem, len(abi.Events))
+	result.EnrichedABI.Functions = make([]FunctionItem, len(abi.Functions))
+	result.EnrichedABI.Errors = make([]ErrorItem, len(abi.Errors))
+	result.EnrichedABI.Positions = abi.Positions
+	result.CompoundTypes = make([]CompoundType, 0)
+	// contexts[i] identifies the parameter which compound type i was created for, independently of the order
+	// of the items in the ABI.
+	contexts := make([]string, 0)
+	addTypes := func(newTypes []CompoundType, context string) {
+		result.CompoundTypes = append(result.CompoundTypes, newTypes...)
+		for range newTypes {
+			contexts = append(contexts, context)
+		}
+	}
+
+	for j, eventItem := range abi.Events {
+		newEventItem := EventItem{Type: eventItem.Type, Name: eventItem.Name, Anonymous: eventItem.Anonymous, Extras: eventItem.Extras}
+		newEventItem.Inputs = make([]EventArgument, len(eventItem.Inputs))
+		for i, inputEventArgument := range eventItem.Inputs {
+			newInputValue, newTypes := CompoundSingleValue(inputEventArgument.Value, &typeCounter, &nameCounter)
+			newEventArgument := EventArgument{Indexed: inputEventArgument.Indexed, Value: newInputValue}
+			newEventItem.Inputs[i] = newEventArgument
+			addTypes(newTypes, fmt.Sprintf("event %s input %d", EventSignature(eventItem), i))
+		}
+
+		result.EnrichedABI.Events[j] = newEventItem
+	}
+
+	for j, functionItem := range abi.Functions {
+		newFunctionItem := FunctionItem{Type: functionItem.Type, Name: functionItem.Name, StateMutability: functionItem.StateMutability, Constant: functionItem.Constant, Payable: functionItem.Payable, Extras: functionItem.Extras}
+		newFunctionItem.Inputs = make([]Value, len(functionItem.Inputs))
+		newFunctionItem.Outputs = make([]Value, len(functionItem.Outputs))
+
+		for i, value := range functionItem.Inputs {
+			newValue, newTypes := CompoundSingleValue(value, &typeCounter, &nameCounter)
+			newFunctionItem.Inputs[i] = newValue
+			addTypes(newTypes, fmt.Sprintf("function %s input %d", FunctionSignature(functionItem), i))
+		}
+
+		for i, value := range functionItem.Outputs {
+			newValue, newTypes := CompoundSingleValue(value, &typeCounter, nil)
+			newFunctionItem.Outputs[i] = newValue
+			addTypes(newTypes, fmt.Sprintf("function %s output %d", FunctionSignature(functionItem), i))
+		}
+
+		result.EnrichedABI.Functions[j] = newFunctionItem
+	}
+
+	for j, errorItem := range abi.Errors {
+		newErrorItem := ErrorItem{Type: errorItem.Type, Name: errorItem.Name, Extras: errorItem.Extras}
+		newErrorItem.Inputs = make([]Value, len(errorItem.Inputs))
+		for i, value := range errorItem.Inputs {
+			newValue, newTypes := CompoundSingleValue(value, &typeCounter, &nameCounter)
+			newErrorItem.Inputs[i] = newValue
+			addTypes(newTypes, fmt.Sprintf("error %s input %d", ErrorSignature(errorItem), i))
+		}
+
+		result.EnrichedABI.Errors[j] = newErrorItem
+	}
+
+	result.EnrichedABI, result.CompoundTypes = renumberCompoundTypes(result.EnrichedABI, result.CompoundTypes, contexts)
+	result.CompoundTypes = SortCompoundTypes(result.CompoundTypes)
+	return result
+}
+
+// Returns a key describing the structure of the given compound type - the names and types of its members,
+// with the members of compound types described by their own structure rather than by their generated names.
+func compoundTypeStructure(compound CompoundType, byName map[string]CompoundType) string {
+	members := make([]string, len(compound.Members))
+	for i, member := range compound.Members {
+		memberType, dimensions := splitArrayDimensions(member.Value.Type)
+		if memberCompound, ok := byName[memberType]; ok {
+			memberType = compoundTypeStructure(memberCompound, byName)
+		}
+		members[i] = fmt.Sprintf("%s%s %s", memberType, dimensions, member.Name)
+	}
+	return fmt.Sprintf("%s(%s)", compound.OriginalName, strings.Join(members, ","))
+}
+
+// Reassigns the numeric suffixes of the names of the given compound types (see GenerateType), which
+// ResolveCompounds generates in the order in which the types occur in the ABI, in the order of their names,
+// original names, structures, and the parameters they were created for (contexts[i] for type i). This keeps
+// the names of the generated structs stable when the items of an ABI are reordered. Returns the given ABI
+// and compound types with every reference to the compound types renamed.
+func renumberCompoundTypes(abi DecodedABI, compoundTypes []CompoundType, contexts []string) (DecodedABI, []CompoundType) {
+	byName := map[string]CompoundType{}
+	for _, compound := range compoundTypes {
+		byName[compound.TypeName] = compound
+	}
+	keys := make([][3]string, len(compoundTypes))
+	order := make([]int, len(compoundTypes))
+	for i, compound := range compoundTypes {
+		keys[i] = [3]string{compoundTypeBaseName(compound), compoundTypeStructure(compound, byName), contexts[i]}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		keyA, keyB := keys[order[a]], keys[order[b]]
+		for k := range keyA {
+			if keyA[k] != keyB[k] {
+				return keyA[k] < keyB[k]
+			}
+		}
+		return false
+	})
+
+	renames := map[string]string{}
+	for counter, i := range order {
+		renames[compoundTypes[i].TypeName] = fmt.Sprintf("%s%d", keys[i][0], counter)
+	}
+	rename := func(value Value) Value {
+		element, dimensions := splitArrayDimensions(value.Type)
+		if renamed, ok := renames[element]; ok {
+			value.Type = renamed + dimensions
+		}
+		return value
+	}
+	abi, compoundTypes = MapValues(abi, compoundTypes, rename)
+	for i := range compoundTypes {
+		compoundTypes[i].TypeName = renames[compoundTypes[i].TypeName]
+	}
+	return abi, compoundTypes
+}
+
+// Returns the name to which GenerateType appended the numeric suffix of the name of the given compound type.
+// Names of compound types without a recorded BaseName are assumed to end in their suffix.
+func compoundTypeBaseName(compound CompoundType) string {
+	if compound.BaseName != "" && strings.HasPrefix(compound.TypeName, compound.BaseName) {
+		return compound.BaseName
+	}
+	return strings.TrimRight(compound.TypeName, "0123456789")
+}
+
+// Returns true if the name of the given compound type sorts before that of the other - alphabetically,
+// except that the numeric suffixes of the names (see GenerateType) are compared as numbers, so that
+// "Order2" sorts before "Order10".
+func compoundTypeNameLess(a, b CompoundType) bool {
+	baseA, baseB := compoundTypeBaseName(a), compoundTypeBaseName(b)
+	if baseA != baseB {
+		return baseA < baseB
+	}
+	suffixA, suffixB := a.TypeName[len(baseA):], b.TypeName[len(baseB):]
+	if len(suffixA) != len(suffixB) {
+		return len(suffixA) < len(suffixB)
+	}
+	return suffixA < suffixB
+}
+
+// Sorts compound types so that every type is declared after the compound types of its members, and
+// otherwise by name (see compoundTypeNameLess). Together with the numbering of the names by
+// ResolveCompounds, this keeps the order of struct declarations stable when the items of an ABI are
+// reordered.
+func SortCompoundTypes(compoundTypes []CompoundType) []CompoundType {
+	byName := map[string]int{}
+	for i, compound := range compoundTypes {
+		byName[compound.TypeName] = i
+	}
+
+	// dependents[i] lists the types with a member of type i, and blockers[i] counts the distinct compound
+	// types that type i has members of.
+	dependents := make([][]int, len(compoundTypes))
+	blockers := make([]int, len(compoundTypes))
+	for i, compound := range compoundTypes {
+		seen := map[int]bool{}
+		for _, member := range compound.Members {
+			memberType, _ := splitArrayDimensions(member.Value.Type)
+			if j, ok := byName[memberType]; ok && j != i && !seen[j] {
+				seen[j] = true
+				dependents[j] = append(dependents[j], i)
+				blockers[i]++
+			}
+		}
+	}
+
+	ready := []int{}
+	for i := range compoundTypes {
+		if blockers[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	sorted := make([]CompoundType, 0, len(compoundTypes))
+	emitted := make([]bool, len(compoundTypes))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(a, b int) bool {
+			return compoundTypeNameLess(compoundTypes[ready[a]], compoundTypes[ready[b]])
+		})
+		next := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, compoundTypes[next])
+		emitted[next] = true
+		for _, dependent := range dependents[next] {
+			blockers[dependent]--
+			if blockers[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	// Cyclic types cannot be ordered - they are kept in their original order after all the other types.
+	for i, compound := range compoundTypes {
+		if !emitted[i] {
+			sorted = append(sorted, compound)
+		}
+	}
+	return sorted
+}
+
+// This is the Go template used to generate Solidity interfaces to contracts with a given ABI.
+// The template is meant to be applied to InterfaceSpecification structs.
+const InterfaceTemplate string = `{{- if .License -}}
+// SPDX-License-Identifier: {{.License}}
+
+{{ end }}
+{{- if .Pragma -}}
+pragma solidity {{.Pragma}};
+{{ end -}}
+{{- range .ExtraPragmas -}}
+pragma {{.}};
+{{ end -}}
+{{- if or .Pragma .ExtraPragmas }}
+{{ end -}}
+{{- range .ContractStubs -}}
+interface {{.}} {}
+
+{{ end -}}
+// Interface generated by solface: https://github.com/moonstream-to/solface
+// solface version: {{.SolfaceVersion}}
+{{- if .GeneratedAt}}
+// generated at: {{.GeneratedAt}}
+{{- end}}
+{{- $name := .Name}}
+{{- $includeAnnotations := .IncludeAnnotations}}
+{{- $annotations := .Annotations}}
+{{- $functionNotes := .FunctionNotes}}
+{{- $empty := not (or .UserDefinedValueTypes .CompoundTypes .Items .SpecialFunctions)}}
+{{ if $includeAnnotations -}}
+// Interface ID: {{printf "%x" .Annotations.InterfaceID}}
+{{ end -}}
+{{range .HeaderNotes}}{{.}}
+{{end -}}
+{{range .LintSuppressions}}{{.}}
+{{end -}}
+interface {{.Name}} {
+{{- if .UserDefinedValueTypes}}
+	// user-defined value types
+{{- range .UserDefinedValueTypes}}
+	type {{.Name}} is {{.Underlying}};
+{{- end}}
+{{ end}}
+{{- if not $empty}}
+	// structs
+{{- end}}
+{{- range .CompoundTypes}}
+	struct {{.TypeName}} {
+	{{- range .Members}}
+		{{.Value.Type}}{{with typeComment .Value}} {{.}}{{end}} {{.Name}};
+	{{- end}}
+	}
+{{- end}}
+
+{{- range $item := .Items}}
+{{- if eq $item.ItemType "section"}}
+
+	// {{$item.Comment}}
+{{- else}}
+{{- if $item.Comment}}
+	{{$item.Comment}}
+{{- end}}
+{{- if eq $item.ItemType "event"}}{{with index $.ABI.Events $item.Index}}
+{{- range index $.EventNotes $item.Index}}
+	{{.}}
+{{- end}}
+	event {{.Name}}({{- range $i, $input := .Inputs}}{{if $i}}, {{end}}{{.Type}}{{with typeComment .Value}} {{.}}{{end}}{{if .Indexed}} indexed{{end}}{{if .Name}} {{.Name}}{{end}}{{- end}}){{if .Anonymous}} anonymous{{end}};
+{{- end}}
+{{- else if eq $item.ItemType "function"}}{{with index $.ABI.Functions $item.Index}}
+	{{if $includeAnnotations -}}
+	// Selector: {{printf "%x" (index $annotations.FunctionSelectors $item.Index)}}
+	{{end -}}
+	{{range index $functionNotes $item.Index}}{{.}}
+	{{end -}}
+	{{renderFunction .}}
+{{- end}}
+{{- else if eq $item.ItemType "error"}}{{with index $.ABI.Errors $item.Index}}
+{{- range index $.ErrorNotes $item.Index}}
+	{{.}}
+{{- end}}
+	error {{.Name}}({{- range $i, $error := .Inputs}}{{if $i}}, {{end}}{{.Type}}{{with typeComment .}} {{.}}{{end}}{{if .Name}} {{.Name}}{{end}}{{- end}});
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .SpecialFunctions}}
+
+	// fallback and receive
+{{- range .SpecialFunctions}}
+	{{.}}
+{{- end}}
+{{- end}}
+{{- if not $empty}}
+{{end -}}
+}
+{{- if and .Codec .CompoundTypes}}
+
+library {{$name}}Codec {
+{{- range $i, $compound := .CompoundTypes}}
+{{- if $i}}
+{{end}}
+	function encode({{$name}}.{{.TypeName}} memory value) internal pure returns (bytes memory) {
+		return abi.encode(value);
+	}
+
+	function decode{{.TypeName}}(bytes memory data) internal pure returns ({{$name}}.{{.TypeName}} memory) {
+		return abi.decode(data, ({{$name}}.{{.TypeName}}));
+	}
+{{- end}}
+}
+{{- if .CodecUsingDirectives}}
+{{range .CompoundTypes}}
+using {{$name}}Codec for {{$name}}.{{.TypeName}};
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .EIP712}}
+
+library {{$name}}EIP712 {
+{{- range .EIP712.Constants}}
+	// {{.Type.EncodeType}}
+	bytes32 internal constant {{.Prefix}}_TYPEHASH = {{.Type.TypeHash}};
+	string internal constant {{.Prefix}}_WITNESS_TYPE_STRING = "{{.Type.WitnessTypeString}}";
+{{- end}}
+{{- range .EIP712.Functions}}
+
+{{.}}
+{{- end}}
+}
+{{- end}}
+{{- if .Deployments}}
+
+library {{$name}}Deployments {
+{{- range .Deployments}}
+	address internal constant {{.Name}} = {{.Address}};
+{{- end}}
+}
+{{- end}}
+{{- if .StorageNamespaces}}
+
+library {{$name}}Storage {
+{{- range .StorageNamespaces}}
+	// erc7201:{{.NamespaceID}}
+	bytes32 internal constant {{.Name}} = {{.Slot}};
+{{- end}}
+
+	// Returns the base storage slot of the ERC-7201 namespace with the given ID.
+	function erc7201Slot(string memory namespaceId) internal pure returns (bytes32) {
+		return keccak256(abi.encode(uint256(keccak256(bytes(namespaceId))) - 1)) & ~bytes32(uint256(0xff));
+	}
+}
+{{- end}}
+`
+
+// Returns the order in which the events, functions, and errors of the given ABI should be rendered in an
+// interface. By default, items are grouped into events, functions, and errors (each under a section
+// heading) in the order in which they appear in the ABI. If preserveABIOrder is set and the ABI records
+// the positions of its items, items are instead rendered in the order of the original ABI JSON array. If
+// indexComments is set, every item is preceded by a comment with its position in the original ABI JSON
+// array (when known).
+func InterfaceItems(abi DecodedABI, preserveABIOrder, indexComments bool) []InterfaceItem {
+	abiIndices := map[string]map[int]int{"event": {}, "function": {}, "error": {}}
+	for _, position := range abi.Positions {
+		abiIndices[position.ItemType][position.ItemIndex] = position.ABIIndex
+	}
+	newItem := func(itemType string, index int) InterfaceItem {
+		item := InterfaceItem{ItemType: itemType, Index: index}
+		if abiIndex, ok := abiIndices[itemType][index]; ok && indexComments {
+			item.Comment = fmt.Sprintf("// ABI index: %d", abiIndex)
+		}
+		return item
+	}
+
+	items := []InterfaceItem{}
+	// Interfaces without events, functions, and errors are rendered without empty section headings.
+	if len(abi.Events)+len(abi.Functions)+len(abi.Errors) == 0 {
+		return items
+	}
+	if preserveABIOrder && len(abi.Positions) == len(abi.Events)+len(abi.Functions)+len(abi.Errors) {
+		items = append(items, InterfaceItem{ItemType: "section", Comment: "events, functions, and errors (in ABI order)"})
+		for _, position := range abi.Positions {
+			items = append(items, newItem(position.ItemType, position.ItemIndex))
+		}
+		return items
+	}
+
+	items = append(items, InterfaceItem{ItemType: "section", Comment: "events"})
+	for i := range abi.Events {
+		items = append(items, newItem("event", i))
+	}
+	items = append(items, InterfaceItem{ItemType: "section", Comment: "functions"})
+	for i := range abi.Functions {
+		items = append(items, newItem("function", i))
+	}
+	items = append(items, InterfaceItem{ItemType: "section", Comment: "errors"})
+	for i := range abi.Errors {
+		items = append(items, newItem("error", i))
+	}
+	return items
+}
+
+// File-level "using ... for" directives were introduced in this Solidity version.
+var fileLevelUsingVersion = [3]int{0, 8, 13}
+
+// Generates a Solidity interface for the given ABI (with the given parameters).
+// The specification is generated by applying the specification to a Go template.
+// If the ABI contains items which cannot be expressed for the given pragma, nothing is written and an
+// *UnsupportedFeaturesError is returned.
+func GenerateInterface(interfaceName, license, pragma string, abi DecodedABI, annotations Annotations, includeAnnotations bool, writer io.Writer) error {
+	options := Options{Name: interfaceName, License: license, Pragma: pragma, IncludeAnnotations: includeAnnotations}
+	return GenerateInterfaceWithOptions(abi, annotations, options, writer)
+}
+
+// Represents an ABI after the transformations which the given options apply to it before an interface is
+// rendered (see transformABI).
+//  1. ABI: The ABI after library filtering (see Options.Kind), name conflict resolution, and assumed view
+//     functions, with the functions under their original names - notes refer to its items, whose
+//     selectors the annotations give.
+//  2. Annotations: The annotations of ABI.
+//  3. RenamedABI: ABI with renamed functions (see Options.FunctionRenames) and, in lite mode, with bytes in
+//     place of tuples - the items which the interface declares, at the same indices as in ABI.
+//  4. EnrichedABI: RenamedABI with compound types replaced by the names of the generated structs (see
+//     ResolveCompounds).
+//  5. CompoundTypes: The structs which the interface declares.
+//  6. OriginalNames: The original names of the renamed functions, by index.
+//  7. LibraryNotes, ConflictNotes, MutabilityNotes, LiteNotes, LiteHeaderNotes: The comment lines which
+//     the transformations document themselves with.
+type transformedABI struct {
+	ABI             DecodedABI
+	Annotations     Annotations
+	RenamedABI      DecodedABI
+	EnrichedABI     DecodedABI
+	CompoundTypes   []CompoundType
+	OriginalNames   map[int]string
+	LibraryNotes    []string
+	ConflictNotes   map[int]string
+	MutabilityNotes map[int]string
+	LiteNotes       [][]string
+	LiteHeaderNotes []string
+}
+
+// Applies the transformations configured by the given options to the given ABI (and its annotations), in
+// the order in which interfaces are generated from them: library filtering, name conflict resolution,
+// assumed view functions, support checks, renames, lite mode, and compound type resolution and pruning.
+// Every target which describes the generated interface (e.g. the intermediate representation) starts from
+// the result, so that it names items and structs as the interface does.
+func transformABI(abi DecodedABI, annotations Annotations, options Options) (transformedABI, error) {
+	var result transformedABI
+	if options.Kind == KindLibrary {
+		abi, result.LibraryNotes = LibraryABI(abi, options.Hasher)
+		// Functions which cannot be called on the library are dropped, so the annotations of the given ABI no
+		// longer line up with its functions.
+		annotations, _ = AnnotateWithHasher(abi, options.Hasher)
+	}
+	result.Annotations = annotations
+	var conflictErr error
+	abi, result.ConflictNotes, conflictErr = ResolveNameConflicts(abi, options.NameConflicts, options.Hasher)
+	if conflictErr != nil {
+		return result, conflictErr
+	}
+	var mutabilityErr error
+	abi, result.MutabilityNotes, mutabilityErr = AssumeView(abi, options.AssumeView)
+	if mutabilityErr != nil {
+		return result, mutabilityErr
+	}
+	result.ABI = abi
+
+	nestingErr := CheckNesting(abi, options.MaxNestingDepth)
+	if nestingErr != nil {
+		return result, nestingErr
+	}
+
+	supportErr := CheckSupport(abi, options.Pragma, options.ExtraPragmas)
+	if supportErr != nil {
+		return result, supportErr
+	}
+
+	var renameErr error
+	result.RenamedABI, result.OriginalNames, renameErr = ApplyRenames(abi, options.FunctionRenames)
+	if renameErr != nil {
+		return result, renameErr
+	}
+
+	if options.Lite {
+		// The selectors documented in lite mode are those of the original functions, not the renamed ones.
+		_, result.LiteNotes, result.LiteHeaderNotes = LiteABI(abi, options.Hasher)
+		result.RenamedABI, _, _ = LiteABI(result.RenamedABI, options.Hasher)
+	}
+
+	resolved, resolveErr := ResolveCompoundsWithOptions(result.RenamedABI, options)
+	if resolveErr != nil {
+		return result, resolveErr
+	}
+	result.EnrichedABI, result.CompoundTypes = resolved.EnrichedABI, PruneCompoundTypes(resolved.EnrichedABI, resolved.CompoundTypes)
+	return result, nil
+}
+
+// Generates a Solidity interface for the given ABI, as configured by the given options.
+// If the ABI contains items which cannot be expressed for the configured pragma, nothing is written and
+// an *UnsupportedFeaturesError is returned. Cyclic or too deeply nested compound types result in a
+// *CompoundCycleError or *NestingDepthError, and name conflicts (see Options.NameConflicts) in a
+// *NameConflictError if configured. Deployments whose chains name the same constant of the deployments
+// library result in an *OptionsError.
+func GenerateInterfaceWithOptions(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	if options.DeploymentsLibrary {
+		if collisions := deploymentConstantCollisions(options.Deployments); len(collisions) > 0 {
+			problems := make([]OptionsProblem, len(collisions))
+			for i, collision := range collisions {
+				problems[i] = OptionsProblem{Fields: []string{"Deployments"}, Message: collision}
+			}
+			return &OptionsError{Problems: problems}
+		}
+	}
+	transformed, transformErr := transformABI(abi, annotations, options)
+	if transformErr != nil {
+		return transformErr
+	}
+	abi, annotations, renamedABI, originalNames := transformed.ABI, transformed.Annotations, transformed.RenamedABI, transformed.OriginalNames
+	libraryNotes, conflictNotes, mutabilityNotes := transformed.LibraryNotes, transformed.ConflictNotes, transformed.MutabilityNotes
+	liteNotes, liteHeaderNotes := transformed.LiteNotes, transformed.LiteHeaderNotes
+	enrichedABI, compoundTypes := transformed.EnrichedABI, transformed.CompoundTypes
+	var eip712 *eip712Library
+	if options.EIP712 {
+		// Type strings use the types of the ABI, so they are derived before types are rewritten.
+		eip712 = buildEIP712Library(options.Name, EIP712Types(enrichedABI, compoundTypes), compoundTypes)
+	}
+	var userDefinedValueTypes []UserDefinedValueType
+	if options.UserDefinedValueTypes {
+		enrichedABI, compoundTypes, userDefinedValueTypes = ApplyUserDefinedValueTypes(enrichedABI, compoundTypes, options.Name)
+	}
+	enrichedABI, compoundTypes, contractStubs := ApplyContractTypes(enrichedABI, compoundTypes, options.ContractTypes, options.Name)
+	spec := InterfaceSpecification{
+		Name:                  options.Name,
+		ABI:                   enrichedABI,
+		Annotations:           annotations,
+		IncludeAnnotations:    options.IncludeAnnotations,
+		CompoundTypes:         compoundTypes,
+		SolfaceVersion:        VERSION,
+		License:               options.License,
+		Pragma:                options.Pragma,
+		ExtraPragmas:          options.ExtraPragmas,
+		ContractStubs:         contractStubs,
+		UserDefinedValueTypes: userDefinedValueTypes,
+		EIP712:                eip712,
+		FunctionNotes:         make([][]string, len(abi.Functions)),
+		EventNotes:            make([][]string, len(abi.Events)),
+		ErrorNotes:            make([][]string, len(abi.Errors)),
+		Codec:                 options.Codec,
+		Items:                 InterfaceItems(enrichedABI, options.PreserveABIOrder, options.IndexComments),
+	}
+	spec.CodecUsingDirectives = options.Codec && PragmaRequiresVersion(options.Pragma, fileLevelUsingVersion)
+	if !options.Timestamp.IsZero() {
+		spec.GeneratedAt = FormatGenerationTime(options.Timestamp)
+	}
+
+	for _, suppression := range options.LintSuppressions {
+		spec.LintSuppressions = append(spec.LintSuppressions, fmt.Sprintf("// %s", suppression))
+	}
+
+	for i := range abi.Functions {
+		if originalName, ok := originalNames[i]; ok {
+			spec.FunctionNotes[i] = append(spec.FunctionNotes[i], fmt.Sprintf("// original: %s", originalName))
+		}
+	}
+
+	if options.NatspecStubs {
+		for i, functionItem := range renamedABI.Functions {
+			// Functions documented by the devdoc (see PayableNotes) do not need stubs.
+			if options.PayableNotes && NormalizedStateMutability(functionItem) == "payable" && devdocNatspec(functionItem, options.Devdoc, options.Userdoc) != nil {
+				continue
+			}
+			spec.FunctionNotes[i] = append(spec.FunctionNotes[i], FunctionNatspecStub(functionItem)...)
+		}
+		for i, eventItem := range abi.Events {
+			spec.EventNotes[i] = EventNatspecStub(eventItem)
+		}
+		for i, errorItem := range abi.Errors {
+			spec.ErrorNotes[i] = ErrorNatspecStub(errorItem)
+		}
+	}
+
+	for i, note := range mutabilityNotes {
+		spec.FunctionNotes[i] = append(spec.FunctionNotes[i], note)
+	}
+
+	for i, note := range conflictNotes {
+		spec.ErrorNotes[i] = append(spec.ErrorNotes[i], note)
+	}
+
+	spec.HeaderNotes = append(spec.HeaderNotes, libraryNotes...)
+	if options.ConstructorComment && abi.Constructor != nil {
+		spec.HeaderNotes = append(spec.HeaderNotes, RenderConstructorComment(*abi.Constructor))
+	}
+	if options.SpecialFunctions {
+		for _, special := range specialFunctions(abi) {
+			spec.SpecialFunctions = append(spec.SpecialFunctions, RenderSpecialFunction(special))
+		}
+	}
+
+	if options.ProxyAddress != "" {
+		spec.HeaderNotes = append(spec.HeaderNotes, fmt.Sprintf("// Proxy: %s (this interface is generated from the ABI of its implementation)", options.ProxyAddress))
+	}
+	spec.HeaderNotes = append(spec.HeaderNotes, deploymentNotes(options.Deployments)...)
+	if options.DeploymentsLibrary {
+		spec.Deployments = deploymentConstants(options.Deployments)
+	}
+	spec.StorageNamespaces = storageNamespaceConstants(options.StorageNamespaces)
+
+	if options.PayableNotes {
+		spec.HeaderNotes = append(spec.HeaderNotes, payableSummary(abi)...)
+		for i, functionItem := range abi.Functions {
+			if NormalizedStateMutability(functionItem) == "payable" {
+				spec.FunctionNotes[i] = append(spec.FunctionNotes[i], devdocNatspec(functionItem, options.Devdoc, options.Userdoc)...)
+			}
+		}
+	}
+
+	if options.SecurityAnnotations {
+		for _, finding := range SecurityFindings(abi) {
+			note := fmt.Sprintf("/// @custom:security %s: %s", finding.Capability, finding.Description)
+			spec.FunctionNotes[finding.FunctionIndex] = append(spec.FunctionNotes[finding.FunctionIndex], note)
+		}
+	}
+
+	if options.IntegerWidthAnnotations {
+		for i, note := range integerWidthNotes(abi) {
+			if note != "" {
+				spec.FunctionNotes[i] = append(spec.FunctionNotes[i], note)
+			}
+		}
+	}
+
+	for i, notes := range liteNotes {
+		spec.FunctionNotes[i] = append(spec.FunctionNotes[i], notes...)
+	}
+	spec.HeaderNotes = append(spec.HeaderNotes, liteHeaderNotes...)
+
+	// Next-line lint suppressions must immediately precede the declarations they apply to.
+	for i, functionItem := range renamedABI.Functions {
+		if !IsMixedCase(functionItem.Name) {
+			for _, suppression := range options.MemberLintSuppressions {
+				spec.FunctionNotes[i] = append(spec.FunctionNotes[i], fmt.Sprintf("// %s", suppression))
+			}
+		}
+	}
+
+	templateFuncs := map[string]any{
+		"renderFunction": RenderFunction,
+		"typeComment":    typeComment,
+	}
+
+	templ, templateParseErr := template.New("solface").Funcs(templateFuncs).Parse(InterfaceTemplate)
+	if templateParseErr != nil {
+		return templateParseErr
+	}
+	templateExecutionErr := templ.Execute(writer, spec)
+
+	return templateExecutionErr
+}
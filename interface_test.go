@@ -0,0 +1,662 @@
+package solface
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestFindCompoundTypesOnDiamondCutFacetABI(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/DiamondCutFacet.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	eventInputs, functionInputs, functionOutputs, errorInputs := FindCompoundTypes(abi)
+
+	expectedEventInputs := []ItemValueIndex{{0, 0}}
+	expectedFunctionInputs := []ItemValueIndex{{0, 0}}
+	expectedFunctionOutputs := []ItemValueIndex{}
+	expectedErrorInputs := []ItemValueIndex{}
+
+	if !reflect.DeepEqual(eventInputs, expectedEventInputs) {
+		t.Fatal("Actual indices of compound event inputs did not match expectation")
+	}
+	if !reflect.DeepEqual(functionInputs, expectedFunctionInputs) {
+		t.Fatal("Actual indices of compound function inputs did not match expectation")
+	}
+	if !reflect.DeepEqual(functionOutputs, expectedFunctionOutputs) {
+		t.Fatal("Actual indices of compound function outputs did not match expectation")
+	}
+	if !reflect.DeepEqual(errorInputs, expectedErrorInputs) {
+		t.Fatal("Actual indices of compound error inputs did not match expectation")
+	}
+}
+
+func TestCompoundSingleValue(t *testing.T) {
+	originalValue := Value{Name: "lol", Type: "tuple()", Components: []Value{
+		{Name: "rofl", Type: "uint256", Components: []Value{}},
+		{Name: "omg", Type: "address", Components: []Value{}},
+	}}
+
+	var typeCounter, nameCounter int
+
+	compound, newTypes := CompoundSingleValue(originalValue, &typeCounter, &nameCounter)
+
+	if compound.Name != originalValue.Name {
+		t.Fatalf("Incorrect name for compound type. Expected: %s, actual: %s", originalValue.Name, compound.Name)
+	}
+
+	if len(newTypes) != 1 {
+		t.Fatalf("Expected 1 new types. Got: %d", len(newTypes))
+	}
+
+	actualNewType := newTypes[0]
+	if len(actualNewType.Members) != 2 {
+		t.Fatalf("Expected 2 members in new type. Got: %d", len(actualNewType.Members))
+	}
+}
+
+func TestCompoundSingleValueDeep(t *testing.T) {
+	originalValue := Value{Name: "lol", Type: "tuple()", Components: []Value{
+		{Name: "rofl", Type: "uint256", Components: []Value{}},
+		{Name: "omg", Type: "tuple()", Components: []Value{
+			{Name: "wtf", Type: "address", Components: []Value{}},
+			{Name: "bbq", Type: "uint256", Components: []Value{}},
+		}},
+	}}
+
+	var typeCounter, nameCounter int
+
+	compound, newTypes := CompoundSingleValue(originalValue, &typeCounter, &nameCounter)
+
+	if compound.Name != originalValue.Name {
+		t.Fatalf("Incorrect name for compound type. Expected: %s, actual: %s", originalValue.Name, compound.Name)
+	}
+
+	if len(newTypes) != 2 {
+		t.Fatalf("Expected 2 new types. Got: %d", len(newTypes))
+	}
+
+	typeIndex := map[string]*CompoundType{}
+	for _, newType := range newTypes {
+		typeIndex[newType.TypeName] = &newType
+	}
+}
+
+func TestResolveCompoundsDiamondCutFacet(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/DiamondCutFacet.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	oldEventInputs, oldFunctionInputs, oldFunctionOutputs, oldErrorInputs := FindCompoundTypes(abi)
+	if len(oldEventInputs) != 1 {
+		t.Fatalf("Expected 1 compound event inputs. Actual: %d", len(oldEventInputs))
+	}
+	if len(oldFunctionInputs) != 1 {
+		t.Fatalf("Expected 1 compound oldFunction inputs. Actual: %d", len(oldFunctionInputs))
+	}
+	if len(oldFunctionOutputs) != 0 {
+		t.Fatalf("Expected 0 compound oldFunction outputs. Actual: %d", len(oldFunctionOutputs))
+	}
+	if len(oldErrorInputs) != 0 {
+		t.Fatalf("Expected 0 compound oldError inputs. Actual: %d", len(oldErrorInputs))
+	}
+
+	enrichedABI := ResolveCompounds(abi)
+
+	if len(enrichedABI.CompoundTypes) != 2 {
+		t.Fatalf("Expected 2 compound types. Actual: %d", len(enrichedABI.CompoundTypes))
+	}
+
+	eventInputs, functionInputs, functionOutputs, errorInputs := FindCompoundTypes(enrichedABI.EnrichedABI)
+	if len(eventInputs) != 0 {
+		t.Fatalf("Expected 0 compound event inputs. Actual: %d", len(eventInputs))
+	}
+	if len(functionInputs) != 0 {
+		t.Fatalf("Expected 0 compound function inputs. Actual: %d", len(functionInputs))
+	}
+	if len(functionOutputs) != 0 {
+		t.Fatalf("Expected 0 compound function outputs. Actual: %d", len(functionOutputs))
+	}
+	if len(errorInputs) != 0 {
+		t.Fatalf("Expected 0 compound error inputs. Actual: %d", len(errorInputs))
+	}
+}
+
+func TestGenerateInterfaceDiamondCutFacet(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/DiamondCutFacet.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var annotations Annotations
+	includeAnnotations := false
+
+	// Replace io.Discard with os.Stdout to inspect output:
+	// err := GenerateInterface("IDiamondCutFacet", "", "", abi, annotations, includeAnnotations, os.Stdout)
+	err := GenerateInterface("IDiamondCutFacet", "", "", abi, annotations, includeAnnotations, io.Discard)
+
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+}
+
+func TestGenerateInterfaceOwnableERC20(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var annotations Annotations
+	includeAnnotations := false
+
+	// Replace io.Discard with os.Stdout to inspect output:
+	// err := GenerateInterface("IOwnableERC20", "Apache-2.0", "^8.20.0", abi, annotations, includeAnnotations, os.Stdout)
+	err := GenerateInterface("IOwnableERC20", "Apache-2.0", "^8.20.0", abi, annotations, includeAnnotations, io.Discard)
+
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+}
+
+func TestGenerateInterfaceUniswapV3Factory(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/UniswapV3Factory.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var annotations Annotations
+	includeAnnotations := false
+
+	// Replace io.Discard with os.Stdout to inspect output:
+	// err := GenerateInterface("IUniswapV3Factory", "UNLICENSED", "^8.20.0", abi, annotations, includeAnnotations, os.Stdout)
+	err := GenerateInterface("IUniswapV3Factory", "UNLICENSED", "^8.20.0", abi, annotations, includeAnnotations, io.Discard)
+
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+}
+
+func TestGenerateInterfaceSeaportEvents(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/Seaport.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	err := GenerateInterface("ISeaport", "", "", abi, Annotations{}, false, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+
+	expectedLines := []string{
+		"struct SpentItem7 {",
+		"struct ReceivedItem6 {",
+		"event CounterIncremented(uint256 newCounter, address indexed offerer);",
+		"event OrderCancelled(bytes32 orderHash, address indexed offerer, address indexed zone);",
+		"event OrderFulfilled(bytes32 orderHash, address indexed offerer, address indexed zone, address recipient, SpentItem7[] offer, ReceivedItem6[] consideration);",
+		"event OrderValidated(bytes32 orderHash, OrderParameters5 orderParameters);",
+	}
+	for _, expectedLine := range expectedLines {
+		if !strings.Contains(output.String(), expectedLine) {
+			t.Fatalf("Expected generated interface to contain line: %s. Actual interface:\n%s", expectedLine, output.String())
+		}
+	}
+}
+
+func TestGenerateInterfaceAnonymousEvent(t *testing.T) {
+	abi := DecodedABI{Events: []EventItem{
+		{Type: "event", Name: "Log", Anonymous: true, Inputs: []EventArgument{
+			{Value: Value{Name: "topic", Type: "bytes32"}, Indexed: true},
+			{Value: Value{Name: "", Type: "uint256"}, Indexed: false},
+		}},
+	}}
+
+	var output strings.Builder
+	err := GenerateInterface("ILog", "", "", abi, Annotations{}, false, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+
+	expectedLine := "event Log(bytes32 indexed topic, uint256) anonymous;"
+	if !strings.Contains(output.String(), expectedLine) {
+		t.Fatalf("Expected generated interface to contain line: %s. Actual interface:\n%s", expectedLine, output.String())
+	}
+}
+
+func TestGenerateInterfaceProxyNote(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{{Type: "function", Name: "upgradeTo", Inputs: []Value{{Name: "implementation", Type: "address"}}, StateMutability: "nonpayable"}}}
+
+	var output strings.Builder
+	err := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IVault", ProxyAddress: "0xcA11bde05977b3631167028862bE2a173976CA11"}, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+
+	expectedLine := "// Proxy: 0xcA11bde05977b3631167028862bE2a173976CA11 (this interface is generated from the ABI of its implementation)\ninterface IVault {"
+	if !strings.Contains(output.String(), expectedLine) {
+		t.Fatalf("Expected generated interface to contain: %s. Actual interface:\n%s", expectedLine, output.String())
+	}
+}
+
+func TestParseInternalTypeStructArrays(t *testing.T) {
+	actual := ParseInternalType("struct ConsiderationItem[]")
+	if actual != "ConsiderationItem" {
+		t.Fatalf("Expected type name: ConsiderationItem. Actual: %s", actual)
+	}
+}
+
+func TestGenerateInterfaceCodec(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/DiamondCutFacet.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	err := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IDiamondCutFacet", Pragma: "^0.8.13", Codec: true}, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+
+	expectedLines := []string{
+		"library IDiamondCutFacetCodec {",
+		"function encode(IDiamondCutFacet.FacetCut0 memory value) internal pure returns (bytes memory) {",
+		"function decodeFacetCut0(bytes memory data) internal pure returns (IDiamondCutFacet.FacetCut0 memory) {",
+		"return abi.decode(data, (IDiamondCutFacet.FacetCut0));",
+		"using IDiamondCutFacetCodec for IDiamondCutFacet.FacetCut0;",
+	}
+	for _, expectedLine := range expectedLines {
+		if !strings.Contains(output.String(), expectedLine) {
+			t.Fatalf("Expected generated interface to contain line: %s. Actual interface:\n%s", expectedLine, output.String())
+		}
+	}
+
+	// File-level using directives are not available before Solidity 0.8.13, which ^0.8.0 admits.
+	for _, pragma := range []string{"0.8.10", "^0.8.0"} {
+		output.Reset()
+		err = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IDiamondCutFacet", Pragma: pragma, Codec: true}, &output)
+		if err != nil {
+			t.Fatalf("Error generating interface: %s", err.Error())
+		}
+		if !strings.Contains(output.String(), "library IDiamondCutFacetCodec {") || strings.Contains(output.String(), "using ") {
+			t.Fatalf("Expected a codec library without using directives (pragma: %s). Actual interface:\n%s", pragma, output.String())
+		}
+	}
+}
+
+func TestGenerateInterfacePreserveABIOrder(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/DiamondCutFacet.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	err := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IDiamondCutFacet", PreserveABIOrder: true, IndexComments: true}, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+
+	// The error comes first in the ABI, followed by the event and then the function.
+	expectedBody := `	// ABI index: 0
+	error InitializationFunctionReverted(address _initializationContractAddress, bytes _calldata);
+	// ABI index: 1
+	event DiamondCut(FacetCut0[] _diamondCut, address _init, bytes _calldata);
+	// ABI index: 2
+	function diamondCut(`
+	if !strings.Contains(output.String(), expectedBody) {
+		t.Fatalf("Expected generated interface to contain:\n%s\nActual interface:\n%s", expectedBody, output.String())
+	}
+}
+
+func TestInterfaceItemsAfterRemoval(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "register", "inputs": [{"name": "callback", "type": "function"}], "outputs": [], "stateMutability": "nonpayable"},
+		{"type": "event", "name": "Registered", "inputs": [], "anonymous": false},
+		{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	pruned, _ := RemoveUnsupported(abi, []UnsupportedItem{{ItemType: "function", ItemIndex: 0, Name: "register"}})
+	items := InterfaceItems(pruned, true, true)
+	expectedItems := []InterfaceItem{
+		{ItemType: "section", Comment: "events, functions, and errors (in ABI order)"},
+		{ItemType: "event", Index: 0, Comment: "// ABI index: 1"},
+		{ItemType: "function", Index: 0, Comment: "// ABI index: 2"},
+	}
+	if !reflect.DeepEqual(items, expectedItems) {
+		t.Fatalf("Expected items: %v. Actual: %v", expectedItems, items)
+	}
+}
+
+// Checks that the interface generated (with annotations) for the ABI in fixtures/abis/<abiFile> matches
+// fixtures/interfaces/<name>.sol.
+func checkGoldenInterface(t *testing.T, abiFile, name string) {
+	contents, readErr := os.ReadFile("fixtures/abis/" + abiFile)
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+	expected, readErr := os.ReadFile("fixtures/interfaces/" + name + ".sol")
+	if readErr != nil {
+		t.Fatal("Could not read file containing expected interface")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	annotations, annotationErr := Annotate(abi)
+	if annotationErr != nil {
+		t.Fatalf("Error annotating ABI: %s", annotationErr.Error())
+	}
+
+	var output strings.Builder
+	err := GenerateInterface(name, "", "", abi, annotations, true, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+	// The solface version changes with every release.
+	actual := strings.Replace(output.String(), fmt.Sprintf("solface version: %s", VERSION), "solface version: 0.2.3", 1)
+	if actual != string(expected) {
+		t.Fatalf("Generated interface does not match fixtures/interfaces/%s.sol. Actual interface:\n%s", name, actual)
+	}
+}
+
+func TestGenerateInterfaceOwnableERC20Golden(t *testing.T) {
+	checkGoldenInterface(t, "OwnableERC20.json", "IOwnableERC20")
+}
+
+func TestGenerateInterfaceUnnamedParametersGolden(t *testing.T) {
+	checkGoldenInterface(t, "UnnamedParameters.json", "IUnnamedParameters")
+}
+
+func TestSortCompoundTypes(t *testing.T) {
+	compoundTypes := []CompoundType{
+		{TypeName: "Zeta0", Members: []NamedValue{{Name: "value", Value: Value{Type: "uint256"}}}},
+		{TypeName: "Order2", Members: []NamedValue{{Name: "items", Value: Value{Type: "Item1[]"}}, {Name: "zeta", Value: Value{Type: "Zeta0"}}}},
+		{TypeName: "Item1", Members: []NamedValue{{Name: "token", Value: Value{Type: "address"}}}},
+		{TypeName: "Alpha3", Members: []NamedValue{{Name: "order", Value: Value{Type: "Order2[3]"}}}},
+		{TypeName: "Beta4", Members: []NamedValue{{Name: "flag", Value: Value{Type: "bool"}}}},
+	}
+
+	sorted := SortCompoundTypes(compoundTypes)
+	actualNames := make([]string, len(sorted))
+	for i, compound := range sorted {
+		actualNames[i] = compound.TypeName
+	}
+	expectedNames := []string{"Beta4", "Item1", "Zeta0", "Order2", "Alpha3"}
+	if !reflect.DeepEqual(actualNames, expectedNames) {
+		t.Fatalf("Expected order: %v. Actual: %v", expectedNames, actualNames)
+	}
+}
+
+func TestResolveCompoundsIndependentOfItemOrder(t *testing.T) {
+	items := make([]string, 12)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"type": "function", "name": "set%d", "stateMutability": "nonpayable", "outputs": [], "inputs": [{"name": "point", "type": "tuple", "internalType": "struct Geo.Point", "components": [{"name": "x%d", "type": "int256"}]}]}`, i, i)
+	}
+	reversed := make([]string, len(items))
+	for i, item := range items {
+		reversed[len(items)-1-i] = item
+	}
+
+	generate := func(items []string) string {
+		abi, decodeErr := Decode([]byte("[" + strings.Join(items, ",") + "]"))
+		if decodeErr != nil {
+			t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+		}
+		resolved := ResolveCompounds(abi)
+		lines := []string{}
+		for _, compound := range resolved.CompoundTypes {
+			lines = append(lines, fmt.Sprintf("%s %s", compound.TypeName, compound.Members[0].Name))
+		}
+		for _, functionItem := range resolved.EnrichedABI.Functions {
+			lines = append(lines, fmt.Sprintf("%s %s", functionItem.Name, functionItem.Inputs[0].Type))
+		}
+		sort.Strings(lines[len(resolved.CompoundTypes):])
+		return strings.Join(lines, "\n")
+	}
+
+	expected := generate(items)
+	actual := generate(reversed)
+	if actual != expected {
+		t.Fatalf("Expected the same compound types for the reordered ABI:\n%s\nActual:\n%s", expected, actual)
+	}
+	if !strings.HasPrefix(expected, "Point0 x0\nPoint1 x1\nPoint2 x10\nPoint3 x11\nPoint4 x2\n") {
+		t.Fatalf("Expected compound types to be numbered by structure and declared in numeric order. Actual:\n%s", expected)
+	}
+}
+
+func TestGenerateInterfaceKeepsDigitsOfStructNames(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "move", "inputs": [{"name": "to", "type": "tuple", "internalType": "struct Lib.Vec3", "components": [{"name": "x", "type": "int256"}, {"name": "y", "type": "int256"}, {"name": "z", "type": "int256"}]}], "outputs": [], "stateMutability": "nonpayable"},
+		{"type": "function", "name": "tokens", "inputs": [], "outputs": [{"name": "", "type": "tuple[]", "internalType": "struct Lib.ERC1155[]", "components": [{"name": "token", "type": "address"}, {"name": "id", "type": "uint256"}]}], "stateMutability": "view"}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "ILib"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	for _, expected := range []string{
+		"struct ERC11550 {",
+		"struct Vec31 {",
+		"function move(Vec31 memory to) external;",
+		"function tokens() external view returns (ERC11550[] memory);",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+		}
+	}
+}
+
+func TestGenerateInterfaceTripleNestedTuplesDeclaresDependenciesFirst(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "fill", "stateMutability": "nonpayable", "outputs": [], "inputs": [
+			{"name": "order", "type": "tuple", "internalType": "struct Market.Order", "components": [
+				{"name": "maker", "type": "address", "internalType": "address"},
+				{"name": "legs", "type": "tuple[]", "internalType": "struct Market.Leg[]", "components": [
+					{"name": "amount", "type": "uint256", "internalType": "uint256"},
+					{"name": "asset", "type": "tuple", "internalType": "struct Market.Asset", "components": [
+						{"name": "token", "type": "address", "internalType": "address"},
+						{"name": "id", "type": "uint256", "internalType": "uint256"}
+					]}
+				]},
+				{"name": "fee", "type": "tuple", "internalType": "struct Market.Asset", "components": [
+					{"name": "token", "type": "address", "internalType": "address"},
+					{"name": "id", "type": "uint256", "internalType": "uint256"}
+				]}
+			]}
+		]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	resolved := ResolveCompounds(abi)
+	if len(resolved.CompoundTypes) != 4 {
+		t.Fatalf("Expected 4 compound types. Actual: %d", len(resolved.CompoundTypes))
+	}
+	typeNames := map[string]bool{}
+	for _, compound := range resolved.CompoundTypes {
+		typeNames[compound.TypeName] = true
+	}
+	declared := map[string]bool{}
+	for _, compound := range resolved.CompoundTypes {
+		for _, member := range compound.Members {
+			memberType := strings.TrimSuffix(member.Value.Type, "[]")
+			if typeNames[memberType] && !declared[memberType] {
+				t.Fatalf("Expected %s to be declared before %s. Actual order: %v", memberType, compound.TypeName, resolved.CompoundTypes)
+			}
+		}
+		declared[compound.TypeName] = true
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IMarket"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	asset := strings.Index(output.String(), "struct Asset")
+	leg := strings.Index(output.String(), "struct Leg")
+	order := strings.Index(output.String(), "struct Order")
+	if asset < 0 || leg < 0 || order < 0 || !(asset < leg && leg < order) {
+		t.Fatalf("Expected Asset, Leg, and Order structs to be declared in dependency order. Actual:\n%s", output.String())
+	}
+}
+
+func TestGenerateInterfaceExtraPragmas(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{{Type: "function", Name: "owner", Outputs: []Value{{Type: "address"}}, StateMutability: "view"}}}
+
+	var output strings.Builder
+	err := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IOwnable", License: "MIT", Pragma: "^0.8.20", ExtraPragmas: []string{"abicoder v2", "experimental SMTChecker"}}, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+	expectedHeader := "// SPDX-License-Identifier: MIT\n\npragma solidity ^0.8.20;\npragma abicoder v2;\npragma experimental SMTChecker;\n\n// Interface generated by solface"
+	if !strings.HasPrefix(output.String(), expectedHeader) {
+		t.Fatalf("Expected generated interface to start with:\n%s\nActual interface:\n%s", expectedHeader, output.String())
+	}
+
+	output.Reset()
+	err = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IOwnable", ExtraPragmas: []string{"abicoder v2"}}, &output)
+	if err != nil {
+		t.Fatalf("Error generating interface: %s", err.Error())
+	}
+	if !strings.HasPrefix(output.String(), "pragma abicoder v2;\n\n// Interface generated by solface") {
+		t.Fatalf("Expected generated interface to start with the abicoder pragma. Actual interface:\n%s", output.String())
+	}
+}
+
+func TestGenerateInterfaceFixedSizeTupleArrays(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "set", "stateMutability": "nonpayable", "inputs": [
+			{"name": "points", "type": "tuple[3]", "internalType": "struct Curve.Point[3]", "components": [
+				{"name": "x", "type": "uint256", "internalType": "uint256"},
+				{"name": "y", "type": "uint256", "internalType": "uint256"}
+			]},
+			{"name": "grid", "type": "tuple[][2]", "internalType": "struct Curve.Point[][2]", "components": [
+				{"name": "x", "type": "uint256", "internalType": "uint256"},
+				{"name": "y", "type": "uint256", "internalType": "uint256"}
+			]}
+		], "outputs": []}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	resolved := ResolveCompounds(abi)
+	inputs := resolved.EnrichedABI.Functions[0].Inputs
+	if inputs[0].Type != "Point0[3]" || inputs[1].Type != "Point1[][2]" {
+		t.Fatalf("Expected types Point0[3] and Point1[][2]. Actual: %s and %s", inputs[0].Type, inputs[1].Type)
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "ICurve"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expected := "function set(Point0[3] memory points, Point1[][2] memory grid) external;"
+	if !strings.Contains(output.String(), expected) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+	}
+	if FunctionSignature(abi.Functions[0]) != "set((uint256,uint256)[3],(uint256,uint256)[][2])" {
+		t.Fatalf("Expected signature set((uint256,uint256)[3],(uint256,uint256)[][2]). Actual: %s", FunctionSignature(abi.Functions[0]))
+	}
+}
+
+func TestSolidityTypeRequiresLocation(t *testing.T) {
+	expected := map[string]bool{
+		"uint256":         false,
+		"int8":            false,
+		"address":         false,
+		"address payable": false,
+		"bool":            false,
+		"bytes32":         false,
+		"fixed128x18":     false,
+		"function":        false,
+		"string":          true,
+		"bytes":           true,
+		"Point0":          true,
+		"internalState":   true,
+		"uint256[5]":      true,
+		"address[2]":      true,
+		"bytes32[][3]":    true,
+		"bool[2][]":       true,
+		"Point0[3]":       true,
+	}
+	for solidityType, requiresLocation := range expected {
+		if SolidityTypeRequiresLocation(solidityType) != requiresLocation {
+			t.Fatalf("Expected SolidityTypeRequiresLocation(%s) to be %t. Actual: %t", solidityType, requiresLocation, !requiresLocation)
+		}
+	}
+
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "batch", "stateMutability": "nonpayable", "inputs": [
+			{"name": "amounts", "type": "uint256[5]", "internalType": "uint256[5]"},
+			{"name": "owners", "type": "address[2]", "internalType": "address[2]"}
+		], "outputs": [{"name": "", "type": "bool[3]", "internalType": "bool[3]"}]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IBatch"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expectedFunction := "function batch(uint256[5] memory amounts, address[2] memory owners) external returns (bool[3] memory);"
+	if !strings.Contains(output.String(), expectedFunction) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expectedFunction, output.String())
+	}
+}
@@ -0,0 +1,188 @@
+package solface
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// The largest number of linearly dependent selectors for which SelectorSubset searches all solutions for
+// the smallest subset.
+const maxSelectorSubsetSearch = 16
+
+// The largest expected number of subsets, no larger than a match, whose selectors would XOR to a random
+// interface ID - above this, SelectorSubset considers the match a coincidence. An ABI with 32 or more
+// functions usually has subsets for every interface ID.
+const maxCoincidentalSubsets = 0.1
+
+// Returned by SelectorSubset if no subset of the selectors has the interface ID.
+var ErrNoSelectorSubset = errors.New("no selector subset")
+
+// Returned by SelectorSubset if subsets of the selectors have the interface ID, but none of them can be
+// attributed to it: several subsets are equally small, there are too many subsets to search for the
+// smallest, or so many selectors that a subset of that size would have the interface ID by coincidence.
+var ErrAmbiguousSelectorSubset = errors.New("ambiguous selector subset")
+
+// Represents a set of selector indices as a bit set.
+type selectorSet []uint64
+
+func newSelectorSet(size int) selectorSet {
+	return make(selectorSet, (size+63)/64)
+}
+
+func (s selectorSet) with(index int) selectorSet {
+	result := append(selectorSet{}, s...)
+	result[index/64] ^= 1 << (index % 64)
+	return result
+}
+
+func (s selectorSet) xor(other selectorSet) selectorSet {
+	result := append(selectorSet{}, s...)
+	for i := range other {
+		result[i] ^= other[i]
+	}
+	return result
+}
+
+func (s selectorSet) size() int {
+	size := 0
+	for _, word := range s {
+		size += bits.OnesCount64(word)
+	}
+	return size
+}
+
+func (s selectorSet) indices() []int {
+	indices := []int{}
+	for i, word := range s {
+		for bit := 0; bit < 64; bit++ {
+			if word&(1<<bit) != 0 {
+				indices = append(indices, i*64+bit)
+			}
+		}
+	}
+	return indices
+}
+
+// Parses an interface ID given as a hex string (with or without 0x prefix), e.g. "0x80ac58cd".
+func ParseInterfaceID(interfaceID string) ([]byte, error) {
+	decoded, decodeErr := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(interfaceID), "0x"))
+	if decodeErr != nil || len(decoded) != 4 {
+		return nil, fmt.Errorf("invalid interface ID %q (expected 4 bytes of hex, e.g. 0x80ac58cd)", interfaceID)
+	}
+	return decoded, nil
+}
+
+// Returns the indices (in increasing order) of the subset of the given 4-byte selectors whose XOR is the
+// given interface ID, as computed by type(I).interfaceId in Solidity.
+//
+// The subset is found by Gaussian elimination over GF(2). Since the selectors of an ABI are usually
+// linearly dependent, many subsets may have the same XOR - the smallest subset is returned, which is the one
+// a declared standard (e.g. ERC-721) would have. Returns ErrNoSelectorSubset if there is no such subset, and
+// ErrAmbiguousSelectorSubset if the smallest subset is not unique, cannot be searched for (more than 16
+// dependent selectors), or is large enough that it would have the interface ID by coincidence.
+func SelectorSubset(selectors [][]byte, interfaceID []byte) ([]int, error) {
+	type basisVector struct {
+		value       uint32
+		combination selectorSet
+	}
+	basis := map[int]basisVector{}
+	dependencies := []selectorSet{}
+
+	// Reduces the given value by the basis, returning the remainder and the selectors XORed into it.
+	reduce := func(value uint32, combination selectorSet) (uint32, selectorSet) {
+		for bit := 31; bit >= 0; bit-- {
+			if vector, ok := basis[bit]; ok && value&(1<<bit) != 0 {
+				value ^= vector.value
+				combination = combination.xor(vector.combination)
+			}
+		}
+		return value, combination
+	}
+
+	for i, selector := range selectors {
+		if len(selector) != 4 {
+			continue
+		}
+		value, combination := reduce(binary.BigEndian.Uint32(selector), newSelectorSet(len(selectors)).with(i))
+		if value == 0 {
+			dependencies = append(dependencies, combination)
+			continue
+		}
+		pivot := 31
+		for value&(1<<pivot) == 0 {
+			pivot--
+		}
+		basis[pivot] = basisVector{value: value, combination: combination}
+	}
+
+	if len(interfaceID) != 4 {
+		return nil, ErrNoSelectorSubset
+	}
+	remainder, solution := reduce(binary.BigEndian.Uint32(interfaceID), newSelectorSet(len(selectors)))
+	if remainder != 0 {
+		return nil, ErrNoSelectorSubset
+	}
+	if len(dependencies) > maxSelectorSubsetSearch {
+		return nil, ErrAmbiguousSelectorSubset
+	}
+
+	best, ties := solution, 1
+	for mask := 1; mask < 1<<len(dependencies); mask++ {
+		candidate := solution
+		for j, dependency := range dependencies {
+			if mask&(1<<j) != 0 {
+				candidate = candidate.xor(dependency)
+			}
+		}
+		if candidate.size() < best.size() {
+			best, ties = candidate, 1
+		} else if candidate.size() == best.size() {
+			ties++
+		}
+	}
+	if ties > 1 || coincidentalSubsets(len(selectors), best.size()) > maxCoincidentalSubsets {
+		return nil, ErrAmbiguousSelectorSubset
+	}
+	return best.indices(), nil
+}
+
+// Returns the expected number of non-empty subsets of at most the given size, out of the given number of
+// random selectors, whose selectors XOR to a given interface ID.
+func coincidentalSubsets(selectors, size int) float64 {
+	expected, binomial := 0.0, 1.0
+	for k := 1; k <= size && k <= selectors; k++ {
+		binomial = binomial * float64(selectors-k+1) / float64(k)
+		expected += binomial / (1 << 32)
+	}
+	return expected
+}
+
+// Returns the ABI consisting of the subset of the functions of the given ABI whose selectors (computed
+// with the given hasher) XOR to the given interface ID (see SelectorSubset), along with all its events and
+// errors, which do not contribute to interface IDs. This helps diagnose why the interface ID of a contract
+// does not match a published one. Returns an error wrapping ErrNoSelectorSubset if no subset of the functions
+// has the interface ID, and ErrAmbiguousSelectorSubset if no subset can be attributed to it.
+func TrimToInterfaceID(abi DecodedABI, interfaceID []byte, hasher Hasher) (DecodedABI, error) {
+	selectors := make([][]byte, len(abi.Functions))
+	for i, functionItem := range abi.Functions {
+		selectors[i] = MethodSelectorWithHasher(functionItem, hasher)
+	}
+	subset, subsetErr := SelectorSubset(selectors, interfaceID)
+	if errors.Is(subsetErr, ErrNoSelectorSubset) {
+		return abi, fmt.Errorf("%w: no subset of the %d functions of the ABI has interface ID 0x%x", subsetErr, len(abi.Functions), interfaceID)
+	}
+	if subsetErr != nil {
+		return abi, fmt.Errorf("%w: subsets of the %d functions of the ABI have interface ID 0x%x, but none can be attributed to it", subsetErr, len(abi.Functions), interfaceID)
+	}
+	kept := map[int]bool{}
+	for _, index := range subset {
+		kept[index] = true
+	}
+	return FilterABI(abi, func(itemType string, itemIndex int) bool {
+		return itemType != "function" || kept[itemIndex]
+	}), nil
+}
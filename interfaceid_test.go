@@ -0,0 +1,115 @@
+package solface
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestTrimToInterfaceID(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/ERC721.json")
+	if readErr != nil {
+		t.Fatalf("Could not read fixture: %s", readErr.Error())
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	// The metadata extension adds functions (name, symbol, tokenURI) outside of the ERC-721 interface ID.
+	metadataContents, readErr := os.ReadFile("standards/abis/ERC721Metadata.json")
+	if readErr != nil {
+		t.Fatalf("Could not read fixture: %s", readErr.Error())
+	}
+	metadata, decodeErr := Decode(metadataContents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	for _, functionItem := range metadata.Functions {
+		if functionItem.Name == "name" || functionItem.Name == "symbol" || functionItem.Name == "tokenURI" {
+			abi.Functions = append(abi.Functions, functionItem)
+		}
+	}
+	if len(abi.Functions) != 12 {
+		t.Fatalf("Expected 12 functions in the combined ABI. Actual: %d", len(abi.Functions))
+	}
+
+	interfaceID, parseErr := ParseInterfaceID("0x80ac58cd")
+	if parseErr != nil {
+		t.Fatalf("Could not parse interface ID: %s", parseErr.Error())
+	}
+	trimmed, trimErr := TrimToInterfaceID(abi, interfaceID, nil)
+	if trimErr != nil {
+		t.Fatalf("Could not trim ABI: %s", trimErr.Error())
+	}
+	if len(trimmed.Functions) != 9 || len(trimmed.Events) != len(abi.Events) {
+		t.Fatalf("Expected the 9 ERC-721 functions and all events. Actual: %d functions, %d events", len(trimmed.Functions), len(trimmed.Events))
+	}
+	annotations, annotateErr := Annotate(trimmed)
+	if annotateErr != nil {
+		t.Fatalf("Could not annotate trimmed ABI: %s", annotateErr.Error())
+	}
+	if string(annotations.InterfaceID) != string(interfaceID) {
+		t.Fatalf("Expected interface ID 80ac58cd. Actual: %x", annotations.InterfaceID)
+	}
+
+	if _, trimErr := TrimToInterfaceID(DecodedABI{}, interfaceID, nil); trimErr == nil {
+		t.Fatalf("Expected an error trimming an ABI without functions")
+	}
+	if _, parseErr := ParseInterfaceID("0x80ac58"); parseErr == nil {
+		t.Fatalf("Expected an error parsing a 3-byte interface ID")
+	}
+}
+
+func TestSelectorSubsetSmallest(t *testing.T) {
+	// 0x0000000f = 0x00000001 ^ 0x00000002 ^ 0x00000004 ^ 0x00000008, but also 0x0000000f itself.
+	selectors := [][]byte{{0, 0, 0, 1}, {0, 0, 0, 2}, {0, 0, 0, 4}, {0, 0, 0, 8}, {0, 0, 0, 0x0f}}
+	subset, subsetErr := SelectorSubset(selectors, []byte{0, 0, 0, 0x0f})
+	if subsetErr != nil || len(subset) != 1 || subset[0] != 4 {
+		t.Fatalf("Expected the subset [4]. Actual: %v (error: %v)", subset, subsetErr)
+	}
+
+	subset, subsetErr = SelectorSubset(selectors, []byte{0, 0, 0, 0x03})
+	if subsetErr != nil || len(subset) != 2 || subset[0] != 0 || subset[1] != 1 {
+		t.Fatalf("Expected the subset [0 1]. Actual: %v (error: %v)", subset, subsetErr)
+	}
+
+	if _, subsetErr := SelectorSubset(selectors, []byte{0, 0, 1, 0}); !errors.Is(subsetErr, ErrNoSelectorSubset) {
+		t.Fatalf("Expected no subset for 0x00000100. Actual error: %v", subsetErr)
+	}
+
+	// 0x00000003 = 0x00000001 ^ 0x00000002 = 0x00000004 ^ 0x00000007.
+	tied := [][]byte{{0, 0, 0, 1}, {0, 0, 0, 2}, {0, 0, 0, 4}, {0, 0, 0, 7}}
+	if subset, subsetErr := SelectorSubset(tied, []byte{0, 0, 0, 0x03}); !errors.Is(subsetErr, ErrAmbiguousSelectorSubset) {
+		t.Fatalf("Expected an ambiguous subset for 0x00000003. Actual: %v (error: %v)", subset, subsetErr)
+	}
+}
+
+func TestTrimToInterfaceIDManyFunctions(t *testing.T) {
+	// With 32 or more functions, subsets of the selectors usually have any interface ID by coincidence.
+	dummies := DecodedABI{}
+	for i := 0; i < 40; i++ {
+		dummies.Functions = append(dummies.Functions, FunctionItem{Type: "function", Name: fmt.Sprintf("dummy%d", i), StateMutability: "view"})
+	}
+	interfaceID, _ := ParseInterfaceID("0x80ac58cd")
+	if trimmed, trimErr := TrimToInterfaceID(dummies, interfaceID, nil); !errors.Is(trimErr, ErrAmbiguousSelectorSubset) {
+		t.Fatalf("Expected an ambiguous subset of 40 unrelated functions. Actual: %d functions (error: %v)", len(trimmed.Functions), trimErr)
+	}
+
+	contents, readErr := os.ReadFile("fixtures/abis/ERC721.json")
+	if readErr != nil {
+		t.Fatalf("Could not read fixture: %s", readErr.Error())
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	abi.Functions = append(abi.Functions, dummies.Functions[:31]...)
+	trimmed, trimErr := TrimToInterfaceID(abi, interfaceID, nil)
+	if trimErr != nil {
+		t.Fatalf("Could not trim ABI with %d functions: %s", len(abi.Functions), trimErr.Error())
+	}
+	if len(trimmed.Functions) != 9 {
+		t.Fatalf("Expected the 9 ERC-721 functions. Actual: %d functions", len(trimmed.Functions))
+	}
+}
@@ -0,0 +1,152 @@
+package solface
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+)
+
+// Represents a parameter of an ABI item in the JSON intermediate representation.
+//  1. Name: The name of the parameter.
+//  2. Type: The Solidity type of the parameter in the generated interface, using the generated struct names.
+//  3. CanonicalType: The canonical ABI type of the parameter, as used in signatures.
+//  4. InternalType: The internalType of the parameter in the original ABI, if any.
+//  5. Indexed: Whether or not the parameter is indexed (only for event inputs).
+type IRParameter struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	CanonicalType string `json:"canonicalType"`
+	InternalType  string `json:"internalType,omitempty"`
+	Indexed       bool   `json:"indexed,omitempty"`
+}
+
+// Represents an event, function, or error in the JSON intermediate representation.
+//  1. Kind: One of "event", "function", or "error".
+//  2. Name: The name of the item.
+//  3. Signature: The canonical signature of the item.
+//  4. Selector: The selector (for functions and errors) or topic (for non-anonymous events) of the item,
+//     as a hex string.
+//  5. StateMutability: The state mutability of the item (only for functions).
+//  6. Anonymous: Whether or not the item is an anonymous event.
+//  7. Inputs: The inputs of the item.
+//  8. Outputs: The outputs of the item (only for functions).
+//  9. Raw: The original JSON of the item - only included if Options.RawIRItems is set and the ABI was
+//     decoded from JSON.
+type IRItem struct {
+	Kind            string          `json:"kind"`
+	Name            string          `json:"name"`
+	Signature       string          `json:"signature"`
+	Selector        string          `json:"selector,omitempty"`
+	StateMutability string          `json:"stateMutability,omitempty"`
+	Anonymous       bool            `json:"anonymous,omitempty"`
+	Inputs          []IRParameter   `json:"inputs"`
+	Outputs         []IRParameter   `json:"outputs,omitempty"`
+	Raw             json.RawMessage `json:"raw,omitempty"`
+}
+
+// Represents the JSON intermediate representation of the interface solface generates for an ABI, for
+// downstream tools (e.g. documentation generators) which need to relate the generated interface to the
+// original ABI.
+//  1. Name: The name of the interface.
+//  2. SolfaceVersion: The version of solface that generated the representation.
+//  3. Items: The events, functions, and errors of the ABI, in that order.
+//  4. Structs: The structs generated for the compound types of the ABI, in declaration order. Each
+//     struct records its original qualified name and the original path of each of its members (see
+//     CompoundType).
+type IntermediateRepresentation struct {
+	Name           string         `json:"name"`
+	SolfaceVersion string         `json:"solfaceVersion"`
+	Items          []IRItem       `json:"items"`
+	Structs        []CompoundType `json:"structs"`
+}
+
+// Returns the parameters of the intermediate representation for the given original values and their
+// counterparts in the ABI enriched with compound types.
+func irParameters(original, enriched []Value) []IRParameter {
+	parameters := make([]IRParameter, len(original))
+	for i, value := range original {
+		parameters[i] = IRParameter{Name: value.Name, Type: enriched[i].Type, CanonicalType: CanonicalType(value), InternalType: value.InternalType}
+	}
+	return parameters
+}
+
+// Builds the JSON intermediate representation of the interface to the given ABI. The ABI is transformed as
+// by GenerateInterfaceWithOptions (e.g. name conflicts are resolved, functions are renamed, and library ABIs
+// are filtered), so that items and structs are named as in the generated interface, and errors are returned
+// in the same cases. Signatures and selectors are those of the items before renames and lite mode (as in
+// annotations), computed with options.Hasher.
+func BuildIntermediateRepresentation(abi DecodedABI, options Options) (IntermediateRepresentation, error) {
+	ir := IntermediateRepresentation{Name: options.Name, SolfaceVersion: VERSION, Items: []IRItem{}}
+
+	transformed, transformErr := transformABI(abi, Annotations{}, options)
+	if transformErr != nil {
+		return ir, transformErr
+	}
+	abi, renamed, enrichedABI := transformed.ABI, transformed.RenamedABI, transformed.EnrichedABI
+	ir.Structs = transformed.CompoundTypes
+
+	for i, eventItem := range abi.Events {
+		original := make([]Value, len(eventItem.Inputs))
+		enriched := make([]Value, len(eventItem.Inputs))
+		for j, input := range eventItem.Inputs {
+			original[j] = input.Value
+			enriched[j] = enrichedABI.Events[i].Inputs[j].Value
+		}
+		item := IRItem{Kind: "event", Name: renamed.Events[i].Name, Signature: EventSignature(eventItem), Anonymous: eventItem.Anonymous, Inputs: irParameters(original, enriched)}
+		for j, input := range eventItem.Inputs {
+			item.Inputs[j].Indexed = input.Indexed
+		}
+		if !eventItem.Anonymous {
+			item.Selector = "0x" + hex.EncodeToString(EventTopicWithHasher(eventItem, options.Hasher))
+		}
+		ir.Items = append(ir.Items, item)
+	}
+
+	for i, functionItem := range abi.Functions {
+		enriched := enrichedABI.Functions[i]
+		ir.Items = append(ir.Items, IRItem{
+			Kind:            "function",
+			Name:            renamed.Functions[i].Name,
+			Signature:       FunctionSignature(functionItem),
+			Selector:        "0x" + hex.EncodeToString(MethodSelectorWithHasher(functionItem, options.Hasher)),
+			StateMutability: NormalizedStateMutability(functionItem),
+			Inputs:          irParameters(functionItem.Inputs, enriched.Inputs),
+			Outputs:         irParameters(functionItem.Outputs, enriched.Outputs),
+		})
+	}
+
+	for i, errorItem := range abi.Errors {
+		ir.Items = append(ir.Items, IRItem{
+			Kind:      "error",
+			Name:      renamed.Errors[i].Name,
+			Signature: ErrorSignature(errorItem),
+			Selector:  "0x" + hex.EncodeToString(hasherOrDefault(options.Hasher).Hash([]byte(ErrorSignature(errorItem)))[:4]),
+			Inputs:    irParameters(errorItem.Inputs, enrichedABI.Errors[i].Inputs),
+		})
+	}
+
+	if options.RawIRItems {
+		// Items are listed in the order events, functions, errors, as in the DecodedABI.
+		offsets := map[string]int{"event": 0, "function": len(abi.Events), "error": len(abi.Events) + len(abi.Functions)}
+		for _, position := range abi.Positions {
+			if itemIndex := offsets[position.ItemType] + position.ItemIndex; itemIndex < len(ir.Items) {
+				ir.Items[itemIndex].Raw = position.Raw
+			}
+		}
+	}
+
+	return ir, nil
+}
+
+// Generates the JSON intermediate representation of the interface to the given ABI (see
+// BuildIntermediateRepresentation).
+func GenerateIntermediateRepresentation(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	ir, buildErr := BuildIntermediateRepresentation(abi, options)
+	if buildErr != nil {
+		return buildErr
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ir)
+}
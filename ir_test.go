@@ -0,0 +1,108 @@
+package solface
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBuildIntermediateRepresentation(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[{"type": "function", "name": "list", "stateMutability": "nonpayable", "outputs": [], "inputs": [{"name": "listings", "type": "tuple[]", "internalType": "struct LibAppStorage.Listing[]", "components": [{"name": "seller", "type": "address", "internalType": "address"}, {"name": "", "type": "uint256", "internalType": "uint256"}]}]}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	ir, buildErr := BuildIntermediateRepresentation(abi, Options{Name: "IMarketplace"})
+	if buildErr != nil {
+		t.Fatalf("Could not build intermediate representation: %s", buildErr.Error())
+	}
+	if len(ir.Items) != 1 || ir.Items[0].Kind != "function" || ir.Items[0].Signature != "list((address,uint256)[])" {
+		t.Fatalf("Expected a single function item. Actual: %v", ir.Items)
+	}
+	if ir.Items[0].Inputs[0].Type != "Listing0[]" || ir.Items[0].Inputs[0].InternalType != "struct LibAppStorage.Listing[]" {
+		t.Fatalf("Expected input of type Listing0[] with original internal type. Actual: %v", ir.Items[0].Inputs[0])
+	}
+
+	if len(ir.Structs) != 1 {
+		t.Fatalf("Expected 1 struct. Actual: %d", len(ir.Structs))
+	}
+	listing := ir.Structs[0]
+	if listing.OriginalName != "LibAppStorage.Listing" {
+		t.Fatalf("Expected original name LibAppStorage.Listing. Actual: %s", listing.OriginalName)
+	}
+	if listing.Members[0].InternalTypePath != "LibAppStorage.Listing.seller" {
+		t.Fatalf("Expected internal type path LibAppStorage.Listing.seller. Actual: %s", listing.Members[0].InternalTypePath)
+	}
+	if listing.Members[1].InternalTypePath != "" {
+		t.Fatalf("Expected no internal type path for unnamed member. Actual: %s", listing.Members[1].InternalTypePath)
+	}
+}
+
+func TestIntermediateRepresentationMatchesInterface(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "transfer", "stateMutability": "nonpayable", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": []},
+		{"type": "function", "name": "fill", "stateMutability": "nonpayable", "outputs": [], "inputs": [{"name": "order", "type": "tuple", "internalType": "struct Market.Order", "components": [{"name": "maker", "type": "address"}]}]},
+		{"type": "error", "name": "Unauthorized", "inputs": []},
+		{"type": "error", "name": "Unauthorized", "inputs": [{"name": "account", "type": "address"}]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	options := Options{Name: "IMarket", FunctionRenames: map[string]string{"0xa9059cbb": "send"}, Lite: true}
+	ir, buildErr := BuildIntermediateRepresentation(abi, options)
+	if buildErr != nil {
+		t.Fatalf("Could not build intermediate representation: %s", buildErr.Error())
+	}
+	var output strings.Builder
+	if generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, options, &output); generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+
+	if len(ir.Structs) != 0 || strings.Contains(output.String(), "struct ") {
+		t.Fatalf("Expected no structs in lite mode. Actual: %v", ir.Structs)
+	}
+	for _, item := range ir.Items {
+		if !strings.Contains(output.String(), fmt.Sprintf(" %s(", item.Name)) {
+			t.Fatalf("Expected item %s of the intermediate representation to be declared in the interface:\n%s", item.Name, output.String())
+		}
+	}
+	if ir.Items[0].Name != "send" || ir.Items[0].Signature != "transfer(address,uint256)" || ir.Items[0].Selector != "0xa9059cbb" {
+		t.Fatalf("Expected the renamed function with its original signature and selector. Actual: %v", ir.Items[0])
+	}
+	if ir.Items[1].Inputs[0].Type != "bytes" || ir.Items[3].Name != "Unauthorized_2" {
+		t.Fatalf("Expected lite parameters and suffixed conflicting errors. Actual: %v", ir.Items)
+	}
+}
+
+func TestRawIRItems(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "error", "name": "Unauthorized", "inputs": []},
+		{"type": "function", "name": "owner", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "address"}], "gas": 2300},
+		{"type": "event", "name": "Ping", "anonymous": false, "inputs": []}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	ir, buildErr := BuildIntermediateRepresentation(abi, Options{Name: "IOwnable"})
+	if buildErr != nil {
+		t.Fatalf("Could not build intermediate representation: %s", buildErr.Error())
+	}
+	for _, item := range ir.Items {
+		if item.Raw != nil {
+			t.Fatalf("Expected no raw JSON without RawIRItems. Actual: %s", string(item.Raw))
+		}
+	}
+
+	ir, buildErr = BuildIntermediateRepresentation(abi, Options{Name: "IOwnable", RawIRItems: true})
+	if buildErr != nil {
+		t.Fatalf("Could not build intermediate representation: %s", buildErr.Error())
+	}
+	expected := map[string]string{"Ping": `"name": "Ping"`, "owner": `"gas": 2300`, "Unauthorized": `"name": "Unauthorized"`}
+	for _, item := range ir.Items {
+		if !strings.Contains(string(item.Raw), expected[item.Name]) {
+			t.Fatalf("Expected raw JSON of %s to contain %s. Actual: %s", item.Name, expected[item.Name], string(item.Raw))
+		}
+	}
+}
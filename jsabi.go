@@ -0,0 +1,212 @@
+package solface
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Matches the start of an array literal assigned to a variable or export in JavaScript or TypeScript
+// source, e.g. "export const erc20Abi = [" or "const ABI: Abi = [". The first group is the variable name
+// (empty for module.exports and default exports).
+var jsArrayDeclaration = regexp.MustCompile(`(?:(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=\n]*)?|module\.exports|export\s+default)\s*=?\s*\[`)
+
+// Returns true if the given input looks like JavaScript or TypeScript source rather than JSON or a
+// human-readable ABI.
+func isJSSource(rawInput []byte) bool {
+	trimmed := bytes.TrimSpace(rawInput)
+	if len(trimmed) == 0 || trimmed[0] == '[' || trimmed[0] == '{' {
+		return false
+	}
+	return jsArrayDeclaration.Match(trimmed)
+}
+
+// Returns the name of the contract whose ABI is assigned to the given variable, e.g. "Vault" for
+// "vaultAbi" or "ERC20" for "ERC20_ABI". Returns an empty string if the variable name does not contain one.
+func jsContractName(variable string) string {
+	for _, suffix := range []string{"_ABI", "_abi", "ABI", "Abi", "abi"} {
+		if strings.HasSuffix(variable, suffix) {
+			variable = strings.TrimSuffix(variable, suffix)
+			break
+		}
+	}
+	if variable == "" {
+		return ""
+	}
+	return strings.ToUpper(variable[:1]) + variable[1:]
+}
+
+// Extracts the ABIs assigned to variables or exports in JavaScript or TypeScript source, e.g.
+//
+//	export const erc20Abi = [{ type: "function", name: "totalSupply", ... }] as const;
+//
+// Extraction is best-effort: array literals are converted to JSON (quoting object keys, converting
+// single-quoted strings, and dropping comments and trailing commas), and arrays which cannot be converted
+// - e.g. because they refer to other variables - or which do not look like ABIs are skipped. There is one
+// artifact for every ABI, named after its variable (see jsContractName).
+func ParseJSSource(source []byte) ([]Artifact, error) {
+	artifacts := []Artifact{}
+	for _, match := range jsArrayDeclaration.FindAllSubmatchIndex(source, -1) {
+		rawABI, ok := jsArrayToJSON(source[match[1]-1:])
+		if !ok || !looksLikeABI(rawABI) {
+			continue
+		}
+		artifact := Artifact{Kind: ArtifactKindJS, ABI: rawABI}
+		if match[2] >= 0 {
+			artifact.ContractName = jsContractName(string(source[match[2]:match[3]]))
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	if len(artifacts) == 0 {
+		return nil, fmt.Errorf("no ABI arrays found in JavaScript/TypeScript source")
+	}
+	return artifacts, nil
+}
+
+// Returns true if the given JSON array is a non-empty list of ABI items (objects with a "type" field) or
+// of human-readable ABI fragments.
+func looksLikeABI(rawJSON []byte) bool {
+	var items []json.RawMessage
+	if json.Unmarshal(rawJSON, &items) != nil || len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		var fragment string
+		if json.Unmarshal(item, &fragment) == nil {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(item, &fields) != nil {
+			return false
+		}
+		if _, hasType := fields["type"]; !hasType {
+			return false
+		}
+	}
+	return true
+}
+
+// Converts the JavaScript array literal at the start of the given source to JSON. Returns false if the
+// literal contains anything other than objects, arrays, strings, numbers, booleans, and null.
+func jsArrayToJSON(source []byte) ([]byte, bool) {
+	var output bytes.Buffer
+	depth := 0
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '/' && i+1 < len(source) && source[i+1] == '/':
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(source) && source[i+1] == '*':
+			end := bytes.Index(source[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, false
+			}
+			i += end + 4
+		case c == '[' || c == '{':
+			depth++
+			output.WriteByte(c)
+			i++
+		case c == ']' || c == '}':
+			// Trailing commas are allowed in JavaScript, but not in JSON.
+			trimmed := bytes.TrimRight(output.Bytes(), ",")
+			output.Truncate(len(trimmed))
+			output.WriteByte(c)
+			depth--
+			i++
+			if depth == 0 {
+				return output.Bytes(), json.Valid(output.Bytes())
+			}
+		case c == ',' || c == ':':
+			output.WriteByte(c)
+			i++
+		case c == '"' || c == '\'' || c == '`':
+			value, length, ok := jsString(source[i:])
+			if !ok {
+				return nil, false
+			}
+			output.WriteString(strconv.Quote(value))
+			i += length
+		case c == '-' || unicode.IsDigit(rune(c)):
+			start := i
+			i++
+			for i < len(source) && (unicode.IsDigit(rune(source[i])) || source[i] == '.' || source[i] == 'e' || source[i] == 'E') {
+				i++
+			}
+			output.Write(source[start:i])
+		case c == '_' || c == '$' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(source) && (source[i] == '_' || source[i] == '$' || unicode.IsLetter(rune(source[i])) || unicode.IsDigit(rune(source[i]))) {
+				i++
+			}
+			identifier := string(source[start:i])
+			if rest := bytes.TrimLeft(source[i:], " \t\r\n"); len(rest) > 0 && rest[0] == ':' {
+				output.WriteString(strconv.Quote(identifier))
+			} else if identifier == "true" || identifier == "false" || identifier == "null" {
+				output.WriteString(identifier)
+			} else {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// Parses the JavaScript string literal (quoted with ", ', or `) at the start of the given source, and
+// returns its value and its length in the source. Template literals with substitutions are rejected.
+func jsString(source []byte) (string, int, bool) {
+	quote := source[0]
+	var value strings.Builder
+	for i := 1; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case c == quote:
+			return value.String(), i + 1, true
+		case c == '\\' && i+1 < len(source):
+			i++
+			switch source[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			default:
+				value.WriteByte(source[i])
+			}
+		case c == '\n' && quote != '`':
+			return "", 0, false
+		case c == '$' && quote == '`' && i+1 < len(source) && source[i+1] == '{':
+			return "", 0, false
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", 0, false
+}
+
+// Extracts the ABIs from JavaScript or TypeScript source (see ParseJSSource), converting human-readable
+// ABIs to JSON.
+func parseJSArtifacts(source []byte) ([]Artifact, error) {
+	artifacts, parseErr := ParseJSSource(source)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	for i, artifact := range artifacts {
+		if fragments, ok := humanReadableFragments(artifact.ABI); ok {
+			rawABI, humanErr := ParseHumanReadableABI(fragments)
+			if humanErr != nil {
+				return nil, humanErr
+			}
+			artifacts[i].ABI = rawABI
+		}
+	}
+	return artifacts, nil
+}
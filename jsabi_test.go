@@ -0,0 +1,63 @@
+package solface
+
+import (
+	"testing"
+)
+
+func TestParseJSSource(t *testing.T) {
+	source := `import type { Abi } from "viem";
+
+// Generated by wagmi
+export const erc20Abi = [
+	{
+		type: 'function',
+		name: 'transfer',
+		inputs: [
+			{ name: "to", type: "address" },
+			{ name: 'amount', type: 'uint256' }, // trailing comma
+		],
+		outputs: [{ name: '', type: 'bool' }],
+		stateMutability: 'nonpayable',
+	},
+	/* events */
+	{ type: "event", name: "Transfer", anonymous: false, inputs: [{ indexed: true, name: "from", type: "address" }] },
+] as const;
+
+export const FEE_TIERS = [500, 3000, 10000];
+
+const VAULT_ABI: Abi = ["function deposit(uint256 assets) returns (uint256)"];
+`
+	artifacts, parseErr := ParseArtifacts([]byte(source))
+	if parseErr != nil {
+		t.Fatalf("Error parsing source: %s", parseErr.Error())
+	}
+	if len(artifacts) != 2 {
+		t.Fatalf("Expected 2 artifacts. Actual: %v", ArtifactNames(artifacts))
+	}
+	if artifacts[0].Kind != ArtifactKindJS || artifacts[0].ContractName != "Erc20" || artifacts[1].ContractName != "VAULT" {
+		t.Fatalf("Expected JavaScript artifacts Erc20 and VAULT. Actual: %s %v", artifacts[0].Kind, ArtifactNames(artifacts))
+	}
+
+	erc20, decodeErr := Decode(artifacts[0].ABI)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding extracted ABI: %s", decodeErr.Error())
+	}
+	if len(erc20.Functions) != 1 || FunctionSignature(erc20.Functions[0]) != "transfer(address,uint256)" || len(erc20.Events) != 1 || !erc20.Events[0].Inputs[0].Indexed {
+		t.Fatalf("Expected transfer(address,uint256) and an indexed Transfer event. Actual: %v", erc20)
+	}
+
+	vault, decodeErr := Decode(artifacts[1].ABI)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding extracted human-readable ABI: %s", decodeErr.Error())
+	}
+	if len(vault.Functions) != 1 || vault.Functions[0].Name != "deposit" {
+		t.Fatalf("Expected deposit function. Actual: %v", vault)
+	}
+}
+
+func TestParseJSSourceWithoutABI(t *testing.T) {
+	source := "const owners = [ownerA, ownerB];\nmodule.exports = [1, 2, 3];\n"
+	if _, parseErr := ParseArtifacts([]byte(source)); parseErr == nil {
+		t.Fatalf("Expected an error for source without ABI arrays")
+	}
+}
@@ -0,0 +1,87 @@
+// Package jsonrpc is a minimal client for Ethereum JSON-RPC endpoints, shared by the parts of solface which
+// talk to nodes (e.g. ENS resolution and registry publishing).
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// The timeout used by clients which do not configure one.
+const DefaultTimeout = 30 * time.Second
+
+// Represents a JSON-RPC endpoint. If Timeout is zero, DefaultTimeout is used. Requests are sent with
+// Transport, or with http.DefaultTransport if it is nil.
+type Client struct {
+	URL       string
+	Timeout   time.Duration
+	Transport http.RoundTripper
+}
+
+// Returned when the endpoint responds to a request with a JSON-RPC error.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+type request struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// Calls the given method with the given parameters and decodes its result into result. Returns an *Error
+// if the endpoint responds with a JSON-RPC error.
+func (c Client) Call(method string, params []interface{}, result interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, marshalErr := json.Marshal(request{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	client := http.Client{Timeout: timeout, Transport: c.Transport}
+	httpResponse, requestErr := client.Post(c.URL, "application/json", bytes.NewReader(body))
+	if requestErr != nil {
+		return requestErr
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("RPC request failed with status %s", httpResponse.Status)
+	}
+
+	var decoded response
+	decodeErr := json.NewDecoder(httpResponse.Body).Decode(&decoded)
+	if decodeErr != nil {
+		return fmt.Errorf("could not decode RPC response: %s", decodeErr.Error())
+	}
+	if decoded.Error != nil {
+		return decoded.Error
+	}
+	if result == nil {
+		return nil
+	}
+	unmarshalErr := json.Unmarshal(decoded.Result, result)
+	if unmarshalErr != nil {
+		return fmt.Errorf("could not decode result of %s: %s", method, unmarshalErr.Error())
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package solface
+
+import "bytes"
+
+// The UTF-8 byte order mark, which some editors prepend to files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Sanitizes hand-maintained JSON so that it can be parsed by encoding/json: a leading byte order mark,
+// line (//) and block (/* */) comments, and trailing commas before closing brackets and braces are
+// removed. Strings are left untouched. Inputs which are already valid JSON are returned unchanged, apart
+// from a byte order mark, as are inputs which do not start like JSON (e.g. encoded or human-readable
+// ABIs, whose slashes and commas are not comments or trailing commas).
+func SanitizeJSON(input []byte) []byte {
+	input = bytes.TrimPrefix(input, utf8BOM)
+	if trimmed := bytes.TrimSpace(input); len(trimmed) == 0 || (trimmed[0] != '[' && trimmed[0] != '{' && trimmed[0] != '/') {
+		return input
+	}
+
+	var output bytes.Buffer
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '"':
+			start := i
+			for i++; i < len(input) && input[i] != '"'; i++ {
+				if input[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(input) {
+				output.Write(input[start:])
+				return output.Bytes()
+			}
+			output.Write(input[start : i+1])
+		case c == '/' && i+1 < len(input) && input[i+1] == '/':
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+			if i < len(input) {
+				output.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(input) && input[i+1] == '*':
+			end := bytes.Index(input[i+2:], []byte("*/"))
+			if end < 0 {
+				return output.Bytes()
+			}
+			i += end + 3
+		case c == ']' || c == '}':
+			// Drop a comma (and the whitespace after it) directly before the closing bracket.
+			trimmed := bytes.TrimRight(output.Bytes(), " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				output.Truncate(len(trimmed) - 1)
+			}
+			output.WriteByte(c)
+		default:
+			output.WriteByte(c)
+		}
+	}
+	return output.Bytes()
+}
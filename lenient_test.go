@@ -0,0 +1,48 @@
+package solface
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSanitizeJSON(t *testing.T) {
+	input := "\xEF\xBB\xBF" + `[
+	// ERC-20 transfer
+	{
+		"type": "function",
+		"name": "transfer", /* renamed from send */
+		"inputs": [
+			{"name": "to", "type": "address"},
+			{"name": "amount", "type": "uint256"},
+		],
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "nonpayable",
+	},
+	{"type": "event", "name": "Note", "inputs": [{"name": "url // not a comment, ]", "type": "string", "indexed": false}], "anonymous": false},
+]`
+	sanitized := SanitizeJSON([]byte(input))
+	if !json.Valid(sanitized) {
+		t.Fatalf("Expected sanitized JSON to be valid. Actual:\n%s", string(sanitized))
+	}
+
+	abi, decodeErr := Decode(sanitized)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding sanitized ABI: %s", decodeErr.Error())
+	}
+	if len(abi.Functions) != 1 || FunctionSignature(abi.Functions[0]) != "transfer(address,uint256)" {
+		t.Fatalf("Expected transfer(address,uint256). Actual: %v", abi.Functions)
+	}
+	if len(abi.Events) != 1 || abi.Events[0].Inputs[0].Name != "url // not a comment, ]" {
+		t.Fatalf("Expected strings to be left untouched. Actual: %v", abi.Events)
+	}
+
+	encoded := "W3sidHlwZSI6ICJmdW5jdGlvbiJ9XQ//,]"
+	if actual := string(SanitizeJSON([]byte(encoded))); actual != encoded {
+		t.Fatalf("Expected non-JSON input to be unchanged. Actual: %s", actual)
+	}
+
+	valid := `[{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}]`
+	if actual := string(SanitizeJSON([]byte(valid))); actual != valid {
+		t.Fatalf("Expected valid JSON to be unchanged. Actual: %s", actual)
+	}
+}
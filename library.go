@@ -0,0 +1,153 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Kinds of contracts whose ABIs solface generates interfaces for (see Options.Kind).
+const (
+	// Every function of the ABI is declared in the interface (the default).
+	KindContract = "contract"
+	// The ABI belongs to a library, and only the functions which other contracts can call on the deployed
+	// library are declared in the interface (see LibraryABI).
+	KindLibrary = "library"
+)
+
+// Returns true if the given type of a library ABI is a storage reference (e.g. "Set.Data storage" or
+// "mapping(address => uint256) storage"), which the ABI does not encode.
+func isStorageReferenceType(solidityType string) bool {
+	return strings.HasSuffix(solidityType, " storage") || strings.HasPrefix(solidityType, "mapping(")
+}
+
+// Returns true if the given type of a library ABI is the name of an enum or contract type (e.g. "Set.Kind"
+// or "IERC20[]") rather than the ABI type which encodes it - library ABIs name these types, and compute
+// selectors from their names.
+func isLibraryNamedType(solidityType string) bool {
+	element, _ := splitArrayDimensions(solidityType)
+	switch element {
+	case "string", "bytes", "tuple":
+		return false
+	}
+	return !isElementaryValueType(element) && !isStorageReferenceType(solidityType)
+}
+
+// Returns true if any of the given values (or their components) satisfies the given predicate.
+func anyLibraryValue(values []Value, predicate func(Value) bool) bool {
+	for _, value := range values {
+		if predicate(value) || anyLibraryValue(value.Components, predicate) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the given value of a library ABI is a struct (or an array of structs) - library selectors
+// name structs by their qualified names (e.g. "norm(Geo.Point)") rather than as tuples.
+func isLibraryStruct(value Value) bool {
+	element, _ := splitArrayDimensions(value.Type)
+	return element == "tuple" && strings.HasPrefix(value.InternalType, "struct ")
+}
+
+// Returns the signature from which the selector of the given library function is computed. Unlike
+// FunctionSignature, it names structs by their qualified names (e.g. "norm(Geo.Point)").
+func LibraryFunctionSignature(functionItem FunctionItem) string {
+	types := make([]string, len(functionItem.Inputs))
+	for i, input := range functionItem.Inputs {
+		types[i] = CanonicalType(input)
+		if isLibraryStruct(input) {
+			types[i] = strings.TrimSpace(strings.TrimPrefix(input.InternalType, "struct "))
+		}
+	}
+	return fmt.Sprintf("%s(%s)", functionItem.Name, strings.Join(types, ","))
+}
+
+// Returns the ABI type which encodes the given value of a library ABI - uint8 for enums and address for
+// contracts, with the array dimensions of the value - along with false if the value names neither.
+func libraryValueABIType(value Value) (string, bool) {
+	_, dimensions := splitArrayDimensions(value.Type)
+	switch {
+	case strings.HasPrefix(value.InternalType, "enum "):
+		return "uint8" + dimensions, true
+	case strings.HasPrefix(value.InternalType, "contract "), strings.HasPrefix(value.InternalType, "interface "):
+		return "address" + dimensions, true
+	}
+	return "", false
+}
+
+// Returns the reason why the given function of a library ABI cannot be called on the deployed library
+// through an interface, or an empty string if it can:
+//  1. Functions which take storage references can only be called by contracts linked against the library.
+//  2. Libraries revert when their state-changing functions are called directly rather than through
+//     delegatecall.
+//  3. The selectors of functions which take enums, contracts, or structs are computed from the names of
+//     those types, which an interface declaring them as uint8, address, or tuples cannot reproduce.
+//  4. Return values whose types are neither ABI types nor enums or contracts cannot be decoded.
+func LibraryFunctionProblem(functionItem FunctionItem, hasher Hasher) string {
+	if anyLibraryValue(functionItem.Inputs, func(value Value) bool { return isStorageReferenceType(value.Type) }) {
+		return "takes storage references, so it can only be called by contracts linked against the library"
+	}
+	if mutability := NormalizedStateMutability(functionItem); mutability != "view" && mutability != "pure" {
+		return fmt.Sprintf("is %s, and libraries revert when state-changing functions are called directly rather than through delegatecall", mutability)
+	}
+	if anyLibraryValue(functionItem.Inputs, func(value Value) bool { return isLibraryNamedType(value.Type) || isLibraryStruct(value) }) {
+		signature := LibraryFunctionSignature(functionItem)
+		selector := hex.EncodeToString(hasherOrDefault(hasher).Hash([]byte(signature))[:4])
+		return fmt.Sprintf("takes enums, contracts, or structs, so its selector (0x%s, from %s) cannot be reproduced by an interface", selector, signature)
+	}
+	if anyLibraryValue(functionItem.Outputs, func(value Value) bool {
+		_, ok := libraryValueABIType(value)
+		return (isStorageReferenceType(value.Type) || isLibraryNamedType(value.Type)) && !ok
+	}) {
+		return "returns values whose types are not ABI types"
+	}
+	return ""
+}
+
+// Returns a diagnostic for every function of the given library ABI which cannot be called on the deployed
+// library through an interface (see LibraryFunctionProblem), and which LibraryABI therefore drops.
+func LibraryDiagnostics(abi DecodedABI, hasher Hasher) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for i, functionItem := range abi.Functions {
+		if problem := LibraryFunctionProblem(functionItem, hasher); problem != "" {
+			diagnostics = append(diagnostics, Diagnostic{ItemType: "function", ItemIndex: i, Name: functionItem.Name, Message: fmt.Sprintf("skipped library function: it %s", problem)})
+		}
+	}
+	return diagnostics
+}
+
+// Prepares the given library ABI for an interface through which other contracts call the deployed library:
+// drops the functions which cannot be called that way (see LibraryFunctionProblem), and replaces the enum
+// and contract types which library ABIs name (e.g. "Set.Kind") with the ABI types which encode them (uint8
+// and address), keeping the names in their internalType. Returns the resulting ABI along with the comment
+// lines to be generated before the interface declaration.
+func LibraryABI(abi DecodedABI, hasher Hasher) (DecodedABI, []string) {
+	result := FilterABI(abi, func(itemType string, itemIndex int) bool {
+		return itemType != "function" || LibraryFunctionProblem(abi.Functions[itemIndex], hasher) == ""
+	})
+
+	var transform func(value Value) Value
+	transform = func(value Value) Value {
+		if isLibraryNamedType(value.Type) {
+			if abiType, ok := libraryValueABIType(value); ok {
+				value.Type = abiType
+			}
+		}
+		if value.Components != nil {
+			components := make([]Value, len(value.Components))
+			for i, component := range value.Components {
+				components[i] = transform(component)
+			}
+			value.Components = components
+		}
+		return value
+	}
+	result, _ = MapValues(result, nil, transform)
+
+	notes := []string{"// Library: only the view and pure functions which can be called on the deployed library are declared"}
+	if skipped := len(abi.Functions) - len(result.Functions); skipped > 0 {
+		notes = append(notes, fmt.Sprintf("// Library: %d function(s) which cannot be called through an interface are omitted", skipped))
+	}
+	return result, notes
+}
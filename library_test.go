@@ -0,0 +1,82 @@
+package solface
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+const setLibraryABI = `[
+	{"type": "function", "name": "add", "stateMutability": "nonpayable", "inputs": [{"name": "self", "type": "Set.Data storage", "internalType": "struct Set.Data storage"}, {"name": "value", "type": "uint256", "internalType": "uint256"}], "outputs": [{"name": "", "type": "bool", "internalType": "bool"}]},
+	{"type": "function", "name": "total", "stateMutability": "view", "inputs": [{"name": "balances", "type": "mapping(address => uint256) storage", "internalType": "mapping(address => uint256)"}], "outputs": [{"name": "", "type": "uint256", "internalType": "uint256"}]},
+	{"type": "function", "name": "weight", "stateMutability": "pure", "inputs": [{"name": "kind", "type": "Set.Kind", "internalType": "enum Set.Kind"}], "outputs": [{"name": "", "type": "uint256", "internalType": "uint256"}]},
+	{"type": "function", "name": "norm", "stateMutability": "pure", "inputs": [{"name": "point", "type": "tuple", "internalType": "struct Geo.Point", "components": [{"name": "x", "type": "int256", "internalType": "int256"}, {"name": "y", "type": "int256", "internalType": "int256"}]}], "outputs": [{"name": "", "type": "uint256", "internalType": "uint256"}]},
+	{"type": "function", "name": "sum", "stateMutability": "pure", "inputs": [{"name": "values", "type": "uint256[]", "internalType": "uint256[]"}], "outputs": [{"name": "", "type": "uint256", "internalType": "uint256"}]},
+	{"type": "function", "name": "defaults", "stateMutability": "pure", "inputs": [], "outputs": [{"name": "kind", "type": "Set.Kind", "internalType": "enum Set.Kind"}, {"name": "tokens", "type": "IERC20[]", "internalType": "contract IERC20[]"}]},
+	{"type": "function", "name": "reset", "stateMutability": "nonpayable", "inputs": [], "outputs": []},
+	{"type": "event", "name": "Added", "anonymous": false, "inputs": [{"name": "value", "type": "uint256", "indexed": false, "internalType": "uint256"}]}
+]`
+
+func TestLibraryDiagnostics(t *testing.T) {
+	abi, decodeErr := Decode([]byte(setLibraryABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	diagnostics := LibraryDiagnostics(abi, nil)
+	names := []string{}
+	for _, diagnostic := range diagnostics {
+		names = append(names, diagnostic.Name)
+	}
+	if strings.Join(names, ",") != "add,total,weight,norm,reset" {
+		t.Fatalf("Expected diagnostics for add, total, weight, norm, and reset. Actual: %v", diagnostics)
+	}
+	// Library selectors are computed from the names of enums.
+	if !strings.Contains(diagnostics[2].Message, "weight(Set.Kind)") || !strings.Contains(diagnostics[2].Message, "0x"+hexSelector("weight(Set.Kind)")) {
+		t.Fatalf("Expected the diagnostic for weight to give its library selector. Actual: %s", diagnostics[2].Message)
+	}
+	// Library selectors name structs by their qualified names, rather than as tuples.
+	if !strings.Contains(diagnostics[3].Message, "norm(Geo.Point)") || !strings.Contains(diagnostics[3].Message, "0x"+hexSelector("norm(Geo.Point)")) {
+		t.Fatalf("Expected the diagnostic for norm to give its library selector. Actual: %s", diagnostics[3].Message)
+	}
+	if !strings.Contains(diagnostics[4].Message, "nonpayable") {
+		t.Fatalf("Expected the diagnostic for reset to give its state mutability. Actual: %s", diagnostics[3].Message)
+	}
+}
+
+func TestGenerateInterfaceLibrary(t *testing.T) {
+	abi, decodeErr := Decode([]byte(setLibraryABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	annotations, annotationErr := Annotate(abi)
+	if annotationErr != nil {
+		t.Fatalf("Error generating annotations: %s", annotationErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, annotations, Options{Name: "ISet", IncludeAnnotations: true, Kind: KindLibrary}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	for _, expected := range []string{
+		"// Library: 5 function(s) which cannot be called through an interface are omitted",
+		"\tevent Added(uint256 value);",
+		"\t// Selector: " + hexSelector("sum(uint256[])") + "\n\tfunction sum(uint256[] memory values) external pure returns (uint256);",
+		"\t// Selector: " + hexSelector("defaults()") + "\n\tfunction defaults() external pure returns (uint8 /* enum Set.Kind */ kind, address[] memory tokens);",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected interface to contain %q. Actual interface:\n%s", expected, output.String())
+		}
+	}
+	for _, unexpected := range []string{"storage", "function add", "function weight", "function norm", "struct Point", "function reset"} {
+		if strings.Contains(output.String(), unexpected) {
+			t.Fatalf("Expected interface not to contain %q. Actual interface:\n%s", unexpected, output.String())
+		}
+	}
+}
+
+// Returns the hex-encoded selector of the given function signature.
+func hexSelector(signature string) string {
+	return hex.EncodeToString(Keccak256Hasher.Hash([]byte(signature))[:4])
+}
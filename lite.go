@@ -0,0 +1,84 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Returns a copy of the given values in which every compound value (tuple or tuple array) is replaced by
+// a bytes value with the same name, along with the NatSpec continuation lines documenting the encoding of
+// each replaced value. Unnamed values are documented by position.
+func liteValues(values []Value, tag string) ([]Value, []string) {
+	result := make([]Value, len(values))
+	notes := []string{}
+	for i, value := range values {
+		result[i] = value
+		if !value.IsCompoundType() {
+			continue
+		}
+		result[i] = Value{Name: value.Name, Type: "bytes"}
+		name := value.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		notes = append(notes, fmt.Sprintf("/// %s %s is the ABI encoding of %s", tag, name, CanonicalType(value)))
+	}
+	return result, notes
+}
+
+// Returns the given ABI with the compound parameters and return values of all items replaced by bytes, so
+// that interfaces generated from it declare no structs (see Options.Lite). The second return value gives,
+// for each function, the NatSpec lines documenting the encoding of the replaced values and the selector of
+// the original function (which the lite declaration does not have). The third return value gives the
+// comment lines documenting events and errors with replaced parameters, whose topics and selectors also
+// differ from the lite declarations.
+func LiteABI(abi DecodedABI, hasher Hasher) (DecodedABI, [][]string, []string) {
+	result := abi
+	functionNotes := make([][]string, len(abi.Functions))
+	headerNotes := []string{}
+
+	result.Functions = make([]FunctionItem, len(abi.Functions))
+	for i, functionItem := range abi.Functions {
+		inputs, inputNotes := liteValues(functionItem.Inputs, "Parameter")
+		outputs, outputNotes := liteValues(functionItem.Outputs, "Return value")
+		result.Functions[i] = functionItem
+		result.Functions[i].Inputs = inputs
+		result.Functions[i].Outputs = outputs
+		if len(inputNotes)+len(outputNotes) > 0 {
+			selector := hex.EncodeToString(MethodSelectorWithHasher(functionItem, hasher))
+			functionNotes[i] = append(functionNotes[i], fmt.Sprintf("/// @dev lite: call with selector 0x%s (%s)", selector, FunctionSignature(functionItem)))
+			functionNotes[i] = append(functionNotes[i], inputNotes...)
+			functionNotes[i] = append(functionNotes[i], outputNotes...)
+		}
+	}
+
+	result.Events = make([]EventItem, len(abi.Events))
+	for i, eventItem := range abi.Events {
+		result.Events[i] = eventItem
+		result.Events[i].Inputs = make([]EventArgument, len(eventItem.Inputs))
+		replaced := false
+		for j, input := range eventItem.Inputs {
+			result.Events[i].Inputs[j] = input
+			if input.IsCompoundType() {
+				result.Events[i].Inputs[j].Value = Value{Name: input.Name, Type: "bytes"}
+				replaced = true
+			}
+		}
+		if replaced {
+			headerNotes = append(headerNotes, fmt.Sprintf("// lite: event %s is declared with bytes in place of tuples (topic 0x%x)", EventSignature(eventItem), EventTopicWithHasher(eventItem, hasher)))
+		}
+	}
+
+	result.Errors = make([]ErrorItem, len(abi.Errors))
+	for i, errorItem := range abi.Errors {
+		inputs, inputNotes := liteValues(errorItem.Inputs, "Parameter")
+		result.Errors[i] = errorItem
+		result.Errors[i].Inputs = inputs
+		if len(inputNotes) > 0 {
+			selector := hasherOrDefault(hasher).Hash([]byte(ErrorSignature(errorItem)))[:4]
+			headerNotes = append(headerNotes, fmt.Sprintf("// lite: error %s is declared with bytes in place of tuples (selector 0x%x)", ErrorSignature(errorItem), selector))
+		}
+	}
+
+	return result, functionNotes, headerNotes
+}
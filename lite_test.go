@@ -0,0 +1,38 @@
+package solface
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerateInterfaceLite(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/DiamondCutFacet.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IDiamondCut", Lite: true, FunctionRenames: map[string]string{"0x1f931c1c": "cut"}}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+
+	if strings.Contains(output.String(), "struct ") {
+		t.Fatalf("Expected no structs in lite interface. Actual:\n%s", output.String())
+	}
+	expectedSections := []string{
+		"// lite: event DiamondCut((address,uint8,bytes4[])[],address,bytes) is declared with bytes in place of tuples (topic 0x8faa70878671ccd212d20771b795c50af8fd3ff6cf27f4bde57e5d4de0aeb673)\ninterface IDiamondCut {",
+		"\tevent DiamondCut(bytes _diamondCut, address _init, bytes _calldata);",
+		"\t// original: diamondCut\n\t/// @dev lite: call with selector 0x1f931c1c (diamondCut((address,uint8,bytes4[])[],address,bytes))\n\t/// Parameter _diamondCut is the ABI encoding of (address,uint8,bytes4[])[]\n\tfunction cut(bytes memory _diamondCut, address _init, bytes memory _calldata) external;",
+	}
+	for _, expected := range expectedSections {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+		}
+	}
+}
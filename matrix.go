@@ -0,0 +1,201 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Represents a row of a compatibility matrix: an item identified by its canonical signature, and whether
+// each of the compared ABIs includes it.
+//  1. Kind: One of "function", "event", or "error".
+//  2. Signature: The canonical signature of the item.
+//  3. Supported: For each compared ABI (in the order of the matrix columns), whether or not the ABI
+//     includes the item.
+type CompatibilityRow struct {
+	Kind      string `json:"kind"`
+	Signature string `json:"signature"`
+	Supported []bool `json:"supported"`
+}
+
+// Represents the items supported by each of several ABIs (e.g. different deployed versions of the same
+// contract), indexed by canonical signature.
+//  1. Columns: The names of the compared ABIs.
+//  2. Rows: One row for every item in any of the ABIs, sorted by kind (functions, then events, then
+//     errors) and then by signature.
+type CompatibilityMatrix struct {
+	Columns []string           `json:"columns"`
+	Rows    []CompatibilityRow `json:"rows"`
+}
+
+// The order in which the kinds of items appear in a compatibility matrix.
+var compatibilityKindOrder = map[string]int{"function": 0, "event": 1, "error": 2}
+
+// Builds the compatibility matrix of the given ABIs, whose names are given by the corresponding entries of
+// names. Items are identified by their kind and canonical signature, so renamed parameters and changed
+// mutability do not affect compatibility.
+func BuildCompatibilityMatrix(names []string, abis []DecodedABI) CompatibilityMatrix {
+	matrix := CompatibilityMatrix{Columns: names, Rows: []CompatibilityRow{}}
+	rowIndices := map[string]int{}
+	mark := func(kind, signature string, column int) {
+		key := kind + " " + signature
+		index, ok := rowIndices[key]
+		if !ok {
+			index = len(matrix.Rows)
+			rowIndices[key] = index
+			matrix.Rows = append(matrix.Rows, CompatibilityRow{Kind: kind, Signature: signature, Supported: make([]bool, len(abis))})
+		}
+		matrix.Rows[index].Supported[column] = true
+	}
+
+	for column, abi := range abis {
+		for _, functionItem := range abi.Functions {
+			mark("function", FunctionSignature(functionItem), column)
+		}
+		for _, eventItem := range abi.Events {
+			mark("event", EventSignature(eventItem), column)
+		}
+		for _, errorItem := range abi.Errors {
+			mark("error", ErrorSignature(errorItem), column)
+		}
+	}
+
+	sort.Slice(matrix.Rows, func(i, j int) bool {
+		if matrix.Rows[i].Kind != matrix.Rows[j].Kind {
+			return compatibilityKindOrder[matrix.Rows[i].Kind] < compatibilityKindOrder[matrix.Rows[j].Kind]
+		}
+		return matrix.Rows[i].Signature < matrix.Rows[j].Signature
+	})
+	return matrix
+}
+
+// Returns the rows of the given matrix for items which are included in some, but not all, of the ABIs.
+func (matrix CompatibilityMatrix) Differences() []CompatibilityRow {
+	differences := []CompatibilityRow{}
+	for _, row := range matrix.Rows {
+		count := 0
+		for _, supported := range row.Supported {
+			if supported {
+				count++
+			}
+		}
+		if count < len(row.Supported) {
+			differences = append(differences, row)
+		}
+	}
+	return differences
+}
+
+// Writes the given compatibility matrix as a Markdown table, with a column for every ABI.
+func WriteCompatibilityMatrixMarkdown(matrix CompatibilityMatrix, writer io.Writer) error {
+	header := []string{"Kind", "Signature"}
+	separator := []string{"---", "---"}
+	for _, column := range matrix.Columns {
+		header = append(header, column)
+		separator = append(separator, ":---:")
+	}
+	_, writeErr := fmt.Fprintf(writer, "| %s |\n| %s |\n", strings.Join(header, " | "), strings.Join(separator, " | "))
+	if writeErr != nil {
+		return writeErr
+	}
+
+	for _, row := range matrix.Rows {
+		cells := []string{row.Kind, fmt.Sprintf("`%s`", row.Signature)}
+		for _, supported := range row.Supported {
+			if supported {
+				cells = append(cells, "yes")
+			} else {
+				cells = append(cells, "no")
+			}
+		}
+		if _, writeErr := fmt.Fprintf(writer, "| %s |\n", strings.Join(cells, " | ")); writeErr != nil {
+			return writeErr
+		}
+	}
+	return nil
+}
+
+// Represents an item which was added to, removed from, or modified in an ABI.
+//  1. Kind: One of "function", "event", or "error".
+//  2. Signature: The canonical signature of the item.
+//  3. Added: Whether the item was added (true) or removed (false).
+//  4. Modified: Whether the item is in both versions of the ABI under the same signature, but differs
+//     otherwise (e.g. in its mutability, parameter names, or struct member names) - see ModifiedABIItems.
+type ABIChange struct {
+	Kind      string `json:"kind"`
+	Signature string `json:"signature"`
+	Added     bool   `json:"added"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+// Returns the items which were added or removed between two versions of an ABI, identified by kind and
+// canonical signature as in BuildCompatibilityMatrix.
+func ABIChanges(previous, current DecodedABI) []ABIChange {
+	changes := []ABIChange{}
+	for _, row := range BuildCompatibilityMatrix([]string{"previous", "current"}, []DecodedABI{previous, current}).Differences() {
+		changes = append(changes, ABIChange{Kind: row.Kind, Signature: row.Signature, Added: row.Supported[1]})
+	}
+	return changes
+}
+
+// Returns the JSON representation of the given function, event, or error, without the fields which do not
+// describe its interface (see FunctionItem.Extras) and with its mutability normalized (see
+// NormalizedStateMutability), so that equivalent items have the same representation.
+func normalizedItemJSON(item interface{}) string {
+	switch typed := item.(type) {
+	case FunctionItem:
+		typed.StateMutability, typed.Constant, typed.Payable = NormalizedStateMutability(typed), false, false
+		typed.Extras = nil
+		item = typed
+	case EventItem:
+		typed.Extras = nil
+		item = typed
+	case ErrorItem:
+		typed.Extras = nil
+		item = typed
+	}
+	serialized, _ := json.Marshal(item)
+	return string(serialized)
+}
+
+// Returns the items which are in both versions of an ABI under the same kind and canonical signature, but
+// differ otherwise (e.g. in their mutability, parameter names, whether event parameters are indexed, or
+// the names of struct members) - changes which ABIChanges does not report. Items are sorted as in
+// BuildCompatibilityMatrix.
+func ModifiedABIItems(previous, current DecodedABI) []ABIChange {
+	previousItems := map[string]string{}
+	for _, functionItem := range previous.Functions {
+		previousItems["function "+FunctionSignature(functionItem)] = normalizedItemJSON(functionItem)
+	}
+	for _, eventItem := range previous.Events {
+		previousItems["event "+EventSignature(eventItem)] = normalizedItemJSON(eventItem)
+	}
+	for _, errorItem := range previous.Errors {
+		previousItems["error "+ErrorSignature(errorItem)] = normalizedItemJSON(errorItem)
+	}
+
+	changes := []ABIChange{}
+	check := func(kind, signature string, item interface{}) {
+		if previousItem, ok := previousItems[kind+" "+signature]; ok && previousItem != normalizedItemJSON(item) {
+			changes = append(changes, ABIChange{Kind: kind, Signature: signature, Modified: true})
+		}
+	}
+	for _, functionItem := range current.Functions {
+		check("function", FunctionSignature(functionItem), functionItem)
+	}
+	for _, eventItem := range current.Events {
+		check("event", EventSignature(eventItem), eventItem)
+	}
+	for _, errorItem := range current.Errors {
+		check("error", ErrorSignature(errorItem), errorItem)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return compatibilityKindOrder[changes[i].Kind] < compatibilityKindOrder[changes[j].Kind]
+		}
+		return changes[i].Signature < changes[j].Signature
+	})
+	return changes
+}
@@ -0,0 +1,97 @@
+package solface
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuildCompatibilityMatrix(t *testing.T) {
+	v1, decodeErr := Decode([]byte(`[{"type": "function", "name": "deposit", "inputs": [{"name": "amount", "type": "uint256"}], "outputs": [], "stateMutability": "nonpayable"}, {"type": "event", "name": "Deposit", "inputs": [{"name": "amount", "type": "uint256", "indexed": false}], "anonymous": false}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	v2, decodeErr := Decode([]byte(`[{"type": "function", "name": "deposit", "inputs": [{"name": "value", "type": "uint256"}], "outputs": [], "stateMutability": "payable"}, {"type": "function", "name": "deposit", "inputs": [{"name": "value", "type": "uint256"}, {"name": "to", "type": "address"}], "outputs": [], "stateMutability": "nonpayable"}, {"type": "error", "name": "Paused", "inputs": []}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	matrix := BuildCompatibilityMatrix([]string{"v1", "v2"}, []DecodedABI{v1, v2})
+	expectedRows := []CompatibilityRow{
+		{Kind: "function", Signature: "deposit(uint256)", Supported: []bool{true, true}},
+		{Kind: "function", Signature: "deposit(uint256,address)", Supported: []bool{false, true}},
+		{Kind: "event", Signature: "Deposit(uint256)", Supported: []bool{true, false}},
+		{Kind: "error", Signature: "Paused()", Supported: []bool{false, true}},
+	}
+	if len(matrix.Rows) != len(expectedRows) {
+		t.Fatalf("Expected %d rows. Actual: %v", len(expectedRows), matrix.Rows)
+	}
+	for i, expected := range expectedRows {
+		actual := matrix.Rows[i]
+		if actual.Kind != expected.Kind || actual.Signature != expected.Signature || actual.Supported[0] != expected.Supported[0] || actual.Supported[1] != expected.Supported[1] {
+			t.Fatalf("Expected row %d to be %v. Actual: %v", i, expected, actual)
+		}
+	}
+
+	if differences := matrix.Differences(); len(differences) != 3 || differences[0].Signature != "deposit(uint256,address)" {
+		t.Fatalf("Expected 3 differences, starting with deposit(uint256,address). Actual: %v", differences)
+	}
+
+	var output bytes.Buffer
+	writeErr := WriteCompatibilityMatrixMarkdown(matrix, &output)
+	if writeErr != nil {
+		t.Fatalf("Could not write matrix: %s", writeErr.Error())
+	}
+	if !strings.Contains(output.String(), "| Kind | Signature | v1 | v2 |\n") || !strings.Contains(output.String(), "| event | `Deposit(uint256)` | yes | no |\n") {
+		t.Fatalf("Unexpected Markdown matrix:\n%s", output.String())
+	}
+}
+
+func TestABIChanges(t *testing.T) {
+	previous, decodeErr := Decode([]byte(`[{"type": "function", "name": "upgradeTo", "inputs": [{"name": "implementation", "type": "address"}], "outputs": [], "stateMutability": "nonpayable"}, {"type": "event", "name": "Upgraded", "inputs": [{"name": "implementation", "type": "address", "indexed": true}], "anonymous": false}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	current, decodeErr := Decode([]byte(`[{"type": "function", "name": "upgradeToAndCall", "inputs": [{"name": "implementation", "type": "address"}, {"name": "data", "type": "bytes"}], "outputs": [], "stateMutability": "payable"}, {"type": "event", "name": "Upgraded", "inputs": [{"name": "newImplementation", "type": "address", "indexed": true}], "anonymous": false}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	changes := ABIChanges(previous, current)
+	expected := []ABIChange{
+		{Kind: "function", Signature: "upgradeTo(address)", Added: false},
+		{Kind: "function", Signature: "upgradeToAndCall(address,bytes)", Added: true},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes. Actual: %v", len(expected), changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Fatalf("Expected change %d to be %v. Actual: %v", i, expected[i], changes[i])
+		}
+	}
+
+	if unchanged := ABIChanges(current, current); len(unchanged) != 0 {
+		t.Fatalf("Expected no changes between identical ABIs. Actual: %v", unchanged)
+	}
+}
+
+func TestModifiedABIItems(t *testing.T) {
+	previous, decodeErr := Decode([]byte(`[{"type": "function", "name": "deposit", "inputs": [], "outputs": [], "stateMutability": "nonpayable"}, {"type": "event", "name": "Upgraded", "inputs": [{"name": "implementation", "type": "address", "indexed": true}], "anonymous": false}, {"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "constant": true}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	current, decodeErr := Decode([]byte(`[{"type": "function", "name": "deposit", "inputs": [], "outputs": [], "stateMutability": "payable"}, {"type": "event", "name": "Upgraded", "inputs": [{"name": "newImplementation", "type": "address", "indexed": true}], "anonymous": false}, {"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view", "gas": 2500}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	changes := ModifiedABIItems(previous, current)
+	expected := []ABIChange{
+		{Kind: "function", Signature: "deposit()", Modified: true},
+		{Kind: "event", Signature: "Upgraded(address)", Modified: true},
+	}
+	if len(changes) != len(expected) || changes[0] != expected[0] || changes[1] != expected[1] {
+		t.Fatalf("Expected changes: %v. Actual: %v", expected, changes)
+	}
+}
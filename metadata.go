@@ -0,0 +1,79 @@
+package solface
+
+import (
+	"encoding/json"
+)
+
+// Represents the parts of the Solidity compiler metadata (https://docs.soliditylang.org/en/latest/metadata.html)
+// that solface uses.
+//  1. CompilationTarget: Maps the source path of the compiled contract to its name.
+//  2. Sources: Maps source paths to their SPDX license identifiers.
+type CompilerMetadata struct {
+	Settings struct {
+		CompilationTarget map[string]string `json:"compilationTarget"`
+	} `json:"settings"`
+	Sources map[string]struct {
+		License string `json:"license"`
+	} `json:"sources"`
+}
+
+// Parses compiler metadata, which may be given either as a JSON object or as a JSON string containing the
+// object (as in the "metadata" field of Hardhat build info and Truffle artifacts).
+func ParseCompilerMetadata(rawJSON []byte) (CompilerMetadata, error) {
+	var metadata CompilerMetadata
+
+	var encoded string
+	if json.Unmarshal(rawJSON, &encoded) == nil {
+		rawJSON = []byte(encoded)
+	}
+
+	unmarshalErr := json.Unmarshal(rawJSON, &metadata)
+	return metadata, unmarshalErr
+}
+
+// Returns the SPDX license identifier of the source file containing the compiled contract, or the empty
+// string if the metadata does not specify one. If the metadata has no compilation target, the license is
+// only returned if every source with a license agrees on it.
+func (metadata CompilerMetadata) License() string {
+	for sourcePath := range metadata.Settings.CompilationTarget {
+		if source, ok := metadata.Sources[sourcePath]; ok {
+			return source.License
+		}
+	}
+
+	license := ""
+	for _, source := range metadata.Sources {
+		if source.License == "" {
+			continue
+		} else if license != "" && source.License != license {
+			return ""
+		}
+		license = source.License
+	}
+	return license
+}
+
+// Returns the SPDX license identifier recorded in the compiler metadata of a compilation artifact (any JSON
+// object with a "metadata" or "rawMetadata" field), or the empty string if the input is not such an
+// artifact or its metadata does not specify a license. Plain ABIs never have a license.
+func ArtifactLicense(rawJSON []byte) string {
+	var artifact map[string]json.RawMessage
+	if json.Unmarshal(rawJSON, &artifact) != nil {
+		return ""
+	}
+
+	for _, field := range []string{"metadata", "rawMetadata"} {
+		rawMetadata, ok := artifact[field]
+		if !ok {
+			continue
+		}
+		metadata, parseErr := ParseCompilerMetadata(rawMetadata)
+		if parseErr != nil {
+			continue
+		}
+		if license := metadata.License(); license != "" {
+			return license
+		}
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+package solface
+
+import (
+	"testing"
+)
+
+func TestArtifactLicense(t *testing.T) {
+	testCases := []struct {
+		description string
+		artifact    string
+		expected    string
+	}{
+		{"plain ABI", `[{"type": "function", "name": "owner", "inputs": [], "outputs": [], "stateMutability": "view"}]`, ""},
+		{"metadata object", `{"abi": [], "metadata": {"settings": {"compilationTarget": {"src/Token.sol": "Token"}}, "sources": {"src/Token.sol": {"license": "MIT"}, "lib/Ownable.sol": {"license": "AGPL-3.0"}}}}`, "MIT"},
+		{"metadata string", `{"abi": [], "metadata": "{\"settings\": {\"compilationTarget\": {\"Token.sol\": \"Token\"}}, \"sources\": {\"Token.sol\": {\"license\": \"Apache-2.0\"}}}"}`, "Apache-2.0"},
+		{"raw metadata without target", `{"abi": [], "rawMetadata": "{\"sources\": {\"A.sol\": {\"license\": \"MIT\"}, \"B.sol\": {}}}"}`, "MIT"},
+		{"conflicting licenses without target", `{"abi": [], "metadata": {"sources": {"A.sol": {"license": "MIT"}, "B.sol": {"license": "GPL-3.0"}}}}`, ""},
+		{"no license", `{"abi": [], "metadata": {"settings": {"compilationTarget": {"A.sol": "A"}}, "sources": {"A.sol": {}}}}`, ""},
+	}
+
+	for _, testCase := range testCases {
+		actual := ArtifactLicense([]byte(testCase.artifact))
+		if actual != testCase.expected {
+			t.Fatalf("Case: %s. Expected license: %q. Actual: %q", testCase.description, testCase.expected, actual)
+		}
+	}
+}
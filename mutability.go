@@ -0,0 +1,103 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Names of well-known getters of ERC token, ownership, and permit interfaces.
+var knownGetterNames = map[string]bool{
+	"allowance":         true,
+	"balanceOf":         true,
+	"decimals":          true,
+	"DOMAIN_SEPARATOR":  true,
+	"getApproved":       true,
+	"isApprovedForAll":  true,
+	"name":              true,
+	"nonces":            true,
+	"owner":             true,
+	"ownerOf":           true,
+	"supportsInterface": true,
+	"symbol":            true,
+	"tokenURI":          true,
+	"totalSupply":       true,
+	"uri":               true,
+}
+
+// Matches the names of functions which are conventionally getters: "getX", "isX", and "hasX", and
+// constant-style names like "MAX_SUPPLY".
+var getterNameRegexp = regexp.MustCompile(`^(?:(?:get|is|has)[A-Z0-9_]|[A-Z][A-Z0-9]*(?:_[A-Z0-9]+)*$)`)
+
+// Returns true if the given function name is conventionally used for getters (e.g. "balanceOf",
+// "totalSupply", "getReserves", or "MAX_SUPPLY").
+func IsGetterName(name string) bool {
+	return knownGetterNames[name] || getterNameRegexp.MatchString(name)
+}
+
+// Returns a diagnostic for every function in the given ABI which is named like a getter (see IsGetterName)
+// and returns values, but which the ABI declares as state-changing - this is often a bug in the tool which
+// generated the ABI (e.g. a hand-written or legacy ABI without stateMutability). Such functions can be
+// declared as view with Options.AssumeView.
+func SuspiciousGetterDiagnostics(abi DecodedABI) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for i, functionItem := range abi.Functions {
+		mutability := NormalizedStateMutability(functionItem)
+		if mutability == "view" || mutability == "pure" || len(functionItem.Outputs) == 0 || !IsGetterName(functionItem.Name) {
+			continue
+		}
+		selector := hex.EncodeToString(MethodSelector(functionItem))
+		diagnostics = append(diagnostics, Diagnostic{ItemType: "function", ItemIndex: i, Name: functionItem.Name, Message: fmt.Sprintf("function is named like a getter but is %s in the ABI - if it does not modify state, it can be declared as view by its selector (0x%s)", mutability, selector)})
+	}
+	return diagnostics
+}
+
+// Declares the functions of the given ABI with the given selectors (with or without a 0x prefix) as view,
+// overriding the state mutability recorded in the ABI. Returns the resulting ABI along with, for every
+// overridden function (by index), a comment documenting the override. Returns an error if a selector does
+// not match any function in the ABI.
+func AssumeView(abi DecodedABI, selectors []string) (DecodedABI, map[int]string, error) {
+	notes := map[int]string{}
+	if len(selectors) == 0 {
+		return abi, notes, nil
+	}
+
+	requested := map[string]bool{}
+	for _, selector := range selectors {
+		requested[NormalizeSelector(selector)] = true
+	}
+
+	result := abi
+	result.Functions = make([]FunctionItem, len(abi.Functions))
+	used := map[string]bool{}
+	for i, functionItem := range abi.Functions {
+		result.Functions[i] = functionItem
+		selector := hex.EncodeToString(MethodSelector(functionItem))
+		if !requested[selector] {
+			continue
+		}
+		used[selector] = true
+		mutability := NormalizedStateMutability(functionItem)
+		if mutability == "view" || mutability == "pure" {
+			continue
+		}
+		result.Functions[i].StateMutability = "view"
+		result.Functions[i].Constant = true
+		result.Functions[i].Payable = false
+		notes[i] = fmt.Sprintf("// mutability: assumed view (%s in the ABI)", mutability)
+	}
+
+	unused := []string{}
+	for selector := range requested {
+		if !used[selector] {
+			unused = append(unused, selector)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return abi, map[int]string{}, fmt.Errorf("selectors assumed to be view do not match any function in the ABI: %s", strings.Join(unused, ", "))
+	}
+	return result, notes, nil
+}
@@ -0,0 +1,79 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+const legacyGettersABI = `[
+	{"type": "function", "name": "balanceOf", "constant": false, "payable": false, "inputs": [{"name": "owner", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "getReserves", "stateMutability": "nonpayable", "inputs": [], "outputs": [{"name": "", "type": "uint112"}]},
+	{"type": "function", "name": "MAX_SUPPLY", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "transfer", "stateMutability": "nonpayable", "inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "isPaused", "stateMutability": "nonpayable", "inputs": [], "outputs": []}
+]`
+
+func TestIsGetterName(t *testing.T) {
+	expected := map[string]bool{
+		"balanceOf":        true,
+		"totalSupply":      true,
+		"getReserves":      true,
+		"isApprovedForAll": true,
+		"hasRole":          true,
+		"MAX_SUPPLY":       true,
+		"transfer":         false,
+		"getaway":          false,
+		"issue":            false,
+		"mint":             false,
+	}
+	for name, getter := range expected {
+		if IsGetterName(name) != getter {
+			t.Fatalf("Expected IsGetterName(%s) to be %t. Actual: %t", name, getter, !getter)
+		}
+	}
+}
+
+func TestSuspiciousGetterDiagnostics(t *testing.T) {
+	abi, decodeErr := Decode([]byte(legacyGettersABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	// MAX_SUPPLY is view, transfer is not a getter, and isPaused returns nothing.
+	diagnostics := SuspiciousGetterDiagnostics(abi)
+	if len(diagnostics) != 2 || diagnostics[0].Name != "balanceOf" || diagnostics[1].Name != "getReserves" {
+		t.Fatalf("Expected diagnostics for balanceOf and getReserves. Actual: %v", diagnostics)
+	}
+	if !strings.Contains(diagnostics[0].Message, "0x70a08231") {
+		t.Fatalf("Expected the diagnostic to give the selector of balanceOf. Actual: %s", diagnostics[0].Message)
+	}
+}
+
+func TestGenerateInterfaceAssumeView(t *testing.T) {
+	abi, decodeErr := Decode([]byte(legacyGettersABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "ILegacy", AssumeView: []string{"0x70a08231"}}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expected := "\t// mutability: assumed view (nonpayable in the ABI)\n\tfunction balanceOf(address owner) external view returns (uint256);"
+	if !strings.Contains(output.String(), expected) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+	}
+	if !strings.Contains(output.String(), "function getReserves() external returns (uint112);") {
+		t.Fatalf("Expected getReserves to keep its mutability. Actual:\n%s", output.String())
+	}
+
+	generateErr = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "ILegacy", AssumeView: []string{"12345678"}}, &output)
+	if generateErr == nil || !strings.Contains(generateErr.Error(), "12345678") {
+		t.Fatalf("Expected an error for a selector which matches no function. Actual: %v", generateErr)
+	}
+
+	if validateErr := (Options{AssumeView: []string{"0x1234"}}).Validate(); validateErr == nil {
+		t.Fatal("Expected an error validating a selector which is not 4 bytes long")
+	}
+}
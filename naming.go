@@ -0,0 +1,159 @@
+package solface
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Represents the values available to filename patterns.
+//  1. Name: The name of the generated artifact (e.g. the interface name).
+//  2. Target: The target that generated the artifact.
+type FilenameData struct {
+	Name   string
+	Target string
+}
+
+// Default filename patterns for each target. Targets which are not listed here use fallbackFilenamePattern.
+var filenamePatterns = map[string]string{
+	TargetInterface:    "{{.Name}}.sol",
+	TargetTestVectors:  "{{.Name}}.vectors.json",
+	TargetGoConstants:  "{{snake .Name}}_constants.go",
+	TargetAnnotations:  "{{.Name}}.annotations.txt",
+	TargetEventFilters: "{{.Name}}.filters.json",
+	TargetSentinel:     "{{.Name}}.sentinel.json",
+	TargetSafeBatch:    "{{.Name}}.safe-batch.json",
+	TargetTSEvents:     "{{.Name}}.events.ts",
+	TargetJSON:         "{{.Name}}.ir.json",
+	TargetSelectors:    "{{.Name}}.selectors.bin",
+	TargetEIP712:       "{{.Name}}.eip712.json",
+}
+
+const fallbackFilenamePattern = "{{.Name}}.txt"
+
+var filenameFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"snake": SnakeCase,
+}
+
+// Returns the default filename pattern for the given target.
+func DefaultFilenamePattern(target string) string {
+	pattern, ok := filenamePatterns[target]
+	if !ok {
+		return fallbackFilenamePattern
+	}
+	return pattern
+}
+
+// Converts a CamelCase name to snake_case (e.g. "ERC721Metadata" becomes "erc721_metadata").
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				builder.WriteRune('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+// Returns the name of the file to which the given target should write the artifact with the given name.
+// The pattern is a Go template (e.g. "I{{.Name}}.sol" or "{{snake .Name}}_bindings.go") which is evaluated
+// against FilenameData, and which may use the lower, upper, and snake functions. If the pattern is empty,
+// the default pattern for the target is used.
+//
+// Patterns must produce a relative path which stays inside the output directory.
+func OutputFilename(pattern, target, name string) (string, error) {
+	if pattern == "" {
+		pattern = DefaultFilenamePattern(target)
+	}
+
+	filenameTemplate, parseErr := template.New("filename").Funcs(filenameFuncs).Option("missingkey=error").Parse(pattern)
+	if parseErr != nil {
+		return "", fmt.Errorf("invalid filename pattern %q: %s", pattern, parseErr.Error())
+	}
+
+	var filename bytes.Buffer
+	executeErr := filenameTemplate.Execute(&filename, FilenameData{Name: name, Target: target})
+	if executeErr != nil {
+		return "", fmt.Errorf("invalid filename pattern %q: %s", pattern, executeErr.Error())
+	}
+
+	result := filepath.Clean(filename.String())
+	if filename.Len() == 0 || filepath.IsAbs(result) || result == ".." || strings.HasPrefix(result, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("filename pattern %q produced an invalid filename for %s: %q", pattern, name, filename.String())
+	}
+	return result, nil
+}
+
+// Derives a contract name from the name of the file at the given path, for ABIs which do not record one
+// (e.g. "uniswap-v3-factory.abi.json" becomes "UniswapV3Factory"). Everything after the first "." in the
+// file name is dropped, and words separated by "-", "_", or spaces are capitalized and joined.
+func ContractNameFromFilename(path string) string {
+	base := filepath.Base(path)
+	if dotIndex := strings.Index(base, "."); dotIndex >= 0 {
+		base = base[:dotIndex]
+	}
+	words := strings.FieldsFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var builder strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		builder.WriteRune(unicode.ToUpper(runes[0]))
+		builder.WriteString(string(runes[1:]))
+	}
+	return builder.String()
+}
+
+// Represents the values available to interface name templates.
+//  1. Base: The name of the input file, up to its first "." (e.g. "Token" for "Token.abi.json").
+//  2. Contract: The name of the contract, or a name derived from the input file if the input does not
+//     record one (see ContractNameFromFilename).
+//  3. Path: The path of the input file.
+type InterfaceNameData struct {
+	Base     string
+	Contract string
+	Path     string
+}
+
+// Returns the data available to interface name templates for the contract with the given name (which may
+// be empty) read from the file at the given path.
+func NewInterfaceNameData(path, contractName string) InterfaceNameData {
+	base := filepath.Base(path)
+	if dotIndex := strings.Index(base, "."); dotIndex >= 0 {
+		base = base[:dotIndex]
+	}
+	if contractName == "" {
+		contractName = ContractNameFromFilename(path)
+	}
+	return InterfaceNameData{Base: base, Contract: contractName, Path: path}
+}
+
+// Evaluates the given interface name template (e.g. "I{{.Base}}" or "{{.Contract}}Interface") against the
+// given data. Like filename patterns, templates may use the lower, upper, and snake functions.
+func InterfaceNameFromTemplate(nameTemplate string, data InterfaceNameData) (string, error) {
+	parsedTemplate, parseErr := template.New("name").Funcs(filenameFuncs).Option("missingkey=error").Parse(nameTemplate)
+	if parseErr != nil {
+		return "", fmt.Errorf("invalid name template %q: %s", nameTemplate, parseErr.Error())
+	}
+
+	var name bytes.Buffer
+	executeErr := parsedTemplate.Execute(&name, data)
+	if executeErr != nil {
+		return "", fmt.Errorf("invalid name template %q: %s", nameTemplate, executeErr.Error())
+	}
+	if name.Len() == 0 {
+		return "", fmt.Errorf("name template %q produced an empty name for %s", nameTemplate, data.Path)
+	}
+	return name.String(), nil
+}
@@ -0,0 +1,75 @@
+package solface
+
+import (
+	"testing"
+)
+
+func TestOutputFilename(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		target   string
+		name     string
+		expected string
+	}{
+		{"", TargetInterface, "IOwnableERC20", "IOwnableERC20.sol"},
+		{"", TargetTestVectors, "IOwnableERC20", "IOwnableERC20.vectors.json"},
+		{"", "unknown", "IOwnableERC20", "IOwnableERC20.txt"},
+		{"I{{.Name}}.sol", TargetInterface, "Foo", "IFoo.sol"},
+		{"{{lower .Name}}.ts", TargetInterface, "Foo", "foo.ts"},
+		{"{{snake .Name}}_bindings.go", TargetInterface, "ERC721Metadata", "erc721_metadata_bindings.go"},
+		{"{{.Target}}/{{.Name}}.md", TargetInterface, "IFoo", "interface/IFoo.md"},
+	}
+
+	for _, testCase := range testCases {
+		actual, filenameErr := OutputFilename(testCase.pattern, testCase.target, testCase.name)
+		if filenameErr != nil {
+			t.Fatalf("Pattern: %q. Unexpected error: %s", testCase.pattern, filenameErr.Error())
+		}
+		if actual != testCase.expected {
+			t.Fatalf("Pattern: %q. Expected filename: %s. Actual: %s", testCase.pattern, testCase.expected, actual)
+		}
+	}
+
+	for _, pattern := range []string{"{{.Missing}}.sol", "../{{.Name}}.sol", "/tmp/{{.Name}}.sol", "{{if false}}x{{end}}", "{{.Name"} {
+		_, filenameErr := OutputFilename(pattern, TargetInterface, "IFoo")
+		if filenameErr == nil {
+			t.Fatalf("Pattern: %q. Expected error. Actual: nil", pattern)
+		}
+	}
+}
+
+func TestContractNameFromFilename(t *testing.T) {
+	testCases := map[string]string{
+		"abis/OwnableERC20.json":      "OwnableERC20",
+		"uniswap-v3-factory.abi.json": "UniswapV3Factory",
+		"/tmp/ownable_erc20.json":     "OwnableErc20",
+		"diamond cut facet.json":      "DiamondCutFacet",
+	}
+	for path, expected := range testCases {
+		if actual := ContractNameFromFilename(path); actual != expected {
+			t.Fatalf("Path: %s. Expected: %s. Actual: %s", path, expected, actual)
+		}
+	}
+}
+
+func TestInterfaceNameFromTemplate(t *testing.T) {
+	data := NewInterfaceNameData("artifacts/contracts/Token.sol/Token.json", "")
+	name, nameErr := InterfaceNameFromTemplate("I{{.Base}}", data)
+	if nameErr != nil {
+		t.Fatalf("Unexpected error: %s", nameErr.Error())
+	}
+	if name != "IToken" {
+		t.Fatalf("Expected: IToken. Actual: %s", name)
+	}
+
+	data = NewInterfaceNameData("abis/uniswap-v3-factory.abi.json", "")
+	name, _ = InterfaceNameFromTemplate("{{.Contract}}Interface", data)
+	if name != "UniswapV3FactoryInterface" {
+		t.Fatalf("Expected: UniswapV3FactoryInterface. Actual: %s", name)
+	}
+
+	_, nameErr = InterfaceNameFromTemplate("I{{.Missing}}", data)
+	if nameErr == nil {
+		t.Fatal("Expected error evaluating template with unknown field. Actual: nil")
+	}
+}
@@ -0,0 +1,48 @@
+package solface
+
+import (
+	"fmt"
+)
+
+// The placeholder text of generated NatSpec stubs.
+const NatspecStubPlaceholder = "TODO"
+
+// Returns the NatSpec stub lines for an item with the given inputs: a @notice tag and a @param tag for
+// every named input.
+func natspecStub(inputs []Value) []string {
+	lines := []string{fmt.Sprintf("/// @notice %s", NatspecStubPlaceholder)}
+	for _, input := range inputs {
+		if input.Name != "" {
+			lines = append(lines, fmt.Sprintf("/// @param %s %s", input.Name, NatspecStubPlaceholder))
+		}
+	}
+	return lines
+}
+
+// Returns the NatSpec stub for the given function: a @notice tag, a @param tag for every named input, and
+// a @return tag for every output.
+func FunctionNatspecStub(function FunctionItem) []string {
+	lines := natspecStub(function.Inputs)
+	for _, output := range function.Outputs {
+		if output.Name != "" {
+			lines = append(lines, fmt.Sprintf("/// @return %s %s", output.Name, NatspecStubPlaceholder))
+		} else {
+			lines = append(lines, fmt.Sprintf("/// @return %s", NatspecStubPlaceholder))
+		}
+	}
+	return lines
+}
+
+// Returns the NatSpec stub for the given event: a @notice tag and a @param tag for every named input.
+func EventNatspecStub(event EventItem) []string {
+	inputs := make([]Value, len(event.Inputs))
+	for i, input := range event.Inputs {
+		inputs[i] = input.Value
+	}
+	return natspecStub(inputs)
+}
+
+// Returns the NatSpec stub for the given error: a @notice tag and a @param tag for every named input.
+func ErrorNatspecStub(errorItem ErrorItem) []string {
+	return natspecStub(errorItem.Inputs)
+}
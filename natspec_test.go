@@ -0,0 +1,30 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateInterfaceNatspecStubs(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[{"type": "function", "name": "withdraw", "inputs": [{"name": "amount", "type": "uint256"}, {"name": "", "type": "address"}], "outputs": [{"name": "success", "type": "bool"}, {"name": "", "type": "uint256"}], "stateMutability": "nonpayable"}, {"type": "event", "name": "Withdrawal", "inputs": [{"name": "amount", "type": "uint256", "indexed": false}], "anonymous": false}, {"type": "error", "name": "InsufficientBalance", "inputs": [{"name": "available", "type": "uint256"}]}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IVault", NatspecStubs: true}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+
+	expectedBlocks := []string{
+		"\t/// @notice TODO\n\t/// @param amount TODO\n\tevent Withdrawal(uint256 amount);",
+		"\t/// @notice TODO\n\t/// @param amount TODO\n\t/// @return success TODO\n\t/// @return TODO\n\tfunction withdraw(uint256 amount, address) external returns (bool success, uint256);",
+		"\t/// @notice TODO\n\t/// @param available TODO\n\terror InsufficientBalance(uint256 available);",
+	}
+	for _, block := range expectedBlocks {
+		if !strings.Contains(output.String(), block) {
+			t.Fatalf("Expected generated interface to contain:\n%s\nActual interface:\n%s", block, output.String())
+		}
+	}
+}
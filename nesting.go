@@ -0,0 +1,150 @@
+package solface
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The maximum nesting depth of compound types used when Options.MaxNestingDepth is not set.
+const DefaultMaxNestingDepth int = 64
+
+// Returned when a compound type (directly or transitively) contains itself. ABIs decoded from JSON can
+// never contain cycles, but DecodedABIs constructed programmatically can.
+// Path lists the names of the values from the top-level parameter to the repeated value.
+type CompoundCycleError struct {
+	ItemType string
+	ItemName string
+	Path     []string
+}
+
+func (e *CompoundCycleError) Error() string {
+	return fmt.Sprintf("compound type cycle in %s %s: %s", e.ItemType, e.ItemName, strings.Join(e.Path, " -> "))
+}
+
+// Returned when compound types are nested more deeply than the configured limit.
+// Path lists the names of the values from the top-level parameter to the value which exceeded the limit.
+type NestingDepthError struct {
+	ItemType string
+	ItemName string
+	Path     []string
+	Limit    int
+}
+
+func (e *NestingDepthError) Error() string {
+	return fmt.Sprintf("compound types nested more than %d levels deep in %s %s: %s", e.Limit, e.ItemType, e.ItemName, strings.Join(e.Path, " -> "))
+}
+
+// Returns a readable name for a value in an error path.
+func pathName(value Value) string {
+	if value.Name != "" {
+		return value.Name
+	}
+	return fmt.Sprintf("<%s>", value.Type)
+}
+
+// Walks the components of the given value, checking for cycles and nesting depth. The ancestors
+// parameter contains the component arrays of the compound values on the current path.
+func checkValueNesting(value Value, path []string, ancestors []*Value, maxDepth int) ([]string, bool, bool) {
+	path = append(path, pathName(value))
+	if !value.IsCompoundType() {
+		return nil, false, false
+	}
+
+	components := &value.Components[0]
+	for _, ancestor := range ancestors {
+		if ancestor == components {
+			return path, true, false
+		}
+	}
+	if len(ancestors)+1 > maxDepth {
+		return path, false, true
+	}
+
+	ancestors = append(ancestors, components)
+	for _, component := range value.Components {
+		if failedPath, isCycle, isTooDeep := checkValueNesting(component, path, ancestors, maxDepth); isCycle || isTooDeep {
+			return failedPath, isCycle, isTooDeep
+		}
+	}
+	return nil, false, false
+}
+
+// Checks the given parameters of an item as CheckNesting does, returning a *CompoundCycleError or
+// *NestingDepthError for the first offending parameter, and nil if there is none.
+func checkItemNesting(itemType, itemName string, values []Value, maxDepth int) error {
+	for _, value := range values {
+		path, isCycle, isTooDeep := checkValueNesting(value, []string{}, []*Value{}, maxDepth)
+		if isCycle {
+			return &CompoundCycleError{ItemType: itemType, ItemName: itemName, Path: path}
+		} else if isTooDeep {
+			return &NestingDepthError{ItemType: itemType, ItemName: itemName, Path: path, Limit: maxDepth}
+		}
+	}
+	return nil
+}
+
+// Returns the inputs of the given event as values.
+func eventInputValues(eventItem EventItem) []Value {
+	values := make([]Value, len(eventItem.Inputs))
+	for i, input := range eventItem.Inputs {
+		values[i] = input.Value
+	}
+	return values
+}
+
+// Checks that the compound types in the given ABI are acyclic and nested at most maxDepth levels deep
+// (if maxDepth is not positive, DefaultMaxNestingDepth is used).
+// Returns a *CompoundCycleError or *NestingDepthError identifying the first offending parameter, and nil
+// if there is none.
+func CheckNesting(abi DecodedABI, maxDepth int) error {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxNestingDepth
+	}
+
+	for _, eventItem := range abi.Events {
+		if checkErr := checkItemNesting("event", eventItem.Name, eventInputValues(eventItem), maxDepth); checkErr != nil {
+			return checkErr
+		}
+	}
+	for _, functionItem := range abi.Functions {
+		if checkErr := checkItemNesting("function", functionItem.Name, append(append([]Value{}, functionItem.Inputs...), functionItem.Outputs...), maxDepth); checkErr != nil {
+			return checkErr
+		}
+	}
+	for _, errorItem := range abi.Errors {
+		if checkErr := checkItemNesting("error", errorItem.Name, errorItem.Inputs, maxDepth); checkErr != nil {
+			return checkErr
+		}
+	}
+
+	return nil
+}
+
+// Returns an UnsupportedItem for every item of the given ABI whose compound types are nested more than
+// maxDepth levels deep (if maxDepth is not positive, DefaultMaxNestingDepth is used), so that they can be
+// dropped with RemoveUnsupported. Cycles are not reported, since they can only be constructed
+// programmatically (see CheckNesting).
+func NestingDepthItems(abi DecodedABI, maxDepth int) []UnsupportedItem {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxNestingDepth
+	}
+
+	items := []UnsupportedItem{}
+	check := func(itemType string, itemIndex int, itemName string, values []Value) {
+		var depthErr *NestingDepthError
+		if errors.As(checkItemNesting(itemType, itemName, values, maxDepth), &depthErr) {
+			items = append(items, UnsupportedItem{ItemType: itemType, ItemIndex: itemIndex, Name: itemName, Reason: fmt.Sprintf("compound types nested more than %d levels deep: %s", maxDepth, strings.Join(depthErr.Path, " -> "))})
+		}
+	}
+	for i, eventItem := range abi.Events {
+		check("event", i, eventItem.Name, eventInputValues(eventItem))
+	}
+	for i, functionItem := range abi.Functions {
+		check("function", i, functionItem.Name, append(append([]Value{}, functionItem.Inputs...), functionItem.Outputs...))
+	}
+	for i, errorItem := range abi.Errors {
+		check("error", i, errorItem.Name, errorItem.Inputs)
+	}
+	return items
+}
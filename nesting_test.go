@@ -0,0 +1,65 @@
+package solface
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestCheckNestingDetectsCycles(t *testing.T) {
+	// The components of "node" contain "node" itself, via the shared backing array.
+	components := make([]Value, 2)
+	node := Value{Name: "node", Type: "tuple", Components: components}
+	components[0] = Value{Name: "value", Type: "uint256"}
+	components[1] = Value{Name: "next", Type: "tuple", Components: components}
+
+	abi := DecodedABI{Functions: []FunctionItem{{Type: "function", Name: "insert", Inputs: []Value{node}, StateMutability: "nonpayable"}}}
+
+	var cycleErr *CompoundCycleError
+	if !errors.As(CheckNesting(abi, 0), &cycleErr) {
+		t.Fatal("Expected a CompoundCycleError")
+	}
+	expectedPath := []string{"node", "next"}
+	if cycleErr.ItemName != "insert" || !reflect.DeepEqual(cycleErr.Path, expectedPath) {
+		t.Fatalf("Unexpected cycle. Expected item: insert, path: %v. Actual item: %s, path: %v", expectedPath, cycleErr.ItemName, cycleErr.Path)
+	}
+
+	// Generation must fail rather than recurse forever.
+	if !errors.As(GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IList"}, io.Discard), &cycleErr) {
+		t.Fatal("Expected GenerateInterfaceWithOptions to return a CompoundCycleError")
+	}
+}
+
+func TestCheckNestingDepthLimit(t *testing.T) {
+	value := Value{Name: "leaf", Type: "uint256"}
+	for i := 0; i < 3; i++ {
+		value = Value{Name: "level", Type: "tuple", Components: []Value{value}}
+	}
+	abi := DecodedABI{Events: []EventItem{{Type: "event", Name: "Nested", Inputs: []EventArgument{{Value: value}}}}}
+
+	if nestingErr := CheckNesting(abi, 3); nestingErr != nil {
+		t.Fatalf("Expected 3 levels of nesting to be allowed with a limit of 3. Got: %s", nestingErr.Error())
+	}
+
+	var depthErr *NestingDepthError
+	if !errors.As(CheckNesting(abi, 2), &depthErr) {
+		t.Fatal("Expected a NestingDepthError with a limit of 2")
+	}
+	if depthErr.Limit != 2 || depthErr.ItemType != "event" {
+		t.Fatalf("Unexpected depth error: %s", depthErr.Error())
+	}
+
+	if items := NestingDepthItems(abi, 3); len(items) != 0 {
+		t.Fatalf("Expected no items nested too deeply with a limit of 3. Actual: %v", items)
+	}
+	items := NestingDepthItems(abi, 2)
+	if len(items) != 1 || items[0].ItemType != "event" || items[0].ItemIndex != 0 || items[0].Name != "Nested" {
+		t.Fatalf("Expected the event to be nested too deeply with a limit of 2. Actual: %v", items)
+	}
+
+	_, resolveErr := ResolveCompoundsWithOptions(abi, Options{MaxNestingDepth: 2})
+	if !errors.As(resolveErr, &depthErr) {
+		t.Fatal("Expected ResolveCompoundsWithOptions to return a NestingDepthError")
+	}
+}
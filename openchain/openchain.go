@@ -0,0 +1,90 @@
+// Package openchain looks up function, error, and event signatures by selector or topic in the
+// openchain.xyz signature database, to recover interfaces of contracts which are only known by their
+// selectors and topics.
+package openchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The openchain.xyz API endpoint for signature lookups.
+const DefaultAPIURL = "https://api.openchain.xyz/signature-database/v1/lookup"
+
+// The name of the signature database, for provenance comments.
+const Source = "openchain.xyz"
+
+// Timeout for requests to the openchain.xyz API.
+var RequestTimeout = 30 * time.Second
+
+type lookupResponse struct {
+	OK     bool   `json:"ok"`
+	Error  string `json:"error"`
+	Result map[string]map[string][]struct {
+		Name     string `json:"name"`
+		Filtered bool   `json:"filtered"`
+	} `json:"result"`
+}
+
+// Looks up the signatures of the given kind ("function" or "event") with the given hex-encoded hash in the
+// openchain.xyz API at apiURL (DefaultAPIURL if empty). Signatures which the database flags as spam are
+// excluded. Requests are sent with transport, or with http.DefaultTransport if it is nil.
+func lookup(transport http.RoundTripper, apiURL, kind, hash string) ([]string, error) {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	requestURL, parseErr := url.Parse(apiURL)
+	if parseErr != nil {
+		return nil, fmt.Errorf("invalid API URL %s: %s", apiURL, parseErr.Error())
+	}
+	query := requestURL.Query()
+	query.Set(kind, hash)
+	query.Set("filter", "true")
+	requestURL.RawQuery = query.Encode()
+
+	client := http.Client{Timeout: RequestTimeout, Transport: transport}
+	response, requestErr := client.Get(requestURL.String())
+	if requestErr != nil {
+		return nil, requestErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openchain.xyz request failed with status %s", response.Status)
+	}
+
+	var decoded lookupResponse
+	decodeErr := json.NewDecoder(response.Body).Decode(&decoded)
+	if decodeErr != nil {
+		return nil, fmt.Errorf("could not decode openchain.xyz response: %s", decodeErr.Error())
+	}
+	if !decoded.OK {
+		return nil, fmt.Errorf("openchain.xyz lookup failed: %s", decoded.Error)
+	}
+
+	signatures := []string{}
+	for _, result := range decoded.Result[kind][hash] {
+		if !result.Filtered {
+			signatures = append(signatures, strings.TrimSpace(result.Name))
+		}
+	}
+	return signatures, nil
+}
+
+// Returns the function signatures with the given selector which are known to the openchain.xyz API at
+// apiURL (DefaultAPIURL if empty), in the order the database returns them. Custom errors have selectors
+// computed in the same way as functions, so error signatures are returned as well. Returns an empty list
+// if the selector is not known. Requests are sent with transport, or with http.DefaultTransport if it is nil.
+func FunctionSignatures(transport http.RoundTripper, apiURL string, selector [4]byte) ([]string, error) {
+	return lookup(transport, apiURL, "function", fmt.Sprintf("0x%x", selector))
+}
+
+// Returns the event signatures with the given topic which are known to the openchain.xyz API at apiURL
+// (DefaultAPIURL if empty). Returns an empty list if the topic is not known. Requests are sent with
+// transport, or with http.DefaultTransport if it is nil.
+func EventSignatures(transport http.RoundTripper, apiURL string, topic [32]byte) ([]string, error) {
+	return lookup(transport, apiURL, "event", fmt.Sprintf("0x%x", topic))
+}
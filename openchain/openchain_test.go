@@ -0,0 +1,46 @@
+package openchain
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSignatures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter") != "true" {
+			t.Errorf("Expected spam to be filtered. Actual query: %s", r.URL.RawQuery)
+		}
+		if function := r.URL.Query().Get("function"); function != "" {
+			fmt.Fprintf(w, `{"ok": true, "result": {"event": {}, "function": {"%s": [{"name": "transfer(address,uint256)", "filtered": false}, {"name": "many_msg_babbage(bytes1)", "filtered": true}]}}}`, function)
+			return
+		}
+		fmt.Fprintf(w, `{"ok": true, "result": {"event": {"%s": null}, "function": {}}}`, r.URL.Query().Get("event"))
+	}))
+	defer server.Close()
+
+	signatures, lookupErr := FunctionSignatures(nil, server.URL, [4]byte{0xa9, 0x05, 0x9c, 0xbb})
+	if lookupErr != nil {
+		t.Fatalf("Error looking up signatures: %s", lookupErr.Error())
+	}
+	if len(signatures) != 1 || signatures[0] != "transfer(address,uint256)" {
+		t.Fatalf("Expected only transfer(address,uint256). Actual: %v", signatures)
+	}
+
+	signatures, lookupErr = EventSignatures(nil, server.URL, [32]byte{1})
+	if lookupErr != nil || len(signatures) != 0 {
+		t.Fatalf("Expected no signatures for unknown topic. Actual: %v (error: %v)", signatures, lookupErr)
+	}
+}
+
+func TestSignaturesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": false, "error": "invalid hash"}`)
+	}))
+	defer server.Close()
+
+	if _, lookupErr := FunctionSignatures(nil, server.URL, [4]byte{}); lookupErr == nil {
+		t.Fatalf("Expected an error when the lookup fails")
+	}
+}
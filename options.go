@@ -0,0 +1,127 @@
+package solface
+
+import "time"
+
+// Options control how solface generates Solidity interfaces.
+//  1. Name: The name of the Solidity interface.
+//  2. License: The SPDX license identifier to be generated at the top of the output - if empty, this will
+//     not be included.
+//  3. Pragma: The Solidity pragma to be generated at the top of the output - if empty, this will not be
+//     included.
+//  4. IncludeAnnotations: Whether or not to include annotations (interface ID, method selectors) in the
+//     generated interface.
+//  5. SecurityAnnotations: Whether or not to annotate functions exposing dangerous capabilities (see
+//     SecurityFindings) with @custom:security natspec.
+//  6. Timestamp: The generation time to record in the header of the output - if zero, no generation time
+//     is included, which keeps outputs byte-identical across runs. See GenerationTime.
+//  7. FunctionRenames: Maps function selectors (hex, with or without 0x prefix) to the names those
+//     functions should have in the generated interface. See ApplyRenames.
+//  8. LintSuppressions: Lint-suppression directives (e.g. "solhint-disable no-empty-blocks") to be
+//     generated as comments before the interface declaration.
+//  9. MemberLintSuppressions: Lint-suppression directives (e.g. "solhint-disable-next-line
+//     func-name-mixedcase") to be generated as comments before every function whose name violates
+//     mixedCase naming rules, such as ALL_CAPS getters generated for public constants.
+//  10. MaxNestingDepth: The maximum nesting depth of compound types - if not positive,
+//     DefaultMaxNestingDepth is used. See CheckNesting.
+//  11. SkipInvalid: Whether DecodeWithOptions should drop undecodable ABI items and items whose compound
+//     types are nested more deeply than MaxNestingDepth (with diagnostics) instead of failing.
+//  12. MaxItems: The maximum number of items an ABI may contain - if not positive, there is no limit.
+//  13. MaxInputBytes: The maximum size (in bytes) of the JSON representation of an ABI - if not positive,
+//     there is no limit.
+//  14. Dialect: The language that produced the ABI (DialectSolidity or DialectVyper) - if empty, the
+//     dialect is detected from the ABI. See DetectDialect.
+//  15. Codec: Whether or not to generate a library (named after the interface, with a "Codec" suffix) with
+//     encode and decode helpers for every struct in the interface. Since Solidity does not allow
+//     overloading on return types, the decoder for struct X is named decodeX.
+//  16. IntegerWidthAnnotations: Whether or not to annotate functions which take or return integers
+//     narrower than 256 bits (see IntegerWidthFindings).
+//  17. PreserveABIOrder: Whether or not to render events, functions, and errors in the order of the
+//     original ABI instead of grouping them by type.
+//  18. IndexComments: Whether or not to precede every event, function, and error with a comment giving
+//     its position in the original ABI.
+//  19. Hasher: The hash function from which selectors, event topics, and interface IDs are derived - if
+//     nil, Keccak256Hasher is used.
+//  20. PayableNotes: Whether or not to list the payable functions in a comment at the top of the interface
+//     and to document each payable function with its NatSpec from Userdoc and Devdoc (if any).
+//  21. Devdoc: The developer documentation of the contract (see ArtifactDevdoc).
+//  22. Deployments: Known deployments of the contract, which are listed in a comment at the top of the
+//     interface.
+//  23. DeploymentsLibrary: Whether or not to also generate a library with an address constant for every
+//     deployment.
+//  24. Userdoc: The user documentation of the contract (see ArtifactUserdoc).
+//  25. GoPackage: The package of files generated by the go-constants target - if empty, this is derived
+//     from Name (see GoPackageName).
+//  26. ConstructorComment: Whether or not to include the signature of the constructor (if the ABI has one)
+//     in a comment at the top of the interface, so that deployment parameters are visible.
+//  27. Lite: Whether or not to replace struct parameters and return values with bytes, documenting their
+//     tuple encoding and the original selectors in NatSpec, so that no structs are declared (see LiteABI).
+//  28. SpecialFunctions: Whether or not to declare the fallback and receive functions of the ABI (if any)
+//     in the interface.
+//  29. ProxyAddress: The address of the proxy whose implementation the ABI belongs to, if the interface is
+//     generated for a proxy - noted in a comment at the top of the interface.
+//  30. NatspecStubs: Whether or not to generate NatSpec stubs (with TODO placeholders) for every function,
+//     event, and error, as a skeleton for documentation (see FunctionNatspecStub).
+//  31. StorageNamespaces: The IDs of ERC-7201 storage namespaces (see StorageNamespaces) to generate slot
+//     constants and a slot-derivation helper for, in a library after the interface.
+//  32. Strict: Whether DecodeWithOptions should validate every item of the ABI (see
+//     StrictValidationError) and fail on questionable items instead of generating output for them.
+//  33. ExtraPragmas: Pragmas other than the Solidity version pragma (e.g. "abicoder v2"), to be generated
+//     in order after it. See ParsePragmas.
+//  34. ContractTypes: How parameters whose internalType is a contract or interface are declared -
+//     ContractTypesAddress (the default, if empty), ContractTypesComment, or ContractTypesStub.
+//  35. RawIRItems: Whether or not the JSON intermediate representation includes the original JSON of every
+//     event, function, and error, so that consumers can recover fields which solface does not model.
+//  36. UserDefinedValueTypes: Whether or not to declare the user-defined value types of the ABI (see
+//     UserDefinedValueTypeOf) in the interface, and to use them in place of the elementary types they wrap.
+//  37. NameConflicts: How errors which share a name (see NameConflicts) are declared - NameConflictsSuffix
+//     (the default, if empty), NameConflictsPreferFirst, or NameConflictsError. Functions and events can be
+//     overloaded, and structs are named uniquely, so only errors conflict.
+//  38. EIP712: Whether or not to generate a library (named after the interface, with an "EIP712" suffix)
+//     with the EIP-712 type hashes of the structs which functions take as input, and functions hashing
+//     them (see EIP712Types).
+//  39. AssumeView: The selectors of functions to declare as view regardless of the state mutability recorded
+//     in the ABI (see AssumeView and SuspiciousGetterDiagnostics), with a comment documenting the override.
+//  40. Kind: The kind of contract the ABI belongs to - KindContract (the default, if empty) or KindLibrary,
+//     for which only the functions that can be called on the deployed library are declared (see LibraryABI).
+type Options struct {
+	Name                    string
+	License                 string
+	Pragma                  string
+	IncludeAnnotations      bool
+	SecurityAnnotations     bool
+	Timestamp               time.Time
+	FunctionRenames         map[string]string
+	LintSuppressions        []string
+	MemberLintSuppressions  []string
+	MaxNestingDepth         int
+	SkipInvalid             bool
+	MaxItems                int
+	MaxInputBytes           int
+	Dialect                 string
+	Codec                   bool
+	IntegerWidthAnnotations bool
+	PreserveABIOrder        bool
+	IndexComments           bool
+	Hasher                  Hasher
+	PayableNotes            bool
+	Devdoc                  Devdoc
+	Deployments             []Deployment
+	DeploymentsLibrary      bool
+	Userdoc                 Userdoc
+	GoPackage               string
+	ConstructorComment      bool
+	Lite                    bool
+	SpecialFunctions        bool
+	ProxyAddress            string
+	NatspecStubs            bool
+	StorageNamespaces       []string
+	Strict                  bool
+	ExtraPragmas            []string
+	ContractTypes           string
+	RawIRItems              bool
+	UserDefinedValueTypes   bool
+	NameConflicts           string
+	EIP712                  bool
+	AssumeView              []string
+	Kind                    string
+}
@@ -0,0 +1,89 @@
+package solface
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Represents a raw ABI persisted next to the interface generated from it.
+//  1. ABIPath: The path of the file containing the raw ABI JSON, exactly as it was fetched.
+//  2. ChecksumPath: The path of the file containing the checksum of the raw ABI, in the format used by
+//     sha256sum (so that it can be verified with "sha256sum -c").
+//  3. SHA256: The hex-encoded SHA-256 checksum of the raw ABI.
+type PinnedABI struct {
+	ABIPath      string
+	ChecksumPath string
+	SHA256       string
+}
+
+// Returned when a pinned ABI does not match its checksum.
+type ChecksumMismatchError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %s: expected %s, got %s", e.Path, e.Expected, e.Actual)
+}
+
+// Returns the paths at which the raw ABI for the interface at the given path is pinned, e.g. the raw ABI
+// for "interfaces/IFoo.sol" is pinned at "interfaces/IFoo.abi.json" with its checksum at
+// "interfaces/IFoo.abi.json.sha256".
+func PinnedABIPaths(interfacePath string) (string, string) {
+	abiPath := strings.TrimSuffix(interfacePath, filepath.Ext(interfacePath)) + ".abi.json"
+	return abiPath, abiPath + ".sha256"
+}
+
+// Persists the given raw ABI JSON next to the interface at the given path (see PinnedABIPaths), along with
+// its checksum, so that the interface can be regenerated offline and reviewers can see exactly which ABI
+// produced it.
+func PinABI(rawABI []byte, interfacePath string) (PinnedABI, error) {
+	abiPath, checksumPath := PinnedABIPaths(interfacePath)
+	digest := sha256.Sum256(rawABI)
+	pinned := PinnedABI{ABIPath: abiPath, ChecksumPath: checksumPath, SHA256: hex.EncodeToString(digest[:])}
+
+	writeErr := os.WriteFile(abiPath, rawABI, 0644)
+	if writeErr != nil {
+		return pinned, writeErr
+	}
+	checksumLine := fmt.Sprintf("%s  %s\n", pinned.SHA256, filepath.Base(abiPath))
+	writeErr = os.WriteFile(checksumPath, []byte(checksumLine), 0644)
+	return pinned, writeErr
+}
+
+// Reads the raw ABI pinned next to the interface at the given path, after verifying it against its
+// checksum. Returns a *ChecksumMismatchError if the raw ABI has been modified since it was pinned.
+func ReadPinnedABI(interfacePath string) ([]byte, error) {
+	abiPath, checksumPath := PinnedABIPaths(interfacePath)
+
+	checksumFile, openErr := os.Open(checksumPath)
+	if openErr != nil {
+		return nil, openErr
+	}
+	defer checksumFile.Close()
+	scanner := bufio.NewScanner(checksumFile)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("empty checksum file: %s", checksumPath)
+	}
+	fields := strings.Fields(scanner.Text())
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid checksum file: %s", checksumPath)
+	}
+	expected := strings.ToLower(fields[0])
+
+	rawABI, readErr := os.ReadFile(abiPath)
+	if readErr != nil {
+		return nil, readErr
+	}
+	digest := sha256.Sum256(rawABI)
+	if actual := hex.EncodeToString(digest[:]); actual != expected {
+		return nil, &ChecksumMismatchError{Path: abiPath, Expected: expected, Actual: actual}
+	}
+	return rawABI, nil
+}
@@ -0,0 +1,42 @@
+package solface
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPinABI(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	interfacePath := filepath.Join(t.TempDir(), "IOwnableERC20.sol")
+	pinned, pinErr := PinABI(contents, interfacePath)
+	if pinErr != nil {
+		t.Fatalf("Error pinning ABI: %s", pinErr.Error())
+	}
+	if filepath.Base(pinned.ABIPath) != "IOwnableERC20.abi.json" || filepath.Base(pinned.ChecksumPath) != "IOwnableERC20.abi.json.sha256" {
+		t.Fatalf("Unexpected pinned paths: %s, %s", pinned.ABIPath, pinned.ChecksumPath)
+	}
+
+	pinnedContents, readErr := ReadPinnedABI(interfacePath)
+	if readErr != nil {
+		t.Fatalf("Error reading pinned ABI: %s", readErr.Error())
+	}
+	if !bytes.Equal(pinnedContents, contents) {
+		t.Fatal("Expected the pinned ABI to be identical to the original ABI")
+	}
+
+	if writeErr := os.WriteFile(pinned.ABIPath, append(contents, '\n'), 0644); writeErr != nil {
+		t.Fatalf("Could not modify pinned ABI: %s", writeErr.Error())
+	}
+	_, readErr = ReadPinnedABI(interfacePath)
+	var mismatchErr *ChecksumMismatchError
+	if !errors.As(readErr, &mismatchErr) {
+		t.Fatalf("Expected a ChecksumMismatchError for a modified ABI. Actual: %v", readErr)
+	}
+}
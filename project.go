@@ -0,0 +1,107 @@
+package solface
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Represents the layout of a smart contract project.
+//  1. Kind: "hardhat", "foundry", or "" if no known layout was detected.
+//  2. SourceDir: The directory (relative to the project root) containing Solidity sources.
+//  3. ArtifactsDir: The directory (relative to the project root) into which build artifacts are written.
+type ProjectLayout struct {
+	Kind         string
+	SourceDir    string
+	ArtifactsDir string
+}
+
+var foundryDirRegexp = regexp.MustCompile(`(?m)^\s*(src|out)\s*=\s*["']([^"']+)["']`)
+
+// Detects whether the given directory contains a Foundry or Hardhat project. Foundry projects are
+// detected by foundry.toml (whose src and out settings are respected) and Hardhat projects by their
+// hardhat.config file.
+func DetectProjectLayout(root string) ProjectLayout {
+	foundryConfig, foundryErr := os.ReadFile(filepath.Join(root, "foundry.toml"))
+	if foundryErr == nil {
+		layout := ProjectLayout{Kind: "foundry", SourceDir: "src", ArtifactsDir: "out"}
+		// Only the first occurrence of each setting (the default profile) is used.
+		seen := map[string]bool{}
+		for _, matches := range foundryDirRegexp.FindAllStringSubmatch(string(foundryConfig), -1) {
+			if seen[matches[1]] {
+				continue
+			}
+			seen[matches[1]] = true
+			if matches[1] == "src" {
+				layout.SourceDir = matches[2]
+			} else {
+				layout.ArtifactsDir = matches[2]
+			}
+		}
+		return layout
+	}
+
+	for _, extension := range []string{"js", "ts", "cjs", "mjs"} {
+		if _, statErr := os.Stat(filepath.Join(root, fmt.Sprintf("hardhat.config.%s", extension))); statErr == nil {
+			return ProjectLayout{Kind: "hardhat", SourceDir: "contracts", ArtifactsDir: "artifacts"}
+		}
+	}
+
+	return ProjectLayout{}
+}
+
+// Generates a starter solface project configuration for the project at the given root. For Hardhat and
+// Foundry projects, this creates a job for every Solidity source file (excluding tests and scripts),
+// pointing at the build artifact that the project's toolchain produces for it. For other projects, a
+// single example job is created.
+func ScaffoldConfig(root string) (Config, error) {
+	config := Config{Version: 1, OutputDir: "interfaces", Pragma: "^0.8.0", Annotations: true, Jobs: []Job{}}
+
+	layout := DetectProjectLayout(root)
+	if layout.Kind == "" {
+		config.Jobs = append(config.Jobs, Job{Name: "IMyContract", ABI: "abis/MyContract.json", Output: filepath.ToSlash(filepath.Join(config.OutputDir, "IMyContract.sol"))})
+		return config, nil
+	}
+
+	sourceRoot := filepath.Join(root, layout.SourceDir)
+	walkErr := filepath.WalkDir(sourceRoot, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == "test" || entry.Name() == "script" || entry.Name() == "mocks" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(entry.Name(), ".sol") || strings.HasSuffix(entry.Name(), ".t.sol") || strings.HasSuffix(entry.Name(), ".s.sol") {
+			return nil
+		}
+
+		contractName := strings.TrimSuffix(entry.Name(), ".sol")
+		var artifactPath string
+		if layout.Kind == "foundry" {
+			artifactPath = filepath.Join(layout.ArtifactsDir, entry.Name(), fmt.Sprintf("%s.json", contractName))
+		} else {
+			relativePath, relErr := filepath.Rel(root, path)
+			if relErr != nil {
+				return relErr
+			}
+			artifactPath = filepath.Join(layout.ArtifactsDir, relativePath, fmt.Sprintf("%s.json", contractName))
+		}
+
+		interfaceName := fmt.Sprintf("I%s", contractName)
+		config.Jobs = append(config.Jobs, Job{Name: interfaceName, ABI: filepath.ToSlash(artifactPath), Output: filepath.ToSlash(filepath.Join(config.OutputDir, fmt.Sprintf("%s.sol", interfaceName)))})
+		return nil
+	})
+	if walkErr != nil && !os.IsNotExist(walkErr) {
+		return config, walkErr
+	}
+
+	sort.Slice(config.Jobs, func(i, j int) bool { return config.Jobs[i].ABI < config.Jobs[j].ABI })
+	return config, nil
+}
@@ -0,0 +1,56 @@
+package solface
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScaffoldConfigHardhat(t *testing.T) {
+	root := t.TempDir()
+	files := []string{"hardhat.config.ts", "contracts/Token.sol", "contracts/governance/Governor.sol", "contracts/test/TokenTest.sol"}
+	for _, file := range files {
+		path := filepath.Join(root, file)
+		if mkdirErr := os.MkdirAll(filepath.Dir(path), 0755); mkdirErr != nil {
+			t.Fatalf("Could not create directory: %s", mkdirErr.Error())
+		}
+		if writeErr := os.WriteFile(path, []byte{}, 0644); writeErr != nil {
+			t.Fatalf("Could not create file: %s", writeErr.Error())
+		}
+	}
+
+	layout := DetectProjectLayout(root)
+	if layout.Kind != "hardhat" {
+		t.Fatalf("Expected hardhat project layout. Actual: %s", layout.Kind)
+	}
+
+	config, scaffoldErr := ScaffoldConfig(root)
+	if scaffoldErr != nil {
+		t.Fatalf("Could not scaffold configuration: %s", scaffoldErr.Error())
+	}
+
+	expectedJobs := []Job{
+		{Name: "IToken", ABI: "artifacts/contracts/Token.sol/Token.json", Output: "interfaces/IToken.sol"},
+		{Name: "IGovernor", ABI: "artifacts/contracts/governance/Governor.sol/Governor.json", Output: "interfaces/IGovernor.sol"},
+	}
+	if !reflect.DeepEqual(config.Jobs, expectedJobs) {
+		t.Fatalf("Unexpected jobs. Expected: %v, actual: %v", expectedJobs, config.Jobs)
+	}
+
+	serialized, marshalErr := MarshalConfig(config)
+	if marshalErr != nil {
+		t.Fatalf("Could not serialize configuration: %s", marshalErr.Error())
+	}
+	configPath := filepath.Join(root, ConfigFileName)
+	if writeErr := os.WriteFile(configPath, serialized, 0644); writeErr != nil {
+		t.Fatalf("Could not write configuration: %s", writeErr.Error())
+	}
+	loaded, loadErr := LoadConfig(configPath)
+	if loadErr != nil {
+		t.Fatalf("Could not load configuration: %s", loadErr.Error())
+	}
+	if !reflect.DeepEqual(loaded, config) {
+		t.Fatalf("Configuration did not survive a round trip. Expected: %v, actual: %v", config, loaded)
+	}
+}
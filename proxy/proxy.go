@@ -0,0 +1,118 @@
+// Package proxy detects upgradeable proxies by reading their implementation slots over JSON-RPC, so that
+// interfaces to proxies can be generated from the ABIs of their implementations.
+//
+// The supported patterns are EIP-1967 (transparent and UUPS proxies, and beacon proxies) and EIP-1822
+// (UUPS proxies predating EIP-1967).
+package proxy
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/jsonrpc"
+)
+
+// Names of the proxy patterns which Detect recognizes.
+const (
+	KindEIP1967       = "EIP-1967"
+	KindEIP1967Beacon = "EIP-1967 beacon"
+	KindEIP1822       = "EIP-1822"
+)
+
+// The storage slot holding the implementation of EIP-1967 proxies: keccak256("eip1967.proxy.implementation") - 1.
+var EIP1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// The storage slot holding the beacon of EIP-1967 beacon proxies: keccak256("eip1967.proxy.beacon") - 1.
+var EIP1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+
+// The storage slot holding the implementation of EIP-1822 proxies: keccak256("PROXIABLE").
+var EIP1822Slot = common.BytesToHash(crypto.Keccak256([]byte("PROXIABLE")))
+
+// Represents a detected proxy.
+//  1. Address: The checksummed address of the proxy.
+//  2. Implementation: The checksummed address of the implementation the proxy delegates to.
+//  3. Kind: The proxy pattern (one of the Kind constants).
+type Proxy struct {
+	Address        string
+	Implementation string
+	Kind           string
+}
+
+// Returns the address held by the given 32-byte result of a JSON-RPC call, or the zero address if the
+// result is empty.
+func decodeAddress(result string) (common.Address, error) {
+	data, hexErr := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+	if hexErr != nil {
+		return common.Address{}, fmt.Errorf("invalid RPC result %q: %s", result, hexErr.Error())
+	}
+	if len(data) == 0 {
+		return common.Address{}, nil
+	}
+	if len(data) != 32 {
+		return common.Address{}, fmt.Errorf("unexpected RPC result %q", result)
+	}
+	return common.BytesToAddress(data), nil
+}
+
+// Reads the address stored in the given slot of the given contract.
+func readSlot(client jsonrpc.Client, address string, slot common.Hash) (common.Address, error) {
+	var result string
+	callErr := client.Call("eth_getStorageAt", []interface{}{address, slot.Hex(), "latest"}, &result)
+	if callErr != nil {
+		return common.Address{}, fmt.Errorf("could not read slot %s of %s: %s", slot.Hex(), address, callErr.Error())
+	}
+	return decodeAddress(result)
+}
+
+// Detects whether the contract at the given address is a proxy, by reading its EIP-1967 and EIP-1822
+// implementation slots (and, for beacon proxies, calling implementation() on the beacon) through the given
+// JSON-RPC client. Returns false if the contract is not a recognized proxy. The address is validated (see
+// solface.ChecksumAddress) before any request is made.
+func Detect(client jsonrpc.Client, address string) (Proxy, bool, error) {
+	checksummed, checksumErr := solface.ChecksumAddress(address)
+	if checksumErr != nil {
+		return Proxy{}, false, checksumErr
+	}
+
+	implementation, readErr := readSlot(client, checksummed, EIP1967ImplementationSlot)
+	if readErr != nil {
+		return Proxy{}, false, readErr
+	}
+	if implementation != (common.Address{}) {
+		return Proxy{Address: checksummed, Implementation: implementation.Hex(), Kind: KindEIP1967}, true, nil
+	}
+
+	beacon, readErr := readSlot(client, checksummed, EIP1967BeaconSlot)
+	if readErr != nil {
+		return Proxy{}, false, readErr
+	}
+	if beacon != (common.Address{}) {
+		calldata := "0x" + hex.EncodeToString(crypto.Keccak256([]byte("implementation()"))[:4])
+		var result string
+		callErr := client.Call("eth_call", []interface{}{map[string]string{"to": beacon.Hex(), "data": calldata}, "latest"}, &result)
+		if callErr != nil {
+			return Proxy{}, false, fmt.Errorf("could not call implementation() on beacon %s: %s", beacon.Hex(), callErr.Error())
+		}
+		implementation, decodeErr := decodeAddress(result)
+		if decodeErr != nil {
+			return Proxy{}, false, decodeErr
+		}
+		if implementation == (common.Address{}) {
+			return Proxy{}, false, fmt.Errorf("beacon %s of %s has no implementation", beacon.Hex(), checksummed)
+		}
+		return Proxy{Address: checksummed, Implementation: implementation.Hex(), Kind: KindEIP1967Beacon}, true, nil
+	}
+
+	implementation, readErr = readSlot(client, checksummed, EIP1822Slot)
+	if readErr != nil {
+		return Proxy{}, false, readErr
+	}
+	if implementation != (common.Address{}) {
+		return Proxy{Address: checksummed, Implementation: implementation.Hex(), Kind: KindEIP1822}, true, nil
+	}
+	return Proxy{}, false, nil
+}
@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moonstream-to/solface/jsonrpc"
+)
+
+const zeroWord = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+// Serves eth_getStorageAt requests from the given storage (slot -> value, zero for missing slots) and
+// eth_call requests from the given call results (contract address -> result).
+func fakeNode(t *testing.T, storage map[string]string, calls map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		result := zeroWord
+		switch request.Method {
+		case "eth_getStorageAt":
+			var slot string
+			json.Unmarshal(request.Params[1], &slot)
+			if value, ok := storage[slot]; ok {
+				result = value
+			}
+		case "eth_call":
+			var call map[string]string
+			json.Unmarshal(request.Params[0], &call)
+			if call["data"] != "0x5c60da1b" {
+				t.Fatalf("Unexpected call: %v", call)
+			}
+			result = calls[strings.ToLower(call["to"])]
+		default:
+			t.Fatalf("Unexpected method: %s", request.Method)
+		}
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": 1, "result": %q}`, result)
+	}))
+}
+
+// Returns the 32-byte word holding the given address.
+func addressWord(address string) string {
+	return "0x000000000000000000000000" + strings.ToLower(strings.TrimPrefix(address, "0x"))
+}
+
+func TestDetect(t *testing.T) {
+	proxyAddress := "0x1F98431c8aD98523631AE4a59f267346ea31F984"
+	implementation := "0xcA11bde05977b3631167028862bE2a173976CA11"
+	beacon := "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+
+	cases := []struct {
+		storage map[string]string
+		kind    string
+	}{
+		{map[string]string{EIP1967ImplementationSlot.Hex(): addressWord(implementation)}, KindEIP1967},
+		{map[string]string{EIP1967BeaconSlot.Hex(): addressWord(beacon)}, KindEIP1967Beacon},
+		{map[string]string{EIP1822Slot.Hex(): addressWord(implementation)}, KindEIP1822},
+		{map[string]string{}, ""},
+	}
+	for _, c := range cases {
+		server := fakeNode(t, c.storage, map[string]string{strings.ToLower(beacon): addressWord(implementation)})
+		detected, isProxy, detectErr := Detect(jsonrpc.Client{URL: server.URL}, proxyAddress)
+		server.Close()
+		if detectErr != nil {
+			t.Fatalf("Error detecting proxy: %s", detectErr.Error())
+		}
+		if c.kind == "" {
+			if isProxy {
+				t.Fatalf("Expected no proxy to be detected. Actual: %v", detected)
+			}
+			continue
+		}
+		if !isProxy || detected.Kind != c.kind || detected.Implementation != implementation || detected.Address != proxyAddress {
+			t.Fatalf("Expected %s proxy with implementation %s. Actual: %v", c.kind, implementation, detected)
+		}
+	}
+}
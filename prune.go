@@ -0,0 +1,60 @@
+package solface
+
+// Returns the indices (in compoundTypes) of the compound types which the given types (e.g. "Order0" or
+// "Item1[][2]") refer to, directly or through the members of other compound types.
+func reachableCompoundTypes(compoundTypes []CompoundType, types []string) map[int]bool {
+	byName := map[string]int{}
+	for i, compound := range compoundTypes {
+		byName[compound.TypeName] = i
+	}
+
+	reachable := map[int]bool{}
+	var visit func(solidityType string)
+	visit = func(solidityType string) {
+		element, _ := splitArrayDimensions(solidityType)
+		i, ok := byName[element]
+		if !ok || reachable[i] {
+			return
+		}
+		reachable[i] = true
+		for _, member := range compoundTypes[i].Members {
+			visit(member.Value.Type)
+		}
+	}
+	for _, solidityType := range types {
+		visit(solidityType)
+	}
+	return reachable
+}
+
+// Returns the compound types which the events, functions, and errors of the given resolved ABI (see
+// ResolveCompounds) refer to, directly or through other compound types, in their original order. Compound
+// types which are no longer referenced once items have been removed from a resolved ABI (e.g. with
+// FilterABI, or when merging or comparing ABIs), or which only the constructor refers to, are dropped.
+func PruneCompoundTypes(abi DecodedABI, compoundTypes []CompoundType) []CompoundType {
+	types := []string{}
+	for _, eventItem := range abi.Events {
+		for _, input := range eventItem.Inputs {
+			types = append(types, input.Type)
+		}
+	}
+	for _, functionItem := range abi.Functions {
+		for _, value := range append(append([]Value{}, functionItem.Inputs...), functionItem.Outputs...) {
+			types = append(types, value.Type)
+		}
+	}
+	for _, errorItem := range abi.Errors {
+		for _, input := range errorItem.Inputs {
+			types = append(types, input.Type)
+		}
+	}
+
+	reachable := reachableCompoundTypes(compoundTypes, types)
+	result := []CompoundType{}
+	for i, compound := range compoundTypes {
+		if reachable[i] {
+			result = append(result, compound)
+		}
+	}
+	return result
+}
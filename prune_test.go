@@ -0,0 +1,55 @@
+package solface
+
+import (
+	"testing"
+)
+
+func TestPruneCompoundTypes(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "fill", "stateMutability": "nonpayable", "inputs": [
+			{"name": "order", "type": "tuple", "internalType": "struct Exchange.Order", "components": [
+				{"name": "maker", "type": "address", "internalType": "address"},
+				{"name": "items", "type": "tuple[][2]", "internalType": "struct Exchange.Item[][2]", "components": [
+					{"name": "token", "type": "address", "internalType": "address"}
+				]}
+			]}
+		], "outputs": []},
+		{"type": "event", "name": "Settled", "anonymous": false, "inputs": [
+			{"name": "receipt", "type": "tuple", "indexed": false, "internalType": "struct Exchange.Receipt", "components": [
+				{"name": "id", "type": "uint256", "internalType": "uint256"}
+			]}
+		]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	resolved := ResolveCompounds(abi)
+	if len(resolved.CompoundTypes) != 3 {
+		t.Fatalf("Expected 3 compound types. Actual: %d", len(resolved.CompoundTypes))
+	}
+	if pruned := PruneCompoundTypes(resolved.EnrichedABI, resolved.CompoundTypes); len(pruned) != 3 {
+		t.Fatalf("Expected every compound type to be kept while all items remain. Actual: %d", len(pruned))
+	}
+
+	withoutEvents := FilterABI(resolved.EnrichedABI, func(itemType string, itemIndex int) bool {
+		return itemType != "event"
+	})
+	pruned := PruneCompoundTypes(withoutEvents, resolved.CompoundTypes)
+	if len(pruned) != 2 {
+		t.Fatalf("Expected the Order and Item compound types to be kept. Actual: %v", pruned)
+	}
+	for _, compound := range pruned {
+		if compound.OriginalName == "Exchange.Receipt" {
+			t.Fatalf("Expected the Receipt compound type to be pruned. Actual: %v", pruned)
+		}
+	}
+
+	withoutFunctions := FilterABI(resolved.EnrichedABI, func(itemType string, itemIndex int) bool {
+		return itemType != "function"
+	})
+	pruned = PruneCompoundTypes(withoutFunctions, resolved.CompoundTypes)
+	if len(pruned) != 1 || pruned[0].OriginalName != "Exchange.Receipt" {
+		t.Fatalf("Expected only the Receipt compound type to be kept. Actual: %v", pruned)
+	}
+}
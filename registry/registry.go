@@ -0,0 +1,147 @@
+// Package registry publishes the interface IDs and selector sets computed by solface to on-chain interface
+// registries, for protocols which maintain canonical registries of the interfaces they support.
+//
+// Registries are contracts implementing:
+//
+//	function register(string calldata name, bytes4 interfaceId, bytes4[] calldata selectors) external;
+package registry
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/solface"
+	"github.com/moonstream-to/solface/jsonrpc"
+)
+
+// The signature of the function that entries are published with.
+const RegisterSignature = "register(string,bytes4,bytes4[])"
+
+// Represents the information about an interface which is published to a registry.
+//  1. Name: The name of the interface.
+//  2. InterfaceID: The ERC-165 interface ID of the interface.
+//  3. Selectors: The selectors of the functions of the interface.
+type Entry struct {
+	Name        string
+	InterfaceID []byte
+	Selectors   [][]byte
+}
+
+// Returns the registry entry for the interface with the given name and annotations (see solface.Annotate).
+func NewEntry(name string, annotations solface.Annotations) Entry {
+	return Entry{Name: name, InterfaceID: annotations.InterfaceID, Selectors: annotations.FunctionSelectors}
+}
+
+// Returns a 32-byte word holding the given integer.
+func uintWord(value int) []byte {
+	word := make([]byte, 32)
+	binary.BigEndian.PutUint64(word[24:], uint64(value))
+	return word
+}
+
+// Returns the given bytes, right-padded with zeros to a multiple of 32 bytes.
+func padRight(data []byte) []byte {
+	padded := make([]byte, (len(data)+31)/32*32)
+	copy(padded, data)
+	return padded
+}
+
+// Returns the calldata for publishing the given entry (a call to RegisterSignature). Returns an error if
+// the interface ID or any selector is not 4 bytes long.
+func Calldata(entry Entry) ([]byte, error) {
+	if len(entry.InterfaceID) != 4 {
+		return nil, fmt.Errorf("interface ID of %s must be 4 bytes, got %d", entry.Name, len(entry.InterfaceID))
+	}
+	for _, selector := range entry.Selectors {
+		if len(selector) != 4 {
+			return nil, fmt.Errorf("selectors of %s must be 4 bytes, got %x", entry.Name, selector)
+		}
+	}
+
+	name := padRight([]byte(entry.Name))
+	calldata := crypto.Keccak256([]byte(RegisterSignature))[:4]
+	// The head holds the offset of the name, the interface ID, and the offset of the selectors.
+	calldata = append(calldata, uintWord(3*32)...)
+	calldata = append(calldata, padRight(entry.InterfaceID)...)
+	calldata = append(calldata, uintWord(3*32+32+len(name))...)
+	calldata = append(calldata, uintWord(len(entry.Name))...)
+	calldata = append(calldata, name...)
+	calldata = append(calldata, uintWord(len(entry.Selectors))...)
+	for _, selector := range entry.Selectors {
+		calldata = append(calldata, padRight(selector)...)
+	}
+	return calldata, nil
+}
+
+// Parses a hex-encoded private key (with or without the "0x" prefix).
+func ParsePrivateKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	key, keyErr := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKey), "0x"))
+	if keyErr != nil {
+		return nil, fmt.Errorf("invalid private key: %s", keyErr.Error())
+	}
+	return key, nil
+}
+
+// Publishes the given entry to the registry at the given address by sending a transaction signed with the
+// given key through the JSON-RPC endpoint of the given client. The chain ID, nonce, gas price, and gas limit
+// are taken from the endpoint. Returns the hash of the transaction, which is not waited for.
+func Publish(client jsonrpc.Client, registryAddress string, key *ecdsa.PrivateKey, entry Entry) (common.Hash, error) {
+	checksummed, checksumErr := solface.ChecksumAddress(registryAddress)
+	if checksumErr != nil {
+		return common.Hash{}, checksumErr
+	}
+	registry := common.HexToAddress(checksummed)
+	calldata, calldataErr := Calldata(entry)
+	if calldataErr != nil {
+		return common.Hash{}, calldataErr
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	var chainID hexutil.Big
+	if callErr := client.Call("eth_chainId", nil, &chainID); callErr != nil {
+		return common.Hash{}, fmt.Errorf("could not get chain ID: %s", callErr.Error())
+	}
+	var nonce hexutil.Uint64
+	if callErr := client.Call("eth_getTransactionCount", []interface{}{from.Hex(), "pending"}, &nonce); callErr != nil {
+		return common.Hash{}, fmt.Errorf("could not get nonce of %s: %s", from.Hex(), callErr.Error())
+	}
+	var gasPrice hexutil.Big
+	if callErr := client.Call("eth_gasPrice", nil, &gasPrice); callErr != nil {
+		return common.Hash{}, fmt.Errorf("could not get gas price: %s", callErr.Error())
+	}
+	var gas hexutil.Uint64
+	call := map[string]string{"from": from.Hex(), "to": registry.Hex(), "data": hexutil.Encode(calldata)}
+	if callErr := client.Call("eth_estimateGas", []interface{}{call}, &gas); callErr != nil {
+		return common.Hash{}, fmt.Errorf("could not estimate gas (is %s a registry?): %s", registry.Hex(), callErr.Error())
+	}
+
+	transaction := types.NewTx(&types.LegacyTx{
+		Nonce:    uint64(nonce),
+		GasPrice: gasPrice.ToInt(),
+		Gas:      uint64(gas),
+		To:       &registry,
+		Value:    big.NewInt(0),
+		Data:     calldata,
+	})
+	signed, signErr := types.SignTx(transaction, types.LatestSignerForChainID(chainID.ToInt()), key)
+	if signErr != nil {
+		return common.Hash{}, signErr
+	}
+	rawTransaction, marshalErr := signed.MarshalBinary()
+	if marshalErr != nil {
+		return common.Hash{}, marshalErr
+	}
+
+	var hash common.Hash
+	if callErr := client.Call("eth_sendRawTransaction", []interface{}{hexutil.Encode(rawTransaction)}, &hash); callErr != nil {
+		return common.Hash{}, fmt.Errorf("could not send transaction: %s", callErr.Error())
+	}
+	return hash, nil
+}
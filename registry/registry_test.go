@@ -0,0 +1,104 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/solface/jsonrpc"
+)
+
+func TestCalldata(t *testing.T) {
+	entry := Entry{Name: "IERC165", InterfaceID: []byte{0x01, 0xff, 0xc9, 0xa7}, Selectors: [][]byte{{0x01, 0xff, 0xc9, 0xa7}}}
+	calldata, calldataErr := Calldata(entry)
+	if calldataErr != nil {
+		t.Fatalf("Error encoding calldata: %s", calldataErr.Error())
+	}
+	expected := "" +
+		hex.EncodeToString(crypto.Keccak256([]byte(RegisterSignature))[:4]) +
+		"0000000000000000000000000000000000000000000000000000000000000060" +
+		"01ffc9a700000000000000000000000000000000000000000000000000000000" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" +
+		"0000000000000000000000000000000000000000000000000000000000000007" +
+		"4945524331363500000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"01ffc9a700000000000000000000000000000000000000000000000000000000"
+	if hex.EncodeToString(calldata) != expected {
+		t.Fatalf("Expected calldata: %s. Actual: %x", expected, calldata)
+	}
+
+	_, calldataErr = Calldata(Entry{Name: "IBroken", InterfaceID: []byte{0x01}})
+	if calldataErr == nil {
+		t.Fatal("Expected error encoding entry with a short interface ID. Actual: nil")
+	}
+}
+
+func TestPublish(t *testing.T) {
+	key, keyErr := ParsePrivateKey("0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if keyErr != nil {
+		t.Fatalf("Error parsing private key: %s", keyErr.Error())
+	}
+	registry := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+	entry := Entry{Name: "IERC165", InterfaceID: []byte{0x01, 0xff, 0xc9, 0xa7}, Selectors: [][]byte{{0x01, 0xff, 0xc9, 0xa7}}}
+
+	var sent *types.Transaction
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		if decodeErr := json.NewDecoder(r.Body).Decode(&request); decodeErr != nil {
+			t.Fatalf("Could not decode RPC request: %s", decodeErr.Error())
+		}
+		var result string
+		switch request.Method {
+		case "eth_chainId":
+			result = "0x5"
+		case "eth_getTransactionCount":
+			result = "0x7"
+		case "eth_gasPrice":
+			result = "0x3b9aca00"
+		case "eth_estimateGas":
+			result = "0x186a0"
+		case "eth_sendRawTransaction":
+			rawTransaction, _ := hexutil.Decode(request.Params[0].(string))
+			sent = new(types.Transaction)
+			if unmarshalErr := sent.UnmarshalBinary(rawTransaction); unmarshalErr != nil {
+				t.Fatalf("Could not decode transaction: %s", unmarshalErr.Error())
+			}
+			result = sent.Hash().Hex()
+		default:
+			t.Fatalf("Unexpected method: %s", request.Method)
+		}
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": "%s"}`, request.ID, result)
+	}))
+	defer server.Close()
+
+	hash, publishErr := Publish(jsonrpc.Client{URL: server.URL}, registry.Hex(), key, entry)
+	if publishErr != nil {
+		t.Fatalf("Error publishing entry: %s", publishErr.Error())
+	}
+	if sent == nil || hash != sent.Hash() {
+		t.Fatalf("Expected hash of the sent transaction. Actual: %s", hash.Hex())
+	}
+	if *sent.To() != registry || sent.Nonce() != 7 || sent.Gas() != 100000 || sent.ChainId().Int64() != 5 {
+		t.Fatalf("Unexpected transaction: to %s, nonce %d, gas %d, chain %d", sent.To().Hex(), sent.Nonce(), sent.Gas(), sent.ChainId().Int64())
+	}
+	expectedCalldata, _ := Calldata(entry)
+	if !bytes.Equal(sent.Data(), expectedCalldata) {
+		t.Fatalf("Expected calldata: %x. Actual: %x", expectedCalldata, sent.Data())
+	}
+	sender, senderErr := types.Sender(types.LatestSignerForChainID(sent.ChainId()), sent)
+	if senderErr != nil || sender != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("Expected transaction to be signed by %s. Actual: %s (error: %v)", crypto.PubkeyToAddress(key.PublicKey).Hex(), sender.Hex(), senderErr)
+	}
+}
@@ -0,0 +1,93 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*$`)
+
+// Returns true if the given string is a valid Solidity identifier.
+func IsValidIdentifier(name string) bool {
+	return identifierRegexp.MatchString(name)
+}
+
+var mixedCaseRegexp = regexp.MustCompile(`^_*[a-z][a-zA-Z0-9$]*$`)
+
+// Returns true if the given name follows the mixedCase convention expected of Solidity function names
+// (ignoring leading underscores).
+func IsMixedCase(name string) bool {
+	return mixedCaseRegexp.MatchString(name)
+}
+
+// Normalizes a 4-byte selector to lowercase hex without a 0x prefix.
+func NormalizeSelector(selector string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(selector), "0x"), "0X"))
+}
+
+// Reads a function renaming map (selector -> function name) from a YAML or JSON file, e.g.:
+//
+//	0x095ea7b3: approveSpender
+//	"a9059cbb": send
+func LoadRenames(path string) (map[string]string, error) {
+	contents, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	renames := map[string]string{}
+	unmarshalErr := yaml.Unmarshal(contents, &renames)
+	return renames, unmarshalErr
+}
+
+// Applies a function renaming map (selector -> function name) to an ABI. Selectors may be given with or
+// without a 0x prefix. Returns the ABI with the renamed functions, along with a map from the indices of
+// the renamed functions to their original names.
+// Note that renamed functions have different selectors in the generated interface than in the original
+// contract - annotations should therefore be computed from the original ABI.
+func ApplyRenames(abi DecodedABI, renames map[string]string) (DecodedABI, map[int]string, error) {
+	originalNames := map[int]string{}
+	if len(renames) == 0 {
+		return abi, originalNames, nil
+	}
+
+	normalized := map[string]string{}
+	for selector, name := range renames {
+		if !IsValidIdentifier(name) {
+			return abi, originalNames, fmt.Errorf("invalid function name for selector %s: %q", selector, name)
+		}
+		normalized[NormalizeSelector(selector)] = name
+	}
+
+	result := abi
+	result.Functions = make([]FunctionItem, len(abi.Functions))
+	used := map[string]bool{}
+	for i, functionItem := range abi.Functions {
+		result.Functions[i] = functionItem
+		selector := hex.EncodeToString(MethodSelector(functionItem))
+		if name, ok := normalized[selector]; ok {
+			originalNames[i] = functionItem.Name
+			result.Functions[i].Name = name
+			used[selector] = true
+		}
+	}
+
+	unused := []string{}
+	for selector := range normalized {
+		if !used[selector] {
+			unused = append(unused, selector)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return abi, map[int]string{}, fmt.Errorf("renamed selectors do not match any function in the ABI: %s", strings.Join(unused, ", "))
+	}
+
+	return result, originalNames, nil
+}
@@ -0,0 +1,85 @@
+package solface
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerateInterfaceWithRenames(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/ERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	annotations, _ := Annotate(abi)
+
+	var output strings.Builder
+	options := Options{Name: "IERC20", IncludeAnnotations: true, FunctionRenames: map[string]string{"0xA9059CBB": "send"}}
+	generateErr := GenerateInterfaceWithOptions(abi, annotations, options, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+
+	// The selector annotation must still refer to the original transfer(address,uint256) function.
+	expectedLines := "\t// Selector: a9059cbb\n\t// original: transfer\n\tfunction send("
+	if !strings.Contains(output.String(), expectedLines) {
+		t.Fatalf("Expected generated interface to contain:\n%s\nActual interface:\n%s", expectedLines, output.String())
+	}
+	if !strings.Contains(output.String(), "// Interface ID: 36372b07") {
+		t.Fatalf("Expected interface ID of the original ABI. Actual interface:\n%s", output.String())
+	}
+}
+
+func TestApplyRenamesErrors(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{{Type: "function", Name: "owner", StateMutability: "view"}}}
+
+	if _, _, renameErr := ApplyRenames(abi, map[string]string{"8da5cb5b": "not a name"}); renameErr == nil {
+		t.Fatal("Expected an error for an invalid function name")
+	}
+	if _, _, renameErr := ApplyRenames(abi, map[string]string{"deadbeef": "unknown"}); renameErr == nil {
+		t.Fatal("Expected an error for a selector which does not match any function")
+	}
+	renamed, originalNames, renameErr := ApplyRenames(abi, map[string]string{"8da5cb5b": "admin"})
+	if renameErr != nil {
+		t.Fatalf("Could not apply renames: %s", renameErr.Error())
+	}
+	if renamed.Functions[0].Name != "admin" || originalNames[0] != "owner" || abi.Functions[0].Name != "owner" {
+		t.Fatalf("Unexpected result of renaming. Renamed: %s, original: %s, input: %s", renamed.Functions[0].Name, originalNames[0], abi.Functions[0].Name)
+	}
+}
+
+func TestGenerateInterfaceWithLintSuppressions(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "DOMAIN_SEPARATOR", Outputs: []Value{{Name: "", Type: "bytes32"}}, StateMutability: "view"},
+		{Type: "function", Name: "nonces", Inputs: []Value{{Name: "owner", Type: "address"}}, Outputs: []Value{{Name: "", Type: "uint256"}}, StateMutability: "view"},
+	}}
+
+	var output strings.Builder
+	options := Options{
+		Name:                   "IPermit",
+		LintSuppressions:       []string{"solhint-disable no-empty-blocks"},
+		MemberLintSuppressions: []string{"solhint-disable-next-line func-name-mixedcase"},
+	}
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, options, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+
+	expectedSnippets := []string{
+		"// solhint-disable no-empty-blocks\ninterface IPermit {",
+		"\t// solhint-disable-next-line func-name-mixedcase\n\tfunction DOMAIN_SEPARATOR()",
+	}
+	for _, expectedSnippet := range expectedSnippets {
+		if !strings.Contains(output.String(), expectedSnippet) {
+			t.Fatalf("Expected generated interface to contain:\n%s\nActual interface:\n%s", expectedSnippet, output.String())
+		}
+	}
+	if strings.Count(output.String(), "solhint-disable-next-line") != 1 {
+		t.Fatalf("Expected exactly one next-line suppression. Actual interface:\n%s", output.String())
+	}
+}
@@ -0,0 +1,136 @@
+package solface
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Matches internal types which name an enum, struct, contract, or interface by a (possibly qualified)
+// identifier, with optional array dimensions, e.g. "enum Token.Status" or "struct SpentItem[][2]".
+var namedInternalTypeRegexp = regexp.MustCompile(`^(enum|struct|contract|interface) [A-Za-z_$][\w$]*(\.[A-Za-z_$][\w$]*)*(\[\d*\])*$`)
+
+// Returns true if the given internal type names an enum, struct, contract, or interface by an identifier
+// (see namedInternalTypeRegexp). Other internal types are not rendered into generated code, since ABIs
+// are untrusted input and their internal types could otherwise inject code (e.g. "enum A */ ... /*").
+func IsNamedInternalType(internalType string) bool {
+	return namedInternalTypeRegexp.MatchString(internalType)
+}
+
+// Returns an inline comment documenting the enum type of the given value (e.g. "/* enum Token.Status */"),
+// if its internalType is an enum, and an empty string otherwise. ABIs encode enums as uint8 and do not list
+// their members, so enums cannot be declared in interfaces - the comment preserves the type for readers.
+// Internal types which do not name an enum by an identifier (see IsNamedInternalType) are not documented.
+func EnumComment(value Value) string {
+	if !strings.HasPrefix(value.InternalType, "enum ") || !IsNamedInternalType(value.InternalType) {
+		return ""
+	}
+	return fmt.Sprintf("/* %s */", value.InternalType)
+}
+
+// Returns the inline comment (if any) documenting the original type of the given value - see EnumComment
+// and Options.ContractTypes.
+func typeComment(value Value) string {
+	if comment := EnumComment(value); comment != "" {
+		return comment
+	}
+	return contractComment(value)
+}
+
+// Renders a function parameter or return value, e.g. "uint256 amount", "bytes memory data", or "address"
+// (for unnamed values). Enum values are rendered with a comment naming the enum (see EnumComment), e.g.
+// "uint8 /* enum Token.Status */ status".
+func RenderParameter(value Value) string {
+	parts := []string{value.Type}
+	if comment := typeComment(value); comment != "" {
+		parts = append(parts, comment)
+	}
+	// Values declared with contract types (see ContractTypesStub) or user-defined value types are value
+	// types, unlike structs - only their arrays need a data location.
+	requiresLocation := SolidityTypeRequiresLocation(value.Type)
+	if value.InternalType != "" && !strings.HasPrefix(value.InternalType, "struct ") && value.Type != "string" && value.Type != "bytes" {
+		_, dimensions, _ := ParseArrayType(value.Type)
+		requiresLocation = len(dimensions) > 0
+	}
+	if requiresLocation {
+		parts = append(parts, "memory")
+	}
+	if value.Name != "" {
+		parts = append(parts, value.Name)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Renders a comma-separated list of function parameters or return values.
+func renderParameters(values []Value) string {
+	rendered := make([]string, len(values))
+	for i, value := range values {
+		rendered[i] = RenderParameter(value)
+	}
+	return strings.Join(rendered, ", ")
+}
+
+// Renders the declaration of the given function in a Solidity interface, e.g.
+// "function transfer(address to, uint256 amount) external returns (bool);".
+//
+// Functions are declared external. Their state mutability (view, pure, or payable) follows, except for
+// nonpayable functions, since that is the default. Functions which only specify their mutability with the
+// legacy constant and payable fields are rendered with the corresponding state mutability. Compound types
+// should already have been resolved (see ResolveCompounds), so that value types are Solidity type names.
+func RenderFunction(function FunctionItem) string {
+	modifiers := []string{"external"}
+	if mutability := NormalizedStateMutability(function); mutability != "nonpayable" {
+		modifiers = append(modifiers, mutability)
+	}
+	if len(function.Outputs) > 0 {
+		modifiers = append(modifiers, fmt.Sprintf("returns (%s)", renderParameters(function.Outputs)))
+	}
+	return fmt.Sprintf("function %s(%s) %s;", function.Name, renderParameters(function.Inputs), strings.Join(modifiers, " "))
+}
+
+// Renders the signature of the given constructor as a comment, e.g.
+// "// constructor(string memory name, (address,uint256) memory config) payable". Since the compound
+// types of constructor parameters are not declared in the interface, they are rendered as canonical tuple
+// types.
+func RenderConstructorComment(constructor ConstructorItem) string {
+	parameters := make([]string, len(constructor.Inputs))
+	for i, input := range constructor.Inputs {
+		parameters[i] = RenderParameter(Value{Name: input.Name, Type: CanonicalType(input)})
+	}
+	comment := fmt.Sprintf("// constructor(%s)", strings.Join(parameters, ", "))
+	if constructor.StateMutability == "payable" {
+		comment += " payable"
+	}
+	return comment
+}
+
+// Renders the declaration of the given fallback or receive function in a Solidity interface, e.g.
+// "fallback() external payable;" or "receive() external payable;". Nonpayable fallback functions are
+// rendered without a state mutability, since that is the default.
+func RenderSpecialFunction(special SpecialFunctionItem) string {
+	if special.StateMutability == "payable" {
+		return fmt.Sprintf("%s() external payable;", special.Type)
+	}
+	return fmt.Sprintf("%s() external;", special.Type)
+}
+
+// Returns the fallback and receive functions of the given ABI (those which it has), in that order.
+func specialFunctions(abi DecodedABI) []SpecialFunctionItem {
+	specials := []SpecialFunctionItem{}
+	for _, special := range []*SpecialFunctionItem{abi.Fallback, abi.Receive} {
+		if special != nil {
+			specials = append(specials, *special)
+		}
+	}
+	return specials
+}
+
+// Returns a diagnostic for every fallback or receive function of the given ABI, for use when they are not
+// declared in the generated interface (see Options.SpecialFunctions).
+func SkippedSpecialFunctionDiagnostics(abi DecodedABI) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for _, special := range specialFunctions(abi) {
+		diagnostics = append(diagnostics, Diagnostic{ItemType: special.Type, Message: fmt.Sprintf("skipped %s function: %s", special.Type, strings.TrimSuffix(RenderSpecialFunction(special), ";"))})
+	}
+	return diagnostics
+}
@@ -0,0 +1,205 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderFunctionMutabilities(t *testing.T) {
+	testCases := []struct {
+		function FunctionItem
+		expected string
+	}{
+		{
+			FunctionItem{Type: "function", Name: "owner", Outputs: []Value{{Type: "address"}}, StateMutability: "view"},
+			"function owner() external view returns (address);",
+		},
+		{
+			FunctionItem{Type: "function", Name: "add", Inputs: []Value{{Name: "a", Type: "uint256"}, {Name: "b", Type: "uint256"}}, Outputs: []Value{{Type: "uint256"}}, StateMutability: "pure"},
+			"function add(uint256 a, uint256 b) external pure returns (uint256);",
+		},
+		{
+			FunctionItem{Type: "function", Name: "deposit", StateMutability: "payable"},
+			"function deposit() external payable;",
+		},
+		{
+			FunctionItem{Type: "function", Name: "depositFor", Inputs: []Value{{Name: "account", Type: "address"}}, Outputs: []Value{{Name: "shares", Type: "uint256"}}, StateMutability: "payable"},
+			"function depositFor(address account) external payable returns (uint256 shares);",
+		},
+		{
+			FunctionItem{Type: "function", Name: "renounceOwnership", StateMutability: "nonpayable"},
+			"function renounceOwnership() external;",
+		},
+		{
+			FunctionItem{Type: "function", Name: "transfer", Inputs: []Value{{Name: "to", Type: "address"}, {Name: "amount", Type: "uint256"}}, Outputs: []Value{{Type: "bool"}}, StateMutability: "nonpayable"},
+			"function transfer(address to, uint256 amount) external returns (bool);",
+		},
+		{
+			FunctionItem{Type: "function", Name: "balanceOf", Inputs: []Value{{Type: "address"}}, Outputs: []Value{{Type: "uint256"}}, Constant: true},
+			"function balanceOf(address) external view returns (uint256);",
+		},
+		{
+			FunctionItem{Type: "function", Name: "buy", Payable: true},
+			"function buy() external payable;",
+		},
+		{
+			FunctionItem{Type: "function", Name: "set", Inputs: []Value{{Type: "address"}}},
+			"function set(address) external;",
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual := RenderFunction(testCase.function)
+		if actual != testCase.expected {
+			t.Fatalf("Expected: %s. Actual: %s", testCase.expected, actual)
+		}
+	}
+}
+
+func TestRenderFunctionLocations(t *testing.T) {
+	function := FunctionItem{
+		Type: "function",
+		Name: "multicall",
+		Inputs: []Value{
+			{Name: "data", Type: "bytes[]"},
+			{Name: "", Type: "string"},
+			{Name: "order", Type: "Order0"},
+			{Name: "salt", Type: "bytes32"},
+		},
+		Outputs:         []Value{{Name: "results", Type: "bytes[]"}, {Name: "", Type: "bytes"}},
+		StateMutability: "payable",
+	}
+
+	expected := "function multicall(bytes[] memory data, string memory, Order0 memory order, bytes32 salt) external payable returns (bytes[] memory results, bytes memory);"
+	actual := RenderFunction(function)
+	if actual != expected {
+		t.Fatalf("Expected: %s. Actual: %s", expected, actual)
+	}
+}
+
+func TestConstructorComment(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "constructor", "inputs": [{"name": "name", "type": "string"}, {"name": "config", "type": "tuple", "components": [{"name": "owner", "type": "address"}, {"name": "fee", "type": "uint256"}]}], "stateMutability": "payable"},
+		{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	if abi.Constructor == nil || len(abi.Constructor.Inputs) != 2 {
+		t.Fatalf("Expected constructor with 2 inputs. Actual: %v", abi.Constructor)
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IToken", ConstructorComment: true}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expected := "// constructor(string memory name, (address,uint256) memory config) payable\ninterface IToken {"
+	if !strings.Contains(output.String(), expected) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+	}
+	if strings.Contains(output.String(), "struct") && !strings.Contains(output.String(), "// structs\n\n") {
+		t.Fatalf("Expected no structs to be declared for constructor parameters. Actual:\n%s", output.String())
+	}
+
+	_, decodeErr = Decode([]byte(`[{"type": "constructor", "inputs": []}, {"type": "constructor", "inputs": [{"name": "x", "type": "uint256"}]}]`))
+	if decodeErr == nil {
+		t.Fatal("Expected error decoding ABI with two constructors. Actual: nil")
+	}
+}
+
+func TestSpecialFunctions(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "fallback", "payable": true},
+		{"type": "receive", "stateMutability": "payable"},
+		{"type": "function", "name": "owner", "inputs": [], "outputs": [{"name": "", "type": "address"}], "stateMutability": "view"}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	if abi.Fallback == nil || abi.Fallback.StateMutability != "payable" || abi.Receive == nil {
+		t.Fatalf("Expected payable fallback and receive functions. Actual: %v, %v", abi.Fallback, abi.Receive)
+	}
+
+	diagnostics := SkippedSpecialFunctionDiagnostics(abi)
+	if len(diagnostics) != 2 || diagnostics[0].ItemType != "fallback" || diagnostics[1].ItemType != "receive" {
+		t.Fatalf("Expected diagnostics for the fallback and receive functions. Actual: %v", diagnostics)
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IWallet"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	if strings.Contains(output.String(), "fallback()") {
+		t.Fatalf("Expected fallback function not to be declared by default. Actual:\n%s", output.String())
+	}
+
+	output.Reset()
+	generateErr = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IWallet", SpecialFunctions: true}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expected := "\t// fallback and receive\n\tfallback() external payable;\n\treceive() external payable;\n}"
+	if !strings.Contains(output.String(), expected) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+	}
+
+	nonpayable, decodeErr := Decode([]byte(`[{"type": "fallback", "stateMutability": "nonpayable"}]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	if RenderSpecialFunction(*nonpayable.Fallback) != "fallback() external;" {
+		t.Fatalf("Expected: fallback() external;. Actual: %s", RenderSpecialFunction(*nonpayable.Fallback))
+	}
+
+	_, decodeErr = Decode([]byte(`[{"type": "fallback", "stateMutability": "payable"}, {"type": "fallback", "stateMutability": "nonpayable"}]`))
+	if decodeErr == nil {
+		t.Fatal("Expected error decoding ABI with two fallback functions. Actual: nil")
+	}
+}
+
+func TestEnumComments(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "event", "name": "StatusChanged", "anonymous": false, "inputs": [{"name": "status", "type": "uint8", "internalType": "enum Token.Status", "indexed": true}]},
+		{"type": "function", "name": "setStatus", "stateMutability": "nonpayable", "inputs": [{"name": "status", "type": "uint8", "internalType": "enum Token.Status"}, {"name": "decimals", "type": "uint8", "internalType": "uint8"}], "outputs": []},
+		{"type": "error", "name": "InvalidStatus", "inputs": [{"name": "status", "type": "uint8", "internalType": "enum Token.Status"}]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IToken"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	for _, expected := range []string{
+		"event StatusChanged(uint8 /* enum Token.Status */ indexed status);",
+		"function setStatus(uint8 /* enum Token.Status */ status, uint8 decimals) external;",
+		"error InvalidStatus(uint8 /* enum Token.Status */ status);",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+		}
+	}
+}
+
+func TestEnumCommentsRejectInjection(t *testing.T) {
+	for _, internalType := range []string{
+		"enum A */ function pwn() external; /*",
+		"enum A\nfunction pwn() external;",
+		"enum A.",
+		"enum 1A",
+	} {
+		if comment := EnumComment(Value{Type: "uint8", InternalType: internalType}); comment != "" {
+			t.Fatalf("Expected no comment for internalType %q. Actual: %s", internalType, comment)
+		}
+	}
+	if comment := EnumComment(Value{Type: "uint8[2]", InternalType: "enum Token.Status[2]"}); comment != "/* enum Token.Status[2] */" {
+		t.Fatalf("Expected a comment for an array of enums. Actual: %q", comment)
+	}
+	if name := QualifiedStructName("struct A*/ B"); name != "" {
+		t.Fatalf("Expected no struct name for a malformed internalType. Actual: %q", name)
+	}
+}
@@ -0,0 +1,109 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// The chain ID used in generated Safe Transaction Builder templates when it cannot be determined from the
+// deployments.
+const SafeChainIDPlaceholder = "<CHAIN_ID>"
+
+// The version of the Safe Transaction Builder batch file format generated by solface.
+const SafeBatchVersion = "1.0"
+
+// Represents the metadata of a Safe Transaction Builder batch.
+type SafeBatchMeta struct {
+	Name                    string `json:"name"`
+	Description             string `json:"description"`
+	TxBuilderVersion        string `json:"txBuilderVersion"`
+	CreatedFromSafeAddress  string `json:"createdFromSafeAddress"`
+	CreatedFromOwnerAddress string `json:"createdFromOwnerAddress"`
+}
+
+// Represents the method called by a transaction in a Safe Transaction Builder batch. Inputs are in the
+// JSON representation of ABI values.
+type SafeContractMethod struct {
+	Inputs  []map[string]interface{} `json:"inputs"`
+	Name    string                   `json:"name"`
+	Payable bool                     `json:"payable"`
+}
+
+// Represents a transaction in a Safe Transaction Builder batch. ContractInputsValues maps the names of the
+// inputs of the method to their values, which are left empty for the operator to fill in.
+type SafeTransaction struct {
+	To                   string             `json:"to"`
+	Value                string             `json:"value"`
+	Data                 *string            `json:"data"`
+	ContractMethod       SafeContractMethod `json:"contractMethod"`
+	ContractInputsValues map[string]string  `json:"contractInputsValues"`
+}
+
+// Represents a Safe Transaction Builder batch file.
+type SafeBatch struct {
+	Version      string            `json:"version"`
+	ChainID      string            `json:"chainId"`
+	CreatedAt    int64             `json:"createdAt"`
+	Meta         SafeBatchMeta     `json:"meta"`
+	Transactions []SafeTransaction `json:"transactions"`
+}
+
+// Returns a Safe Transaction Builder batch template with one transaction for every state-changing
+// (nonpayable or payable) function of the given ABI. If there is exactly one deployment, the transactions
+// are sent to it (and the chain ID is filled in if it is known). Otherwise, the address and chain ID are
+// left as EventFilterAddressPlaceholder and SafeChainIDPlaceholder. Unnamed inputs are named after their
+// positions (e.g. "param0"), since the Transaction Builder identifies inputs by name.
+func SafeBatchTemplate(abi DecodedABI, name string, deployments []Deployment) SafeBatch {
+	batch := SafeBatch{
+		Version:      SafeBatchVersion,
+		ChainID:      SafeChainIDPlaceholder,
+		Meta:         SafeBatchMeta{Name: name, Description: fmt.Sprintf("Transactions for %s, generated by solface", name)},
+		Transactions: []SafeTransaction{},
+	}
+	to := EventFilterAddressPlaceholder
+	if len(deployments) == 1 {
+		to = deployments[0].Address
+		if chainID, ok := ChainID(deployments[0].Chain); ok {
+			batch.ChainID = strconv.FormatUint(chainID, 10)
+		}
+	}
+
+	for _, functionItem := range abi.Functions {
+		mutability := NormalizedStateMutability(functionItem)
+		if mutability == "view" || mutability == "pure" {
+			continue
+		}
+		transaction := SafeTransaction{
+			To:                   to,
+			Value:                "0",
+			ContractMethod:       SafeContractMethod{Inputs: []map[string]interface{}{}, Name: functionItem.Name, Payable: mutability == "payable"},
+			ContractInputsValues: map[string]string{},
+		}
+		for i, input := range functionItem.Inputs {
+			if input.Name == "" {
+				input.Name = fmt.Sprintf("param%d", i)
+			}
+			transaction.ContractMethod.Inputs = append(transaction.ContractMethod.Inputs, abiValueJSON(input))
+			transaction.ContractInputsValues[input.Name] = ""
+		}
+		batch.Transactions = append(batch.Transactions, transaction)
+	}
+	return batch
+}
+
+// Generates a Safe Transaction Builder batch template for the given ABI as JSON (see SafeBatchTemplate),
+// for the deployments in options.Deployments. If options.Timestamp is set, it is recorded as the creation
+// time of the batch.
+func GenerateSafeBatch(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	batch := SafeBatchTemplate(abi, options.Name, options.Deployments)
+	if !options.Timestamp.IsZero() {
+		batch.CreatedAt = options.Timestamp.UnixMilli()
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	// Keep the placeholders readable.
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(batch)
+}
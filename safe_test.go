@@ -0,0 +1,47 @@
+package solface
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSafeBatchTemplate(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatalf("Could not read file containing ABI: %s", readErr.Error())
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	batch := SafeBatchTemplate(abi, "IOwnableERC20", nil)
+	if batch.ChainID != SafeChainIDPlaceholder {
+		t.Fatalf("Expected chain ID placeholder. Actual: %s", batch.ChainID)
+	}
+	// approve, decreaseAllowance, increaseAllowance, mint, renounceOwnership, transfer, transferFrom,
+	// transferOwnership
+	if len(batch.Transactions) != 8 {
+		t.Fatalf("Expected 8 transactions. Actual: %d", len(batch.Transactions))
+	}
+	approve := batch.Transactions[0]
+	if approve.ContractMethod.Name != "approve" || approve.To != EventFilterAddressPlaceholder || len(approve.ContractInputsValues) != 2 {
+		t.Fatalf("Expected approve transaction with 2 inputs. Actual: %v", approve)
+	}
+
+	payableABI, decodeErr := Decode([]byte(`[{"type": "function", "name": "deposit", "inputs": [{"name": "", "type": "address"}], "outputs": [], "stateMutability": "payable"}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	batch = SafeBatchTemplate(payableABI, "IVault", []Deployment{{Chain: "base", Address: "0xcA11bde05977b3631167028862bE2a173976CA11"}})
+	if batch.ChainID != "8453" || batch.Transactions[0].To != "0xcA11bde05977b3631167028862bE2a173976CA11" {
+		t.Fatalf("Expected transaction to the deployment on base. Actual: %v", batch)
+	}
+	deposit := batch.Transactions[0]
+	if !deposit.ContractMethod.Payable || deposit.ContractMethod.Inputs[0]["name"] != "param0" {
+		t.Fatalf("Expected payable deposit with input param0. Actual: %v", deposit.ContractMethod)
+	}
+	if _, ok := deposit.ContractInputsValues["param0"]; !ok {
+		t.Fatalf("Expected value for param0. Actual: %v", deposit.ContractInputsValues)
+	}
+}
@@ -0,0 +1,163 @@
+package solface
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The JSON Schema dialect of the schemas generated by JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Names of the built-in schemas (see GetSchemaType).
+const (
+	SchemaIR                  = "ir"
+	SchemaDecodedABI          = "decoded-abi"
+	SchemaCompoundType        = "compound-type"
+	SchemaAnnotations         = "annotations"
+	SchemaDiagnostic          = "diagnostic"
+	SchemaCompatibilityMatrix = "compatibility-matrix"
+	SchemaABIChanges          = "abi-changes"
+)
+
+// Maps the names of the built-in schemas to zero values of the types they describe.
+var schemaTypes = map[string]interface{}{
+	SchemaIR:                  IntermediateRepresentation{},
+	SchemaDecodedABI:          DecodedABI{},
+	SchemaCompoundType:        CompoundType{},
+	SchemaAnnotations:         Annotations{},
+	SchemaDiagnostic:          Diagnostic{},
+	SchemaCompatibilityMatrix: CompatibilityMatrix{},
+	SchemaABIChanges:          []ABIChange{},
+}
+
+// Returns a zero value of the type described by the schema with the given name, and false if there is no
+// such schema.
+func GetSchemaType(name string) (interface{}, bool) {
+	value, ok := schemaTypes[name]
+	return value, ok
+}
+
+// Returns the names of all built-in schemas, sorted alphabetically.
+func SchemaNames() []string {
+	names := make([]string, 0, len(schemaTypes))
+	for name := range schemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// Builds JSON Schemas from Go types, collecting the schemas of named struct types as definitions.
+type schemaBuilder struct {
+	definitions map[string]interface{}
+}
+
+// Generates the JSON Schema of the JSON encoding (by encoding/json) of the given value's type, with the
+// given title. Named struct types are described in "$defs" and referenced by name, so that recursive
+// types (such as Value) are supported. Slices and maps may be null, as encoding/json encodes nil slices
+// and maps as null, and []byte values are base64-encoded strings.
+func JSONSchema(title string, value interface{}) map[string]interface{} {
+	builder := schemaBuilder{definitions: map[string]interface{}{}}
+	schema := builder.schema(reflect.TypeOf(value))
+	schema["$schema"] = JSONSchemaDialect
+	schema["title"] = title
+	if len(builder.definitions) > 0 {
+		schema["$defs"] = builder.definitions
+	}
+	return schema
+}
+
+// Returns the schema of the given type.
+func (b *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	if t.Kind() != reflect.Pointer && t.Implements(jsonMarshalerType) {
+		// Types with custom encodings (e.g. json.RawMessage) may encode as anything.
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Interface:
+		return map[string]interface{}{}
+	case reflect.Pointer:
+		return map[string]interface{}{"anyOf": []interface{}{b.schema(t.Elem()), map[string]interface{}{"type": "null"}}}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": []string{"string", "null"}, "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": b.schema(t.Elem())}
+	case reflect.Array:
+		return map[string]interface{}{"type": "array", "items": b.schema(t.Elem()), "minItems": t.Len(), "maxItems": t.Len()}
+	case reflect.Map:
+		return map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		if _, defined := b.definitions[t.Name()]; !defined {
+			// Registered before the fields are described, so that recursive references terminate.
+			b.definitions[t.Name()] = map[string]interface{}{}
+			b.definitions[t.Name()] = b.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	}
+	return map[string]interface{}{}
+}
+
+// Returns the schema of the given struct type. Fields are named as encoding/json names them, fields of
+// embedded structs are promoted, and fields without omitempty are required.
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	var addFields func(t reflect.Type)
+	addFields = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, tagOptions, _ := strings.Cut(tag, ",")
+			if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+				addFields(field.Type)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = b.schema(field.Type)
+			if !strings.Contains(","+tagOptions+",", ",omitempty,") {
+				required = append(required, name)
+			}
+		}
+	}
+	addFields(t)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties, "additionalProperties": false}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
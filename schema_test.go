@@ -0,0 +1,83 @@
+package solface
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema("solface IR", IntermediateRepresentation{})
+	if schema["$schema"] != JSONSchemaDialect || schema["$ref"] != "#/$defs/IntermediateRepresentation" {
+		t.Fatalf("Expected a reference to the IntermediateRepresentation definition. Actual: %v", schema)
+	}
+	definitions := schema["$defs"].(map[string]interface{})
+	for _, name := range []string{"IntermediateRepresentation", "IRItem", "IRParameter", "CompoundType", "NamedValue", "Value"} {
+		if _, ok := definitions[name]; !ok {
+			t.Fatalf("Expected definition %s. Actual definitions: %v", name, definitions)
+		}
+	}
+
+	// Value is recursive: its components are Values.
+	value := definitions["Value"].(map[string]interface{})
+	components := value["properties"].(map[string]interface{})["components"].(map[string]interface{})
+	if components["items"].(map[string]interface{})["$ref"] != "#/$defs/Value" {
+		t.Fatalf("Expected components to refer to Value. Actual: %v", components)
+	}
+	required := value["required"].([]string)
+	if len(required) != 2 || required[0] != "name" || required[1] != "type" {
+		t.Fatalf("Expected name and type to be required (internalType and components are omitted when empty). Actual: %v", required)
+	}
+
+	// Every key of the generated IR is described by the schema.
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+	var output bytes.Buffer
+	if generateErr := GenerateIntermediateRepresentation(abi, Annotations{}, Options{Name: "IOwnableERC20"}, &output); generateErr != nil {
+		t.Fatalf("Error generating IR: %s", generateErr.Error())
+	}
+	var ir struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	if unmarshalErr := json.Unmarshal(output.Bytes(), &ir); unmarshalErr != nil {
+		t.Fatalf("Error parsing IR: %s", unmarshalErr.Error())
+	}
+	itemProperties := definitions["IRItem"].(map[string]interface{})["properties"].(map[string]interface{})
+	for _, item := range ir.Items {
+		for key := range item {
+			if _, ok := itemProperties[key]; !ok {
+				t.Fatalf("Expected IR item key %s to be described by the schema. Actual properties: %v", key, itemProperties)
+			}
+		}
+	}
+}
+
+func TestJSONSchemaEmbeddedAndUntaggedFields(t *testing.T) {
+	schema := JSONSchema("decoded ABI", DecodedABI{})
+	definitions := schema["$defs"].(map[string]interface{})
+
+	// EventArgument embeds Value, whose fields are promoted.
+	argument := definitions["EventArgument"].(map[string]interface{})["properties"].(map[string]interface{})
+	for _, key := range []string{"name", "type", "Indexed"} {
+		if _, ok := argument[key]; !ok {
+			t.Fatalf("Expected EventArgument property %s. Actual: %v", key, argument)
+		}
+	}
+	if _, ok := definitions["FunctionItem"].(map[string]interface{})["properties"].(map[string]interface{})["Extras"]; ok {
+		t.Fatalf("Expected fields tagged json:\"-\" to be omitted")
+	}
+
+	for _, name := range SchemaNames() {
+		value, _ := GetSchemaType(name)
+		if _, marshalErr := json.Marshal(JSONSchema(name, value)); marshalErr != nil {
+			t.Fatalf("Error encoding schema %s: %s", name, marshalErr.Error())
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Represents a function whose name or signature suggests that it exposes a dangerous capability.
+// Capability is one of "delegatecall", "upgrade", "selfdestruct", or "arbitrary-call".
+type SecurityFinding struct {
+	FunctionIndex int    `json:"functionIndex"`
+	Signature     string `json:"signature"`
+	Selector      string `json:"selector"`
+	Capability    string `json:"capability"`
+	Description   string `json:"description"`
+}
+
+// Name fragments which suggest that a function forwards arbitrary calls when it also accepts an address
+// and calldata.
+var arbitraryCallNameFragments = []string{"execute", "exec", "call", "forward", "relay"}
+
+// Returns the capability and description of the dangerous capability the given function appears to
+// expose, or empty strings if it does not appear to expose one.
+func securityCapability(functionItem FunctionItem) (string, string) {
+	name := strings.ToLower(functionItem.Name)
+
+	if strings.Contains(name, "delegatecall") {
+		return "delegatecall", "may delegatecall into arbitrary code, which runs with this contract's storage and balance"
+	}
+	if strings.HasPrefix(name, "upgradeto") || strings.HasPrefix(name, "upgradebeaconto") || strings.Contains(name, "setimplementation") {
+		return "upgrade", "may replace the contract's implementation"
+	}
+	if strings.Contains(name, "selfdestruct") || name == "destroy" || name == "kill" {
+		return "selfdestruct", "may destroy the contract"
+	}
+
+	var hasAddress, hasCalldata bool
+	for _, input := range functionItem.Inputs {
+		if input.Type == "address" {
+			hasAddress = true
+		} else if input.Type == "bytes" {
+			hasCalldata = true
+		}
+	}
+	if hasAddress && hasCalldata {
+		for _, fragment := range arbitraryCallNameFragments {
+			if strings.Contains(name, fragment) {
+				return "arbitrary-call", "may call arbitrary contracts with arbitrary calldata"
+			}
+		}
+	}
+
+	return "", ""
+}
+
+// Flags the functions of an ABI whose names or signatures suggest that they expose dangerous
+// capabilities: delegatecalls, implementation upgrades, self-destruction, and arbitrary calls (e.g.
+// execute(address,bytes)). These are heuristics meant to focus integration reviews, not proofs.
+func SecurityFindings(abi DecodedABI) []SecurityFinding {
+	findings := []SecurityFinding{}
+	for i, functionItem := range abi.Functions {
+		capability, description := securityCapability(functionItem)
+		if capability == "" {
+			continue
+		}
+		findings = append(findings, SecurityFinding{
+			FunctionIndex: i,
+			Signature:     FunctionSignature(functionItem),
+			Selector:      hex.EncodeToString(MethodSelector(functionItem)),
+			Capability:    capability,
+			Description:   description,
+		})
+	}
+	return findings
+}
+
+// Converts security findings into diagnostics.
+func SecurityDiagnostics(abi DecodedABI) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for _, finding := range SecurityFindings(abi) {
+		diagnostics = append(diagnostics, Diagnostic{ItemType: "function", ItemIndex: finding.FunctionIndex, Name: abi.Functions[finding.FunctionIndex].Name, Message: fmt.Sprintf("security (%s): %s", finding.Capability, finding.Description)})
+	}
+	return diagnostics
+}
+
+// Writes a markdown report of the given security findings.
+func WriteSecurityMarkdown(findings []SecurityFinding, writer io.Writer) error {
+	_, writeErr := fmt.Fprintf(writer, "# Security-relevant functions\n\n| Selector | Signature | Capability | Description |\n| --- | --- | --- | --- |\n")
+	if writeErr != nil {
+		return writeErr
+	}
+	for _, finding := range findings {
+		if _, writeErr := fmt.Fprintf(writer, "| `%s` | `%s` | %s | %s |\n", finding.Selector, finding.Signature, finding.Capability, finding.Description); writeErr != nil {
+			return writeErr
+		}
+	}
+	return nil
+}
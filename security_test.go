@@ -0,0 +1,37 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecurityFindings(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "upgradeToAndCall", Inputs: []Value{{Name: "newImplementation", Type: "address"}, {Name: "data", Type: "bytes"}}, StateMutability: "payable"},
+		{Type: "function", Name: "execute", Inputs: []Value{{Name: "target", Type: "address"}, {Name: "value", Type: "uint256"}, {Name: "data", Type: "bytes"}}, StateMutability: "payable"},
+		{Type: "function", Name: "safeTransferFrom", Inputs: []Value{{Name: "from", Type: "address"}, {Name: "to", Type: "address"}, {Name: "tokenId", Type: "uint256"}, {Name: "data", Type: "bytes"}}, StateMutability: "nonpayable"},
+		{Type: "function", Name: "destroy", StateMutability: "nonpayable"},
+	}}
+
+	findings := SecurityFindings(abi)
+
+	expectedCapabilities := map[int]string{0: "upgrade", 1: "arbitrary-call", 3: "selfdestruct"}
+	if len(findings) != len(expectedCapabilities) {
+		t.Fatalf("Expected %d findings. Actual: %d", len(expectedCapabilities), len(findings))
+	}
+	for _, finding := range findings {
+		if finding.Capability != expectedCapabilities[finding.FunctionIndex] {
+			t.Fatalf("Function %d: Expected capability: %s. Actual capability: %s", finding.FunctionIndex, expectedCapabilities[finding.FunctionIndex], finding.Capability)
+		}
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IProxy", SecurityAnnotations: true}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expectedLines := "\t/// @custom:security selfdestruct: may destroy the contract\n\tfunction destroy() external;"
+	if !strings.Contains(output.String(), expectedLines) {
+		t.Fatalf("Expected generated interface to contain:\n%s\nActual interface:\n%s", expectedLines, output.String())
+	}
+}
@@ -0,0 +1,69 @@
+package solface
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Size, in bytes, of each entry of a selector table.
+const SelectorTableEntrySize = 4
+
+// Encodes the given selectors as a selector table: the distinct selectors, sorted in ascending order and
+// concatenated, 4 bytes each, with no header. Membership of a selector in the table can be checked with a
+// binary search over its entries, which is cheap enough for routers and firewalls to do for every call.
+func EncodeSelectorTable(selectors [][4]byte) []byte {
+	sorted := make([][4]byte, len(selectors))
+	copy(sorted, selectors)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	table := make([]byte, 0, len(sorted)*SelectorTableEntrySize)
+	for i, selector := range sorted {
+		if i > 0 && selector == sorted[i-1] {
+			continue
+		}
+		table = append(table, selector[:]...)
+	}
+	return table
+}
+
+// Decodes a selector table produced by EncodeSelectorTable. Returns an error if the table is not a whole
+// number of entries, or if its entries are not distinct and sorted.
+func DecodeSelectorTable(table []byte) ([][4]byte, error) {
+	if len(table)%SelectorTableEntrySize != 0 {
+		return nil, fmt.Errorf("selector table has %d bytes, which is not a multiple of %d", len(table), SelectorTableEntrySize)
+	}
+
+	selectors := make([][4]byte, len(table)/SelectorTableEntrySize)
+	for i := range selectors {
+		copy(selectors[i][:], table[i*SelectorTableEntrySize:])
+		if i > 0 && bytes.Compare(selectors[i-1][:], selectors[i][:]) >= 0 {
+			return nil, fmt.Errorf("selector table is not sorted: %x follows %x", selectors[i], selectors[i-1])
+		}
+	}
+	return selectors, nil
+}
+
+// Returns true if the given selector is an entry of the given selector table. The table is not validated;
+// use DecodeSelectorTable to check tables from untrusted sources.
+func SelectorTableContains(table []byte, selector [4]byte) bool {
+	entries := len(table) / SelectorTableEntrySize
+	index := sort.Search(entries, func(i int) bool {
+		return bytes.Compare(table[i*SelectorTableEntrySize:(i+1)*SelectorTableEntrySize], selector[:]) >= 0
+	})
+	return index < entries && bytes.Equal(table[index*SelectorTableEntrySize:(index+1)*SelectorTableEntrySize], selector[:])
+}
+
+// Generates the selector table (see EncodeSelectorTable) of the functions in the given ABI. The output is
+// binary.
+func GenerateSelectorTable(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	selectors := make([][4]byte, len(abi.Functions))
+	for i, functionItem := range abi.Functions {
+		copy(selectors[i][:], MethodSelectorWithHasher(functionItem, options.Hasher))
+	}
+	_, writeErr := writer.Write(EncodeSelectorTable(selectors))
+	return writeErr
+}
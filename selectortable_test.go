@@ -0,0 +1,62 @@
+package solface
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestGenerateSelectorTable(t *testing.T) {
+	rawABI := `[
+		{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}], "stateMutability": "nonpayable"},
+		{"type": "function", "name": "balanceOf", "inputs": [{"name": "owner", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}], "stateMutability": "view"},
+		{"type": "function", "name": "approve", "inputs": [{"name": "spender", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}], "stateMutability": "nonpayable"},
+		{"type": "event", "name": "Transfer", "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "amount", "type": "uint256", "indexed": false}], "anonymous": false}
+	]`
+	abi, decodeErr := Decode([]byte(rawABI))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output bytes.Buffer
+	generateErr := GenerateSelectorTable(abi, Annotations{}, Options{}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating selector table: %s", generateErr.Error())
+	}
+
+	expected := "095ea7b370a08231a9059cbb"
+	if actual := hex.EncodeToString(output.Bytes()); actual != expected {
+		t.Fatalf("Expected selector table %s. Actual: %s", expected, actual)
+	}
+
+	if !SelectorTableContains(output.Bytes(), [4]byte{0x70, 0xa0, 0x82, 0x31}) {
+		t.Fatalf("Expected selector table to contain 70a08231")
+	}
+	if SelectorTableContains(output.Bytes(), [4]byte{0x23, 0xb8, 0x72, 0xdd}) {
+		t.Fatalf("Expected selector table not to contain 23b872dd")
+	}
+
+	selectors, tableErr := DecodeSelectorTable(output.Bytes())
+	if tableErr != nil {
+		t.Fatalf("Error decoding selector table: %s", tableErr.Error())
+	}
+	if len(selectors) != 3 || selectors[2] != [4]byte{0xa9, 0x05, 0x9c, 0xbb} {
+		t.Fatalf("Expected 3 selectors, ending with a9059cbb. Actual: %x", selectors)
+	}
+}
+
+func TestEncodeSelectorTableDeduplicates(t *testing.T) {
+	table := EncodeSelectorTable([][4]byte{{0xff, 0, 0, 0}, {0x01, 0, 0, 0}, {0xff, 0, 0, 0}})
+	if actual := hex.EncodeToString(table); actual != "01000000ff000000" {
+		t.Fatalf("Expected selector table 01000000ff000000. Actual: %s", actual)
+	}
+}
+
+func TestDecodeSelectorTableInvalid(t *testing.T) {
+	if _, tableErr := DecodeSelectorTable([]byte{1, 2, 3}); tableErr == nil {
+		t.Fatalf("Expected an error decoding a table with a partial entry")
+	}
+	if _, tableErr := DecodeSelectorTable([]byte{2, 0, 0, 0, 1, 0, 0, 0}); tableErr == nil {
+		t.Fatalf("Expected an error decoding an unsorted table")
+	}
+}
@@ -0,0 +1,205 @@
+// Package standards provides canonical ABIs and interface IDs for common Ethereum standards (ERCs),
+// for use as reference ABIs and for detecting which standards a contract implements.
+package standards
+
+import (
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/moonstream-to/solface"
+)
+
+//go:embed abis/*.json
+var embeddedABIs embed.FS
+
+// Represents a standard interface.
+//  1. Name: The name of the standard (e.g. "ERC721").
+//  2. ABI: The canonical ABI of the standard.
+//  3. InterfaceID: The ERC165 interface ID of the standard, i.e. the XOR of the selectors of its functions.
+type Standard struct {
+	Name        string
+	ABI         solface.DecodedABI
+	InterfaceID []byte
+}
+
+var registry map[string]Standard
+var registryLock sync.RWMutex
+
+func init() {
+	registry = map[string]Standard{}
+
+	entries, readDirErr := embeddedABIs.ReadDir("abis")
+	if readDirErr != nil {
+		panic(fmt.Sprintf("could not read embedded standard ABIs: %s", readDirErr.Error()))
+	}
+
+	for _, entry := range entries {
+		contents, readErr := embeddedABIs.ReadFile(path.Join("abis", entry.Name()))
+		if readErr != nil {
+			panic(fmt.Sprintf("could not read embedded standard ABI %s: %s", entry.Name(), readErr.Error()))
+		}
+
+		abi, decodeErr := solface.Decode(contents)
+		if decodeErr != nil {
+			panic(fmt.Sprintf("could not decode embedded standard ABI %s: %s", entry.Name(), decodeErr.Error()))
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		registry[name] = newStandard(name, abi)
+	}
+}
+
+func newStandard(name string, abi solface.DecodedABI) Standard {
+	// Annotate never returns an error.
+	annotations, _ := solface.Annotate(abi)
+	return Standard{Name: name, ABI: abi, InterfaceID: annotations.InterfaceID}
+}
+
+// Registers a custom standard (e.g. an organization's in-house interface), so that it is returned by All
+// and Get and considered by Detect. Registering a standard with the name of an existing standard replaces
+// it. It is safe to call Register concurrently with the other functions in this package.
+func Register(name string, abi solface.DecodedABI) {
+	standard := newStandard(name, abi)
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[name] = standard
+}
+
+// Registers every ABI in the given directory (files with a ".json" extension) as a custom standard named
+// after its file, e.g. "IVault.json" is registered as "IVault". Either every ABI is registered, or none
+// of them are.
+func RegisterDirectory(dir string) error {
+	paths, globErr := filepath.Glob(filepath.Join(dir, "*.json"))
+	if globErr != nil {
+		return globErr
+	}
+
+	abis := make(map[string]solface.DecodedABI, len(paths))
+	for _, abiPath := range paths {
+		contents, readErr := os.ReadFile(abiPath)
+		if readErr != nil {
+			return readErr
+		}
+		abi, decodeErr := solface.Decode(contents)
+		if decodeErr != nil {
+			return fmt.Errorf("could not decode standard ABI %s: %s", abiPath, decodeErr.Error())
+		}
+		abis[strings.TrimSuffix(filepath.Base(abiPath), ".json")] = abi
+	}
+
+	for name, abi := range abis {
+		Register(name, abi)
+	}
+	return nil
+}
+
+// Returns every known standard, sorted by name.
+func All() []Standard {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	result := make([]Standard, 0, len(registry))
+	for _, standard := range registry {
+		result = append(result, standard)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Returns the standard with the given name (e.g. "ERC20"), and false if there is no such standard.
+func Get(name string) (Standard, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	standard, ok := registry[name]
+	return standard, ok
+}
+
+// Returns the standards that are implemented by a contract with the given ABI, sorted by name.
+// A standard is implemented if the ABI contains every one of its functions (by selector).
+func Detect(abi solface.DecodedABI) []Standard {
+	selectors := map[string]bool{}
+	for _, functionItem := range abi.Functions {
+		selectors[hex.EncodeToString(solface.MethodSelector(functionItem))] = true
+	}
+
+	result := []Standard{}
+	for _, standard := range All() {
+		if len(standard.ABI.Functions) == 0 {
+			continue
+		}
+		implemented := true
+		for _, functionItem := range standard.ABI.Functions {
+			if !selectors[hex.EncodeToString(solface.MethodSelector(functionItem))] {
+				implemented = false
+				break
+			}
+		}
+		if implemented {
+			result = append(result, standard)
+		}
+	}
+	return result
+}
+
+// The name of the slice of an ABI which does not belong to any standard (see Split).
+const ResidualSliceName = "Custom"
+
+// Represents the part of an ABI which implements a single standard, or which does not belong to any
+// standard (in which case Name is ResidualSliceName).
+type Slice struct {
+	Name string
+	ABI  solface.DecodedABI
+}
+
+// Splits an ABI into one slice per standard that it implements (see Detect), sorted by standard name,
+// followed by a residual slice containing the items which do not belong to any of those standards. A
+// slice contains the contract's own declarations of the standard's functions (by selector) and events (by
+// topic), so items which are part of several standards appear in each of their slices. The residual slice
+// is omitted if it would be empty.
+func Split(abi solface.DecodedABI) []Slice {
+	slices := []Slice{}
+	claimedFunctions := map[int]bool{}
+	claimedEvents := map[int]bool{}
+
+	for _, standard := range Detect(abi) {
+		selectors := map[string]bool{}
+		for _, functionItem := range standard.ABI.Functions {
+			selectors[hex.EncodeToString(solface.MethodSelector(functionItem))] = true
+		}
+		topics := map[string]bool{}
+		for _, eventItem := range standard.ABI.Events {
+			topics[hex.EncodeToString(solface.EventTopic(eventItem))] = true
+		}
+
+		sliceABI := solface.FilterABI(abi, func(itemType string, itemIndex int) bool {
+			if itemType == "function" && selectors[hex.EncodeToString(solface.MethodSelector(abi.Functions[itemIndex]))] {
+				claimedFunctions[itemIndex] = true
+				return true
+			} else if itemType == "event" && topics[hex.EncodeToString(solface.EventTopic(abi.Events[itemIndex]))] {
+				claimedEvents[itemIndex] = true
+				return true
+			}
+			return false
+		})
+		// The constructor and the fallback and receive functions belong to the contract, not to any of the
+		// standards it implements.
+		sliceABI.Constructor = nil
+		sliceABI.Fallback = nil
+		sliceABI.Receive = nil
+		slices = append(slices, Slice{Name: standard.Name, ABI: sliceABI})
+	}
+
+	residual := solface.FilterABI(abi, func(itemType string, itemIndex int) bool {
+		return (itemType == "function" && !claimedFunctions[itemIndex]) || (itemType == "event" && !claimedEvents[itemIndex]) || itemType == "error"
+	})
+	if len(residual.Events)+len(residual.Functions)+len(residual.Errors) > 0 {
+		slices = append(slices, Slice{Name: ResidualSliceName, ABI: residual})
+	}
+	return slices
+}
@@ -0,0 +1,139 @@
+package standards
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/moonstream-to/solface"
+)
+
+func TestStandardInterfaceIDs(t *testing.T) {
+	expectedInterfaceIDs := map[string]string{
+		"ERC165":             "01ffc9a7",
+		"ERC173":             "7f5828d0",
+		"ERC20":              "36372b07",
+		"ERC721":             "80ac58cd",
+		"ERC721Enumerable":   "780e9d63",
+		"ERC721Metadata":     "5b5e139f",
+		"ERC1155":            "d9b67a26",
+		"ERC1155MetadataURI": "0e89341c",
+		"ERC1271":            "1626ba7e",
+		"ERC2981":            "2a55205a",
+	}
+
+	for name, expectedInterfaceID := range expectedInterfaceIDs {
+		standard, ok := Get(name)
+		if !ok {
+			t.Fatalf("Standard %s not found", name)
+		}
+		interfaceID := hex.EncodeToString(standard.InterfaceID)
+		if interfaceID != expectedInterfaceID {
+			t.Fatalf("Incorrect interface ID for %s. Expected: %s, actual: %s", name, expectedInterfaceID, interfaceID)
+		}
+	}
+}
+
+func TestDetectERC20(t *testing.T) {
+	contents, readErr := os.ReadFile("../fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := solface.Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	detected := Detect(abi)
+	detectedNames := make([]string, len(detected))
+	for i, standard := range detected {
+		detectedNames[i] = standard.Name
+	}
+
+	expectedNames := []string{"ERC173", "ERC20", "ERC20Metadata"}
+	if !reflect.DeepEqual(detectedNames, expectedNames) {
+		t.Fatalf("Unexpected standards detected. Expected: %v, actual: %v", expectedNames, detectedNames)
+	}
+}
+
+func TestRegisterDirectory(t *testing.T) {
+	dir := t.TempDir()
+	mintable := `[{"type": "function", "name": "mint", "inputs": [{"name": "account", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [], "stateMutability": "nonpayable"}]`
+	if writeErr := os.WriteFile(filepath.Join(dir, "IMintable.json"), []byte(mintable), 0644); writeErr != nil {
+		t.Fatalf("Could not write standard ABI: %s", writeErr.Error())
+	}
+	t.Cleanup(func() {
+		registryLock.Lock()
+		defer registryLock.Unlock()
+		delete(registry, "IMintable")
+	})
+
+	if registerErr := RegisterDirectory(dir); registerErr != nil {
+		t.Fatalf("Error registering standards: %s", registerErr.Error())
+	}
+
+	standard, ok := Get("IMintable")
+	if !ok {
+		t.Fatal("Standard IMintable not found")
+	}
+	if hex.EncodeToString(standard.InterfaceID) != "40c10f19" {
+		t.Fatalf("Standard IMintable: Expected interface ID: 40c10f19. Actual: %x", standard.InterfaceID)
+	}
+
+	contents, readErr := os.ReadFile("../fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+	abi, decodeErr := solface.Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	detectedNames := []string{}
+	for _, detected := range Detect(abi) {
+		detectedNames = append(detectedNames, detected.Name)
+	}
+	expectedNames := []string{"ERC173", "ERC20", "ERC20Metadata", "IMintable"}
+	if !reflect.DeepEqual(detectedNames, expectedNames) {
+		t.Fatalf("Unexpected standards detected. Expected: %v, actual: %v", expectedNames, detectedNames)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	erc721, _ := Get("ERC721")
+	erc2981, _ := Get("ERC2981")
+	abi := solface.DecodedABI{
+		Events:    erc721.ABI.Events,
+		Functions: append(append(append([]solface.FunctionItem{}, erc721.ABI.Functions...), erc2981.ABI.Functions...), solface.FunctionItem{Type: "function", Name: "mint", Inputs: []solface.Value{{Name: "to", Type: "address"}}, StateMutability: "nonpayable"}),
+		Errors:    []solface.ErrorItem{{Type: "error", Name: "NotOwner"}},
+	}
+
+	slices := Split(abi)
+	sliceNames := make([]string, len(slices))
+	for i, slice := range slices {
+		sliceNames[i] = slice.Name
+	}
+	expectedNames := []string{"ERC2981", "ERC721", ResidualSliceName}
+	if !reflect.DeepEqual(sliceNames, expectedNames) {
+		t.Fatalf("Expected slices: %v. Actual: %v", expectedNames, sliceNames)
+	}
+
+	for _, slice := range slices[:2] {
+		standard, _ := Get(slice.Name)
+		annotations, _ := solface.Annotate(slice.ABI)
+		if hex.EncodeToString(annotations.InterfaceID) != hex.EncodeToString(standard.InterfaceID) {
+			t.Fatalf("Expected the %s slice to have interface ID %x. Actual: %x", slice.Name, standard.InterfaceID, annotations.InterfaceID)
+		}
+		if len(slice.ABI.Events) != len(standard.ABI.Events) {
+			t.Fatalf("Expected the %s slice to have %d events. Actual: %d", slice.Name, len(standard.ABI.Events), len(slice.ABI.Events))
+		}
+	}
+
+	residual := slices[2].ABI
+	if len(residual.Functions) != 1 || residual.Functions[0].Name != "mint" || len(residual.Events) != 0 || len(residual.Errors) != 1 {
+		t.Fatalf("Expected the residual slice to contain function mint and error NotOwner. Actual: %v", residual)
+	}
+}
@@ -0,0 +1,185 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Codes of the diagnostics raised by strict validation (see Options.Strict).
+const (
+	DiagnosticMalformedItem     = "malformed-item"
+	DiagnosticUnknownItemType   = "unknown-item-type"
+	DiagnosticMissingName       = "missing-name"
+	DiagnosticInvalidType       = "invalid-parameter-type"
+	DiagnosticInvalidMutability = "invalid-state-mutability"
+	DiagnosticTooManyIndexed    = "too-many-indexed"
+)
+
+// Returned by DecodeWithOptions in strict mode (see Options.Strict) if the ABI contains questionable
+// items. Diagnostics lists every problem, with the index of the offending item in the raw ABI array.
+type StrictValidationError struct {
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+func (e *StrictValidationError) Error() string {
+	descriptions := make([]string, len(e.Diagnostics))
+	for i, diagnostic := range e.Diagnostics {
+		descriptions[i] = diagnostic.String()
+	}
+	return fmt.Sprintf("ABI failed strict validation with %d problem(s): %s", len(e.Diagnostics), strings.Join(descriptions, "; "))
+}
+
+// Represents an ABI item as loosely as possible, so that strict validation can report on items which
+// the decoder would accept.
+type strictItem struct {
+	Type            *string
+	Name            string
+	Inputs          []strictParameter
+	Outputs         []strictParameter
+	StateMutability string
+	Anonymous       bool
+}
+
+// Represents a parameter of an ABI item, for strict validation.
+type strictParameter struct {
+	Name       string
+	Type       string
+	Indexed    bool
+	Components []strictParameter
+}
+
+var (
+	strictArraySuffixRegexp = regexp.MustCompile(`\[(\d*)\]$`)
+	strictIntegerRegexp     = regexp.MustCompile(`^u?int(\d+)$`)
+	strictBytesRegexp       = regexp.MustCompile(`^bytes(\d+)$`)
+	strictFixedRegexp       = regexp.MustCompile(`^u?fixed(\d+)x(\d+)$`)
+)
+
+// Returns a description of the problem with the given parameter type (e.g. "uint256[2]"), or an empty
+// string if the type is a canonical ABI type. Tuples must have components, and other types must not.
+func strictTypeProblem(parameter strictParameter) string {
+	base := parameter.Type
+	for {
+		match := strictArraySuffixRegexp.FindStringSubmatch(base)
+		if match == nil {
+			break
+		}
+		if match[1] == "0" {
+			return fmt.Sprintf("%s has a zero-length array dimension", parameter.Type)
+		}
+		base = strings.TrimSuffix(base, match[0])
+	}
+
+	switch base {
+	case "address", "bool", "string", "bytes", "function":
+	case "tuple":
+		if len(parameter.Components) == 0 {
+			return fmt.Sprintf("%s has no components", parameter.Type)
+		}
+		return ""
+	case "uint", "int", "fixed", "ufixed":
+		return fmt.Sprintf("%s is not canonical (selectors are computed from canonical types such as uint256)", parameter.Type)
+	default:
+		if match := strictIntegerRegexp.FindStringSubmatch(base); match != nil {
+			if width, _ := strconv.Atoi(match[1]); width < 8 || width > 256 || width%8 != 0 {
+				return fmt.Sprintf("%s is not a valid integer type (widths are multiples of 8 from 8 to 256)", parameter.Type)
+			}
+		} else if match := strictBytesRegexp.FindStringSubmatch(base); match != nil {
+			if size, _ := strconv.Atoi(match[1]); size < 1 || size > 32 {
+				return fmt.Sprintf("%s is not a valid fixed-size bytes type (sizes range from 1 to 32)", parameter.Type)
+			}
+		} else if match := strictFixedRegexp.FindStringSubmatch(base); match != nil {
+			width, _ := strconv.Atoi(match[1])
+			decimals, _ := strconv.Atoi(match[2])
+			if width < 8 || width > 256 || width%8 != 0 || decimals < 1 || decimals > 80 {
+				return fmt.Sprintf("%s is not a valid fixed-point type", parameter.Type)
+			}
+		} else {
+			return fmt.Sprintf("%q is not an ABI type", parameter.Type)
+		}
+	}
+	if len(parameter.Components) > 0 {
+		return fmt.Sprintf("%s has components, but is not a tuple", parameter.Type)
+	}
+	return ""
+}
+
+// Returns the problems with the types of the given parameters and their components, named by their
+// path (e.g. "input order.items").
+func strictParameterProblems(kind string, parameters []strictParameter) []string {
+	problems := []string{}
+	for i, parameter := range parameters {
+		name := parameter.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		if problem := strictTypeProblem(parameter); problem != "" {
+			problems = append(problems, fmt.Sprintf("%s %s: %s", kind, name, problem))
+		}
+		for _, problem := range strictParameterProblems("component", parameter.Components) {
+			problems = append(problems, fmt.Sprintf("%s %s: %s", kind, name, problem))
+		}
+	}
+	return problems
+}
+
+// Validates every item of the given raw ABI array, and returns a diagnostic for every problem found:
+// unknown or missing item types, missing names, parameter types which are not canonical ABI types,
+// unknown state mutabilities, and events with more indexed parameters than they have topics for.
+func strictDiagnostics(rawMessages []json.RawMessage) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for i, rawMessage := range rawMessages {
+		var item strictItem
+		if unmarshalErr := json.Unmarshal(rawMessage, &item); unmarshalErr != nil {
+			diagnostics = append(diagnostics, Diagnostic{ItemIndex: i, Code: DiagnosticMalformedItem, Message: unmarshalErr.Error()})
+			continue
+		}
+		itemType := ""
+		if item.Type != nil {
+			itemType = *item.Type
+		}
+		add := func(code, message string) {
+			diagnostics = append(diagnostics, Diagnostic{ItemType: itemType, ItemIndex: i, Name: item.Name, Code: code, Message: message})
+		}
+
+		switch itemType {
+		case "function", "event", "error":
+			if item.Name == "" {
+				add(DiagnosticMissingName, fmt.Sprintf("%s has no name", itemType))
+			}
+		case "constructor", "fallback", "receive":
+		case "":
+			add(DiagnosticUnknownItemType, "item has no type")
+			continue
+		default:
+			add(DiagnosticUnknownItemType, fmt.Sprintf("unknown item type %q", itemType))
+			continue
+		}
+
+		for _, problem := range append(strictParameterProblems("input", item.Inputs), strictParameterProblems("output", item.Outputs)...) {
+			add(DiagnosticInvalidType, problem)
+		}
+
+		switch item.StateMutability {
+		case "", "pure", "view", "nonpayable", "payable":
+		default:
+			add(DiagnosticInvalidMutability, fmt.Sprintf("unknown state mutability %q", item.StateMutability))
+		}
+
+		if itemType == "event" {
+			indexed := 0
+			for _, input := range item.Inputs {
+				if input.Indexed {
+					indexed++
+				}
+			}
+			if maximum := MaxIndexedEventInputs(item.Anonymous); indexed > maximum {
+				add(DiagnosticTooManyIndexed, fmt.Sprintf("%d indexed parameters, but at most %d can be indexed", indexed, maximum))
+			}
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,56 @@
+package solface
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	rawABI := `[
+		{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint"}], "outputs": [{"name": "", "type": "bool"}], "stateMutability": "nonpayable"},
+		{"type": "function", "name": "", "inputs": [], "outputs": [], "stateMutability": "readonly"},
+		{"type": "event", "name": "Swap", "inputs": [{"name": "a", "type": "address", "indexed": true}, {"name": "b", "type": "address", "indexed": true}, {"name": "c", "type": "uint256", "indexed": true}, {"name": "d", "type": "uint256", "indexed": true}], "anonymous": false},
+		{"type": "event", "name": "Raw", "inputs": [{"name": "a", "type": "bytes32", "indexed": true}, {"name": "b", "type": "bytes32", "indexed": true}, {"name": "c", "type": "bytes32", "indexed": true}, {"name": "d", "type": "bytes32", "indexed": true}], "anonymous": true},
+		{"type": "error", "name": "Bad", "inputs": [{"name": "order", "type": "tuple", "components": [{"name": "data", "type": "bytes33"}, {"name": "items", "type": "uint7[0]"}]}, {"name": "empty", "type": "tuple[]"}]},
+		{"type": "modifier", "name": "onlyOwner"}
+	]`
+
+	// Without strict mode, the ABI decodes.
+	if _, _, decodeErr := DecodeWithOptions([]byte(rawABI), Options{}); decodeErr != nil {
+		t.Fatalf("Expected ABI to decode without strict mode. Actual error: %s", decodeErr.Error())
+	}
+
+	_, diagnostics, decodeErr := DecodeWithOptions([]byte(rawABI), Options{Strict: true})
+	var strictErr *StrictValidationError
+	if !errors.As(decodeErr, &strictErr) {
+		t.Fatalf("Expected a *StrictValidationError. Actual: %v", decodeErr)
+	}
+	if len(diagnostics) != len(strictErr.Diagnostics) {
+		t.Fatalf("Expected the diagnostics to be returned as well. Actual: %v", diagnostics)
+	}
+
+	expected := []struct {
+		index int
+		code  string
+	}{
+		{0, DiagnosticInvalidType},
+		{1, DiagnosticMissingName},
+		{1, DiagnosticInvalidMutability},
+		{2, DiagnosticTooManyIndexed},
+		{4, DiagnosticInvalidType},
+		{4, DiagnosticInvalidType},
+		{4, DiagnosticInvalidType},
+		{5, DiagnosticUnknownItemType},
+	}
+	if len(strictErr.Diagnostics) != len(expected) {
+		t.Fatalf("Expected %d diagnostics. Actual: %v", len(expected), strictErr.Diagnostics)
+	}
+	for i, diagnostic := range strictErr.Diagnostics {
+		if diagnostic.ItemIndex != expected[i].index || diagnostic.Code != expected[i].code {
+			t.Fatalf("Expected diagnostic %d to be %s at index %d. Actual: %v", i, expected[i].code, expected[i].index, diagnostic)
+		}
+	}
+	if message := strictErr.Diagnostics[5].Message; message != "input order: component items: uint7[0] has a zero-length array dimension" {
+		t.Fatalf("Expected the path of nested components in the message. Actual: %s", message)
+	}
+}
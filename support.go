@@ -0,0 +1,302 @@
+package solface
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Represents an ABI item that solface cannot correctly express in a Solidity interface.
+// ItemType is one of "event", "function", or "error" and ItemIndex is the position of the item in the
+// corresponding array of the DecodedABI.
+type UnsupportedItem struct {
+	ItemType  string `json:"itemType"`
+	ItemIndex int    `json:"itemIndex"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+}
+
+// Returned when an interface would not compile because the ABI uses features which cannot be expressed
+// for the requested target. Items lists every offending ABI item.
+type UnsupportedFeaturesError struct {
+	Items []UnsupportedItem `json:"items"`
+}
+
+func (e *UnsupportedFeaturesError) Error() string {
+	descriptions := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		descriptions[i] = fmt.Sprintf("%s %s (index %d): %s", item.ItemType, item.Name, item.ItemIndex, item.Reason)
+	}
+	return fmt.Sprintf("interface would not compile, %d unsupported item(s): %s", len(e.Items), strings.Join(descriptions, "; "))
+}
+
+// Solidity only supports structs in external function signatures and events by default (ABI coder v2)
+// from this version on.
+var abiCoderV2DefaultVersion = [3]int{0, 8, 0}
+
+// Returns true if the given pragmas other than the Solidity version pragma (see Options.ExtraPragmas)
+// enable ABI coder v2 explicitly, with "abicoder v2" or "experimental ABIEncoderV2", which supports structs
+// before it became the default.
+func enablesABICoderV2(extraPragmas []string) bool {
+	for _, pragma := range extraPragmas {
+		switch strings.Join(strings.Fields(pragma), " ") {
+		case "abicoder v2", "experimental ABIEncoderV2":
+			return true
+		}
+	}
+	return false
+}
+
+// Custom errors were introduced in this Solidity version.
+var customErrorsVersion = [3]int{0, 8, 4}
+
+// Returns true if the given type (or its base type, for arrays) is a Solidity function type. The ABI
+// only records the 24-byte encoding of such parameters, so their signatures cannot be reconstructed.
+func isFunctionType(solidityType string) bool {
+	return solidityType == "function" || strings.HasPrefix(solidityType, "function[")
+}
+
+// Returns the maximum number of indexed inputs of an event - events have at most 4 topics, and
+// non-anonymous events use their first topic for the event signature.
+func MaxIndexedEventInputs(anonymous bool) int {
+	if anonymous {
+		return 4
+	}
+	return 3
+}
+
+// Returns the reasons (if any) that the given value cannot be rendered in an interface targeting a
+// compiler which satisfies the given pragma, with ABI coder v2 enabled explicitly if abiCoderV2 is set.
+func unsupportedValueReasons(value Value, pragma string, abiCoderV2 bool) []string {
+	reasons := []string{}
+	if isFunctionType(value.Type) {
+		reasons = append(reasons, fmt.Sprintf("parameter %s has a function type, which cannot be reconstructed from an ABI", value.Name))
+	}
+	if value.IsCompoundType() && !abiCoderV2 && !PragmaAllowsVersion(pragma, abiCoderV2DefaultVersion) {
+		reasons = append(reasons, fmt.Sprintf("parameter %s is a struct, which requires Solidity >= 0.8.0 or pragma abicoder v2 (pragma: %s)", value.Name, pragma))
+	}
+	for _, member := range functionTypedMembers(value.Components, "") {
+		reasons = append(reasons, fmt.Sprintf("member %s of parameter %s has a function type, which cannot be reconstructed from an ABI", member, value.Name))
+	}
+	return reasons
+}
+
+// Returns the paths (e.g. "callback" or "hooks.callback") of the members with function types among the
+// given components of a struct, or of an array of structs, and of the structs nested in them at any depth.
+func functionTypedMembers(components []Value, prefix string) []string {
+	members := []string{}
+	for _, component := range components {
+		path := prefix + component.Name
+		if isFunctionType(component.Type) {
+			members = append(members, path)
+		}
+		members = append(members, functionTypedMembers(component.Components, path+".")...)
+	}
+	return members
+}
+
+// Checks that every item in the given ABI can be rendered as valid Solidity for a compiler satisfying the
+// given pragma (an empty pragma places no constraints on the compiler version), along with the given pragmas
+// other than the Solidity version pragma (see Options.ExtraPragmas) - "abicoder v2" or "experimental
+// ABIEncoderV2" allow structs before Solidity 0.8.0.
+// Returns nil if every item is supported, and an *UnsupportedFeaturesError listing the offending
+// items otherwise.
+func CheckSupport(abi DecodedABI, pragma string, extraPragmas []string) error {
+	unsupported := []UnsupportedItem{}
+	abiCoderV2 := enablesABICoderV2(extraPragmas)
+
+	for i, eventItem := range abi.Events {
+		indexed := 0
+		for _, input := range eventItem.Inputs {
+			if input.Indexed {
+				indexed++
+			}
+			for _, reason := range unsupportedValueReasons(input.Value, pragma, abiCoderV2) {
+				unsupported = append(unsupported, UnsupportedItem{ItemType: "event", ItemIndex: i, Name: eventItem.Name, Reason: reason})
+			}
+		}
+		if maximum := MaxIndexedEventInputs(eventItem.Anonymous); indexed > maximum {
+			unsupported = append(unsupported, UnsupportedItem{ItemType: "event", ItemIndex: i, Name: eventItem.Name, Reason: fmt.Sprintf("%d indexed parameters, but at most %d can be indexed", indexed, maximum)})
+		}
+	}
+
+	for i, functionItem := range abi.Functions {
+		values := append(append([]Value{}, functionItem.Inputs...), functionItem.Outputs...)
+		for _, value := range values {
+			for _, reason := range unsupportedValueReasons(value, pragma, abiCoderV2) {
+				unsupported = append(unsupported, UnsupportedItem{ItemType: "function", ItemIndex: i, Name: functionItem.Name, Reason: reason})
+			}
+		}
+	}
+
+	for i, errorItem := range abi.Errors {
+		if !PragmaAllowsVersion(pragma, customErrorsVersion) {
+			unsupported = append(unsupported, UnsupportedItem{ItemType: "error", ItemIndex: i, Name: errorItem.Name, Reason: fmt.Sprintf("custom errors require Solidity >= 0.8.4 (pragma: %s)", pragma)})
+		}
+		for _, input := range errorItem.Inputs {
+			for _, reason := range unsupportedValueReasons(input, pragma, abiCoderV2) {
+				unsupported = append(unsupported, UnsupportedItem{ItemType: "error", ItemIndex: i, Name: errorItem.Name, Reason: reason})
+			}
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return &UnsupportedFeaturesError{Items: unsupported}
+	}
+	return nil
+}
+
+// Removes the given unsupported items from the ABI so that the rest of it can still be rendered.
+// Returns the pruned ABI along with a diagnostic for every removed item.
+func RemoveUnsupported(abi DecodedABI, unsupported []UnsupportedItem) (DecodedABI, []Diagnostic) {
+	diagnostics := []Diagnostic{}
+	removed := map[string]map[int]bool{"event": {}, "function": {}, "error": {}}
+	for _, item := range unsupported {
+		if _, ok := removed[item.ItemType]; !ok {
+			continue
+		}
+		if !removed[item.ItemType][item.ItemIndex] {
+			diagnostics = append(diagnostics, Diagnostic{ItemType: item.ItemType, ItemIndex: item.ItemIndex, Name: item.Name, Message: fmt.Sprintf("skipped unrenderable item: %s", item.Reason)})
+		}
+		removed[item.ItemType][item.ItemIndex] = true
+	}
+
+	result := FilterABI(abi, func(itemType string, itemIndex int) bool {
+		return !removed[itemType][itemIndex]
+	})
+	return result, diagnostics
+}
+
+var pragmaComparatorRegexp = regexp.MustCompile(`^(\^|~|>=|<=|>|<|=)?v?(\d+)(?:\.(\d+|x|\*))?(?:\.(\d+|x|\*))?$`)
+var pragmaOperatorSpacingRegexp = regexp.MustCompile(`(\^|~|>=|<=|>|<|=)\s+`)
+
+// Parses a version like "0.8" or "0.8.17" into its (major, minor, patch) components. Missing or wildcard
+// components are parsed as 0.
+func parseVersionComponents(matches []string) [3]int {
+	var version [3]int
+	for i := 0; i < 3; i++ {
+		component, parseErr := strconv.Atoi(matches[i])
+		if parseErr == nil {
+			version[i] = component
+		}
+	}
+	return version
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := 0; i < 3; i++ {
+		if a[i] < b[i] {
+			return -1
+		} else if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Returns true if every compiler version which the given Solidity version pragma (e.g. "^0.8.13",
+// ">=0.8.13 <0.9.0") admits is greater than or equal to the given version, i.e. if code generated for the
+// pragma may use features introduced in that version. Empty or unparseable pragmas admit every version, so
+// they do not require any.
+func PragmaRequiresVersion(pragma string, version [3]int) bool {
+	pragma = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(pragma), "solidity"))
+	if pragma == "" {
+		return false
+	}
+
+	for _, comparatorSet := range strings.Split(pragma, "||") {
+		normalized := pragmaOperatorSpacingRegexp.ReplaceAllString(strings.TrimSpace(comparatorSet), "$1")
+
+		// The smallest version admitted by the comparator set is the largest of its lower bounds.
+		var minimum [3]int
+		for _, comparator := range strings.Fields(normalized) {
+			matches := pragmaComparatorRegexp.FindStringSubmatch(comparator)
+			if matches == nil {
+				continue
+			}
+			lower := parseVersionComponents(matches[2:5])
+			switch matches[1] {
+			case "<", "<=":
+				continue
+			case ">":
+				lower[2]++
+			}
+			if compareVersions(lower, minimum) > 0 {
+				minimum = lower
+			}
+		}
+
+		if compareVersions(minimum, version) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if the given Solidity version pragma (e.g. "^0.8.0", ">=0.6.0 <0.9.0", "0.7.6") admits some
+// compiler version greater than or equal to the given version.
+// Empty or unparseable pragmas are treated as unconstrained.
+func PragmaAllowsVersion(pragma string, version [3]int) bool {
+	pragma = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(pragma), "solidity"))
+	if pragma == "" {
+		return true
+	}
+
+	for _, comparatorSet := range strings.Split(pragma, "||") {
+		// Normalize ">= 0.8.0" to ">=0.8.0" so that comparators can be split on whitespace.
+		normalized := pragmaOperatorSpacingRegexp.ReplaceAllString(strings.TrimSpace(comparatorSet), "$1")
+
+		admitted := true
+		for _, comparator := range strings.Fields(normalized) {
+			matches := pragmaComparatorRegexp.FindStringSubmatch(comparator)
+			if matches == nil {
+				continue
+			}
+
+			operator := matches[1]
+			bound := parseVersionComponents(matches[2:5])
+			var upper [3]int
+			hasUpper, inclusive := true, false
+			switch operator {
+			case "^":
+				if bound[0] > 0 {
+					upper = [3]int{bound[0] + 1, 0, 0}
+				} else if bound[1] > 0 {
+					upper = [3]int{0, bound[1] + 1, 0}
+				} else {
+					upper, inclusive = bound, true
+				}
+			case "~":
+				upper = [3]int{bound[0], bound[1] + 1, 0}
+			case "<":
+				upper = bound
+			case "<=":
+				upper, inclusive = bound, true
+			case "=", "":
+				// "0.8" and "0.8.x" admit every patch version of 0.8.
+				if _, patchErr := strconv.Atoi(matches[4]); patchErr != nil {
+					upper = [3]int{bound[0], bound[1] + 1, 0}
+				} else {
+					upper, inclusive = bound, true
+				}
+			default:
+				hasUpper = false
+			}
+
+			if hasUpper {
+				comparison := compareVersions(version, upper)
+				if comparison > 0 || (comparison == 0 && !inclusive) {
+					admitted = false
+				}
+			}
+		}
+
+		if admitted {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,246 @@
+package solface
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestPragmaAllowsVersion(t *testing.T) {
+	testCases := []struct {
+		pragma   string
+		version  [3]int
+		expected bool
+	}{
+		{"", [3]int{0, 8, 4}, true},
+		{"^0.8.0", [3]int{0, 8, 4}, true},
+		{"^0.7.0", [3]int{0, 8, 0}, false},
+		{">=0.6.0 <0.9.0", [3]int{0, 8, 4}, true},
+		{">= 0.6.0 < 0.8.0", [3]int{0, 8, 0}, false},
+		{"0.8.3", [3]int{0, 8, 4}, false},
+		{"0.8", [3]int{0, 8, 4}, true},
+		{"~0.8.2", [3]int{0, 8, 4}, true},
+		{"^0.6.0 || ^0.8.0", [3]int{0, 8, 0}, true},
+		{">=0.5.0", [3]int{0, 8, 4}, true},
+	}
+
+	for _, testCase := range testCases {
+		actual := PragmaAllowsVersion(testCase.pragma, testCase.version)
+		if actual != testCase.expected {
+			t.Fatalf("Pragma: %s, version: %v. Expected: %t, actual: %t", testCase.pragma, testCase.version, testCase.expected, actual)
+		}
+	}
+}
+
+func TestPragmaRequiresVersion(t *testing.T) {
+	testCases := []struct {
+		pragma   string
+		version  [3]int
+		expected bool
+	}{
+		{"", [3]int{0, 8, 13}, false},
+		{"^0.8.0", [3]int{0, 8, 13}, false},
+		{"^0.8.13", [3]int{0, 8, 13}, true},
+		{"^0.8.20", [3]int{0, 8, 13}, true},
+		{">=0.8.13 <0.9.0", [3]int{0, 8, 13}, true},
+		{">0.8.12", [3]int{0, 8, 13}, true},
+		{"<0.9.0", [3]int{0, 8, 13}, false},
+		{"0.8.8", [3]int{0, 8, 8}, true},
+		{"~0.8.7", [3]int{0, 8, 8}, false},
+		{"^0.8.13 || ^0.7.0", [3]int{0, 8, 13}, false},
+		{"^0.8.13 || >=0.9.0", [3]int{0, 8, 13}, true},
+	}
+
+	for _, testCase := range testCases {
+		actual := PragmaRequiresVersion(testCase.pragma, testCase.version)
+		if actual != testCase.expected {
+			t.Fatalf("Pragma: %s, version: %v. Expected: %t, actual: %t", testCase.pragma, testCase.version, testCase.expected, actual)
+		}
+	}
+}
+
+func TestCheckSupportDiamondCutFacetOldPragma(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/DiamondCutFacet.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	if supportErr := CheckSupport(abi, "^0.8.0", nil); supportErr != nil {
+		t.Fatalf("Expected no unsupported items for pragma ^0.8.0. Got: %s", supportErr.Error())
+	}
+
+	supportErr := CheckSupport(abi, "^0.7.0", nil)
+	var unsupportedErr *UnsupportedFeaturesError
+	if !errors.As(supportErr, &unsupportedErr) {
+		t.Fatalf("Expected an UnsupportedFeaturesError for pragma ^0.7.0. Got: %v", supportErr)
+	}
+
+	// DiamondCut event (struct), diamondCut function (struct), InitializationFunctionReverted error (custom error).
+	expectedItemTypes := []string{"event", "function", "error"}
+	if len(unsupportedErr.Items) != len(expectedItemTypes) {
+		t.Fatalf("Expected %d unsupported items. Actual: %d", len(expectedItemTypes), len(unsupportedErr.Items))
+	}
+	for i, item := range unsupportedErr.Items {
+		if item.ItemType != expectedItemTypes[i] {
+			t.Fatalf("Unsupported item %d: Expected item type: %s. Actual item type: %s", i, expectedItemTypes[i], item.ItemType)
+		}
+	}
+}
+
+func TestCheckSupportSeaportABICoderV2(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/Seaport.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	// Without ABI coder v2, every item which takes or returns a struct is unsupported before Solidity 0.8.0.
+	var unsupportedErr *UnsupportedFeaturesError
+	if !errors.As(CheckSupport(abi, "^0.7.6", nil), &unsupportedErr) || len(unsupportedErr.Items) <= 2 {
+		t.Fatalf("Expected structs to be unsupported for pragma ^0.7.6. Actual: %v", unsupportedErr)
+	}
+
+	// With it, only the custom errors (which require Solidity 0.8.4) are.
+	for _, extraPragma := range []string{"abicoder v2", "experimental ABIEncoderV2"} {
+		if !errors.As(CheckSupport(abi, "^0.7.6", []string{extraPragma}), &unsupportedErr) {
+			t.Fatalf("Expected custom errors to be unsupported for pragma ^0.7.6 with %s", extraPragma)
+		}
+		for _, item := range unsupportedErr.Items {
+			if item.ItemType != "error" {
+				t.Fatalf("Expected only custom errors to be unsupported with %s. Actual: %v", extraPragma, unsupportedErr.Items)
+			}
+		}
+	}
+
+	supported, _ := RemoveUnsupported(abi, unsupportedErr.Items)
+	var output bytes.Buffer
+	generateErr := GenerateInterfaceWithOptions(supported, Annotations{}, Options{Name: "ISeaport", Pragma: "^0.7.6", ExtraPragmas: []string{"abicoder v2"}}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	for _, expected := range []string{"pragma solidity ^0.7.6;\npragma abicoder v2;", "struct ", "function getOrderHash("} {
+		if !bytes.Contains(output.Bytes(), []byte(expected)) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+		}
+	}
+}
+
+func TestGenerateInterfaceRejectsFunctionTypes(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "register", Inputs: []Value{{Name: "callback", Type: "function"}}, StateMutability: "nonpayable"},
+	}}
+
+	var output bytes.Buffer
+	generateErr := GenerateInterface("IRegistry", "", "", abi, Annotations{}, false, &output)
+
+	var unsupportedErr *UnsupportedFeaturesError
+	if !errors.As(generateErr, &unsupportedErr) {
+		t.Fatalf("Expected an UnsupportedFeaturesError. Got: %v", generateErr)
+	}
+	if len(unsupportedErr.Items) != 1 || unsupportedErr.Items[0].Name != "register" {
+		t.Fatalf("Expected a single unsupported item for function register. Got: %v", unsupportedErr.Items)
+	}
+	if output.Len() != 0 {
+		t.Fatal("Expected no output to be written for an unsupported ABI")
+	}
+}
+
+func TestCheckSupportNestedFunctionTypes(t *testing.T) {
+	hook := Value{Name: "hook", Type: "tuple", Components: []Value{{Name: "target", Type: "address"}, {Name: "callback", Type: "function"}}}
+	abi := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "register", Inputs: []Value{{Name: "config", Type: "tuple", Components: []Value{{Name: "owner", Type: "address"}, hook}}}, StateMutability: "nonpayable"},
+		{Type: "function", Name: "registerAll", Inputs: []Value{{Name: "configs", Type: "tuple[]", Components: []Value{{Name: "hooks", Type: "tuple[2]", Components: hook.Components}}}}, StateMutability: "nonpayable"},
+	}}
+
+	var unsupportedErr *UnsupportedFeaturesError
+	if !errors.As(CheckSupport(abi, "", nil), &unsupportedErr) || len(unsupportedErr.Items) != 2 {
+		t.Fatalf("Expected both functions to be unsupported. Actual: %v", unsupportedErr)
+	}
+	expectedReasons := []string{
+		"member hook.callback of parameter config has a function type, which cannot be reconstructed from an ABI",
+		"member hooks.callback of parameter configs has a function type, which cannot be reconstructed from an ABI",
+	}
+	for i, item := range unsupportedErr.Items {
+		if item.Reason != expectedReasons[i] {
+			t.Fatalf("Expected reason: %s. Actual: %s", expectedReasons[i], item.Reason)
+		}
+	}
+
+	var output bytes.Buffer
+	if !errors.As(GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IRegistry"}, &output), &unsupportedErr) {
+		t.Fatal("Expected GenerateInterfaceWithOptions to return an UnsupportedFeaturesError")
+	}
+	if output.Len() != 0 {
+		t.Fatalf("Expected no output to be written. Actual:\n%s", output.String())
+	}
+}
+
+func TestRemoveUnsupported(t *testing.T) {
+	abi := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "register", Inputs: []Value{{Name: "callback", Type: "function"}}, StateMutability: "nonpayable"},
+		{Type: "function", Name: "owner", Outputs: []Value{{Name: "", Type: "address"}}, StateMutability: "view"},
+	}}
+
+	var unsupportedErr *UnsupportedFeaturesError
+	if !errors.As(CheckSupport(abi, "", nil), &unsupportedErr) {
+		t.Fatal("Expected an UnsupportedFeaturesError")
+	}
+
+	pruned, diagnostics := RemoveUnsupported(abi, unsupportedErr.Items)
+	if len(pruned.Functions) != 1 || pruned.Functions[0].Name != "owner" {
+		t.Fatalf("Expected only function owner to remain. Actual functions: %v", pruned.Functions)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Name != "register" {
+		t.Fatalf("Expected a single diagnostic for function register. Actual: %v", diagnostics)
+	}
+}
+
+func TestCheckSupportTooManyIndexedInputs(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "event", "name": "Moved", "anonymous": false, "inputs": [
+			{"name": "a", "type": "address", "indexed": true},
+			{"name": "b", "type": "address", "indexed": true},
+			{"name": "c", "type": "uint256", "indexed": true},
+			{"name": "d", "type": "uint256", "indexed": true}
+		]},
+		{"type": "event", "name": "Logged", "anonymous": true, "inputs": [
+			{"name": "a", "type": "bytes32", "indexed": true},
+			{"name": "b", "type": "bytes32", "indexed": true},
+			{"name": "c", "type": "bytes32", "indexed": true},
+			{"name": "d", "type": "bytes32", "indexed": true},
+			{"name": "e", "type": "bytes", "indexed": false}
+		]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	// Anonymous events have no signature topic, so Logged may index 4 inputs.
+	var unsupportedErr *UnsupportedFeaturesError
+	if !errors.As(CheckSupport(abi, "", nil), &unsupportedErr) || len(unsupportedErr.Items) != 1 || unsupportedErr.Items[0].Name != "Moved" {
+		t.Fatalf("Expected Moved to be the only unsupported item. Actual: %v", unsupportedErr)
+	}
+	if unsupportedErr.Items[0].Reason != "4 indexed parameters, but at most 3 can be indexed" {
+		t.Fatalf("Unexpected reason: %s", unsupportedErr.Items[0].Reason)
+	}
+
+	var output bytes.Buffer
+	abi.Events = abi.Events[1:]
+	if generateErr := GenerateInterface("ILogger", "", "", abi, Annotations{}, false, &output); generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	expected := "event Logged(bytes32 indexed a, bytes32 indexed b, bytes32 indexed c, bytes32 indexed d, bytes e) anonymous;"
+	if !bytes.Contains(output.Bytes(), []byte(expected)) {
+		t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+	}
+}
@@ -0,0 +1,65 @@
+package solface
+
+import (
+	"io"
+	"sort"
+)
+
+// Represents an output format that solface can generate from an ABI, as configured by the given options.
+type Target func(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error
+
+// Names of the built-in targets.
+const (
+	TargetInterface    = "interface"
+	TargetTestVectors  = "test-vectors"
+	TargetGoConstants  = "go-constants"
+	TargetAnnotations  = "annotations"
+	TargetEventFilters = "event-filters"
+	TargetSentinel     = "defender-sentinel"
+	TargetSafeBatch    = "safe-batch"
+	TargetTSEvents     = "ts-event-fixtures"
+	TargetJSON         = "json"
+	TargetSelectors    = "selector-table"
+	TargetEIP712       = "eip712"
+)
+
+var targets = map[string]Target{
+	TargetInterface:    GenerateInterfaceWithOptions,
+	TargetTestVectors:  GenerateTestVectors,
+	TargetGoConstants:  GenerateGoConstants,
+	TargetAnnotations:  GenerateAnnotations,
+	TargetEventFilters: GenerateEventFilters,
+	TargetSentinel:     GenerateSentinelConfigs,
+	TargetSafeBatch:    GenerateSafeBatch,
+	TargetTSEvents:     GenerateTSEventFixtures,
+	TargetJSON:         GenerateIntermediateRepresentation,
+	TargetSelectors:    GenerateSelectorTable,
+	TargetEIP712:       GenerateEIP712Definitions,
+}
+
+// Targets whose output is binary rather than text.
+var binaryTargets = map[string]bool{
+	TargetSelectors: true,
+}
+
+// Returns true if the target with the given name generates binary output, which cannot be embedded in JSON
+// or concatenated with other outputs as is.
+func IsBinaryTarget(name string) bool {
+	return binaryTargets[name]
+}
+
+// Returns the target with the given name, and false if there is no such target.
+func GetTarget(name string) (Target, bool) {
+	target, ok := targets[name]
+	return target, ok
+}
+
+// Returns the names of all targets, sorted alphabetically.
+func TargetNames() []string {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,33 @@
+package solface
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// The environment variable which, per https://reproducible-builds.org/specs/source-date-epoch/, pins
+// timestamps embedded in build outputs.
+const SourceDateEpochVariable string = "SOURCE_DATE_EPOCH"
+
+// Returns the time that should be recorded in generated artifacts. If the SOURCE_DATE_EPOCH environment
+// variable is set, the time it specifies (in seconds since the Unix epoch) is returned so that outputs
+// are reproducible. Otherwise, the current time is returned. Times are always in UTC.
+func GenerationTime() (time.Time, error) {
+	sourceDateEpoch, ok := os.LookupEnv(SourceDateEpochVariable)
+	if !ok || sourceDateEpoch == "" {
+		return time.Now().UTC(), nil
+	}
+
+	seconds, parseErr := strconv.ParseInt(sourceDateEpoch, 10, 64)
+	if parseErr != nil {
+		return time.Time{}, fmt.Errorf("invalid %s (%s): %s", SourceDateEpochVariable, sourceDateEpoch, parseErr.Error())
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// Formats a time for inclusion in generated artifacts.
+func FormatGenerationTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
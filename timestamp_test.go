@@ -0,0 +1,63 @@
+package solface
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerationTimeSourceDateEpoch(t *testing.T) {
+	t.Setenv(SourceDateEpochVariable, "1700000000")
+
+	generationTime, timeErr := GenerationTime()
+	if timeErr != nil {
+		t.Fatalf("Could not determine generation time: %s", timeErr.Error())
+	}
+
+	expected := "2023-11-14T22:13:20Z"
+	if FormatGenerationTime(generationTime) != expected {
+		t.Fatalf("Incorrect generation time. Expected: %s, actual: %s", expected, FormatGenerationTime(generationTime))
+	}
+
+	t.Setenv(SourceDateEpochVariable, "yesterday")
+	if _, timeErr := GenerationTime(); timeErr == nil {
+		t.Fatal("Expected an error for an invalid SOURCE_DATE_EPOCH")
+	}
+}
+
+func TestGenerateInterfaceIsReproducible(t *testing.T) {
+	t.Setenv(SourceDateEpochVariable, "1700000000")
+
+	contents, readErr := os.ReadFile("fixtures/abis/Seaport.json")
+	if readErr != nil {
+		t.Fatal("Could not read file containing ABI")
+	}
+
+	outputs := make([]string, 2)
+	for i := range outputs {
+		abi, decodeErr := Decode(contents)
+		if decodeErr != nil {
+			t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+		}
+		annotations, _ := Annotate(abi)
+		generationTime, timeErr := GenerationTime()
+		if timeErr != nil {
+			t.Fatalf("Could not determine generation time: %s", timeErr.Error())
+		}
+
+		var output strings.Builder
+		options := Options{Name: "ISeaport", IncludeAnnotations: true, SecurityAnnotations: true, Timestamp: generationTime}
+		generateErr := GenerateInterfaceWithOptions(abi, annotations, options, &output)
+		if generateErr != nil {
+			t.Fatalf("Error generating interface: %s", generateErr.Error())
+		}
+		outputs[i] = output.String()
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Fatal("Expected identical outputs for identical inputs and options")
+	}
+	if !strings.Contains(outputs[0], "// generated at: 2023-11-14T22:13:20Z\n") {
+		t.Fatalf("Expected output to contain the generation time. Actual output:\n%s", outputs[0])
+	}
+}
@@ -0,0 +1,344 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Returns the canonical type of the given value as used in function and event signatures. Tuples are
+// expanded into the parenthesized list of the canonical types of their components, e.g. a "tuple[]" with
+// an address and a uint256 component has canonical type "(address,uint256)[]".
+func CanonicalType(value Value) string {
+	if !strings.HasPrefix(value.Type, "tuple") {
+		return value.Type
+	}
+
+	componentTypes := make([]string, len(value.Components))
+	for i, component := range value.Components {
+		componentTypes[i] = CanonicalType(component)
+	}
+	return fmt.Sprintf("(%s)%s", strings.Join(componentTypes, ","), strings.TrimPrefix(value.Type, "tuple"))
+}
+
+// Returns the canonical signature of the given event, e.g. "Transfer(address,address,uint256)".
+func EventSignature(event EventItem) string {
+	argumentTypes := make([]string, len(event.Inputs))
+	for i, input := range event.Inputs {
+		argumentTypes[i] = CanonicalType(input.Value)
+	}
+	return fmt.Sprintf("%s(%s)", event.Name, strings.Join(argumentTypes, ","))
+}
+
+// Calculates the 32-byte topic (the Keccak256 hash of the event signature) which identifies logs of the
+// given event. Note that anonymous events do not emit this topic.
+func EventTopic(event EventItem) []byte {
+	return EventTopicWithHasher(event, Keccak256Hasher)
+}
+
+// Calculates the topic which identifies logs of the given event using the given hasher (Keccak256Hasher if
+// nil) instead of Keccak-256.
+func EventTopicWithHasher(event EventItem, hasher Hasher) []byte {
+	return hasherOrDefault(hasher).Hash([]byte(EventSignature(event)))
+}
+
+// Computes the topics for logs of the given event, suitable for constructing log filters.
+// For non-anonymous events, the first topic is the event topic (see EventTopic). The remaining topics
+// correspond to the indexed inputs of the event, in order, and values must contain exactly one value for
+// each indexed input. A nil value produces a nil topic, which log filters interpret as a wildcard.
+//
+// Values of static types are ABI-encoded into a single 32-byte word. Values of dynamic types (string,
+// bytes), arrays, and tuples are hashed according to the Solidity ABI specification:
+// https://docs.soliditylang.org/en/v0.8.17/abi-spec.html#encoding-of-indexed-event-parameters
+//
+// Accepted Go values are: bool for bool; integer types, *big.Int and decimal strings for (u)intN;
+// common.Address, [20]byte and hex strings for address; byte slices, byte arrays and hex strings for
+// bytesN and bytes; strings for string; and slices or arrays of the above for arrays and tuples.
+func EventTopics(event EventItem, values ...interface{}) ([][]byte, error) {
+	topics := [][]byte{}
+	if !event.Anonymous {
+		topics = append(topics, EventTopic(event))
+	}
+
+	indexedInputs := []Value{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexedInputs = append(indexedInputs, input.Value)
+		}
+	}
+	if len(values) != len(indexedInputs) {
+		return topics, fmt.Errorf("event %s has %d indexed inputs but %d values were provided", event.Name, len(indexedInputs), len(values))
+	}
+
+	for i, input := range indexedInputs {
+		if values[i] == nil {
+			topics = append(topics, nil)
+			continue
+		}
+
+		topic, topicErr := indexedTopic(input, values[i])
+		if topicErr != nil {
+			return topics, fmt.Errorf("could not compute topic for input %s of event %s: %s", input.Name, event.Name, topicErr.Error())
+		}
+		topics = append(topics, topic)
+	}
+
+	return topics, nil
+}
+
+// Computes the topic for a single indexed value.
+func indexedTopic(input Value, value interface{}) ([]byte, error) {
+	_, arraySuffix := splitArrayType(input.Type)
+	if input.Type == "string" || input.Type == "bytes" {
+		// Top-level strings and bytes are hashed without any padding.
+		raw, rawErr := dynamicBytes(input.Type, value)
+		if rawErr != nil {
+			return nil, rawErr
+		}
+		return crypto.Keccak256(raw), nil
+	} else if arraySuffix == "" && !strings.HasPrefix(input.Type, "tuple") {
+		return encodeElementary(input.Type, value)
+	}
+
+	encoded, encodeErr := encodeInPlace(input, value)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// Splits an array type into its element type and the outermost array suffix, e.g. "uint256[2][]" is split
+// into "uint256[2]" and "[]". The suffix is empty for non-array types.
+func splitArrayType(solidityType string) (string, string) {
+	element, dimensions, parseErr := ParseArrayType(solidityType)
+	if parseErr != nil || len(dimensions) == 0 {
+		return solidityType, ""
+	}
+	last := len(dimensions) - 1
+	return element + FormatArrayDimensions(dimensions[:last]), FormatArrayDimensions(dimensions[last:])
+}
+
+// Encodes a value in the "in-place" encoding used for indexed array and tuple event parameters: dynamic
+// values are padded to a multiple of 32 bytes without a length prefix, and the elements of arrays and
+// tuples are concatenated without offsets or lengths.
+func encodeInPlace(input Value, value interface{}) ([]byte, error) {
+	elementType, arraySuffix := splitArrayType(input.Type)
+	if arraySuffix != "" {
+		elements, elementsErr := sliceElements(value)
+		if elementsErr != nil {
+			return nil, elementsErr
+		}
+		if arraySuffix != "[]" {
+			expectedLength, lengthErr := strconv.Atoi(strings.Trim(arraySuffix, "[]"))
+			if lengthErr != nil {
+				return nil, fmt.Errorf("invalid array type: %s", input.Type)
+			}
+			if len(elements) != expectedLength {
+				return nil, fmt.Errorf("expected %d elements for type %s, got %d", expectedLength, input.Type, len(elements))
+			}
+		}
+
+		elementValue := Value{Name: input.Name, Type: elementType, Components: input.Components}
+		result := []byte{}
+		for _, element := range elements {
+			encoded, encodeErr := encodeInPlace(elementValue, element)
+			if encodeErr != nil {
+				return nil, encodeErr
+			}
+			result = append(result, encoded...)
+		}
+		return result, nil
+	}
+
+	if input.Type == "tuple" {
+		members, membersErr := sliceElements(value)
+		if membersErr != nil {
+			return nil, membersErr
+		}
+		if len(members) != len(input.Components) {
+			return nil, fmt.Errorf("expected %d members for tuple %s, got %d", len(input.Components), input.Name, len(members))
+		}
+
+		result := []byte{}
+		for i, component := range input.Components {
+			encoded, encodeErr := encodeInPlace(component, members[i])
+			if encodeErr != nil {
+				return nil, encodeErr
+			}
+			result = append(result, encoded...)
+		}
+		return result, nil
+	}
+
+	if input.Type == "string" || input.Type == "bytes" {
+		raw, rawErr := dynamicBytes(input.Type, value)
+		if rawErr != nil {
+			return nil, rawErr
+		}
+		padding := (32 - len(raw)%32) % 32
+		return append(raw, make([]byte, padding)...), nil
+	}
+
+	return encodeElementary(input.Type, value)
+}
+
+// Returns the raw bytes of a value of type string or bytes.
+func dynamicBytes(solidityType string, value interface{}) ([]byte, error) {
+	if solidityType == "string" {
+		stringValue, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string value, got %T", value)
+		}
+		return []byte(stringValue), nil
+	}
+	return bytesValue(value)
+}
+
+// Returns the elements of a slice or array value.
+func sliceElements(value interface{}) ([]interface{}, error) {
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice or array value, got %T", value)
+	}
+	elements := make([]interface{}, reflected.Len())
+	for i := range elements {
+		elements[i] = reflected.Index(i).Interface()
+	}
+	return elements, nil
+}
+
+// Returns the bytes represented by a byte slice, byte array, or hex string value.
+func bytesValue(value interface{}) ([]byte, error) {
+	if stringValue, ok := value.(string); ok {
+		decoded, decodeErr := hex.DecodeString(strings.TrimPrefix(stringValue, "0x"))
+		if decodeErr != nil {
+			return nil, fmt.Errorf("invalid hex string %q: %s", stringValue, decodeErr.Error())
+		}
+		return decoded, nil
+	}
+
+	reflected := reflect.ValueOf(value)
+	if (reflected.Kind() == reflect.Slice || reflected.Kind() == reflect.Array) && reflected.Type().Elem().Kind() == reflect.Uint8 {
+		result := make([]byte, reflected.Len())
+		for i := range result {
+			result[i] = byte(reflected.Index(i).Uint())
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("expected a byte slice, byte array, or hex string, got %T", value)
+}
+
+// Returns the integer represented by an integer, *big.Int, or decimal string value.
+func integerValue(value interface{}) (*big.Int, error) {
+	switch typedValue := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(typedValue), nil
+	case string:
+		result, ok := new(big.Int).SetString(typedValue, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer string %q", typedValue)
+		}
+		return result, nil
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(reflected.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(reflected.Uint()), nil
+	}
+
+	return nil, fmt.Errorf("expected an integer value, got %T", value)
+}
+
+// ABI-encodes a value of an elementary static type into a single 32-byte word.
+func encodeElementary(solidityType string, value interface{}) ([]byte, error) {
+	word := make([]byte, 32)
+
+	switch {
+	case solidityType == "bool":
+		boolValue, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a bool value, got %T", value)
+		}
+		if boolValue {
+			word[31] = 1
+		}
+		return word, nil
+
+	case solidityType == "address":
+		var address []byte
+		if typedValue, ok := value.(common.Address); ok {
+			address = typedValue.Bytes()
+		} else {
+			var addressErr error
+			address, addressErr = bytesValue(value)
+			if addressErr != nil {
+				return nil, addressErr
+			}
+		}
+		if len(address) != common.AddressLength {
+			return nil, fmt.Errorf("expected a %d-byte address, got %d bytes", common.AddressLength, len(address))
+		}
+		copy(word[32-common.AddressLength:], address)
+		return word, nil
+
+	case strings.HasPrefix(solidityType, "uint") || strings.HasPrefix(solidityType, "int"):
+		signed := strings.HasPrefix(solidityType, "int")
+		bits := 256
+		if widthString := strings.TrimPrefix(strings.TrimPrefix(solidityType, "u"), "int"); widthString != "" {
+			width, widthErr := strconv.Atoi(widthString)
+			if widthErr != nil {
+				return nil, fmt.Errorf("invalid integer type: %s", solidityType)
+			}
+			bits = width
+		}
+
+		integer, integerErr := integerValue(value)
+		if integerErr != nil {
+			return nil, integerErr
+		}
+
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		if signed {
+			half := new(big.Int).Rsh(limit, 1)
+			if integer.Cmp(new(big.Int).Neg(half)) < 0 || integer.Cmp(half) >= 0 {
+				return nil, fmt.Errorf("value %s out of range for %s", integer.String(), solidityType)
+			}
+		} else if integer.Sign() < 0 || integer.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("value %s out of range for %s", integer.String(), solidityType)
+		}
+
+		// Negative values are encoded in two's complement over the full 256-bit word.
+		if integer.Sign() < 0 {
+			integer.Add(integer, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		integer.FillBytes(word)
+		return word, nil
+
+	case strings.HasPrefix(solidityType, "bytes"):
+		size, sizeErr := strconv.Atoi(strings.TrimPrefix(solidityType, "bytes"))
+		if sizeErr != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("invalid fixed-size bytes type: %s", solidityType)
+		}
+		raw, rawErr := bytesValue(value)
+		if rawErr != nil {
+			return nil, rawErr
+		}
+		if len(raw) != size {
+			return nil, fmt.Errorf("expected %d bytes for %s, got %d", size, solidityType, len(raw))
+		}
+		copy(word, raw)
+		return word, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type: %s", solidityType)
+}
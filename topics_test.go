@@ -0,0 +1,76 @@
+package solface
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestEventTopicsERC20Transfer(t *testing.T) {
+	transfer := EventItem{Type: "event", Name: "Transfer", Inputs: []EventArgument{
+		{Value: Value{Name: "from", Type: "address"}, Indexed: true},
+		{Value: Value{Name: "to", Type: "address"}, Indexed: true},
+		{Value: Value{Name: "value", Type: "uint256"}, Indexed: false},
+	}}
+
+	topics, topicsErr := EventTopics(transfer, "0x000000000000000000000000000000000000dEaD", nil)
+	if topicsErr != nil {
+		t.Fatalf("Could not compute topics: %s", topicsErr.Error())
+	}
+
+	expectedTopics := []string{
+		"ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"000000000000000000000000000000000000000000000000000000000000dead",
+		"",
+	}
+	if len(topics) != len(expectedTopics) {
+		t.Fatalf("Expected %d topics. Actual: %d", len(expectedTopics), len(topics))
+	}
+	for i, topic := range topics {
+		if hex.EncodeToString(topic) != expectedTopics[i] {
+			t.Fatalf("Topic %d: Expected: %s, actual: %s", i, expectedTopics[i], hex.EncodeToString(topic))
+		}
+	}
+
+	if _, topicsErr := EventTopics(transfer, nil); topicsErr == nil {
+		t.Fatal("Expected an error when the number of values does not match the number of indexed inputs")
+	}
+}
+
+func TestEventTopicsDynamicTypes(t *testing.T) {
+	event := EventItem{Type: "event", Name: "Registered", Anonymous: true, Inputs: []EventArgument{
+		{Value: Value{Name: "name", Type: "string"}, Indexed: true},
+		{Value: Value{Name: "amounts", Type: "uint256[]"}, Indexed: true},
+		{Value: Value{Name: "delta", Type: "int8"}, Indexed: true},
+	}}
+
+	topics, topicsErr := EventTopics(event, "solface", []*big.Int{big.NewInt(1), big.NewInt(2)}, -1)
+	if topicsErr != nil {
+		t.Fatalf("Could not compute topics: %s", topicsErr.Error())
+	}
+
+	// Anonymous events do not emit the event topic.
+	if len(topics) != 3 {
+		t.Fatalf("Expected 3 topics. Actual: %d", len(topics))
+	}
+
+	expectedNameTopic := hex.EncodeToString(crypto.Keccak256([]byte("solface")))
+	if hex.EncodeToString(topics[0]) != expectedNameTopic {
+		t.Fatalf("Incorrect topic for indexed string. Expected: %s, actual: %s", expectedNameTopic, hex.EncodeToString(topics[0]))
+	}
+
+	amounts := make([]byte, 64)
+	amounts[31] = 1
+	amounts[63] = 2
+	expectedAmountsTopic := hex.EncodeToString(crypto.Keccak256(amounts))
+	if hex.EncodeToString(topics[1]) != expectedAmountsTopic {
+		t.Fatalf("Incorrect topic for indexed array. Expected: %s, actual: %s", expectedAmountsTopic, hex.EncodeToString(topics[1]))
+	}
+
+	expectedDeltaTopic := "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+	if hex.EncodeToString(topics[2]) != expectedDeltaTopic {
+		t.Fatalf("Incorrect topic for indexed int8. Expected: %s, actual: %s", expectedDeltaTopic, hex.EncodeToString(topics[2]))
+	}
+}
@@ -0,0 +1,91 @@
+package solface
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The type declarations at the top of every TypeScript event fixtures module.
+const tsEventFixtureTypes = `export interface EventInputFixture {
+  name: string;
+  type: string;
+  indexed: boolean;
+  components?: readonly EventInputFixture[];
+}
+
+export interface EventFixture {
+  name: string;
+  signature: string;
+  topic0: string | null;
+  anonymous: boolean;
+  inputs: readonly EventInputFixture[];
+}
+`
+
+// Returns the given string as a TypeScript string literal.
+func tsString(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
+
+// Writes the TypeScript object literal describing the given event inputs (or tuple components), indented
+// by the given prefix.
+func writeTSInputs(builder *strings.Builder, values []Value, indexed []bool, indent string) {
+	if len(values) == 0 {
+		builder.WriteString("[]")
+		return
+	}
+	builder.WriteString("[\n")
+	for i, value := range values {
+		fmt.Fprintf(builder, "%s  { name: %s, type: %s, indexed: %t", indent, tsString(value.Name), tsString(CanonicalType(value)), indexed != nil && indexed[i])
+		if len(value.Components) > 0 {
+			builder.WriteString(", components: ")
+			writeTSInputs(builder, value.Components, nil, indent+"  ")
+		}
+		builder.WriteString(" },\n")
+	}
+	builder.WriteString(indent + "]")
+}
+
+// Generates a TypeScript module of fixtures for the events of the given ABI, for frontend unit tests of
+// event decoding: the name, canonical signature, topic (null for anonymous events), and input layout of
+// every event, in the order of the ABI. The fixtures are exported as <options.Name>EventFixtures. Topics
+// are derived with options.Hasher.
+func GenerateTSEventFixtures(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	name := options.Name
+	if name == "" {
+		name = "Contract"
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "// Event fixtures generated by solface: https://github.com/moonstream-to/solface\n// solface version: %s\n\n", VERSION)
+	builder.WriteString(tsEventFixtureTypes)
+	fmt.Fprintf(&builder, "\nexport const %sEventFixtures: readonly EventFixture[] = [\n", name)
+	for _, eventItem := range abi.Events {
+		topic := "null"
+		if !eventItem.Anonymous {
+			topic = tsString(fmt.Sprintf("0x%x", EventTopicWithHasher(eventItem, options.Hasher)))
+		}
+		values := make([]Value, len(eventItem.Inputs))
+		indexed := make([]bool, len(eventItem.Inputs))
+		for i, input := range eventItem.Inputs {
+			values[i] = input.Value
+			indexed[i] = input.Indexed
+		}
+
+		builder.WriteString("  {\n")
+		fmt.Fprintf(&builder, "    name: %s,\n", tsString(eventItem.Name))
+		fmt.Fprintf(&builder, "    signature: %s,\n", tsString(EventSignature(eventItem)))
+		fmt.Fprintf(&builder, "    topic0: %s,\n", topic)
+		fmt.Fprintf(&builder, "    anonymous: %t,\n", eventItem.Anonymous)
+		builder.WriteString("    inputs: ")
+		writeTSInputs(&builder, values, indexed, "    ")
+		builder.WriteString(",\n  },\n")
+	}
+	builder.WriteString("];\n")
+
+	_, writeErr := io.WriteString(writer, builder.String())
+	return writeErr
+}
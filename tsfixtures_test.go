@@ -0,0 +1,53 @@
+package solface
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTSEventFixtures(t *testing.T) {
+	contents, readErr := os.ReadFile("fixtures/abis/OwnableERC20.json")
+	if readErr != nil {
+		t.Fatalf("Could not read file containing ABI: %s", readErr.Error())
+	}
+	abi, decodeErr := Decode(contents)
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+
+	var output bytes.Buffer
+	generateErr := GenerateTSEventFixtures(abi, Annotations{}, Options{Name: "IOwnableERC20"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Could not generate event fixtures: %s", generateErr.Error())
+	}
+	fixtures := output.String()
+
+	expectedLines := []string{
+		"export const IOwnableERC20EventFixtures: readonly EventFixture[] = [",
+		`    signature: "Transfer(address,address,uint256)",`,
+		`    topic0: "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",`,
+		`      { name: "from", type: "address", indexed: true },`,
+		`      { name: "value", type: "uint256", indexed: false },`,
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(fixtures, line+"\n") {
+			t.Fatalf("Expected fixtures to contain line: %s. Actual:\n%s", line, fixtures)
+		}
+	}
+
+	anonymousABI, decodeErr := Decode([]byte(`[{"type": "event", "name": "Log", "anonymous": true, "inputs": [{"name": "point", "type": "tuple", "indexed": false, "components": [{"name": "x", "type": "uint256"}]}]}]`))
+	if decodeErr != nil {
+		t.Fatalf("Could not decode ABI: %s", decodeErr.Error())
+	}
+	output.Reset()
+	generateErr = GenerateTSEventFixtures(anonymousABI, Annotations{}, Options{Name: "ILogger"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Could not generate event fixtures: %s", generateErr.Error())
+	}
+	fixtures = output.String()
+	if !strings.Contains(fixtures, "    topic0: null,\n") || !strings.Contains(fixtures, `{ name: "point", type: "(uint256)", indexed: false, components: [`) {
+		t.Fatalf("Expected anonymous event with tuple components. Actual:\n%s", fixtures)
+	}
+}
@@ -0,0 +1,102 @@
+package solface
+
+import (
+	"regexp"
+	"strings"
+)
+
+// User-defined value types were introduced in this Solidity version.
+var userDefinedValueTypesVersion = [3]int{0, 8, 8}
+
+// Matches the internalType of a user-defined value type (e.g. "Price" or "Oracle.Price"), without array
+// dimensions.
+var userDefinedValueTypeRegexp = regexp.MustCompile(`^(?:[A-Za-z_$][A-Za-z0-9_$]*\.)?([A-Za-z_$][A-Za-z0-9_$]*)$`)
+
+// Represents a user-defined value type (e.g. "type Price is uint128;") to be declared in an interface.
+//  1. Name: The name of the type in the interface (e.g. "Price").
+//  2. OriginalName: The internalType of the type in the original ABI (e.g. "Oracle.Price").
+//  3. Underlying: The elementary type which the type wraps (e.g. "uint128").
+type UserDefinedValueType struct {
+	Name         string `json:"name"`
+	OriginalName string `json:"originalName"`
+	Underlying   string `json:"underlying"`
+}
+
+// Returns the user-defined value type of the given value, if its internalType names one, and false
+// otherwise. The ABI records user-defined value types (available since Solidity 0.8.8) as the elementary
+// types they wrap, with their names (qualified by the contract that declares them, if any) as
+// internalType.
+func UserDefinedValueTypeOf(value Value) (UserDefinedValueType, bool) {
+	internalType := value.InternalType
+	if internalType == "" || internalType == value.Type || strings.Contains(internalType, " ") || len(value.Components) > 0 {
+		return UserDefinedValueType{}, false
+	}
+	underlying, dimensions := splitArrayDimensions(value.Type)
+	if !strings.HasSuffix(internalType, dimensions) {
+		return UserDefinedValueType{}, false
+	}
+	switch underlying {
+	case "tuple", "function", "string", "bytes":
+		// Only elementary value types can be wrapped.
+		return UserDefinedValueType{}, false
+	}
+	match := userDefinedValueTypeRegexp.FindStringSubmatch(strings.TrimSuffix(internalType, dimensions))
+	if match == nil {
+		return UserDefinedValueType{}, false
+	}
+	switch match[0] {
+	case "uint", "int", "fixed", "ufixed", "byte":
+		// Aliases of elementary types.
+		return UserDefinedValueType{}, false
+	}
+	if strictTypeProblem(strictParameter{Type: match[0]}) == "" {
+		return UserDefinedValueType{}, false
+	}
+	return UserDefinedValueType{Name: match[1], OriginalName: strings.TrimSuffix(internalType, dimensions), Underlying: underlying}, true
+}
+
+// Declares the parameters, return values, and struct members of the given resolved ABI whose internalType
+// is a user-defined value type with that type (e.g. "Price" instead of "uint128"), and returns the
+// rewritten ABI and compound types along with the types to declare, in order of first use. Types with the
+// same name (e.g. "Price" and "Oracle.Price") are declared once, unless they wrap different types - then
+// they are left as their elementary types, as are types named like the interface (given by name) or one of
+// its structs.
+func ApplyUserDefinedValueTypes(abi DecodedABI, compoundTypes []CompoundType, name string) (DecodedABI, []CompoundType, []UserDefinedValueType) {
+	reserved := map[string]bool{name: true}
+	for _, compound := range compoundTypes {
+		reserved[compound.TypeName] = true
+	}
+
+	declarations := []UserDefinedValueType{}
+	declared := map[string]UserDefinedValueType{}
+	conflicting := map[string]bool{}
+	MapValues(abi, compoundTypes, func(value Value) Value {
+		udvt, ok := UserDefinedValueTypeOf(value)
+		if !ok || reserved[udvt.Name] {
+			return value
+		}
+		if existing, ok := declared[udvt.Name]; !ok {
+			declared[udvt.Name] = udvt
+			declarations = append(declarations, udvt)
+		} else if existing.Underlying != udvt.Underlying {
+			conflicting[udvt.Name] = true
+		}
+		return value
+	})
+
+	result := []UserDefinedValueType{}
+	for _, udvt := range declarations {
+		if !conflicting[udvt.Name] {
+			result = append(result, udvt)
+		}
+	}
+	rewrittenABI, rewrittenCompounds := MapValues(abi, compoundTypes, func(value Value) Value {
+		udvt, ok := UserDefinedValueTypeOf(value)
+		if !ok || reserved[udvt.Name] || conflicting[udvt.Name] {
+			return value
+		}
+		value.Type = udvt.Name + strings.TrimPrefix(value.Type, udvt.Underlying)
+		return value
+	})
+	return rewrittenABI, rewrittenCompounds, result
+}
@@ -0,0 +1,50 @@
+package solface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUserDefinedValueTypes(t *testing.T) {
+	abi, decodeErr := Decode([]byte(`[
+		{"type": "function", "name": "prices", "stateMutability": "view", "inputs": [{"name": "ids", "type": "uint64[]", "internalType": "AssetId[]"}], "outputs": [{"name": "", "type": "uint128", "internalType": "Oracle.Price"}]},
+		{"type": "event", "name": "PriceSet", "anonymous": false, "inputs": [{"name": "id", "type": "uint64", "internalType": "AssetId", "indexed": true}, {"name": "amount", "type": "uint256", "internalType": "uint256", "indexed": false}]},
+		{"type": "function", "name": "quote", "stateMutability": "view", "inputs": [], "outputs": [{"name": "q", "type": "tuple", "internalType": "struct Oracle.Quote", "components": [{"name": "price", "type": "uint128", "internalType": "Price"}]}]},
+		{"type": "function", "name": "mode", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "uint8", "internalType": "enum Oracle.Mode"}]}
+	]`))
+	if decodeErr != nil {
+		t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+	}
+
+	var output strings.Builder
+	generateErr := GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IOracle", UserDefinedValueTypes: true}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	for _, expected := range []string{
+		"interface IOracle {\n\t// user-defined value types\n\ttype AssetId is uint64;\n\ttype Price is uint128;\n\n\t// structs\n\tstruct Quote0 {\n\t\tPrice price;\n\t}",
+		"event PriceSet(AssetId indexed id, uint256 amount);",
+		"function prices(AssetId[] memory ids) external view returns (Price);",
+		"function mode() external view returns (uint8 /* enum Oracle.Mode */);",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("Expected output to contain:\n%s\nActual:\n%s", expected, output.String())
+		}
+	}
+
+	output.Reset()
+	generateErr = GenerateInterfaceWithOptions(abi, Annotations{}, Options{Name: "IOracle"}, &output)
+	if generateErr != nil {
+		t.Fatalf("Error generating interface: %s", generateErr.Error())
+	}
+	if strings.Contains(output.String(), "AssetId") || !strings.Contains(output.String(), "function prices(uint64[] memory ids) external view returns (uint128);") {
+		t.Fatalf("Expected elementary types without UserDefinedValueTypes. Actual:\n%s", output.String())
+	}
+
+	if _, ok := UserDefinedValueTypeOf(Value{Type: "uint256", InternalType: "uint"}); ok {
+		t.Fatalf("Expected internalType uint not to be a user-defined value type")
+	}
+	if _, ok := UserDefinedValueTypeOf(Value{Type: "string", InternalType: "Name"}); ok {
+		t.Fatalf("Expected a string not to be a user-defined value type")
+	}
+}
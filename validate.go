@@ -0,0 +1,209 @@
+package solface
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Represents a problem with a combination of options.
+//  1. Fields: The names of the Options fields involved (e.g. "Codec" and "Lite").
+//  2. Message: A description of the problem, including how to resolve it.
+type OptionsProblem struct {
+	Fields  []string `json:"fields"`
+	Message string   `json:"message"`
+}
+
+// Returned by Options.Validate when options conflict or are invalid. Problems lists every problem, so
+// that they can all be fixed at once.
+type OptionsError struct {
+	Problems []OptionsProblem `json:"problems"`
+}
+
+func (e *OptionsError) Error() string {
+	descriptions := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		descriptions[i] = fmt.Sprintf("%s: %s", strings.Join(problem.Fields, ", "), problem.Message)
+	}
+	return fmt.Sprintf("%d invalid option(s): %s", len(e.Problems), strings.Join(descriptions, "; "))
+}
+
+// Returns true if the given pragma contains at least one version constraint which PragmaAllowsVersion
+// understands.
+func isParseablePragma(pragma string) bool {
+	pragma = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(pragma), "solidity"))
+	for _, comparatorSet := range strings.Split(pragma, "||") {
+		normalized := pragmaOperatorSpacingRegexp.ReplaceAllString(strings.TrimSpace(comparatorSet), "$1")
+		for _, comparator := range strings.Fields(normalized) {
+			if pragmaComparatorRegexp.MatchString(comparator) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns true if every comparator of the given pragma is a version constraint which PragmaAllowsVersion
+// understands, as opposed to other pragmas such as "abicoder v2".
+func isVersionPragma(pragma string) bool {
+	comparators := 0
+	for _, comparatorSet := range strings.Split(pragma, "||") {
+		normalized := pragmaOperatorSpacingRegexp.ReplaceAllString(strings.TrimSpace(comparatorSet), "$1")
+		for _, comparator := range strings.Fields(normalized) {
+			if !pragmaComparatorRegexp.MatchString(comparator) {
+				return false
+			}
+			comparators++
+		}
+	}
+	return comparators > 0
+}
+
+// Matches the pragmas other than the Solidity version pragma which solface generates: "abicoder v1",
+// "abicoder v2", and "experimental <feature>" (e.g. "experimental ABIEncoderV2").
+var extraPragmaRegexp = regexp.MustCompile(`^(abicoder v[12]|experimental [A-Za-z_][A-Za-z0-9_]*)$`)
+
+// Returns a description of the problem with the given pragma other than the Solidity version pragma (see
+// extraPragmaRegexp), or an empty string if it is supported.
+func extraPragmaProblem(pragma string) string {
+	if extraPragmaRegexp.MatchString(pragma) {
+		return ""
+	}
+	return fmt.Sprintf("%q is not a Solidity version constraint (e.g. ^0.8.4 or >=0.8.0 <0.9.0) or a supported pragma (abicoder v1, abicoder v2, or experimental <feature>)", pragma)
+}
+
+// Splits the given pragmas (e.g. "^0.8.20", "solidity ^0.8.20", "abicoder v2", or "pragma abicoder v2;")
+// into the Solidity version constraint (see Options.Pragma) and the other pragmas, in order (see
+// Options.ExtraPragmas). Pragmas are given without the "pragma" keyword and the trailing semicolon, which
+// are stripped if present. Returns an error if more than one Solidity version pragma is given, or if any
+// other pragma is not supported (see extraPragmaRegexp).
+func ParsePragmas(pragmas []string) (string, []string, error) {
+	version := ""
+	extra := []string{}
+	for _, pragma := range pragmas {
+		pragma = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(pragma), ";"))
+		pragma = strings.TrimSpace(strings.TrimPrefix(pragma, "pragma "))
+		if pragma == "" {
+			continue
+		}
+		if strings.HasPrefix(pragma, "solidity ") || isVersionPragma(pragma) {
+			if version != "" {
+				return "", nil, fmt.Errorf("more than one Solidity version pragma: %q and %q", version, pragma)
+			}
+			version = strings.TrimSpace(strings.TrimPrefix(pragma, "solidity "))
+			continue
+		}
+		if problem := extraPragmaProblem(pragma); problem != "" {
+			return "", nil, fmt.Errorf("%s", problem)
+		}
+		extra = append(extra, pragma)
+	}
+	return version, extra, nil
+}
+
+// Checks the options for invalid values and for combinations which cannot be generated, before any ABI
+// is processed. Returns nil if the options are valid, and an *OptionsError listing every problem
+// otherwise. Problems which depend on the ABI (e.g. custom errors with a pragma that predates them) are
+// reported by CheckSupport instead.
+func (options Options) Validate() error {
+	problems := []OptionsProblem{}
+	add := func(message string, fields ...string) {
+		problems = append(problems, OptionsProblem{Fields: fields, Message: message})
+	}
+
+	if options.Name != "" && !IsValidIdentifier(options.Name) {
+		add(fmt.Sprintf("%q is not a valid Solidity identifier", options.Name), "Name")
+	}
+	if options.Pragma != "" && !isParseablePragma(options.Pragma) {
+		add(fmt.Sprintf("%q is not a Solidity version constraint (e.g. ^0.8.4 or >=0.8.0 <0.9.0)", options.Pragma), "Pragma")
+	}
+	for _, pragma := range options.ExtraPragmas {
+		if problem := extraPragmaProblem(pragma); problem != "" {
+			add(problem, "ExtraPragmas")
+		}
+	}
+	if options.Dialect != "" && options.Dialect != DialectSolidity && options.Dialect != DialectVyper {
+		add(fmt.Sprintf("unknown dialect %q (expected %q or %q)", options.Dialect, DialectSolidity, DialectVyper), "Dialect")
+	}
+	if options.ContractTypes != "" && options.ContractTypes != ContractTypesAddress && options.ContractTypes != ContractTypesComment && options.ContractTypes != ContractTypesStub {
+		add(fmt.Sprintf("unknown contract type mode %q (expected %q, %q, or %q)", options.ContractTypes, ContractTypesAddress, ContractTypesComment, ContractTypesStub), "ContractTypes")
+	}
+	if options.UserDefinedValueTypes && options.Pragma != "" && isParseablePragma(options.Pragma) && !PragmaRequiresVersion(options.Pragma, userDefinedValueTypesVersion) {
+		add(fmt.Sprintf("user-defined value types require Solidity >= 0.8.8 (pragma: %s)", options.Pragma), "UserDefinedValueTypes", "Pragma")
+	}
+	if options.NameConflicts != "" && options.NameConflicts != NameConflictsSuffix && options.NameConflicts != NameConflictsPreferFirst && options.NameConflicts != NameConflictsError {
+		add(fmt.Sprintf("unknown name conflict strategy %q (expected %q, %q, or %q)", options.NameConflicts, NameConflictsError, NameConflictsSuffix, NameConflictsPreferFirst), "NameConflicts")
+	}
+	if options.Kind != "" && options.Kind != KindContract && options.Kind != KindLibrary {
+		add(fmt.Sprintf("unknown kind %q (expected %q or %q)", options.Kind, KindContract, KindLibrary), "Kind")
+	}
+	if options.Strict && options.Kind == KindLibrary {
+		add("library ABIs name enum, contract, and storage types (e.g. \"Set.Data storage\"), which strict validation rejects as not ABI types - use only one of them", "Strict", "Kind")
+	}
+	if options.MaxNestingDepth < 0 {
+		add("must not be negative", "MaxNestingDepth")
+	}
+	if options.MaxItems < 0 {
+		add("must not be negative", "MaxItems")
+	}
+	if options.MaxInputBytes < 0 {
+		add("must not be negative", "MaxInputBytes")
+	}
+	if options.Strict && options.SkipInvalid {
+		add("strict mode fails on questionable items, while skipping drops undecodable ones - use only one of them", "Strict", "SkipInvalid")
+	}
+	if options.Codec && options.Lite {
+		add("lite interfaces declare no structs, so there is nothing to generate a codec for - use only one of them", "Codec", "Lite")
+	}
+	if options.EIP712 && options.Lite {
+		add("lite interfaces declare no structs, so there is nothing to generate EIP-712 helpers for - use only one of them", "EIP712", "Lite")
+	}
+	if options.EIP712 && options.UserDefinedValueTypes {
+		add("EIP-712 helpers hash struct members as the elementary types of the ABI, so they cannot be combined with user-defined value types", "EIP712", "UserDefinedValueTypes")
+	}
+	if options.EIP712 && options.ContractTypes == ContractTypesStub {
+		add("EIP-712 helpers hash struct members as the elementary types of the ABI, so they cannot be combined with contract type stubs", "EIP712", "ContractTypes")
+	}
+	if options.DeploymentsLibrary && len(options.Deployments) == 0 {
+		add("a deployments library requires deployments", "DeploymentsLibrary", "Deployments")
+	}
+	if options.DeploymentsLibrary {
+		for _, collision := range deploymentConstantCollisions(options.Deployments) {
+			add(collision, "Deployments")
+		}
+	}
+
+	selectors := make([]string, 0, len(options.FunctionRenames))
+	for selector := range options.FunctionRenames {
+		selectors = append(selectors, selector)
+	}
+	sort.Strings(selectors)
+	for _, selector := range selectors {
+		if decoded, decodeErr := hex.DecodeString(NormalizeSelector(selector)); decodeErr != nil || len(decoded) != 4 {
+			add(fmt.Sprintf("%q is not a 4-byte function selector", selector), "FunctionRenames")
+		}
+		if name := options.FunctionRenames[selector]; !IsValidIdentifier(name) {
+			add(fmt.Sprintf("%q (for selector %s) is not a valid Solidity identifier", name, selector), "FunctionRenames")
+		}
+	}
+
+	for _, selector := range options.AssumeView {
+		if decoded, decodeErr := hex.DecodeString(NormalizeSelector(selector)); decodeErr != nil || len(decoded) != 4 {
+			add(fmt.Sprintf("%q is not a 4-byte function selector", selector), "AssumeView")
+		}
+	}
+
+	for _, namespaceID := range options.StorageNamespaces {
+		if strings.TrimSpace(namespaceID) == "" {
+			add("namespace IDs must not be empty", "StorageNamespaces")
+			break
+		}
+	}
+
+	if len(problems) > 0 {
+		return &OptionsError{Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package solface
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateOptions(t *testing.T) {
+	if validateErr := (Options{Name: "IERC20", Pragma: ">= 0.8.4 <0.9.0", Dialect: DialectVyper, FunctionRenames: map[string]string{"0xa9059cbb": "send"}}).Validate(); validateErr != nil {
+		t.Fatalf("Expected valid options. Actual error: %s", validateErr.Error())
+	}
+
+	options := Options{
+		Name:               "I-ERC20",
+		Pragma:             "latest",
+		Dialect:            "fe",
+		Kind:               "module",
+		MaxItems:           -1,
+		Codec:              true,
+		Lite:               true,
+		DeploymentsLibrary: true,
+		FunctionRenames:    map[string]string{"0xa9059cbb": "send it", "0x1234": "short"},
+	}
+	var optionsErr *OptionsError
+	if !errors.As(options.Validate(), &optionsErr) {
+		t.Fatalf("Expected an *OptionsError")
+	}
+
+	expectedFields := []string{"Name", "Pragma", "Dialect", "Kind", "MaxItems", "Codec", "DeploymentsLibrary", "FunctionRenames", "FunctionRenames"}
+	if len(optionsErr.Problems) != len(expectedFields) {
+		t.Fatalf("Expected %d problems. Actual: %v", len(expectedFields), optionsErr.Problems)
+	}
+	for i, field := range expectedFields {
+		if optionsErr.Problems[i].Fields[0] != field {
+			t.Fatalf("Expected problem %d to concern %s. Actual: %v", i, field, optionsErr.Problems[i])
+		}
+	}
+}
+
+func TestValidateUserDefinedValueTypesPragma(t *testing.T) {
+	// ^0.8.0 admits compilers which predate user-defined value types.
+	if validateErr := (Options{UserDefinedValueTypes: true, Pragma: "^0.8.0"}).Validate(); validateErr == nil {
+		t.Fatalf("Expected an error validating user-defined value types with pragma ^0.8.0")
+	}
+	if validateErr := (Options{UserDefinedValueTypes: true, Pragma: "^0.8.8"}).Validate(); validateErr != nil {
+		t.Fatalf("Expected valid options with pragma ^0.8.8. Actual error: %s", validateErr.Error())
+	}
+}
+
+func TestParsePragmas(t *testing.T) {
+	version, extra, parseErr := ParsePragmas([]string{"abicoder v2", "pragma solidity >=0.8.0 <0.9.0;", "experimental ABIEncoderV2"})
+	if parseErr != nil {
+		t.Fatalf("Error parsing pragmas: %s", parseErr.Error())
+	}
+	if version != ">=0.8.0 <0.9.0" || len(extra) != 2 || extra[0] != "abicoder v2" || extra[1] != "experimental ABIEncoderV2" {
+		t.Fatalf("Expected version >=0.8.0 <0.9.0 and 2 other pragmas in order. Actual: %q %q", version, extra)
+	}
+
+	if _, _, parseErr := ParsePragmas([]string{"^0.8.20", "solidity ^0.8.0"}); parseErr == nil {
+		t.Fatalf("Expected an error for two Solidity version pragmas")
+	}
+	for _, pragma := range []string{"latest", "abicoder v3", "experimental", "experimental SMTChecker; contract X {}", "0.8.x.y"} {
+		if _, _, parseErr := ParsePragmas([]string{pragma}); parseErr == nil {
+			t.Fatalf("Expected an error for the unsupported pragma %q", pragma)
+		}
+		if validateErr := (Options{ExtraPragmas: []string{pragma}}).Validate(); validateErr == nil {
+			t.Fatalf("Expected an error validating the unsupported pragma %q", pragma)
+		}
+	}
+}
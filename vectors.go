@@ -0,0 +1,141 @@
+package solface
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Represents a conformance test vector for a function in an ABI: its canonical signature and selector, and
+// the calldata for a call to it in which every argument has its zero value (false, 0, the zero address,
+// empty strings, bytes, and dynamic arrays, and fixed-size arrays and tuples of zero values).
+type TestVector struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"`
+	Selector  string `json:"selector"`
+	Calldata  string `json:"calldata"`
+}
+
+// Returns true if values of the given type are dynamic in the sense of the ABI specification, i.e. encoded
+// in the tail of the enclosing sequence.
+func isDynamicType(value Value) bool {
+	elementType, arraySuffix := splitArrayType(value.Type)
+	if arraySuffix == "[]" {
+		return true
+	} else if arraySuffix != "" {
+		return isDynamicType(Value{Type: elementType, Components: value.Components})
+	}
+
+	if value.Type == "string" || value.Type == "bytes" {
+		return true
+	}
+	if value.Type == "tuple" {
+		for _, component := range value.Components {
+			if isDynamicType(component) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ABI-encodes a sequence of values of the given types, each of which has its zero value.
+func encodeZeroSequence(values []Value) ([]byte, error) {
+	headSize := 0
+	encodings := make([][]byte, len(values))
+	for i, value := range values {
+		encoding, encodeErr := encodeZeroValue(value)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		encodings[i] = encoding
+		if isDynamicType(value) {
+			headSize += 32
+		} else {
+			headSize += len(encoding)
+		}
+	}
+
+	head := []byte{}
+	tail := []byte{}
+	for i, value := range values {
+		if isDynamicType(value) {
+			offset, offsetErr := encodeElementary("uint256", headSize+len(tail))
+			if offsetErr != nil {
+				return nil, offsetErr
+			}
+			head = append(head, offset...)
+			tail = append(tail, encodings[i]...)
+		} else {
+			head = append(head, encodings[i]...)
+		}
+	}
+	return append(head, tail...), nil
+}
+
+// ABI-encodes the zero value of the given type.
+func encodeZeroValue(value Value) ([]byte, error) {
+	elementType, arraySuffix := splitArrayType(value.Type)
+	if arraySuffix == "[]" {
+		// Empty dynamic arrays are encoded as their length.
+		return make([]byte, 32), nil
+	} else if arraySuffix != "" {
+		length, lengthErr := strconv.Atoi(strings.Trim(arraySuffix, "[]"))
+		if lengthErr != nil {
+			return nil, fmt.Errorf("invalid array type: %s", value.Type)
+		}
+		elements := make([]Value, length)
+		for i := range elements {
+			elements[i] = Value{Name: value.Name, Type: elementType, Components: value.Components}
+		}
+		return encodeZeroSequence(elements)
+	}
+
+	if value.Type == "tuple" {
+		return encodeZeroSequence(value.Components)
+	}
+
+	// Every other type (including empty strings and bytes, which are encoded as their length) is encoded
+	// as a single zero word.
+	return make([]byte, 32), nil
+}
+
+// Generates a test vector for every function in the given ABI.
+func TestVectors(abi DecodedABI) ([]TestVector, error) {
+	return TestVectorsWithHasher(abi, Keccak256Hasher)
+}
+
+// Generates a test vector for every function in the given ABI, deriving selectors with the given hasher
+// (Keccak256Hasher if nil).
+func TestVectorsWithHasher(abi DecodedABI, hasher Hasher) ([]TestVector, error) {
+	vectors := make([]TestVector, len(abi.Functions))
+	for i, functionItem := range abi.Functions {
+		selector := MethodSelectorWithHasher(functionItem, hasher)
+		arguments, encodeErr := encodeZeroSequence(functionItem.Inputs)
+		if encodeErr != nil {
+			return nil, fmt.Errorf("could not encode calldata for function %s: %s", functionItem.Name, encodeErr.Error())
+		}
+		vectors[i] = TestVector{
+			Name:      functionItem.Name,
+			Signature: FunctionSignature(functionItem),
+			Selector:  "0x" + hex.EncodeToString(selector),
+			Calldata:  "0x" + hex.EncodeToString(append(selector, arguments...)),
+		}
+	}
+	return vectors, nil
+}
+
+// Writes the test vectors for the given ABI (see TestVectors) to the given writer as a JSON array. This
+// implements the "test-vectors" target.
+func GenerateTestVectors(abi DecodedABI, annotations Annotations, options Options, writer io.Writer) error {
+	vectors, vectorsErr := TestVectorsWithHasher(abi, options.Hasher)
+	if vectorsErr != nil {
+		return vectorsErr
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(vectors)
+}
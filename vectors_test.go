@@ -0,0 +1,107 @@
+package solface
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Returns the zero value of the given type, with every *big.Int (including those nested in structs and
+// arrays) set to 0 so that go-ethereum can encode it.
+func zeroGoValue(t reflect.Type) reflect.Value {
+	if t == reflect.TypeOf(&big.Int{}) {
+		return reflect.ValueOf(new(big.Int))
+	}
+
+	value := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			value.Field(i).Set(zeroGoValue(t.Field(i).Type))
+		}
+	case reflect.Array:
+		for i := 0; i < t.Len(); i++ {
+			value.Index(i).Set(zeroGoValue(t.Elem()))
+		}
+	}
+	return value
+}
+
+func TestTestVectorsMatchGoEthereum(t *testing.T) {
+	fixtures := []string{"DiamondCutFacet", "ERC20", "ERC721", "OwnableERC20", "Seaport", "UniswapV3Factory"}
+	for _, fixture := range fixtures {
+		contents, readErr := os.ReadFile("fixtures/abis/" + fixture + ".json")
+		if readErr != nil {
+			t.Fatal("Could not read file containing ABI")
+		}
+
+		decoded, decodeErr := Decode(contents)
+		if decodeErr != nil {
+			t.Fatalf("Error decoding ABI: %s", decodeErr.Error())
+		}
+		reference, referenceErr := abi.JSON(bytes.NewReader(contents))
+		if referenceErr != nil {
+			t.Fatalf("go-ethereum could not parse %s: %s", fixture, referenceErr.Error())
+		}
+
+		vectors, vectorsErr := TestVectors(decoded)
+		if vectorsErr != nil {
+			t.Fatalf("Error generating test vectors for %s: %s", fixture, vectorsErr.Error())
+		}
+		if len(vectors) != len(decoded.Functions) {
+			t.Fatalf("%s: Expected %d test vectors. Actual: %d", fixture, len(decoded.Functions), len(vectors))
+		}
+
+		for _, vector := range vectors {
+			selector, _ := hex.DecodeString(strings.TrimPrefix(vector.Selector, "0x"))
+			method, methodErr := reference.MethodById(selector)
+			if methodErr != nil {
+				t.Fatalf("%s: go-ethereum has no method with selector %s (%s)", fixture, vector.Selector, vector.Signature)
+			}
+			if method.Sig != vector.Signature {
+				t.Fatalf("%s: Expected signature: %s. Actual: %s", fixture, method.Sig, vector.Signature)
+			}
+
+			arguments := make([]interface{}, len(method.Inputs))
+			for i, input := range method.Inputs {
+				arguments[i] = zeroGoValue(input.Type.GetType()).Interface()
+			}
+			packed, packErr := method.Inputs.Pack(arguments...)
+			if packErr != nil {
+				t.Fatalf("%s: go-ethereum could not encode arguments for %s: %s", fixture, vector.Signature, packErr.Error())
+			}
+			expectedCalldata := "0x" + hex.EncodeToString(append(method.ID, packed...))
+			if vector.Calldata != expectedCalldata {
+				t.Fatalf("%s: Function %s: Expected calldata: %s. Actual: %s", fixture, vector.Signature, expectedCalldata, vector.Calldata)
+			}
+		}
+	}
+}
+
+func TestTestVectorsFixedArrayOfDynamicType(t *testing.T) {
+	decoded := DecodedABI{Functions: []FunctionItem{
+		{Type: "function", Name: "f", StateMutability: "nonpayable", Inputs: []Value{{Name: "names", Type: "string[2]"}, {Name: "x", Type: "uint256"}}},
+	}}
+
+	vectors, vectorsErr := TestVectors(decoded)
+	if vectorsErr != nil {
+		t.Fatalf("Error generating test vectors: %s", vectorsErr.Error())
+	}
+
+	// Head: offset of names (0x40), x. Tail (names): offsets of the two strings (0x40, 0x60), and their
+	// (zero) lengths.
+	words := []string{"40", "00", "40", "60", "00", "00"}
+	expectedCalldata := vectors[0].Selector
+	for _, word := range words {
+		expectedCalldata += strings.Repeat("0", 62) + word
+	}
+	if vectors[0].Calldata != expectedCalldata {
+		t.Fatalf("Expected calldata: %s. Actual: %s", expectedCalldata, vectors[0].Calldata)
+	}
+}
@@ -1,4 +1,4 @@
-package lib
+package solface
 
 // The current version of solface.
 var VERSION string = "0.2.3"